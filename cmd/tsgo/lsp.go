@@ -39,6 +39,7 @@ func runLSP(args []string) int {
 		Cwd:                core.Must(os.Getwd()),
 		FS:                 fs,
 		DefaultLibraryPath: defaultLibraryPath,
+		ExecutingFilePath:  executingFilePath(),
 	})
 
 	if err := s.Run(); err != nil && !errors.Is(err, io.EOF) {
@@ -2,16 +2,18 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
 	"runtime/pprof"
 	"slices"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/microsoft/typescript-go/internal/ast"
@@ -22,6 +24,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/diagnosticwriter"
 	"github.com/microsoft/typescript-go/internal/execute"
 	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 	"github.com/microsoft/typescript-go/internal/vfs/osvfs"
 )
@@ -120,13 +123,28 @@ func parseArgs() *cliOptions {
 	return opts
 }
 
+func runTsc(args []string) int {
+	// Watch mode runs until it is interrupted.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	sys := newSystem()
+	defer sys.Close()
+	return int(execute.CommandLine(ctx, sys, nil, args))
+}
+
 func main() {
 	if args := os.Args[1:]; len(args) > 0 {
 		switch args[0] {
 		case "tsc":
-			os.Exit(int(execute.CommandLine(newSystem(), nil, args[1:])))
+			os.Exit(runTsc(args[1:]))
 		case "lsp":
 			os.Exit(runLSP(args[1:]))
+		case "organizeImports":
+			os.Exit(runOrganizeImports(args[1:]))
+		case "transpileDeclaration":
+			os.Exit(runTranspileDeclaration(args[1:]))
+		case "resolve":
+			os.Exit(runResolve(args[1:]))
 		}
 	}
 	opts := parseArgs()
@@ -172,6 +190,7 @@ func main() {
 	})
 	parseTime := time.Since(parseStart)
 
+	commandLineOptions := compilerOptions
 	compilerOptions = program.Options()
 
 	if compilerOptions.ListFilesOnly.IsTrue() {
@@ -180,12 +199,7 @@ func main() {
 	}
 
 	if compilerOptions.ShowConfig.IsTrue() {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "    ")
-		if err := enc.Encode(compilerOptions); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
-			os.Exit(1)
-		}
+		showConfig(host, configFileName, commandLineOptions)
 		os.Exit(0)
 	}
 
@@ -310,6 +324,14 @@ func listFiles(p *ts.Program) {
 	}
 }
 
+func showConfig(host ts.CompilerHost, configFileName string, commandLineOptions *core.CompilerOptions) {
+	configFileText, _ := host.FS().ReadFile(configFileName)
+	configFilePath := tspath.ToPath(configFileName, host.GetCurrentDirectory(), host.FS().UseCaseSensitiveFileNames())
+	configFile := tsoptions.NewTsconfigSourceFileFromFilePath(configFileName, configFilePath, configFileText)
+	config := tsoptions.ParseJsonSourceFileConfigFileContent(configFile, host, host.GetCurrentDirectory(), commandLineOptions, configFileName, nil, nil, nil)
+	fmt.Println(core.Must(core.StringifyJson(tsoptions.ConvertToTSConfig(config, configFileName, getFormatOpts(host).ComparePathsOptions), "", "    ")))
+}
+
 func getFormatOpts(host ts.CompilerHost) *diagnosticwriter.FormattingOptions {
 	return &diagnosticwriter.FormattingOptions{
 		NewLine: host.NewLine(),
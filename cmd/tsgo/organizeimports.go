@@ -0,0 +1,146 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/bundled"
+	ts "github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs/osvfs"
+)
+
+// runOrganizeImports organizes the imports of the files of a project the same way the language server
+// does, so that CI can rewrite or verify them.
+func runOrganizeImports(args []string) int {
+	flag := flag.NewFlagSet("organizeImports", flag.ContinueOnError)
+	project := flag.String("p", "", "path to a tsconfig.json file or to a directory containing one")
+	check := flag.Bool("check", false, "list the files whose imports are not organized instead of rewriting them")
+	mode := flag.String("mode", string(ls.OrganizeImportsModeAll), "one of All, SortAndCombine or RemoveUnused")
+	var ignoreCase tristateFlag
+	flag.Var(&ignoreCase, "ignoreCase", "sort case-insensitively; detected from the existing imports when unset")
+	numericCollation := flag.Bool("numericCollation", false, "sort runs of digits by their numeric value")
+	if err := flag.Parse(args); err != nil {
+		return 2
+	}
+
+	organizeImportsMode := ls.OrganizeImportsMode(*mode)
+	if !slices.Contains([]ls.OrganizeImportsMode{ls.OrganizeImportsModeAll, ls.OrganizeImportsModeSortAndCombine, ls.OrganizeImportsModeRemoveUnused}, organizeImportsMode) {
+		fmt.Fprintf(os.Stderr, "Unknown mode %q.\n", *mode)
+		return 2
+	}
+	preferences := &ls.UserPreferences{
+		OrganizeImportsIgnoreCase:       core.Tristate(ignoreCase),
+		OrganizeImportsNumericCollation: *numericCollation,
+	}
+
+	currentDirectory := tspath.NormalizePath(core.Must(os.Getwd()))
+	fs := bundled.WrapFS(osvfs.FS())
+	configFileName := tspath.ResolvePath(currentDirectory, *project)
+	if !fs.FileExists(configFileName) {
+		configFileName = tspath.CombinePaths(configFileName, "tsconfig.json")
+		if !fs.FileExists(configFileName) {
+			fmt.Fprintf(os.Stderr, "Error: The file %v does not exist.\n", configFileName)
+			return 1
+		}
+	}
+
+	compilerOptions := &core.CompilerOptions{}
+	host := ts.NewCompilerHost(compilerOptions, tspath.GetDirectoryPath(configFileName), fs, bundled.LibPath())
+	program := ts.NewProgram(ts.ProgramOptions{
+		ConfigFileName: configFileName,
+		Options:        compilerOptions,
+		Host:           host,
+	})
+	if diagnostics := program.GetConfigFileParsingDiagnostics(); len(diagnostics) != 0 {
+		printDiagnostics(diagnostics, host, program.Options())
+		return 1
+	}
+
+	var files []*ast.SourceFile
+	if flag.NArg() != 0 {
+		for _, arg := range flag.Args() {
+			fileName := tspath.ResolvePath(currentDirectory, arg)
+			file := program.GetSourceFile(fileName)
+			if file == nil {
+				fmt.Fprintf(os.Stderr, "Error: The file %v is not part of the project.\n", fileName)
+				return 1
+			}
+			files = append(files, file)
+		}
+	} else {
+		files = core.Filter(program.SourceFiles(), func(file *ast.SourceFile) bool {
+			return !file.IsDeclarationFile && !program.IsSourceFileDefaultLibrary(file) && !strings.Contains(file.FileName(), "/node_modules/")
+		})
+	}
+
+	service := ls.NewLanguageService(&programHost{CompilerHost: host, program: program, exportInfoMap: ls.NewExportInfoMap()})
+	comparePathsOptions := tspath.ComparePathsOptions{
+		CurrentDirectory:          currentDirectory,
+		UseCaseSensitiveFileNames: fs.UseCaseSensitiveFileNames(),
+	}
+	unorganized := 0
+	for _, file := range files {
+		changes := service.OrganizeImports(file.FileName(), organizeImportsMode, preferences)
+		if len(changes) == 0 {
+			continue
+		}
+		unorganized++
+		if *check {
+			fmt.Println(tspath.ConvertToRelativePath(file.FileName(), comparePathsOptions))
+			continue
+		}
+		text := file.Text
+		textChanges := changes[0].TextChanges
+		for i := len(textChanges) - 1; i >= 0; i-- {
+			text = textChanges[i].ApplyTo(text)
+		}
+		if err := fs.WriteFile(file.FileName(), text, false /*writeByteOrderMark*/); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %v: %v\n", file.FileName(), err)
+			return 1
+		}
+	}
+	if *check && unorganized != 0 {
+		return 1
+	}
+	return 0
+}
+
+// programHost is an ls.Host for a program that does not change.
+type programHost struct {
+	ts.CompilerHost
+	program       *ts.Program
+	exportInfoMap *ls.ExportInfoMap
+}
+
+var _ ls.Host = (*programHost)(nil)
+
+func (h *programHost) GetProjectVersion() int {
+	return 0
+}
+
+func (h *programHost) GetRootFileNames() []string {
+	return core.Map(h.program.SourceFiles(), (*ast.SourceFile).FileName)
+}
+
+func (h *programHost) GetCompilerOptions() *core.CompilerOptions {
+	return h.program.Options()
+}
+
+func (h *programHost) GetProgram() *ts.Program {
+	return h.program
+}
+
+func (h *programHost) GetDefaultLibraryPath() string {
+	return h.DefaultLibraryPath()
+}
+
+func (h *programHost) GetExportInfoMap() *ls.ExportInfoMap {
+	return h.exportInfoMap
+}
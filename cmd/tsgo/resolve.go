@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/microsoft/typescript-go/internal/bundled"
+	ts "github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/compiler/module"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs/osvfs"
+)
+
+// resolution is the result of runResolve in JSON.
+type resolution struct {
+	Specifier               string              `json:"specifier"`
+	ContainingFile          string              `json:"containingFile"`
+	ResolvedFileName        string              `json:"resolvedFileName,omitempty"`
+	Extension               string              `json:"extension,omitempty"`
+	PackageId               string              `json:"packageId,omitempty"`
+	IsExternalLibraryImport bool                `json:"isExternalLibraryImport,omitempty"`
+	Trace                   []module.TraceEvent `json:"trace"`
+}
+
+// runResolve explains how a single module specifier, or type reference directive, is resolved from a file, with
+// the compiler options of the project of the file. It writes the same trace as --traceResolution, or the
+// result and the steps of the resolution in JSON for tools.
+func runResolve(args []string) int {
+	flag := flag.NewFlagSet("resolve", flag.ContinueOnError)
+	from := flag.String("from", "", "file the specifier is imported from")
+	project := flag.String("p", "", "path to a tsconfig.json file or to a directory containing one; the nearest tsconfig.json of the file when unset")
+	mode := flag.String("mode", "", "resolution mode of the import, import or require; unset when empty")
+	types := flag.Bool("types", false, "resolve a type reference directive rather than a module")
+	jsonOutput := flag.Bool("json", false, "write the result and the steps of the resolution in JSON")
+	// flags may come before or after the specifier
+	var specifiers []string
+	for {
+		if err := flag.Parse(args); err != nil {
+			return 2
+		}
+		if flag.NArg() == 0 {
+			break
+		}
+		specifiers = append(specifiers, flag.Arg(0))
+		args = flag.Args()[1:]
+	}
+	if len(specifiers) != 1 || *from == "" {
+		fmt.Fprintln(os.Stderr, "Usage: tsgo resolve <specifier> --from <file>")
+		return 2
+	}
+	var resolutionMode core.ResolutionMode
+	switch *mode {
+	case "":
+		resolutionMode = core.ResolutionModeNone
+	case "import":
+		resolutionMode = core.ModuleKindESNext
+	case "require":
+		resolutionMode = core.ModuleKindCommonJS
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown mode %q.\n", *mode)
+		return 2
+	}
+
+	currentDirectory := tspath.NormalizePath(core.Must(os.Getwd()))
+	fs := bundled.WrapFS(osvfs.FS())
+	containingFile := tspath.ResolvePath(currentDirectory, *from)
+	compilerOptions := &core.CompilerOptions{}
+	host := ts.NewCompilerHost(compilerOptions, currentDirectory, fs, bundled.LibPath())
+
+	var configFileName string
+	if *project != "" {
+		configFileName = tspath.ResolvePath(currentDirectory, *project)
+		if !fs.FileExists(configFileName) {
+			configFileName = tspath.CombinePaths(configFileName, "tsconfig.json")
+		}
+	} else {
+		configFileName, _ = tspath.ForEachAncestorDirectory(tspath.GetDirectoryPath(containingFile), func(directory string) (string, bool) {
+			configFileName := tspath.CombinePaths(directory, "tsconfig.json")
+			return configFileName, fs.FileExists(configFileName)
+		})
+	}
+	if configFileName != "" {
+		configText, ok := fs.ReadFile(configFileName)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: The file %v does not exist.\n", configFileName)
+			return 1
+		}
+		configPath := tspath.ToPath(configFileName, currentDirectory, fs.UseCaseSensitiveFileNames())
+		config := tsoptions.ParseJsonSourceFileConfigFileContent(
+			tsoptions.NewTsconfigSourceFileFromFilePath(configFileName, configPath, configText),
+			host,
+			tspath.GetDirectoryPath(configFileName),
+			nil, /*existingOptions*/
+			configFileName,
+			nil, /*resolutionStack*/
+			nil, /*extraFileExtensions*/
+			nil, /*extendedConfigCache*/
+		)
+		if len(config.Errors) != 0 {
+			printDiagnostics(config.Errors, host, compilerOptions)
+			return 1
+		}
+		compilerOptions = config.CompilerOptions()
+	}
+
+	resolver := module.NewResolver(host, compilerOptions)
+	result := resolution{Specifier: specifiers[0], ContainingFile: containingFile}
+	if *types {
+		resolved, trace := resolver.ResolveTypeReferenceDirectiveWithTrace(result.Specifier, containingFile, resolutionMode, nil /*redirectedReference*/)
+		result.ResolvedFileName = resolved.ResolvedFileName
+		result.Extension = tspath.TryGetExtensionFromPath(resolved.ResolvedFileName)
+		if resolved.PackageId.Name != "" {
+			result.PackageId = resolved.PackageId.String()
+		}
+		result.IsExternalLibraryImport = resolved.IsExternalLibraryImport
+		result.Trace = trace
+	} else {
+		resolved, trace := resolver.ResolveModuleNameWithTrace(result.Specifier, containingFile, resolutionMode, nil /*redirectedReference*/)
+		result.ResolvedFileName = resolved.ResolvedFileName
+		result.Extension = resolved.Extension
+		if resolved.PackageId.Name != "" {
+			result.PackageId = resolved.PackageId.String()
+		}
+		result.IsExternalLibraryImport = resolved.IsExternalLibraryImport
+		result.Trace = trace
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "    ")
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	} else {
+		for _, event := range result.Trace {
+			fmt.Println(event.Message)
+		}
+	}
+	if result.ResolvedFileName == "" {
+		return 1
+	}
+	return 0
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"time"
 
@@ -13,12 +14,16 @@ import (
 	"github.com/microsoft/typescript-go/internal/tspath"
 	"github.com/microsoft/typescript-go/internal/vfs"
 	"github.com/microsoft/typescript-go/internal/vfs/osvfs"
+	"github.com/microsoft/typescript-go/internal/vfs/vfswatch"
 )
 
 type osSys struct {
+	*vfswatch.Host
+
 	writer             io.Writer
 	fs                 vfs.FS
 	defaultLibraryPath string
+	executingFilePath  string
 	newLine            string
 	cwd                string
 }
@@ -27,6 +32,10 @@ func (s *osSys) Now() time.Time {
 	return time.Now()
 }
 
+func (s *osSys) AfterFunc(d time.Duration, f func()) execute.Timer {
+	return time.AfterFunc(d, f)
+}
+
 func (s *osSys) FS() vfs.FS {
 	return s.fs
 }
@@ -35,6 +44,10 @@ func (s *osSys) DefaultLibraryPath() string {
 	return s.defaultLibraryPath
 }
 
+func (s *osSys) GetExecutingFilePath() string {
+	return s.executingFilePath
+}
+
 func (s *osSys) GetCurrentDirectory() string {
 	return s.cwd
 }
@@ -43,6 +56,23 @@ func (s *osSys) NewLine() string {
 	return s.newLine
 }
 
+func (s *osSys) GetWidthOfTerminal() int {
+	return getWidthOfTerminal(os.Stdout)
+}
+
+func (s *osSys) GetEnvironmentVariable(name string) string {
+	return os.Getenv(name)
+}
+
+func (s *osSys) GetMemoryUsage() uint64 {
+	var memStats runtime.MemStats
+	// GC must be called twice to allow things to settle.
+	runtime.GC()
+	runtime.GC()
+	runtime.ReadMemStats(&memStats)
+	return memStats.HeapAlloc
+}
+
 func (s *osSys) Writer() io.Writer {
 	return s.writer
 }
@@ -59,11 +89,27 @@ func newSystem() *osSys {
 		os.Exit(int(execute.ExitStatusInvalidProject_OutputsSkipped))
 	}
 
+	cwd = tspath.NormalizePath(cwd)
+	fs := bundled.WrapFS(osvfs.FS())
 	return &osSys{
-		cwd:                tspath.NormalizePath(cwd),
-		fs:                 bundled.WrapFS(osvfs.FS()),
+		Host:               vfswatch.NewHost(fs, cwd),
+		cwd:                cwd,
+		fs:                 fs,
 		defaultLibraryPath: bundled.LibPath(),
+		executingFilePath:  executingFilePath(),
 		writer:             os.Stdout,
 		newLine:            core.IfElse(runtime.GOOS == "windows", "\r\n", "\n"),
 	}
 }
+
+// executingFilePath returns the path of the tsgo executable, or "" if it cannot be determined.
+func executingFilePath() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	return tspath.NormalizePath(exe)
+}
@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package main
+
+import "os"
+
+// getWidthOfTerminal returns 0, as the width of the terminal is not known on this platform.
+func getWidthOfTerminal(f *os.File) int {
+	return 0
+}
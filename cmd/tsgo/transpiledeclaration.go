@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/bundled"
+	ts "github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/parser"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs/osvfs"
+)
+
+// runTranspileDeclaration writes the declaration files of the given files one file at a time, from their syntax
+// alone, the way `tsc --isolatedDeclarations` does, so that builds can produce them without a program or a
+// type check.
+func runTranspileDeclaration(args []string) int {
+	flag := flag.NewFlagSet("transpileDeclaration", flag.ContinueOnError)
+	outDir := flag.String("outDir", "", "directory to write the declaration files to; next to the inputs when unset")
+	rootDir := flag.String("rootDir", "", "directory whose structure is kept under outDir; the current directory when unset")
+	var strictNullChecks tristateFlag
+	flag.Var(&strictNullChecks, "strictNullChecks", "keep null and undefined in the inferred types")
+	var pretty tristateFlag
+	flag.Var(&pretty, "pretty", "stylize errors with color and context")
+	if err := flag.Parse(args); err != nil {
+		return 2
+	}
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Error: No input files.")
+		return 2
+	}
+
+	currentDirectory := tspath.NormalizePath(core.Must(os.Getwd()))
+	fs := bundled.WrapFS(osvfs.FS())
+	compilerOptions := &core.CompilerOptions{
+		Declaration:          core.TSTrue,
+		IsolatedDeclarations: core.TSTrue,
+		StrictNullChecks:     core.Tristate(strictNullChecks),
+		Pretty:               core.Tristate(pretty),
+	}
+	host := ts.NewCompilerHost(compilerOptions, currentDirectory, fs, bundled.LibPath())
+	sourceDirectory := tspath.ResolvePath(currentDirectory, *rootDir)
+
+	fileNames := core.Map(flag.Args(), func(arg string) string { return tspath.ResolvePath(currentDirectory, arg) })
+	results := make([][]*ast.Diagnostic, len(fileNames))
+	wg := core.NewWorkGroup(false /*singleThreaded*/)
+	for i, fileName := range fileNames {
+		wg.Queue(func() {
+			results[i] = transpileDeclarationFile(fileName, host, compilerOptions, *outDir, sourceDirectory)
+		})
+	}
+	wg.RunAndWait()
+
+	diagnostics := slices.Concat(results...)
+	if len(diagnostics) != 0 {
+		printDiagnostics(ts.SortAndDeduplicateDiagnostics(diagnostics), host, compilerOptions)
+		return 1
+	}
+	return 0
+}
+
+// transpileDeclarationFile writes the declaration file of fileName unless its declarations need the checker,
+// in which case it returns the diagnostics explaining why.
+func transpileDeclarationFile(fileName string, host ts.CompilerHost, compilerOptions *core.CompilerOptions, outDir string, sourceDirectory string) []*ast.Diagnostic {
+	text, ok := host.FS().ReadFile(fileName)
+	if !ok {
+		return []*ast.Diagnostic{ast.NewCompilerDiagnostic(diagnostics.File_0_not_found, fileName)}
+	}
+	path := tspath.ToPath(fileName, host.GetCurrentDirectory(), host.FS().UseCaseSensitiveFileNames())
+	sourceFile := parser.ParseSourceFile(fileName, path, text, core.ScriptTargetLatest, scanner.JSDocParsingModeParseForTypeErrors)
+	if len(sourceFile.Diagnostics()) != 0 {
+		return sourceFile.Diagnostics()
+	}
+	declarationText, declarationDiagnostics := ts.TranspileDeclaration(sourceFile, compilerOptions)
+	if len(declarationDiagnostics) != 0 {
+		return declarationDiagnostics
+	}
+
+	outputFileName := fileName
+	if outDir != "" {
+		relativeFileName := tspath.GetRelativePathFromDirectory(sourceDirectory, fileName, tspath.ComparePathsOptions{
+			CurrentDirectory:          host.GetCurrentDirectory(),
+			UseCaseSensitiveFileNames: host.FS().UseCaseSensitiveFileNames(),
+		})
+		outputFileName = tspath.CombinePaths(tspath.ResolvePath(host.GetCurrentDirectory(), outDir), relativeFileName)
+	}
+	outputFileName = tspath.RemoveFileExtension(outputFileName) + core.GetDeclarationEmitExtensionForPath(fileName)
+	if err := host.FS().WriteFile(outputFileName, declarationText, false /*writeByteOrderMark*/); err != nil {
+		return []*ast.Diagnostic{ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, outputFileName, err.Error())}
+	}
+	return nil
+}
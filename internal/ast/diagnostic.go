@@ -17,8 +17,12 @@ type Diagnostic struct {
 	code               int32
 	category           diagnostics.Category
 	message            string
+	sourceMessage      *diagnostics.Message
+	args               []any
 	messageChain       []*Diagnostic
 	relatedInformation []*Diagnostic
+	reportsUnnecessary bool
+	reportsDeprecated  bool
 }
 
 func (d *Diagnostic) File() *SourceFile                 { return d.file }
@@ -31,6 +35,17 @@ func (d *Diagnostic) Category() diagnostics.Category    { return d.category }
 func (d *Diagnostic) Message() string                   { return d.message }
 func (d *Diagnostic) MessageChain() []*Diagnostic       { return d.messageChain }
 func (d *Diagnostic) RelatedInformation() []*Diagnostic { return d.relatedInformation }
+func (d *Diagnostic) ReportsUnnecessary() bool          { return d.reportsUnnecessary }
+func (d *Diagnostic) ReportsDeprecated() bool           { return d.reportsDeprecated }
+
+// Localize returns the message of the diagnostic translated into locale, or the English message when
+// locale is nil or has no translation of it.
+func (d *Diagnostic) Localize(locale *diagnostics.Locale) string {
+	if locale == nil {
+		return d.message
+	}
+	return d.sourceMessage.Localize(locale, d.args...)
+}
 
 func (d *Diagnostic) SetFile(file *SourceFile)                  { d.file = file }
 func (d *Diagnostic) SetLocation(loc core.TextRange)            { d.loc = loc }
@@ -62,11 +77,15 @@ func (d *Diagnostic) AddRelatedInfo(relatedInformation *Diagnostic) *Diagnostic
 
 func NewDiagnostic(file *SourceFile, loc core.TextRange, message *diagnostics.Message, args ...any) *Diagnostic {
 	return &Diagnostic{
-		file:     file,
-		loc:      loc,
-		code:     message.Code(),
-		category: message.Category(),
-		message:  message.Format(args...),
+		file:               file,
+		loc:                loc,
+		code:               message.Code(),
+		category:           message.Category(),
+		message:            message.Format(args...),
+		sourceMessage:      message,
+		args:               args,
+		reportsUnnecessary: message.ReportsUnnecessary(),
+		reportsDeprecated:  message.ReportsDeprecated(),
 	}
 }
 
@@ -2437,6 +2437,10 @@ func isGeneratorFunctionExpression(node *ast.Node) bool {
 }
 
 func (b *Binder) addToContainerChain(next *ast.Node) {
+	// The node may be reused from a previous parse, so clear the data of the previous binding.
+	data := next.LocalsContainerData()
+	data.Locals = nil
+	data.NextContainer = nil
 	if b.lastContainer != nil {
 		b.lastContainer.LocalsContainerData().NextContainer = next
 	}
@@ -530,6 +530,8 @@ type Checker struct {
 	fileIndexMap                              map[*ast.SourceFile]int
 	compareSymbols                            func(*ast.Symbol, *ast.Symbol) int
 	TypeCount                                 uint32
+	typeCatalog                               []*Type // the types created, when --generateTrace is set
+	recordTypes                               bool
 	symbolCount                               uint32
 	totalInstantiationCount                   uint32
 	instantiationCount                        uint32
@@ -811,6 +813,7 @@ func NewChecker(program Program) *Checker {
 	c.program = program
 	// c.host = program.host
 	c.compilerOptions = program.Options()
+	c.recordTypes = c.compilerOptions.GenerateTrace != ""
 	c.files = program.SourceFiles()
 	c.fileIndexMap = createFileIndexMap(c.files)
 	c.compareSymbols = c.compareSymbolsWorker // Closure optimization
@@ -2250,10 +2253,8 @@ func (c *Checker) checkDeferredNode(node *ast.Node) {
 }
 
 func (c *Checker) checkJSDocNodes(sourceFile *ast.SourceFile) {
-	// !!!
-	// This performs minimal checking of JSDoc nodes to ensure that @link references to entities are recorded
-	// for purposes of checking unused identifiers. We pass down a location node because the binder doesn't currently
-	// set parent references in JSDoc nodes.
+	// This resolves the names of @link and @see tags so that the entities they reference are recorded for
+	// purposes of checking unused identifiers. Names are resolved from the node the JSDoc belongs to.
 	for location, jsdocs := range sourceFile.JSDocCache() {
 		for _, jsdoc := range jsdocs {
 			c.checkJSDocComments(jsdoc, location)
@@ -2261,6 +2262,9 @@ func (c *Checker) checkJSDocNodes(sourceFile *ast.SourceFile) {
 			if tags != nil {
 				for _, tag := range tags.Nodes {
 					c.checkJSDocComments(tag, location)
+					if tag.Kind == ast.KindJSDocSeeTag && tag.AsJSDocSeeTag().NameExpression != nil {
+						c.resolveJSDocMemberName(tag.AsJSDocSeeTag().NameExpression.Name(), location)
+					}
 				}
 			}
 		}
@@ -2300,6 +2304,7 @@ func (c *Checker) resolveJSDocMemberName(name *ast.Node, location *ast.Node) *as
 				return c.getPropertyOfType(t, name.AsQualifiedName().Right.Text())
 			}
 		}
+		return symbol
 	}
 	return nil
 }
@@ -12964,6 +12969,13 @@ func (c *Checker) GetDiagnosticsWithoutCheck(sourceFile *ast.SourceFile) []*ast.
 	return c.diagnostics.GetDiagnosticsForFile(sourceFile.FileName())
 }
 
+// GetSuggestionDiagnostics checks a file and returns its suggestion diagnostics, such as uses of
+// declarations marked `@deprecated`.
+func (c *Checker) GetSuggestionDiagnostics(sourceFile *ast.SourceFile) []*ast.Diagnostic {
+	c.CheckSourceFile(sourceFile)
+	return c.suggestionDiagnostics.GetDiagnosticsForFile(sourceFile.FileName())
+}
+
 func (c *Checker) GetGlobalDiagnostics() []*ast.Diagnostic {
 	return c.diagnostics.GetGlobalDiagnostics()
 }
@@ -14907,8 +14919,42 @@ func (c *Checker) getWriteTypeOfSymbol(symbol *ast.Symbol) *Type {
 }
 
 func (c *Checker) GetTypeOfSymbolAtLocation(symbol *ast.Symbol, location *ast.Node) *Type {
-	// !!!
-	return c.getTypeOfSymbol(symbol)
+	symbol = c.getExportSymbolOfValueSymbolIfExported(symbol)
+	// If we have an identifier or a property access at the given location, if the location is
+	// a reference to the symbol, and if the location is part of an expression or a shorthand
+	// property assignment, return the narrowed type
+	if ast.IsIdentifier(location) || ast.IsPrivateIdentifier(location) {
+		if isRightSideOfQualifiedNameOrPropertyAccess(location) {
+			location = location.Parent
+		}
+		if ast.IsExpressionNode(location) && (!ast.IsAssignmentTarget(location) || isWriteAccess(location)) {
+			var t *Type
+			if isWriteAccess(location) && ast.IsPropertyAccessExpression(location) {
+				t = c.checkPropertyAccessExpression(location, CheckModeNormal, true /*writeOnly*/)
+			} else {
+				t = c.getTypeOfExpression(location)
+			}
+			t = c.removeOptionalTypeMarker(t)
+			var resolvedSymbol *ast.Symbol
+			if ast.IsIdentifier(location) {
+				resolvedSymbol = c.identifierSymbols[location]
+			} else {
+				resolvedSymbol = c.typeNodeLinks.Get(location).resolvedSymbol
+			}
+			if resolvedSymbol != nil && c.getExportSymbolOfValueSymbolIfExported(resolvedSymbol) == symbol {
+				return t
+			}
+		}
+	}
+	// The location isn't a reference to the given symbol, meaning we're being asked
+	// a hypothetical question of what type the symbol would have if there was a reference
+	// to it at the given location. Since we have no control flow information for the
+	// hypothetical reference (control flow information is created and attached by the
+	// binder), we simply return the declared type of the symbol.
+	if ast.IsPropertyAccessExpression(location.Parent) && location.Parent.Name() == location && isWriteAccess(location.Parent) {
+		return c.getWriteTypeOfSymbol(symbol)
+	}
+	return c.getNonMissingTypeOfSymbol(symbol)
 }
 
 func (c *Checker) getTypeOfSymbol(symbol *ast.Symbol) *Type {
@@ -21884,6 +21930,29 @@ func (c *Checker) getEnumMemberValue(node *ast.Node) EvaluatorResult {
 	return c.enumMemberLinks.Get(node).value
 }
 
+// getConstantValue returns the value of an enum member, or of a property or element access that references a
+// member of a const enum, or nil if there is no such value.
+func (c *Checker) getConstantValue(node *ast.Node) any {
+	if ast.IsEnumMember(node) {
+		return c.getEnumMemberValue(node).value
+	}
+	if c.typeNodeLinks.Get(node).resolvedSymbol == nil {
+		c.checkExpressionCached(node) // ensure cached resolved symbol is set
+	}
+	symbol := c.typeNodeLinks.Get(node).resolvedSymbol
+	if symbol == nil && ast.IsEntityNameExpression(node) {
+		symbol = c.resolveEntityName(node, ast.SymbolFlagsValue, true /*ignoreErrors*/, false /*dontResolveAlias*/, nil /*location*/)
+	}
+	if symbol != nil && symbol.Flags&ast.SymbolFlagsEnumMember != 0 {
+		// inline property\index accesses only for const enums
+		member := symbol.ValueDeclaration
+		if ast.IsEnumConst(member.Parent) {
+			return c.getEnumMemberValue(member).value
+		}
+	}
+	return nil
+}
+
 func (c *Checker) createComputedEnumType(symbol *ast.Symbol) *Type {
 	regularType := c.newLiteralType(TypeFlagsEnum, nil, nil)
 	regularType.symbol = symbol
@@ -22961,6 +23030,9 @@ func (c *Checker) newType(flags TypeFlags, objectFlags ObjectFlags, data TypeDat
 	t.id = TypeId(c.TypeCount)
 	t.checker = c
 	t.data = data
+	if c.recordTypes {
+		c.typeCatalog = append(c.typeCatalog, t)
+	}
 	return t
 }
 
@@ -29076,6 +29148,23 @@ func (c *Checker) getSymbolOfNameOrPropertyAccessExpression(name *ast.Node) *ast
 		return c.getSymbolOfNode(name.Parent)
 	}
 
+	if isPartOfJSDocLinkOrSeeName(name) {
+		// The right side of `Foo.bar` names the member, which is resolved through its left side.
+		for ast.IsQualifiedName(name.Parent) && name.Parent.AsQualifiedName().Right == name {
+			name = name.Parent
+		}
+		if symbol := c.resolveJSDocMemberName(name, nil /*location*/); symbol != nil {
+			return symbol
+		}
+		// In the JSDoc of a class or interface or its members, a plain name may refer to a member.
+		if ast.IsIdentifier(name) {
+			if container := ast.FindAncestor(name, func(node *ast.Node) bool { return ast.IsClassLike(node) || ast.IsInterfaceDeclaration(node) }); container != nil {
+				return c.getPropertyOfType(c.getDeclaredTypeOfSymbol(c.getSymbolOfDeclaration(container)), name.Text())
+			}
+		}
+		return nil
+	}
+
 	if name.Parent.Kind == ast.KindExportAssignment && ast.IsEntityNameExpression(name) {
 		// Even an entity name expression that doesn't resolve as an entityname may still typecheck as a property access expression
 		success := c.resolveEntityName(
@@ -164,6 +164,17 @@ func (r *emitResolver) GetExternalModuleFileFromDeclaration(node *ast.Node) *ast
 	return nil
 }
 
+func (r *emitResolver) GetConstantValue(node *ast.Node) any {
+	if !ast.IsParseTreeNode(node) {
+		return nil
+	}
+
+	r.checkerMu.Lock()
+	defer r.checkerMu.Unlock()
+
+	return r.checker.getConstantValue(node)
+}
+
 func (r *emitResolver) getReferenceResolver() binder.ReferenceResolver {
 	if r.referenceResolver == nil {
 		r.referenceResolver = binder.NewReferenceResolver(binder.ReferenceResolverHooks{
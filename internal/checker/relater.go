@@ -1915,7 +1915,9 @@ func (c *Checker) getParameterNameAtPosition(signature *Signature, pos int) stri
 	restType := c.getTypeOfSymbol(restParameter)
 	if isTupleType(restType) {
 		index := pos - paramCount
-		c.getTupleElementLabel(restType.TargetTupleType().elementInfos[index], restParameter, index)
+		if elementInfos := restType.TargetTupleType().elementInfos; index < len(elementInfos) {
+			return c.getTupleElementLabel(elementInfos[index], restParameter, index)
+		}
 	}
 	return restParameter.Name
 }
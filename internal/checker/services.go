@@ -0,0 +1,139 @@
+package checker
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+)
+
+// This file contains the checker entry points used by the language service.
+
+func (c *Checker) GetSignaturesOfType(t *Type, kind SignatureKind) []*Signature {
+	return c.getSignaturesOfType(t, kind)
+}
+
+func (c *Checker) GetPropertiesOfType(t *Type) []*ast.Symbol {
+	return c.getPropertiesOfType(t)
+}
+
+func (c *Checker) GetTypeOfSymbol(symbol *ast.Symbol) *Type {
+	return c.getTypeOfSymbol(symbol)
+}
+
+func (c *Checker) GetDeclaredTypeOfSymbol(symbol *ast.Symbol) *Type {
+	return c.getDeclaredTypeOfSymbol(symbol)
+}
+
+func (c *Checker) GetResolvedSignature(node *ast.Node) *Signature {
+	return c.getResolvedSignature(node, nil /*candidatesOutArray*/, CheckModeNormal)
+}
+
+func (c *Checker) GetSignatureFromDeclaration(declaration *ast.Node) *Signature {
+	return c.getSignatureFromDeclaration(declaration)
+}
+
+func (c *Checker) GetReturnTypeOfSignature(signature *Signature) *Type {
+	return c.getReturnTypeOfSignature(signature)
+}
+
+func (c *Checker) GetParameterNameAtPosition(signature *Signature, pos int) string {
+	return c.getParameterNameAtPosition(signature, pos)
+}
+
+// GetConstantValue returns the value of an enum member, or of an access to a member of a const enum, or nil if the
+// value cannot be computed.
+func (c *Checker) GetConstantValue(node *ast.Node) any {
+	return c.getConstantValue(node)
+}
+
+func (c *Checker) ValueToString(value any) string {
+	return c.valueToString(value)
+}
+
+func (c *Checker) SignatureToString(signature *Signature) string {
+	return c.signatureToString(signature)
+}
+
+func (c *Checker) GetPropertyOfType(t *Type, name string) *ast.Symbol {
+	return c.getPropertyOfType(t, name)
+}
+
+func (c *Checker) GetExportsOfModule(moduleSymbol *ast.Symbol) ast.SymbolTable {
+	return c.getExportsOfModule(moduleSymbol)
+}
+
+// GetAmbientModules returns the symbols of the ambient modules declared in the program, such as
+// `declare module "fs" {}`, sorted by name.
+func (c *Checker) GetAmbientModules() []*ast.Symbol {
+	var modules []*ast.Symbol
+	for name, symbol := range c.globals {
+		if strings.HasPrefix(name, "\"") && strings.HasSuffix(name, "\"") {
+			modules = append(modules, symbol)
+		}
+	}
+	slices.SortFunc(modules, func(a, b *ast.Symbol) int { return strings.Compare(a.Name, b.Name) })
+	return modules
+}
+
+// IsAliasDeclarationReferenced reports whether the alias declared by an import clause, namespace import,
+// import specifier or import equals declaration is referenced in its file, either as a value (the marking
+// that import elision relies on) or in a type position. The containing file is checked if necessary.
+func (c *Checker) IsAliasDeclarationReferenced(node *ast.Node) bool {
+	c.checkSourceFile(ast.GetSourceFileOfNode(node))
+	symbol := c.getSymbolOfDeclaration(node)
+	if symbol == nil {
+		return true
+	}
+	return c.symbolReferenceLinks.Get(symbol).referenceKinds != 0 || c.aliasSymbolLinks.Get(symbol).referenced
+}
+
+func (c *Checker) IsArrayType(t *Type) bool {
+	return c.isArrayType(t)
+}
+
+func (c *Checker) GetElementTypeOfArrayType(t *Type) *Type {
+	return c.getElementTypeOfArrayType(t)
+}
+
+// GetPromisedTypeOfPromise returns the type a promise resolves to, or nil if t is not a promise.
+func (c *Checker) GetPromisedTypeOfPromise(t *Type) *Type {
+	return c.getPromisedTypeOfPromise(t)
+}
+
+func (c *Checker) IsTypeAssignableTo(source *Type, target *Type) bool {
+	return c.isTypeAssignableTo(source, target)
+}
+
+// GetRootSymbol returns the symbol an instantiated symbol was created from, such as the declared method of
+// a class for the method of one of its instances.
+func (c *Checker) GetRootSymbol(symbol *ast.Symbol) *ast.Symbol {
+	for symbol.CheckFlags&ast.CheckFlagsInstantiated != 0 {
+		symbol = c.valueSymbolLinks.Get(symbol).target
+	}
+	return symbol
+}
+
+// GetBaseTypes returns the types a class or interface extends, or nil for other types.
+func (c *Checker) GetBaseTypes(t *Type) []*Type {
+	if t.objectFlags&ObjectFlagsClassOrInterface == 0 {
+		return nil
+	}
+	return c.getBaseTypes(t)
+}
+
+func (c *Checker) GetContextualType(node *ast.Node) *Type {
+	return c.getContextualType(node, ContextFlagsNone)
+}
+
+func (c *Checker) GetSuggestedSymbolForNonexistentProperty(name *ast.Node, containingType *Type) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentProperty(name, containingType)
+}
+
+func (c *Checker) GetSuggestedSymbolForNonexistentSymbol(location *ast.Node, name string, meaning ast.SymbolFlags) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentSymbol(location, name, meaning)
+}
+
+func (c *Checker) GetNonNullableType(t *Type) *Type {
+	return c.getNonNullableType(t)
+}
@@ -0,0 +1,159 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tracing"
+)
+
+// This file contains the statistics and the list of types reported by --extendedDiagnostics and --generateTrace.
+
+// SymbolCount returns the number of symbols created by the checker, which excludes the symbols of the files.
+func (c *Checker) SymbolCount() int {
+	return int(c.symbolCount)
+}
+
+// InstantiationCount returns the number of types instantiated by the checker.
+func (c *Checker) InstantiationCount() int {
+	return int(c.totalInstantiationCount)
+}
+
+// RelationCacheSizes are the numbers of cached results of the type relations.
+type RelationCacheSizes struct {
+	Assignable    int
+	Identity      int
+	Subtype       int
+	StrictSubtype int
+}
+
+func (c *Checker) RelationCacheSizes() RelationCacheSizes {
+	return RelationCacheSizes{
+		Assignable:    len(c.assignableRelation.results),
+		Identity:      len(c.identityRelation.results),
+		Subtype:       len(c.subtypeRelation.results),
+		StrictSubtype: len(c.strictSubtypeRelation.results),
+	}
+}
+
+var typeFlagNames = []struct {
+	flag TypeFlags
+	name string
+}{
+	{TypeFlagsAny, "Any"},
+	{TypeFlagsUnknown, "Unknown"},
+	{TypeFlagsUndefined, "Undefined"},
+	{TypeFlagsNull, "Null"},
+	{TypeFlagsVoid, "Void"},
+	{TypeFlagsString, "String"},
+	{TypeFlagsNumber, "Number"},
+	{TypeFlagsBigInt, "BigInt"},
+	{TypeFlagsBoolean, "Boolean"},
+	{TypeFlagsESSymbol, "ESSymbol"},
+	{TypeFlagsStringLiteral, "StringLiteral"},
+	{TypeFlagsNumberLiteral, "NumberLiteral"},
+	{TypeFlagsBigIntLiteral, "BigIntLiteral"},
+	{TypeFlagsBooleanLiteral, "BooleanLiteral"},
+	{TypeFlagsUniqueESSymbol, "UniqueESSymbol"},
+	{TypeFlagsEnumLiteral, "EnumLiteral"},
+	{TypeFlagsEnum, "Enum"},
+	{TypeFlagsNever, "Never"},
+	{TypeFlagsTypeParameter, "TypeParameter"},
+	{TypeFlagsObject, "Object"},
+	{TypeFlagsUnion, "Union"},
+	{TypeFlagsIntersection, "Intersection"},
+	{TypeFlagsIndex, "Index"},
+	{TypeFlagsIndexedAccess, "IndexedAccess"},
+	{TypeFlagsConditional, "Conditional"},
+	{TypeFlagsSubstitution, "Substitution"},
+	{TypeFlagsNonPrimitive, "NonPrimitive"},
+	{TypeFlagsTemplateLiteral, "TemplateLiteral"},
+	{TypeFlagsStringMapping, "StringMapping"},
+}
+
+// DescribeTypes describes the types created by the checker, in the order they were created, when --generateTrace
+// is set.
+func (c *Checker) DescribeTypes() []tracing.TypeDescriptor {
+	descriptors := make([]tracing.TypeDescriptor, 0, len(c.typeCatalog))
+	for _, t := range c.typeCatalog {
+		descriptor := tracing.TypeDescriptor{
+			Id:    uint32(t.id),
+			Flags: []string{},
+		}
+		for _, flag := range typeFlagNames {
+			if t.flags&flag.flag != 0 {
+				descriptor.Flags = append(descriptor.Flags, flag.name)
+			}
+		}
+		if intrinsic, ok := t.data.(*IntrinsicType); ok {
+			descriptor.IntrinsicName = intrinsic.intrinsicName
+		}
+		if symbol := t.symbol; symbol != nil {
+			descriptor.SymbolName = getTraceSymbolName(symbol)
+			if len(symbol.Declarations) != 0 {
+				descriptor.FirstDeclaration = getTraceLocation(symbol.Declarations[0])
+			}
+		} else if t.alias != nil {
+			descriptor.SymbolName = getTraceSymbolName(t.alias.symbol)
+		}
+		if t.alias != nil {
+			descriptor.AliasTypeArguments = getTypeIds(t.alias.typeArguments)
+		}
+		switch {
+		case t.flags&TypeFlagsUnion != 0:
+			descriptor.UnionTypes = getTypeIds(t.Types())
+		case t.flags&TypeFlagsIntersection != 0:
+			descriptor.IntersectionTypes = getTypeIds(t.Types())
+		case t.flags&TypeFlagsIndex != 0:
+			descriptor.KeyofType = uint32(t.AsIndexType().target.id)
+		case t.flags&TypeFlagsIndexedAccess != 0:
+			descriptor.IndexedAccessObjectType = uint32(t.AsIndexedAccessType().objectType.id)
+			descriptor.IndexedAccessIndexType = uint32(t.AsIndexedAccessType().indexType.id)
+		case t.objectFlags&ObjectFlagsReference != 0:
+			descriptor.IsTuple = isTupleType(t)
+			if target := t.Target(); target != nil {
+				descriptor.InstantiatedType = uint32(target.id)
+			}
+			// the type arguments of deferred references are not resolved, so as not to create types
+			descriptor.TypeArguments = getTypeIds(t.AsTypeReference().resolvedTypeArguments)
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+	return descriptors
+}
+
+// getTraceSymbolName returns the name of symbol, with the prefix of internal names, which is not valid UTF-8,
+// written as the "__" of the internal names of tsc.
+func getTraceSymbolName(symbol *ast.Symbol) string {
+	name := ast.SymbolName(symbol)
+	if rest, ok := strings.CutPrefix(name, ast.InternalSymbolNamePrefix); ok {
+		return "__" + rest
+	}
+	return name
+}
+
+func getTypeIds(types []*Type) []uint32 {
+	if len(types) == 0 {
+		return nil
+	}
+	ids := make([]uint32, len(types))
+	for i, t := range types {
+		ids[i] = uint32(t.id)
+	}
+	return ids
+}
+
+func getTraceLocation(node *ast.Node) *tracing.Location {
+	file := ast.GetSourceFileOfNode(node)
+	if file == nil {
+		return nil
+	}
+	startLine, startCharacter := scanner.GetLineAndCharacterOfPosition(file, scanner.GetTokenPosOfNode(node, file, false /*includeJsDoc*/))
+	endLine, endCharacter := scanner.GetLineAndCharacterOfPosition(file, node.End())
+	return &tracing.Location{
+		Path:  file.FileName(),
+		Start: tracing.Position{Line: startLine, Character: startCharacter},
+		End:   tracing.Position{Line: endLine, Character: endCharacter},
+	}
+}
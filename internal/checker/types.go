@@ -627,6 +627,22 @@ func (t *Type) Distributed() []*Type {
 
 // Common accessors
 
+func (t *Type) Flags() TypeFlags {
+	return t.flags
+}
+
+func (t *Type) ObjectFlags() ObjectFlags {
+	return t.objectFlags
+}
+
+func (t *Type) Symbol() *ast.Symbol {
+	return t.symbol
+}
+
+func (t *Type) Alias() *TypeAlias {
+	return t.alias
+}
+
 func (t *Type) Target() *Type {
 	switch {
 	case t.flags&TypeFlagsObject != 0:
@@ -1082,6 +1098,26 @@ type Signature struct {
 	composite                *CompositeSignature
 }
 
+func (s *Signature) Declaration() *ast.Node {
+	return s.declaration
+}
+
+func (s *Signature) TypeParameters() []*Type {
+	return s.typeParameters
+}
+
+func (s *Signature) Parameters() []*ast.Symbol {
+	return s.parameters
+}
+
+func (s *Signature) ThisParameter() *ast.Symbol {
+	return s.thisParameter
+}
+
+func (s *Signature) HasRestParameter() bool {
+	return s.flags&SignatureFlagsHasRestParameter != 0
+}
+
 type CompositeSignature struct {
 	isUnion    bool         // True for union, false for intersection
 	signatures []*Signature // Individual signatures
@@ -478,6 +478,15 @@ func getExternalModuleImportEqualsDeclarationExpression(node *ast.Node) *ast.Nod
 	return node.AsImportEqualsDeclaration().ModuleReference.AsExternalModuleReference().Expression
 }
 
+// isPartOfJSDocLinkOrSeeName reports whether a name is, or is part of, the entity name of an inline JSDoc
+// link or of a `@see` tag.
+func isPartOfJSDocLinkOrSeeName(name *ast.Node) bool {
+	for ast.IsQualifiedName(name.Parent) {
+		name = name.Parent
+	}
+	return ast.NodeKindIs(name.Parent, ast.KindJSDocLink, ast.KindJSDocLinkCode, ast.KindJSDocLinkPlain, ast.KindJSDocNameReference)
+}
+
 func isRightSideOfQualifiedNameOrPropertyAccess(node *ast.Node) bool {
 	parent := node.Parent
 	switch parent.Kind {
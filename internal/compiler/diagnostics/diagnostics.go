@@ -1,8 +1,6 @@
 // Package diagnostics contains generated localizable diagnostic messages.
 package diagnostics
 
-import "github.com/microsoft/typescript-go/internal/stringutil"
-
 //go:generate go run generate.go -output ./diagnostics_generated.go
 //go:generate go tool golang.org/x/tools/cmd/stringer -type=Category -output=stringer_generated.go
 
@@ -48,9 +46,5 @@ func (m *Message) ElidedInCompatibilityPyramid() bool { return m.elidedInCompati
 func (m *Message) ReportsDeprecated() bool            { return m.reportsDeprecated }
 
 func (m *Message) Format(args ...any) string {
-	text := m.Message()
-	if len(args) != 0 {
-		text = stringutil.Format(text, args)
-	}
-	return text
+	return format(m.text, args)
 }
@@ -0,0 +1,41 @@
+package diagnostics
+
+import (
+	"encoding/json"
+
+	"github.com/microsoft/typescript-go/internal/stringutil"
+)
+
+// Locale holds the translations of the messages into a language, as found in the
+// diagnosticMessages.generated.json files shipped with TypeScript.
+type Locale struct {
+	messages map[string]string
+}
+
+// ParseLocale parses the contents of a diagnosticMessages.generated.json file, which maps the keys of
+// messages to their translated texts.
+func ParseLocale(text string) (*Locale, error) {
+	var messages map[string]string
+	if err := json.Unmarshal([]byte(text), &messages); err != nil {
+		return nil, err
+	}
+	return &Locale{messages: messages}, nil
+}
+
+// Localize formats the message with args like Format, using its translation in locale. It falls back to
+// the English text when locale is nil or has no translation of the message.
+func (m *Message) Localize(locale *Locale, args ...any) string {
+	if locale != nil {
+		if text, ok := locale.messages[m.key]; ok {
+			return format(text, args)
+		}
+	}
+	return m.Format(args...)
+}
+
+func format(text string, args []any) string {
+	if len(args) != 0 {
+		text = stringutil.Format(text, args)
+	}
+	return text
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/transformers"
 	"github.com/microsoft/typescript-go/internal/tspath"
 )
@@ -29,12 +30,18 @@ type emitter struct {
 	writer             printer.EmitTextWriter
 	paths              *outputPaths
 	sourceFile         *ast.SourceFile
+	tracer             *tracing.Tracer
 }
 
 func (e *emitter) emit() {
-	// !!! tracing
+	span := e.tracer.Begin(tracing.PhaseEmit, "emitJsFileOrBundle", tracing.Args{"jsFilePath": e.paths.jsFilePath})
 	e.emitJsFile(e.sourceFile, e.paths.jsFilePath, e.paths.sourceMapFilePath)
-	e.emitDeclarationFile(e.sourceFile, e.paths.declarationFilePath, e.paths.declarationMapPath)
+	span.End()
+	if e.paths.declarationFilePath != "" {
+		span := e.tracer.Begin(tracing.PhaseEmit, "emitDeclarationFileOrBundle", tracing.Args{"declarationFilePath": e.paths.declarationFilePath})
+		e.emitDeclarationFile(e.sourceFile, e.paths.declarationFilePath, e.paths.declarationMapPath)
+		span.End()
+	}
 	e.emitBuildInfo(e.paths.buildInfoPath)
 }
 
@@ -67,10 +74,15 @@ func (e *emitter) getScriptTransformers(emitContext *printer.EmitContext, source
 	// JS files don't use reference calculations as they don't do import ellision, no need to calculate it
 	importElisionEnabled := !options.VerbatimModuleSyntax.IsTrue() && !ast.IsInJSFile(sourceFile.AsNode())
 
+	// JS files don't have const enums whose references need to be inlined
+	constEnumInliningEnabled := !ast.IsInJSFile(sourceFile.AsNode())
+
 	var emitResolver printer.EmitResolver
 	var referenceResolver binder.ReferenceResolver
-	if importElisionEnabled {
+	if importElisionEnabled || constEnumInliningEnabled {
 		emitResolver = e.host.GetEmitResolver(sourceFile, false /*skipDiagnostics*/) // !!! conditionally skip diagnostics
+	}
+	if importElisionEnabled {
 		emitResolver.MarkLinkedReferencesRecursively(sourceFile)
 		referenceResolver = emitResolver
 	} else {
@@ -80,6 +92,11 @@ func (e *emitter) getScriptTransformers(emitContext *printer.EmitContext, source
 	// erase types
 	tx = append(tx, transformers.NewTypeEraserTransformer(emitContext, options))
 
+	// inline references to const enum members
+	if constEnumInliningEnabled {
+		tx = append(tx, transformers.NewConstEnumInliningTransformer(emitContext, options, emitResolver))
+	}
+
 	// elide impors
 	if importElisionEnabled {
 		tx = append(tx, transformers.NewImportElisionTransformer(emitContext, options, emitResolver))
@@ -100,7 +117,11 @@ func (e *emitter) emitJsFile(sourceFile *ast.SourceFile, jsFilePath string, sour
 		return
 	}
 
-	if options.NoEmit == core.TSTrue || e.host.IsEmitBlocked(jsFilePath) {
+	if options.NoEmit == core.TSTrue {
+		return
+	}
+	if e.host.IsEmitBlocked(jsFilePath) {
+		e.emitSkipped = true
 		return
 	}
 
@@ -110,8 +131,9 @@ func (e *emitter) emitJsFile(sourceFile *ast.SourceFile, jsFilePath string, sour
 	}
 
 	printerOptions := printer.PrinterOptions{
-		NewLine:       options.NewLine,
-		NoEmitHelpers: options.NoEmitHelpers.IsTrue(),
+		NewLine:        options.NewLine,
+		RemoveComments: options.RemoveComments.IsTrue(),
+		NoEmitHelpers:  options.NoEmitHelpers.IsTrue(),
 		// !!!
 	}
 
@@ -131,7 +153,61 @@ func (e *emitter) emitJsFile(sourceFile *ast.SourceFile, jsFilePath string, sour
 }
 
 func (e *emitter) emitDeclarationFile(sourceFile *ast.SourceFile, declarationFilePath string, declarationMapPath string) {
-	// !!!
+	options := e.host.Options()
+
+	if sourceFile == nil || e.emitOnly != emitAll && e.emitOnly != emitOnlyDts || len(declarationFilePath) == 0 {
+		return
+	}
+
+	// !!! declaration emit for JS files and checker-based declaration emit; only the syntactic
+	// declaration emit used under `isolatedDeclarations` is implemented
+	if ast.IsInJSFile(sourceFile.AsNode()) || !options.IsolatedDeclarations.IsTrue() {
+		return
+	}
+
+	emitContext := printer.NewEmitContext()
+	declarationTransformer := transformers.NewDeclarationTransformer(emitContext, options)
+	sourceFile = declarationTransformer.TransformSourceFile(sourceFile)
+	declarationDiagnostics := declarationTransformer.Diagnostics()
+	for _, diagnostic := range declarationDiagnostics {
+		e.emitterDiagnostics.Add(diagnostic)
+	}
+
+	declBlocked := len(declarationDiagnostics) > 0 || e.host.IsEmitBlocked(declarationFilePath) || options.NoEmit.IsTrue()
+	if declBlocked {
+		e.emitSkipped = true
+		return
+	}
+
+	printerOptions := printer.PrinterOptions{
+		NewLine: options.NewLine,
+		// !!!
+	}
+
+	// create a printer to print the nodes
+	printer := printer.NewPrinter(printerOptions, printer.PrintHandlers{
+		// !!!
+	}, emitContext)
+
+	// !!! declaration maps
+	e.printSourceFile(declarationFilePath, "" /*sourceMapFilePath*/, sourceFile, printer)
+
+	if e.emittedFilesList != nil {
+		e.emittedFilesList = append(e.emittedFilesList, declarationFilePath)
+	}
+}
+
+// TranspileDeclaration produces the text of the declaration file for sourceFile from its syntax alone, the way
+// declaration emit does under `isolatedDeclarations`, without a program or a type checker. The returned diagnostics
+// report the declarations whose types cannot be determined without the checker; when there are any, the text is
+// incomplete and should not be written.
+func TranspileDeclaration(sourceFile *ast.SourceFile, options *core.CompilerOptions) (string, []*ast.Diagnostic) {
+	binder.BindSourceFile(sourceFile, options)
+	emitContext := printer.NewEmitContext()
+	declarationTransformer := transformers.NewDeclarationTransformer(emitContext, options)
+	declarationFile := declarationTransformer.TransformSourceFile(sourceFile)
+	printer := printer.NewPrinter(printer.PrinterOptions{NewLine: options.NewLine}, printer.PrintHandlers{}, emitContext)
+	return printer.EmitSourceFile(declarationFile), declarationTransformer.Diagnostics()
 }
 
 func (e *emitter) emitBuildInfo(buildInfoPath string) {
@@ -200,8 +276,24 @@ func getSourceMapFilePath(jsFilePath string, options *core.CompilerOptions) stri
 }
 
 func getDeclarationEmitOutputFilePath(file string, host EmitHost) string {
-	// !!!
-	return ""
+	options := host.Options()
+	outputDir := options.DeclarationDir
+	if len(outputDir) == 0 {
+		outputDir = options.OutDir
+	}
+	var path string
+	if len(outputDir) > 0 {
+		path = getSourceFilePathInNewDir(
+			file,
+			outputDir,
+			host.GetCurrentDirectory(),
+			host.CommonSourceDirectory(),
+			host.UseCaseSensitiveFileNames(),
+		)
+	} else {
+		path = file
+	}
+	return tspath.RemoveFileExtension(path) + core.GetDeclarationEmitExtensionForPath(path)
 }
 
 type outputPaths struct {
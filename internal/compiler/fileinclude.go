@@ -0,0 +1,212 @@
+package compiler
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/compiler/module"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+type FileIncludeKind int
+
+const (
+	FileIncludeKindRootFile FileIncludeKind = iota
+	FileIncludeKindSourceFromProjectReference
+	FileIncludeKindOutputFromProjectReference
+	FileIncludeKindImport
+	FileIncludeKindReferenceFile
+	FileIncludeKindTypeReferenceDirective
+	FileIncludeKindLibFile
+	FileIncludeKindLibReferenceDirective
+	FileIncludeKindAutomaticTypeDirectiveFile
+)
+
+// FileIncludeReason describes one of the reasons a file is part of a program.
+type FileIncludeReason struct {
+	Kind FileIncludeKind
+	// File is the path of the file whose import or reference includes the file, for imports, references,
+	// type reference directives and lib reference directives.
+	File tspath.Path
+	// Index is the index of the root file among the root files of the program, of the lib in the lib compiler
+	// option (-1 for the default library), or of the import or reference among those of its kind in File.
+	Index int
+	// TypeReference is the name of the type library of an automatic type directive file.
+	TypeReference string
+	// PackageId is the package the file was resolved in, if any.
+	PackageId module.PackageId
+}
+
+func (r *FileIncludeReason) isReferencedFile() bool {
+	switch r.Kind {
+	case FileIncludeKindImport, FileIncludeKindReferenceFile, FileIncludeKindTypeReferenceDirective, FileIncludeKindLibReferenceDirective:
+		return true
+	}
+	return false
+}
+
+// GetFileIncludeReasons returns the reasons each file is part of the program, by path, in the order the
+// references to the files were found.
+func (p *Program) GetFileIncludeReasons() map[tspath.Path][]*FileIncludeReason {
+	return p.fileIncludeReasons
+}
+
+// getReferencedFileLocation returns the file and range of the import or reference of a referenced file reason.
+func (p *Program) getReferencedFileLocation(reason *FileIncludeReason) (*ast.SourceFile, core.TextRange) {
+	file := p.filesByPath[reason.File]
+	switch reason.Kind {
+	case FileIncludeKindImport:
+		name := getModuleNames(file)[reason.Index]
+		return file, core.NewTextRange(scanner.SkipTrivia(file.Text, name.Pos()), name.End())
+	case FileIncludeKindReferenceFile:
+		return file, file.ReferencedFiles[reason.Index].TextRange
+	case FileIncludeKindTypeReferenceDirective:
+		return file, file.TypeReferenceDirectives[reason.Index].TextRange
+	case FileIncludeKindLibReferenceDirective:
+		return file, file.LibReferenceDirectives[reason.Index].TextRange
+	}
+	panic("Unexpected file include kind")
+}
+
+// ExplainFileIncludeReason returns a message that describes reason, such as "Imported via './a' from file
+// 'b.ts'". File names are written with fileNameConvertor, when it is not nil.
+func (p *Program) ExplainFileIncludeReason(reason *FileIncludeReason, fileNameConvertor func(fileName string) string) *ast.Diagnostic {
+	toFileName := func(fileName string) string {
+		if fileNameConvertor != nil {
+			return fileNameConvertor(fileName)
+		}
+		return fileName
+	}
+	options := p.compilerOptions
+	if reason.isReferencedFile() {
+		file, loc := p.getReferencedFileLocation(reason)
+		referenceText := file.Text[loc.Pos():loc.End()]
+		switch reason.Kind {
+		case FileIncludeKindImport:
+			if reason.PackageId.Name != "" {
+				return ast.NewCompilerDiagnostic(diagnostics.Imported_via_0_from_file_1_with_packageId_2, referenceText, toFileName(file.FileName()), reason.PackageId.String())
+			}
+			return ast.NewCompilerDiagnostic(diagnostics.Imported_via_0_from_file_1, referenceText, toFileName(file.FileName()))
+		case FileIncludeKindReferenceFile:
+			return ast.NewCompilerDiagnostic(diagnostics.Referenced_via_0_from_file_1, referenceText, toFileName(file.FileName()))
+		case FileIncludeKindTypeReferenceDirective:
+			if reason.PackageId.Name != "" {
+				return ast.NewCompilerDiagnostic(diagnostics.Type_library_referenced_via_0_from_file_1_with_packageId_2, referenceText, toFileName(file.FileName()), reason.PackageId.String())
+			}
+			return ast.NewCompilerDiagnostic(diagnostics.Type_library_referenced_via_0_from_file_1, referenceText, toFileName(file.FileName()))
+		default:
+			return ast.NewCompilerDiagnostic(diagnostics.Library_referenced_via_0_from_file_1, referenceText, toFileName(file.FileName()))
+		}
+	}
+	switch reason.Kind {
+	case FileIncludeKindRootFile:
+		if p.config == nil || p.config.ConfigFile == nil {
+			return ast.NewCompilerDiagnostic(diagnostics.Root_file_specified_for_compilation)
+		}
+		fileName := tspath.GetNormalizedAbsolutePath(p.rootFiles[reason.Index], p.host.GetCurrentDirectory())
+		if p.config.GetMatchedFileSpec(fileName, p.comparePathsOptions) != "" {
+			return ast.NewCompilerDiagnostic(diagnostics.Part_of_files_list_in_tsconfig_json)
+		}
+		includeSpec, isDefault := p.config.GetMatchedIncludeSpec(fileName, p.comparePathsOptions)
+		if includeSpec != "" {
+			return ast.NewCompilerDiagnostic(diagnostics.Matched_by_include_pattern_0_in_1, includeSpec, toFileName(p.config.ConfigFile.SourceFile.FileName()))
+		}
+		if isDefault {
+			return ast.NewCompilerDiagnostic(diagnostics.Matched_by_default_include_pattern_Asterisk_Asterisk_Slash_Asterisk)
+		}
+		return ast.NewCompilerDiagnostic(diagnostics.Root_file_specified_for_compilation)
+	case FileIncludeKindAutomaticTypeDirectiveFile:
+		if options.Types != nil {
+			if reason.PackageId.Name != "" {
+				return ast.NewCompilerDiagnostic(diagnostics.Entry_point_of_type_library_0_specified_in_compilerOptions_with_packageId_1, reason.TypeReference, reason.PackageId.String())
+			}
+			return ast.NewCompilerDiagnostic(diagnostics.Entry_point_of_type_library_0_specified_in_compilerOptions, reason.TypeReference)
+		}
+		if reason.PackageId.Name != "" {
+			return ast.NewCompilerDiagnostic(diagnostics.Entry_point_for_implicit_type_library_0_with_packageId_1, reason.TypeReference, reason.PackageId.String())
+		}
+		return ast.NewCompilerDiagnostic(diagnostics.Entry_point_for_implicit_type_library_0, reason.TypeReference)
+	case FileIncludeKindLibFile:
+		if reason.Index >= 0 && reason.Index < len(options.Lib) {
+			return ast.NewCompilerDiagnostic(diagnostics.Library_0_specified_in_compilerOptions, options.Lib[reason.Index])
+		}
+		if target := tsoptions.GetNameOfScriptTarget(options.GetEmitScriptTarget()); target != "" {
+			return ast.NewCompilerDiagnostic(diagnostics.Default_library_for_target_0, target)
+		}
+		return ast.NewCompilerDiagnostic(diagnostics.Default_library)
+	}
+	// !!! project references
+	panic("Unexpected file include kind")
+}
+
+// explainFileIncludeReasonLocation returns a diagnostic at the import or reference of a referenced file
+// reason, such as "File is included via import here.", or nil for other reasons.
+func (p *Program) explainFileIncludeReasonLocation(reason *FileIncludeReason) *ast.Diagnostic {
+	if !reason.isReferencedFile() {
+		// !!! locations of the "files" and "include" specs, and of the lib option, in the config file
+		return nil
+	}
+	var message *diagnostics.Message
+	switch reason.Kind {
+	case FileIncludeKindImport:
+		message = diagnostics.File_is_included_via_import_here
+	case FileIncludeKindReferenceFile:
+		message = diagnostics.File_is_included_via_reference_here
+	case FileIncludeKindTypeReferenceDirective:
+		message = diagnostics.File_is_included_via_type_library_reference_here
+	default:
+		message = diagnostics.File_is_included_via_library_reference_here
+	}
+	file, loc := p.getReferencedFileLocation(reason)
+	return ast.NewDiagnostic(file, loc, message)
+}
+
+// createDiagnosticExplainingFile creates a diagnostic about file that is chained to the reasons the file is
+// part of the program. The diagnostic is reported at fileProcessingReason, or else at the first import or
+// reference of the file, and the other imports and references are related information.
+func (p *Program) createDiagnosticExplainingFile(file *ast.SourceFile, fileProcessingReason *FileIncludeReason, message *diagnostics.Message, args ...any) *ast.Diagnostic {
+	var locationReason *FileIncludeReason
+	if fileProcessingReason != nil && fileProcessingReason.isReferencedFile() {
+		locationReason = fileProcessingReason
+	}
+	var fileIncludeReasons []*ast.Diagnostic
+	var relatedInformation []*ast.Diagnostic
+	seenReasons := core.Set[*FileIncludeReason]{}
+	processReason := func(reason *FileIncludeReason) {
+		if seenReasons.Has(reason) {
+			return
+		}
+		seenReasons.Add(reason)
+		fileIncludeReasons = append(fileIncludeReasons, p.ExplainFileIncludeReason(reason, nil))
+		if locationReason == nil && reason.isReferencedFile() {
+			locationReason = reason
+		} else if locationReason != reason {
+			if relatedInfo := p.explainFileIncludeReasonLocation(reason); relatedInfo != nil {
+				relatedInformation = append(relatedInformation, relatedInfo)
+			}
+		}
+	}
+	for _, reason := range p.fileIncludeReasons[file.Path()] {
+		processReason(reason)
+	}
+	if fileProcessingReason != nil {
+		processReason(fileProcessingReason)
+	}
+	if locationReason != nil && len(fileIncludeReasons) == 1 {
+		fileIncludeReasons = nil
+	}
+
+	var diagnostic *ast.Diagnostic
+	if locationReason != nil {
+		locationFile, loc := p.getReferencedFileLocation(locationReason)
+		diagnostic = ast.NewDiagnostic(locationFile, loc, message, args...)
+	} else {
+		diagnostic = ast.NewCompilerDiagnostic(message, args...)
+	}
+	if fileIncludeReasons != nil {
+		diagnostic.AddMessageChain(ast.NewCompilerDiagnostic(diagnostics.The_file_is_in_the_program_because_Colon).SetMessageChain(fileIncludeReasons))
+	}
+	return diagnostic.SetRelatedInfo(relatedInformation)
+}
@@ -8,8 +8,10 @@ import (
 	"sync"
 
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
 	"github.com/microsoft/typescript-go/internal/compiler/module"
 	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 )
@@ -22,6 +24,9 @@ type fileLoader struct {
 	resolver             *module.Resolver
 	resolvedModulesMutex sync.Mutex
 	resolvedModules      map[tspath.Path]module.ModeAwareCache[*module.ResolvedModule]
+	// failedLookupLocations are the locations the module resolutions of each file found no file at.
+	failedLookupLocations map[tspath.Path][]string
+	oldProgram            *Program
 
 	mu                      sync.Mutex
 	wg                      core.WorkGroup
@@ -31,6 +36,26 @@ type fileLoader struct {
 	comparePathsOptions     tspath.ComparePathsOptions
 	rootTasks               []*parseTask
 	supportedExtensions     []string
+	includeReasons          map[tspath.Path][]*FileIncludeReason
+
+	// includedFileNames are the names the files were first referenced by, by path; includedPathsIgnoreCase are
+	// the paths of the files by lower case path, when file names are case sensitive.
+	includedFileNames         map[tspath.Path]string
+	includedPathsIgnoreCase   map[string]tspath.Path
+	fileProcessingDiagnostics []*fileProcessingDiagnostic
+
+	// automaticTypeDirectiveTraces are the traces of the resolutions of the automatic type directives, when
+	// traceResolution is set.
+	automaticTypeDirectiveTraces []string
+}
+
+// fileProcessingDiagnostic is a problem with a file found while the files of a program are loaded, which is
+// reported with the reasons the file is part of the program once all of them are known.
+type fileProcessingDiagnostic struct {
+	file    tspath.Path
+	reason  *FileIncludeReason
+	message *diagnostics.Message
+	args    []any
 }
 
 func processAllProgramFiles(
@@ -40,55 +65,80 @@ func processAllProgramFiles(
 	resolver *module.Resolver,
 	rootFiles []string,
 	libs []string,
-) (files []*ast.SourceFile, resolvedModules map[tspath.Path]module.ModeAwareCache[*module.ResolvedModule]) {
+	automaticTypeDirectiveNames []string,
+	oldProgram *Program,
+) (files []*ast.SourceFile, resolvedModules map[tspath.Path]module.ModeAwareCache[*module.ResolvedModule], failedLookupLocations map[tspath.Path][]string, includeReasons map[tspath.Path][]*FileIncludeReason, fileProcessingDiagnostics []*fileProcessingDiagnostic) {
 	supportedExtensions := tsoptions.GetSupportedExtensions(compilerOptions, nil /*extraFileExtensions*/)
 	loader := fileLoader{
 		host:               host,
 		programOptions:     programOptions,
 		compilerOptions:    compilerOptions,
 		resolver:           resolver,
+		oldProgram:         oldProgram,
 		tasksByFileName:    make(map[string]*parseTask),
 		defaultLibraryPath: tspath.GetNormalizedAbsolutePath(host.DefaultLibraryPath(), host.GetCurrentDirectory()),
 		comparePathsOptions: tspath.ComparePathsOptions{
 			UseCaseSensitiveFileNames: host.FS().UseCaseSensitiveFileNames(),
 			CurrentDirectory:          host.GetCurrentDirectory(),
 		},
-		wg:                  core.NewWorkGroup(programOptions.SingleThreaded),
+		// resolutions share caches, so they are traced the same way every time only if they run one at a time
+		wg:                  core.NewWorkGroup(programOptions.SingleThreaded || compilerOptions.TraceResolution.IsTrue()),
 		rootTasks:           make([]*parseTask, 0, len(rootFiles)+len(libs)),
 		supportedExtensions: core.Flatten(tsoptions.GetSupportedExtensionsWithJsonIfResolveJsonModule(compilerOptions, supportedExtensions)),
+		includeReasons:      make(map[tspath.Path][]*FileIncludeReason),
+		includedFileNames:   make(map[tspath.Path]string),
+	}
+	if loader.comparePathsOptions.UseCaseSensitiveFileNames {
+		loader.includedPathsIgnoreCase = make(map[string]tspath.Path)
 	}
 
 	loader.addRootTasks(rootFiles, false)
 	loader.addRootTasks(libs, true)
-	loader.addAutomaticTypeDirectiveTasks()
+	automaticTypeDirectiveTasksStart := len(loader.rootTasks)
+	loader.addAutomaticTypeDirectiveTasks(automaticTypeDirectiveNames)
 
 	loader.startTasks(loader.rootTasks)
 
 	loader.wg.RunAndWait()
 
+	// the traces of the resolutions are written as the tasks are collected, so that they are in the order of
+	// the files rather than in the order the resolutions happened to run in; as in tsc, the automatic type
+	// directives are resolved once the root files have been processed
 	files, libFiles := []*ast.SourceFile{}, []*ast.SourceFile{}
-	for task := range loader.collectTasks(loader.rootTasks) {
-		if task.isLib {
-			libFiles = append(libFiles, task.file)
-		} else {
-			files = append(files, task.file)
+	collect := func(tasks []*parseTask) {
+		for task := range loader.collectTasks(tasks) {
+			if task.isLib {
+				libFiles = append(libFiles, task.file)
+			} else {
+				files = append(files, task.file)
+			}
 		}
 	}
+	collect(loader.rootTasks[:automaticTypeDirectiveTasksStart])
+	loader.writeTraces(loader.automaticTypeDirectiveTraces)
+	collect(loader.rootTasks[automaticTypeDirectiveTasksStart:])
 	loader.sortLibs(libFiles)
 
-	return append(libFiles, files...), loader.resolvedModules
+	return append(libFiles, files...), loader.resolvedModules, loader.failedLookupLocations, loader.includeReasons, loader.fileProcessingDiagnostics
 }
 
 func (p *fileLoader) addRootTasks(files []string, isLib bool) {
-	for _, fileName := range files {
+	for i, fileName := range files {
 		absPath := tspath.GetNormalizedAbsolutePath(fileName, p.host.GetCurrentDirectory())
 		if core.Tristate.IsTrue(p.compilerOptions.AllowNonTsExtensions) || slices.Contains(p.supportedExtensions, tspath.TryGetExtensionFromPath(absPath)) {
-			p.rootTasks = append(p.rootTasks, &parseTask{normalizedFilePath: absPath, isLib: isLib})
+			reason := &FileIncludeReason{Kind: FileIncludeKindRootFile, Index: i}
+			if isLib {
+				reason = &FileIncludeReason{Kind: FileIncludeKindLibFile, Index: i}
+				if p.compilerOptions.Lib == nil {
+					reason.Index = -1
+				}
+			}
+			p.rootTasks = append(p.rootTasks, &parseTask{normalizedFilePath: absPath, isLib: isLib, includeReason: reason})
 		}
 	}
 }
 
-func (p *fileLoader) addAutomaticTypeDirectiveTasks() {
+func (p *fileLoader) addAutomaticTypeDirectiveTasks(automaticTypeDirectiveNames []string) {
 	var containingDirectory string
 	if p.compilerOptions.ConfigFilePath != "" {
 		containingDirectory = tspath.GetDirectoryPath(p.compilerOptions.ConfigFilePath)
@@ -97,11 +147,14 @@ func (p *fileLoader) addAutomaticTypeDirectiveTasks() {
 	}
 	containingFileName := tspath.CombinePaths(containingDirectory, module.InferredTypesContainingFile)
 
-	automaticTypeDirectiveNames := module.GetAutomaticTypeDirectiveNames(p.compilerOptions, p.host)
 	for _, name := range automaticTypeDirectiveNames {
-		resolved := p.resolver.ResolveTypeReferenceDirective(name, containingFileName, core.ModuleKindNodeNext, nil)
+		resolved := p.resolveTypeReferenceDirective(name, containingFileName, core.ModuleKindNodeNext, &p.automaticTypeDirectiveTraces)
 		if resolved.IsResolved() {
-			p.rootTasks = append(p.rootTasks, &parseTask{normalizedFilePath: resolved.ResolvedFileName, isLib: false})
+			p.rootTasks = append(p.rootTasks, &parseTask{
+				normalizedFilePath: resolved.ResolvedFileName,
+				isLib:              false,
+				includeReason:      &FileIncludeReason{Kind: FileIncludeKindAutomaticTypeDirectiveFile, TypeReference: name, PackageId: resolved.PackageId},
+			})
 		}
 	}
 }
@@ -110,15 +163,20 @@ func (p *fileLoader) startTasks(tasks []*parseTask) {
 	if len(tasks) > 0 {
 		p.mu.Lock()
 		defer p.mu.Unlock()
-		for i, task := range tasks {
-			// dedup tasks to ensure correct file order, regardless of which task would be started first
-			if existingTask, ok := p.tasksByFileName[task.normalizedFilePath]; ok {
-				tasks[i] = existingTask
-			} else {
+		var startedTasks []*parseTask
+		for _, task := range tasks {
+			// dedup tasks; collectTasks walks the task that was started first, wherever the file is referenced,
+			// to ensure correct file order regardless of which task would be started first
+			if _, ok := p.tasksByFileName[task.normalizedFilePath]; !ok {
 				p.tasksByFileName[task.normalizedFilePath] = task
-				task.start(p)
+				startedTasks = append(startedTasks, task)
 			}
 		}
+		// a single-threaded work group runs the task queued last first, so queueing the tasks in reverse
+		// processes the files depth first in the order they are referenced, as tsc does
+		for _, task := range slices.Backward(startedTasks) {
+			task.start(p)
+		}
 	}
 }
 
@@ -130,9 +188,22 @@ func (p *fileLoader) collectTasks(tasks []*parseTask) iter.Seq[*parseTask] {
 
 func (p *fileLoader) collectTasksWorker(tasks []*parseTask, yield func(*parseTask) bool) bool {
 	for _, task := range tasks {
-		if _, ok := p.tasksByFileName[task.normalizedFilePath]; ok {
+		// every reference to the file is a reason for its inclusion, even though its task is only walked once
+		path := tspath.ToPath(task.normalizedFilePath, p.host.GetCurrentDirectory(), p.host.FS().UseCaseSensitiveFileNames())
+		p.includeFile(task, path)
+		p.includeReasons[path] = append(p.includeReasons[path], task.includeReason)
+
+		// a file referenced by names that differ only in casing is parsed once for each name, but only the
+		// first name is part of the program
+		if p.includedFileNames[path] != task.normalizedFilePath {
+			continue
+		}
+
+		if startedTask, ok := p.tasksByFileName[task.normalizedFilePath]; ok {
 			// ensure we only walk each task once
 			delete(p.tasksByFileName, task.normalizedFilePath)
+			task = startedTask
+			p.writeTraces(task.traces)
 
 			if len(task.subTasks) > 0 {
 				if !p.collectTasksWorker(task.subTasks, yield) {
@@ -150,6 +221,57 @@ func (p *fileLoader) collectTasksWorker(tasks []*parseTask, yield func(*parseTas
 	return true
 }
 
+// includeFile records the name a file is first referenced by and, unless forceConsistentCasingInFileNames is
+// disabled, reports references of the file, or of another file, by a name that differs only in casing.
+func (p *fileLoader) includeFile(task *parseTask, path tspath.Path) {
+	checkCasing := !p.compilerOptions.ForceConsistentCasingInFileNames.IsFalse()
+	if includedFileName, ok := p.includedFileNames[path]; ok {
+		if checkCasing && includedFileName != task.normalizedFilePath {
+			p.reportFileNamesDifferOnlyInCasing(task.normalizedFilePath, path, includedFileName, task.includeReason)
+		}
+		return
+	}
+	p.includedFileNames[path] = task.normalizedFilePath
+	if !checkCasing {
+		return
+	}
+	if p.includedPathsIgnoreCase != nil {
+		pathLowerCase := tspath.ToFileNameLowerCase(string(path))
+		if includedPath, ok := p.includedPathsIgnoreCase[pathLowerCase]; ok {
+			p.reportFileNamesDifferOnlyInCasing(task.normalizedFilePath, includedPath, p.includedFileNames[includedPath], task.includeReason)
+		} else {
+			p.includedPathsIgnoreCase[pathLowerCase] = path
+		}
+	} else if startedTask, ok := p.tasksByFileName[task.normalizedFilePath]; ok && p.differsOnlyInCasing(task.normalizedFilePath, startedTask.realFileName) {
+		p.reportFileNamesDifferOnlyInCasing(task.normalizedFilePath, path, startedTask.realFileName, task.includeReason)
+	}
+}
+
+// differsOnlyInCasing reports whether fileName is the real name of a file written in another casing, outside
+// of the current directory, whose casing is up to the user.
+func (p *fileLoader) differsOnlyInCasing(fileName string, realFileName string) bool {
+	if fileName == realFileName || len(fileName) != len(realFileName) || !strings.EqualFold(fileName, realFileName) {
+		return false
+	}
+	currentDirectory := tspath.EnsureTrailingDirectorySeparator(p.comparePathsOptions.CurrentDirectory)
+	if strings.HasPrefix(strings.ToLower(fileName), strings.ToLower(currentDirectory)) {
+		return fileName[len(currentDirectory):] != realFileName[len(currentDirectory):]
+	}
+	return true
+}
+
+func (p *fileLoader) reportFileNamesDifferOnlyInCasing(fileName string, existingPath tspath.Path, existingFileName string, reason *FileIncludeReason) {
+	diagnostic := &fileProcessingDiagnostic{file: existingPath, reason: reason}
+	if !reason.isReferencedFile() && slices.ContainsFunc(p.includeReasons[existingPath], (*FileIncludeReason).isReferencedFile) {
+		diagnostic.message = diagnostics.Already_included_file_name_0_differs_from_file_name_1_only_in_casing
+		diagnostic.args = []any{existingFileName, fileName}
+	} else {
+		diagnostic.message = diagnostics.File_name_0_differs_from_already_included_file_name_1_only_in_casing
+		diagnostic.args = []any{fileName, existingFileName}
+	}
+	p.fileProcessingDiagnostics = append(p.fileProcessingDiagnostics, diagnostic)
+}
+
 func (p *fileLoader) sortLibs(libFiles []*ast.SourceFile) {
 	slices.SortFunc(libFiles, func(f1 *ast.SourceFile, f2 *ast.SourceFile) int {
 		return cmp.Compare(p.getDefaultLibFilePriority(f1), p.getDefaultLibFilePriority(f2))
@@ -181,40 +303,47 @@ type parseTask struct {
 	file               *ast.SourceFile
 	isLib              bool
 	subTasks           []*parseTask
+	includeReason      *FileIncludeReason
+	// realFileName is the name of the file as written on disk, when file names are not case sensitive.
+	realFileName string
+	// traces are the traces of the resolutions of the references and imports of the file, when traceResolution
+	// is set.
+	traces []string
 }
 
 func (t *parseTask) start(loader *fileLoader) {
 	loader.wg.Queue(func() {
 		file := loader.parseSourceFile(t.normalizedFilePath)
+		if !t.isLib && !loader.comparePathsOptions.UseCaseSensitiveFileNames && !loader.compilerOptions.ForceConsistentCasingInFileNames.IsFalse() {
+			t.realFileName = loader.host.FS().Realpath(t.normalizedFilePath)
+		}
 
 		// !!! if noResolve, skip all of this
 		t.subTasks = make([]*parseTask, 0, len(file.ReferencedFiles)+len(file.Imports)+len(file.ModuleAugmentations))
 
-		for _, ref := range file.ReferencedFiles {
+		for i, ref := range file.ReferencedFiles {
 			resolvedPath := loader.resolveTripleslashPathReference(ref.FileName, file.FileName())
-			t.addSubTask(resolvedPath, false)
+			t.addSubTask(resolvedPath, false, &FileIncludeReason{Kind: FileIncludeKindReferenceFile, File: file.Path(), Index: i})
 		}
 
-		for _, ref := range file.TypeReferenceDirectives {
-			resolved := loader.resolver.ResolveTypeReferenceDirective(ref.FileName, file.FileName(), core.ModuleKindCommonJS /* !!! */, nil)
+		for i, ref := range file.TypeReferenceDirectives {
+			resolved := loader.resolveTypeReferenceDirective(ref.FileName, file.FileName(), core.ModuleKindCommonJS /* !!! */, &t.traces)
 			if resolved.IsResolved() {
-				t.addSubTask(resolved.ResolvedFileName, false)
+				t.addSubTask(resolved.ResolvedFileName, false, &FileIncludeReason{Kind: FileIncludeKindTypeReferenceDirective, File: file.Path(), Index: i, PackageId: resolved.PackageId})
 			}
 		}
 
 		if loader.compilerOptions.NoLib != core.TSTrue {
-			for _, lib := range file.LibReferenceDirectives {
+			for i, lib := range file.LibReferenceDirectives {
 				name, ok := tsoptions.GetLibFileName(lib.FileName)
 				if !ok {
 					continue
 				}
-				t.addSubTask(tspath.CombinePaths(loader.defaultLibraryPath, name), true)
+				t.addSubTask(tspath.CombinePaths(loader.defaultLibraryPath, name), true, &FileIncludeReason{Kind: FileIncludeKindLibReferenceDirective, File: file.Path(), Index: i})
 			}
 		}
 
-		for _, imp := range loader.resolveImportsAndModuleAugmentations(file) {
-			t.addSubTask(imp, false)
-		}
+		t.subTasks = append(t.subTasks, loader.resolveImportsAndModuleAugmentations(file, &t.traces)...)
 
 		t.file = file
 		loader.startTasks(t.subTasks)
@@ -222,14 +351,15 @@ func (t *parseTask) start(loader *fileLoader) {
 }
 
 func (p *fileLoader) parseSourceFile(fileName string) *ast.SourceFile {
+	defer p.programOptions.Tracer.Begin(tracing.PhaseParse, "createSourceFile", tracing.Args{"path": fileName}).End()
 	path := tspath.ToPath(fileName, p.host.GetCurrentDirectory(), p.host.FS().UseCaseSensitiveFileNames())
 	sourceFile := p.host.GetSourceFile(fileName, path, p.compilerOptions.GetEmitScriptTarget())
 	return sourceFile
 }
 
-func (t *parseTask) addSubTask(fileName string, isLib bool) {
+func (t *parseTask) addSubTask(fileName string, isLib bool, includeReason *FileIncludeReason) {
 	normalizedFilePath := tspath.NormalizePath(fileName)
-	t.subTasks = append(t.subTasks, &parseTask{normalizedFilePath: normalizedFilePath, isLib: isLib})
+	t.subTasks = append(t.subTasks, &parseTask{normalizedFilePath: normalizedFilePath, isLib: isLib, includeReason: includeReason})
 }
 
 func (p *fileLoader) resolveTripleslashPathReference(moduleName string, containingFile string) string {
@@ -242,11 +372,11 @@ func (p *fileLoader) resolveTripleslashPathReference(moduleName string, containi
 	return tspath.NormalizePath(referencedFileName)
 }
 
-func (p *fileLoader) resolveImportsAndModuleAugmentations(file *ast.SourceFile) []string {
-	toParse := make([]string, 0, len(file.Imports))
+func (p *fileLoader) resolveImportsAndModuleAugmentations(file *ast.SourceFile, traces *[]string) []*parseTask {
+	toParse := make([]*parseTask, 0, len(file.Imports))
 	if len(file.Imports) > 0 || len(file.ModuleAugmentations) > 0 {
 		moduleNames := getModuleNames(file)
-		resolutions := p.resolveModuleNames(moduleNames, file)
+		resolutions, failedLookupLocations := p.resolveModuleNames(moduleNames, file, traces)
 
 		resolutionsInFile := make(module.ModeAwareCache[*module.ResolvedModule], len(resolutions))
 
@@ -256,6 +386,12 @@ func (p *fileLoader) resolveImportsAndModuleAugmentations(file *ast.SourceFile)
 			p.resolvedModules = make(map[tspath.Path]module.ModeAwareCache[*module.ResolvedModule])
 		}
 		p.resolvedModules[file.Path()] = resolutionsInFile
+		if len(failedLookupLocations) > 0 {
+			if p.failedLookupLocations == nil {
+				p.failedLookupLocations = make(map[tspath.Path][]string)
+			}
+			p.failedLookupLocations[file.Path()] = failedLookupLocations
+		}
 
 		for i, resolution := range resolutions {
 			resolvedFileName := resolution.ResolvedFileName
@@ -279,29 +415,84 @@ func (p *fileLoader) resolveImportsAndModuleAugmentations(file *ast.SourceFile)
 			// TODO(ercornel): !!!: other checks on whether or not to add the file
 
 			if shouldAddFile {
-				// p.findSourceFile(resolvedFileName, FileIncludeReason{Import, 0})
-				toParse = append(toParse, resolvedFileName)
+				toParse = append(toParse, &parseTask{
+					normalizedFilePath: tspath.NormalizePath(resolvedFileName),
+					includeReason:      &FileIncludeReason{Kind: FileIncludeKindImport, File: file.Path(), Index: i, PackageId: resolution.PackageId},
+				})
 			}
 		}
 	}
 	return toParse
 }
 
-func (p *fileLoader) resolveModuleNames(entries []*ast.Node, file *ast.SourceFile) []*module.ResolvedModule {
+func (p *fileLoader) resolveModuleNames(entries []*ast.Node, file *ast.SourceFile, traces *[]string) (resolvedModules []*module.ResolvedModule, failedLookupLocations []string) {
 	if len(entries) == 0 {
-		return nil
+		return nil, nil
 	}
+	defer p.programOptions.Tracer.Begin(tracing.PhaseProgram, "resolveModuleNamesWorker", tracing.Args{"containingFileName": file.FileName()}).End()
+
+	resolvedModules = make([]*module.ResolvedModule, 0, len(entries))
 
-	resolvedModules := make([]*module.ResolvedModule, 0, len(entries))
+	// Resolutions of files that are unchanged since the old program are reused, unless a file has been created
+	// where they found none.
+	var oldResolutions module.ModeAwareCache[*module.ResolvedModule]
+	if p.oldProgram != nil && p.oldProgram.filesByPath[file.Path()] == file &&
+		(p.programOptions.HasInvalidatedResolutions == nil || !p.programOptions.HasInvalidatedResolutions(file.Path())) {
+		oldResolutions = p.oldProgram.resolvedModules[file.Path()]
+		failedLookupLocations = slices.Clip(p.oldProgram.failedLookupLocations[file.Path()])
+	}
 
 	for _, entry := range entries {
 		moduleName := entry.Text()
 		if moduleName == "" {
 			continue
 		}
-		resolvedModule := p.resolver.ResolveModuleName(moduleName, file.FileName(), core.ModuleKindCommonJS /* !!! */, nil)
+		if resolvedModule, ok := oldResolutions[module.ModeAwareCacheKey{Name: moduleName, Mode: core.ModuleKindCommonJS}]; ok {
+			resolvedModules = append(resolvedModules, resolvedModule)
+			continue
+		}
+		resolvedModule := p.resolveModuleName(moduleName, file.FileName(), core.ModuleKindCommonJS /* !!! */, traces)
 		resolvedModules = append(resolvedModules, resolvedModule)
+		if lookupLocations := p.resolver.GetLookupLocationsForResolvedModule(resolvedModule); lookupLocations != nil {
+			failedLookupLocations = append(failedLookupLocations, lookupLocations.FailedLookupLocations...)
+		}
 	}
 
-	return resolvedModules
+	return resolvedModules, failedLookupLocations
+}
+
+// resolveModuleName resolves a module name and, when traceResolution is set, appends the trace of the resolution
+// to traces rather than writing it to the host right away.
+func (p *fileLoader) resolveModuleName(moduleName string, containingFile string, resolutionMode core.ResolutionMode, traces *[]string) *module.ResolvedModule {
+	if !p.compilerOptions.TraceResolution.IsTrue() {
+		return p.resolver.ResolveModuleName(moduleName, containingFile, resolutionMode, nil)
+	}
+	resolvedModule, events := p.resolver.ResolveModuleNameWithTrace(moduleName, containingFile, resolutionMode, nil)
+	*traces = appendTraceMessages(*traces, events)
+	return resolvedModule
+}
+
+// resolveTypeReferenceDirective resolves a type reference directive and, when traceResolution is set, appends
+// the trace of the resolution to traces rather than writing it to the host right away.
+func (p *fileLoader) resolveTypeReferenceDirective(name string, containingFile string, resolutionMode core.ResolutionMode, traces *[]string) *module.ResolvedTypeReferenceDirective {
+	defer p.programOptions.Tracer.Begin(tracing.PhaseProgram, "resolveTypeReferenceDirective", tracing.Args{"typeReferenceDirective": name, "containingFileName": containingFile}).End()
+	if !p.compilerOptions.TraceResolution.IsTrue() {
+		return p.resolver.ResolveTypeReferenceDirective(name, containingFile, resolutionMode, nil)
+	}
+	resolved, events := p.resolver.ResolveTypeReferenceDirectiveWithTrace(name, containingFile, resolutionMode, nil)
+	*traces = appendTraceMessages(*traces, events)
+	return resolved
+}
+
+func appendTraceMessages(traces []string, events []module.TraceEvent) []string {
+	for _, event := range events {
+		traces = append(traces, event.Message)
+	}
+	return traces
+}
+
+func (p *fileLoader) writeTraces(traces []string) {
+	for _, trace := range traces {
+		p.host.Trace(trace)
+	}
 }
@@ -30,14 +30,22 @@ type compilerHost struct {
 	currentDirectory   string
 	fs                 vfs.FS
 	defaultLibraryPath string
+	trace              func(msg string)
 }
 
 func NewCompilerHost(options *core.CompilerOptions, currentDirectory string, fs vfs.FS, defaultLibraryPath string) CompilerHost {
+	return NewCompilerHostWithTrace(options, currentDirectory, fs, defaultLibraryPath, nil)
+}
+
+// NewCompilerHostWithTrace creates a compiler host that writes traces, such as those of --traceResolution, with
+// trace.
+func NewCompilerHostWithTrace(options *core.CompilerOptions, currentDirectory string, fs vfs.FS, defaultLibraryPath string, trace func(msg string)) CompilerHost {
 	h := &compilerHost{}
 	h.options = options
 	h.currentDirectory = currentDirectory
 	h.fs = fs
 	h.defaultLibraryPath = defaultLibraryPath
+	h.trace = trace
 	return h
 }
 
@@ -65,7 +73,9 @@ func (h *compilerHost) NewLine() string {
 }
 
 func (h *compilerHost) Trace(msg string) {
-	//!!! TODO: implement
+	if h.trace != nil {
+		h.trace(msg)
+	}
 }
 
 func (h *compilerHost) GetSourceFile(fileName string, path tspath.Path, languageVersion core.ScriptTarget) *ast.SourceFile {
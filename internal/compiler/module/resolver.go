@@ -53,6 +53,8 @@ type resolutionState struct {
 	extensions          extensions
 	compilerOptions     *core.CompilerOptions
 	redirectedReference *ResolvedProjectReference
+	// tracer receives the steps of the resolution; it is nil unless the resolution is traced
+	tracer *tracer
 
 	// state fields
 	resultFromCache                 *ResolvedModule
@@ -71,12 +73,14 @@ func newResolutionState(
 	compilerOptions *core.CompilerOptions,
 	redirectedReference *ResolvedProjectReference,
 	resolver *Resolver,
+	tracer *tracer,
 ) *resolutionState {
 	state := &resolutionState{
 		name:                name,
 		containingDirectory: containingDirectory,
 		compilerOptions:     compilerOptions,
 		resolver:            resolver,
+		tracer:              tracer,
 	}
 
 	if redirectedReference != nil {
@@ -137,16 +141,34 @@ func (r *Resolver) GetLookupLocationsForResolvedTypeReferenceDirective(resolvedT
 	return r.typeReferenceDirectiveCache.getLookupLocations(resolvedTypeReferenceDirective)
 }
 
-func (r *Resolver) traceEnabled() bool {
-	return r.compilerOptions.TraceResolution == core.TSTrue
+// newTracer returns the tracer that writes the steps of a resolution to the host, or nil when traceResolution is
+// not set.
+func (r *Resolver) newTracer() *tracer {
+	if r.compilerOptions.TraceResolution == core.TSTrue {
+		return &tracer{host: r.host}
+	}
+	return nil
 }
 
 func (r *Resolver) GetPackageScopeForPath(directory string) *packagejson.InfoCacheEntry {
-	return (&resolutionState{compilerOptions: r.compilerOptions, resolver: r}).getPackageScopeForPath(directory)
+	return (&resolutionState{compilerOptions: r.compilerOptions, resolver: r, tracer: r.newTracer()}).getPackageScopeForPath(directory)
 }
 
 func (r *Resolver) ResolveTypeReferenceDirective(typeReferenceDirectiveName string, containingFile string, resolutionMode core.ResolutionMode, redirectedReference *ResolvedProjectReference) *ResolvedTypeReferenceDirective {
-	traceEnabled := r.traceEnabled()
+	return r.resolveTypeReferenceDirective(typeReferenceDirectiveName, containingFile, resolutionMode, redirectedReference, r.newTracer())
+}
+
+// ResolveTypeReferenceDirectiveWithTrace resolves a type reference directive like ResolveTypeReferenceDirective,
+// and returns the steps of the resolution whether or not traceResolution is set. The steps are not written to the
+// host.
+func (r *Resolver) ResolveTypeReferenceDirectiveWithTrace(typeReferenceDirectiveName string, containingFile string, resolutionMode core.ResolutionMode, redirectedReference *ResolvedProjectReference) (*ResolvedTypeReferenceDirective, []TraceEvent) {
+	tracer := &tracer{collect: true}
+	result := r.resolveTypeReferenceDirective(typeReferenceDirectiveName, containingFile, resolutionMode, redirectedReference, tracer)
+	return result, tracer.events
+}
+
+func (r *Resolver) resolveTypeReferenceDirective(typeReferenceDirectiveName string, containingFile string, resolutionMode core.ResolutionMode, redirectedReference *ResolvedProjectReference, tracer *tracer) *ResolvedTypeReferenceDirective {
+	traceEnabled := tracer != nil
 
 	compilerOptions := r.compilerOptions
 	if redirectedReference != nil {
@@ -158,25 +180,25 @@ func (r *Resolver) ResolveTypeReferenceDirective(typeReferenceDirectiveName stri
 
 	if result != nil {
 		if traceEnabled {
-			r.host.Trace(diagnostics.Resolving_type_reference_directive_0_containing_file_1.Format(typeReferenceDirectiveName, containingFile))
+			tracer.message(diagnostics.Resolving_type_reference_directive_0_containing_file_1.Format(typeReferenceDirectiveName, containingFile))
 			if redirectedReference != nil {
-				r.host.Trace(diagnostics.Using_compiler_options_of_project_reference_redirect_0.Format(redirectedReference.SourceFile.FileName()))
+				tracer.message(diagnostics.Using_compiler_options_of_project_reference_redirect_0.Format(redirectedReference.SourceFile.FileName()))
 			}
-			r.host.Trace(diagnostics.Resolution_for_type_reference_directive_0_was_found_in_cache_from_location_1.Format(typeReferenceDirectiveName, containingDirectory))
-			r.traceTypeReferenceDirectiveResult(typeReferenceDirectiveName, result)
+			tracer.message(diagnostics.Resolution_for_type_reference_directive_0_was_found_in_cache_from_location_1.Format(typeReferenceDirectiveName, containingDirectory))
+			traceTypeReferenceDirectiveResult(tracer, typeReferenceDirectiveName, result)
 		}
 		return result
 	}
 
 	typeRoots, fromConfig := compilerOptions.GetEffectiveTypeRoots(r.host.GetCurrentDirectory())
 	if traceEnabled {
-		r.host.Trace(diagnostics.Resolving_type_reference_directive_0_containing_file_1_root_directory_2.Format(typeReferenceDirectiveName, containingFile, strings.Join(typeRoots, ",")))
+		tracer.message(diagnostics.Resolving_type_reference_directive_0_containing_file_1_root_directory_2.Format(typeReferenceDirectiveName, containingFile, strings.Join(typeRoots, ",")))
 		if redirectedReference != nil {
-			r.host.Trace(diagnostics.Using_compiler_options_of_project_reference_redirect_0.Format(redirectedReference.SourceFile.FileName()))
+			tracer.message(diagnostics.Using_compiler_options_of_project_reference_redirect_0.Format(redirectedReference.SourceFile.FileName()))
 		}
 	}
 
-	state := newResolutionState(typeReferenceDirectiveName, containingDirectory, true /*isTypeReferenceDirective*/, resolutionMode, compilerOptions, redirectedReference, r)
+	state := newResolutionState(typeReferenceDirectiveName, containingDirectory, true /*isTypeReferenceDirective*/, resolutionMode, compilerOptions, redirectedReference, r, tracer)
 	result = state.resolveTypeReferenceDirective(typeRoots, fromConfig, strings.HasSuffix(containingFile, InferredTypesContainingFile))
 
 	if !r.typeReferenceDirectiveCache.isReadonly {
@@ -188,13 +210,25 @@ func (r *Resolver) ResolveTypeReferenceDirective(typeReferenceDirectiveName stri
 	}
 
 	if traceEnabled {
-		r.traceTypeReferenceDirectiveResult(typeReferenceDirectiveName, result)
+		traceTypeReferenceDirectiveResult(tracer, typeReferenceDirectiveName, result)
 	}
 	return result
 }
 
 func (r *Resolver) ResolveModuleName(moduleName string, containingFile string, resolutionMode core.ResolutionMode, redirectedReference *ResolvedProjectReference) *ResolvedModule {
-	traceEnabled := r.traceEnabled()
+	return r.resolveModuleName(moduleName, containingFile, resolutionMode, redirectedReference, r.newTracer())
+}
+
+// ResolveModuleNameWithTrace resolves a module name like ResolveModuleName, and returns the steps of the
+// resolution whether or not traceResolution is set. The steps are not written to the host.
+func (r *Resolver) ResolveModuleNameWithTrace(moduleName string, containingFile string, resolutionMode core.ResolutionMode, redirectedReference *ResolvedProjectReference) (*ResolvedModule, []TraceEvent) {
+	tracer := &tracer{collect: true}
+	result := r.resolveModuleName(moduleName, containingFile, resolutionMode, redirectedReference, tracer)
+	return result, tracer.events
+}
+
+func (r *Resolver) resolveModuleName(moduleName string, containingFile string, resolutionMode core.ResolutionMode, redirectedReference *ResolvedProjectReference, tracer *tracer) *ResolvedModule {
+	traceEnabled := tracer != nil
 
 	compilerOptions := r.compilerOptions
 	if redirectedReference != nil {
@@ -202,9 +236,9 @@ func (r *Resolver) ResolveModuleName(moduleName string, containingFile string, r
 	}
 
 	if traceEnabled {
-		r.host.Trace(diagnostics.Resolving_module_0_from_1.Format(moduleName, containingFile))
+		tracer.message(diagnostics.Resolving_module_0_from_1.Format(moduleName, containingFile))
 		if redirectedReference != nil {
-			r.host.Trace(diagnostics.Using_compiler_options_of_project_reference_redirect_0.Format(redirectedReference.SourceFile.FileName()))
+			tracer.message(diagnostics.Using_compiler_options_of_project_reference_redirect_0.Format(redirectedReference.SourceFile.FileName()))
 		}
 	}
 	containingDirectory := tspath.GetDirectoryPath(containingFile)
@@ -212,24 +246,24 @@ func (r *Resolver) ResolveModuleName(moduleName string, containingFile string, r
 
 	if result != nil {
 		if traceEnabled {
-			r.host.Trace(diagnostics.Resolution_for_module_0_was_found_in_cache_from_location_1.Format(moduleName, containingDirectory))
+			tracer.message(diagnostics.Resolution_for_module_0_was_found_in_cache_from_location_1.Format(moduleName, containingDirectory))
 		}
 	} else {
 		moduleResolution := compilerOptions.ModuleResolution
 		if moduleResolution == core.ModuleResolutionKindUnknown {
 			moduleResolution = compilerOptions.GetModuleResolutionKind()
 			if traceEnabled {
-				r.host.Trace(diagnostics.Module_resolution_kind_is_not_specified_using_0.Format(moduleResolution.String()))
+				tracer.message(diagnostics.Module_resolution_kind_is_not_specified_using_0.Format(moduleResolution.String()))
 			}
 		} else {
 			if traceEnabled {
-				r.host.Trace(diagnostics.Explicitly_specified_module_resolution_kind_Colon_0.Format(moduleResolution.String()))
+				tracer.message(diagnostics.Explicitly_specified_module_resolution_kind_Colon_0.Format(moduleResolution.String()))
 			}
 		}
 
 		switch moduleResolution {
 		case core.ModuleResolutionKindNode16, core.ModuleResolutionKindNodeNext, core.ModuleResolutionKindBundler:
-			state := newResolutionState(moduleName, containingDirectory, false /*isTypeReferenceDirective*/, resolutionMode, compilerOptions, redirectedReference, r)
+			state := newResolutionState(moduleName, containingDirectory, false /*isTypeReferenceDirective*/, resolutionMode, compilerOptions, redirectedReference, r, tracer)
 			result = state.resolveNodeLike()
 		default:
 			panic(fmt.Sprintf("Unexpected moduleResolution: %d", moduleResolution))
@@ -247,12 +281,12 @@ func (r *Resolver) ResolveModuleName(moduleName string, containingFile string, r
 	if traceEnabled {
 		if result.IsResolved() {
 			if result.PackageId.Name != "" {
-				r.host.Trace(diagnostics.Module_name_0_was_successfully_resolved_to_1_with_Package_ID_2.Format(moduleName, result.ResolvedFileName, result.PackageId.String()))
+				tracer.message(diagnostics.Module_name_0_was_successfully_resolved_to_1_with_Package_ID_2.Format(moduleName, result.ResolvedFileName, result.PackageId.String()))
 			} else {
-				r.host.Trace(diagnostics.Module_name_0_was_successfully_resolved_to_1.Format(moduleName, result.ResolvedFileName))
+				tracer.message(diagnostics.Module_name_0_was_successfully_resolved_to_1.Format(moduleName, result.ResolvedFileName))
 			}
 		} else {
-			r.host.Trace(diagnostics.Module_name_0_was_not_resolved.Format(moduleName))
+			tracer.message(diagnostics.Module_name_0_was_not_resolved.Format(moduleName))
 		}
 	}
 
@@ -261,24 +295,24 @@ func (r *Resolver) ResolveModuleName(moduleName string, containingFile string, r
 
 func (r *Resolver) resolveConfig(moduleName string, containingFile string) *ResolvedModule {
 	containingDirectory := tspath.GetDirectoryPath(containingFile)
-	state := newResolutionState(moduleName, containingDirectory, false /*isTypeReferenceDirective*/, core.ModuleKindCommonJS, r.compilerOptions, nil, r)
+	state := newResolutionState(moduleName, containingDirectory, false /*isTypeReferenceDirective*/, core.ModuleKindCommonJS, r.compilerOptions, nil, r, r.newTracer())
 	state.isConfigLookup = true
 	state.extensions = extensionsJson
 	return state.resolveNodeLike()
 }
 
-func (r *Resolver) traceTypeReferenceDirectiveResult(typeReferenceDirectiveName string, result *ResolvedTypeReferenceDirective) {
+func traceTypeReferenceDirectiveResult(tracer *tracer, typeReferenceDirectiveName string, result *ResolvedTypeReferenceDirective) {
 	if !result.IsResolved() {
-		r.host.Trace(diagnostics.Type_reference_directive_0_was_not_resolved.Format(typeReferenceDirectiveName))
+		tracer.message(diagnostics.Type_reference_directive_0_was_not_resolved.Format(typeReferenceDirectiveName))
 	} else if result.PackageId.Name != "" {
-		r.host.Trace(diagnostics.Type_reference_directive_0_was_successfully_resolved_to_1_with_Package_ID_2_primary_Colon_3.Format(
+		tracer.message(diagnostics.Type_reference_directive_0_was_successfully_resolved_to_1_with_Package_ID_2_primary_Colon_3.Format(
 			typeReferenceDirectiveName,
 			result.ResolvedFileName,
 			result.PackageId.String(),
 			result.Primary,
 		))
 	} else {
-		r.host.Trace(diagnostics.Type_reference_directive_0_was_successfully_resolved_to_1_primary_Colon_2.Format(
+		tracer.message(diagnostics.Type_reference_directive_0_was_successfully_resolved_to_1_primary_Colon_2.Format(
 			typeReferenceDirectiveName,
 			result.ResolvedFileName,
 			result.Primary,
@@ -289,14 +323,14 @@ func (r *Resolver) traceTypeReferenceDirectiveResult(typeReferenceDirectiveName
 func (r *resolutionState) resolveTypeReferenceDirective(typeRoots []string, fromConfig bool, fromInferredTypesContainingFile bool) *ResolvedTypeReferenceDirective {
 	// Primary lookup
 	if len(typeRoots) > 0 {
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Resolving_with_primary_search_path_0.Format(strings.Join(typeRoots, ", ")))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Resolving_with_primary_search_path_0.Format(strings.Join(typeRoots, ", ")))
 		}
 		for _, typeRoot := range typeRoots {
 			candidate := r.getCandidateFromTypeRoot(typeRoot)
 			directoryExists := r.resolver.host.FS().DirectoryExists(candidate)
-			if !directoryExists && r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(typeRoot))
+			if !directoryExists && r.traceEnabled() {
+				r.tracer.trace(TraceEvent{Kind: TraceEventKindLookup, Message: diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(typeRoot), Path: typeRoot})
 			}
 			if fromConfig {
 				// Custom typeRoots resolve as file or directory just like we do modules
@@ -312,15 +346,15 @@ func (r *resolutionState) resolveTypeReferenceDirective(typeRoots []string, from
 				return r.createResolvedTypeReferenceDirective(resolvedFromDirectory, true /*primary*/)
 			}
 		}
-	} else if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Root_directory_cannot_be_determined_skipping_primary_search_paths.Format())
+	} else if r.traceEnabled() {
+		r.tracer.message(diagnostics.Root_directory_cannot_be_determined_skipping_primary_search_paths.Format())
 	}
 
 	// Secondary lookup
 	var resolved *resolved
 	if !fromConfig || !fromInferredTypesContainingFile {
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Looking_up_in_node_modules_folder_initial_location_0.Format(r.containingDirectory))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Looking_up_in_node_modules_folder_initial_location_0.Format(r.containingDirectory))
 		}
 		if !tspath.IsExternalModuleNameRelative(r.name) {
 			resolved = r.loadModuleFromNearestNodeModulesDirectory(false /*typesScopeOnly*/)
@@ -328,8 +362,8 @@ func (r *resolutionState) resolveTypeReferenceDirective(typeRoots []string, from
 			candidate := normalizePathForCJSResolution(r.containingDirectory, r.name)
 			resolved = r.nodeLoadModuleByRelativeName(extensionsDeclaration, candidate, false /*onlyRecordFailures*/, true /*considerPackageJson*/)
 		}
-	} else if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Resolving_type_reference_directive_for_program_that_specifies_custom_typeRoots_skipping_lookup_in_node_modules_folder.Format())
+	} else if r.traceEnabled() {
+		r.tracer.message(diagnostics.Resolving_type_reference_directive_for_program_that_specifies_custom_typeRoots_skipping_lookup_in_node_modules_folder.Format())
 	}
 	return r.createResolvedTypeReferenceDirective(resolved, false /*primary*/)
 }
@@ -344,8 +378,8 @@ func (r *resolutionState) getCandidateFromTypeRoot(typeRoot string) string {
 
 func (r *resolutionState) mangleScopedPackageName(name string) string {
 	mangled := MangleScopedPackageName(name)
-	if r.resolver.traceEnabled() && mangled != name {
-		r.resolver.host.Trace(diagnostics.Scoped_package_detected_looking_in_0.Format(mangled))
+	if r.traceEnabled() && mangled != name {
+		r.tracer.message(diagnostics.Scoped_package_detected_looking_in_0.Format(mangled))
 	}
 	return mangled
 }
@@ -369,12 +403,12 @@ func (r *resolutionState) getResolvedPackageDirectory() bool {
 }
 
 func (r *resolutionState) resolveNodeLike() *ResolvedModule {
-	if r.resolver.traceEnabled() {
+	if r.traceEnabled() {
 		conditions := strings.Join(core.Map(r.conditions, func(c string) string { return `'` + c + `'` }), ", ")
 		if r.esmMode {
-			r.resolver.host.Trace(diagnostics.Resolving_in_0_mode_with_conditions_1.Format("ESM", conditions))
+			r.tracer.message(diagnostics.Resolving_in_0_mode_with_conditions_1.Format("ESM", conditions))
 		} else {
-			r.resolver.host.Trace(diagnostics.Resolving_in_0_mode_with_conditions_1.Format("CJS", conditions))
+			r.tracer.message(diagnostics.Resolving_in_0_mode_with_conditions_1.Format("CJS", conditions))
 		}
 	}
 	result := r.resolveNodeLikeWorker()
@@ -388,8 +422,8 @@ func (r *resolutionState) resolveNodeLike() *ResolvedModule {
 		result.IsExternalLibraryImport &&
 		!extensionIsOk(extensionsTypeScript|extensionsDeclaration, result.Extension) &&
 		slices.Contains(r.conditions, "import") {
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Resolution_of_non_relative_name_failed_trying_with_modern_Node_resolution_features_disabled_to_see_if_npm_library_needs_configuration_update.Format())
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Resolution_of_non_relative_name_failed_trying_with_modern_Node_resolution_features_disabled_to_see_if_npm_library_needs_configuration_update.Format())
 		}
 		r.features = r.features & ^NodeResolutionFeaturesExports
 		r.extensions = r.extensions & (extensionsTypeScript | extensionsDeclaration)
@@ -420,13 +454,13 @@ func (r *resolutionState) resolveNodeLikeWorker() *ResolvedModule {
 			}
 		}
 		if strings.Contains(r.name, ":") {
-			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.Skipping_module_0_that_looks_like_an_absolute_URI_target_file_types_Colon_1.Format(r.name, r.extensions.String()))
+			if r.traceEnabled() {
+				r.tracer.message(diagnostics.Skipping_module_0_that_looks_like_an_absolute_URI_target_file_types_Colon_1.Format(r.name, r.extensions.String()))
 			}
 			return r.createResolvedModule(nil, false)
 		}
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Loading_module_0_from_node_modules_folder_target_file_types_Colon_1.Format(r.name, r.extensions.String()))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Loading_module_0_from_node_modules_folder_target_file_types_Colon_1.Format(r.name, r.extensions.String()))
 		}
 		if resolved := r.loadModuleFromNearestNodeModulesDirectory(false /*typesScopeOnly*/); !resolved.shouldContinueSearching() {
 			return r.createResolvedModuleHandlingSymlink(resolved)
@@ -497,24 +531,24 @@ func (r *resolutionState) loadModuleFromSelfNameReference() *resolved {
 
 func (r *resolutionState) loadModuleFromImports() *resolved {
 	if r.name == "#" || strings.HasPrefix(r.name, "#/") {
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Invalid_import_specifier_0_has_no_possible_resolutions.Format(r.name))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Invalid_import_specifier_0_has_no_possible_resolutions.Format(r.name))
 		}
 		return continueSearching()
 	}
 	directoryPath := tspath.GetNormalizedAbsolutePath(r.containingDirectory, r.resolver.host.GetCurrentDirectory())
 	scope := r.getPackageScopeForPath(directoryPath)
 	if !scope.Exists() {
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Directory_0_has_no_containing_package_json_scope_Imports_will_not_resolve.Format(directoryPath))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Directory_0_has_no_containing_package_json_scope_Imports_will_not_resolve.Format(directoryPath))
 		}
 		return continueSearching()
 	}
 	if scope.Contents.Imports.Type != packagejson.JSONValueTypeObject {
 		// !!! Old compiler only checks for undefined, but then assumes `imports` is an object if present.
 		// Maybe should have a new diagnostic for imports of an invalid type. Also, array should be handled?
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_no_imports_defined.Format(scope.PackageDirectory))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.X_package_json_scope_0_has_no_imports_defined.Format(scope.PackageDirectory))
 		}
 		return continueSearching()
 	}
@@ -523,8 +557,8 @@ func (r *resolutionState) loadModuleFromImports() *resolved {
 		return result
 	}
 
-	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Import_specifier_0_does_not_exist_in_package_json_scope_at_path_1.Format(r.name, scope.PackageDirectory))
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.Import_specifier_0_does_not_exist_in_package_json_scope_at_path_1.Format(r.name, scope.PackageDirectory))
 	}
 	return continueSearching()
 }
@@ -556,8 +590,8 @@ func (r *resolutionState) loadModuleFromExports(packageInfo *packagejson.InfoCac
 		}
 	}
 
-	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Export_specifier_0_does_not_exist_in_package_json_scope_at_path_1.Format(subpath, packageInfo.PackageDirectory))
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.Export_specifier_0_does_not_exist_in_package_json_scope_at_path_1.Format(subpath, packageInfo.PackageDirectory))
 	}
 	return continueSearching()
 }
@@ -608,8 +642,8 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 	case packagejson.JSONValueTypeString:
 		targetString, _ := target.Value.(string)
 		if !isPattern && len(subpath) > 0 && !strings.HasSuffix(targetString, "/") {
-			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+			if r.traceEnabled() {
+				r.tracer.message(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 			}
 			return continueSearching()
 		}
@@ -619,9 +653,9 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 				if isPattern {
 					combinedLookup = strings.ReplaceAll(targetString, "*", subpath)
 				}
-				if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.Using_0_subpath_1_with_target_2.Format("imports", key, combinedLookup))
-					r.resolver.host.Trace(diagnostics.Resolving_module_0_from_1.Format(combinedLookup, scope.PackageDirectory+"/"))
+				if r.traceEnabled() {
+					r.tracer.message(diagnostics.Using_0_subpath_1_with_target_2.Format("imports", key, combinedLookup))
+					r.tracer.message(diagnostics.Resolving_module_0_from_1.Format(combinedLookup, scope.PackageDirectory+"/"))
 				}
 				name, containingDirectory := r.name, r.containingDirectory
 				r.name, r.containingDirectory = combinedLookup, scope.PackageDirectory+"/"
@@ -639,8 +673,8 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 				}
 				return continueSearching()
 			}
-			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+			if r.traceEnabled() {
+				r.tracer.message(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 			}
 			return continueSearching()
 		}
@@ -652,8 +686,8 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 		}
 		partsAfterFirst := parts[1:]
 		if slices.Contains(partsAfterFirst, "..") || slices.Contains(partsAfterFirst, ".") || slices.Contains(partsAfterFirst, "node_modules") {
-			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+			if r.traceEnabled() {
+				r.tracer.message(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 			}
 			return continueSearching()
 		}
@@ -662,20 +696,20 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 		// to be in the business of validating everyone's import and export map correctness.
 		subpathParts := tspath.GetPathComponents(subpath, "")
 		if slices.Contains(subpathParts, "..") || slices.Contains(subpathParts, ".") || slices.Contains(subpathParts, "node_modules") {
-			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+			if r.traceEnabled() {
+				r.tracer.message(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 			}
 			return continueSearching()
 		}
 
-		if r.resolver.traceEnabled() {
+		if r.traceEnabled() {
 			var messageTarget string
 			if isPattern {
 				messageTarget = strings.ReplaceAll(targetString, "*", subpath)
 			} else {
 				messageTarget = targetString + subpath
 			}
-			r.resolver.host.Trace(diagnostics.Using_0_subpath_1_with_target_2.Format(core.IfElse(isImports, "imports", "exports"), key, messageTarget))
+			r.tracer.message(diagnostics.Using_0_subpath_1_with_target_2.Format(core.IfElse(isImports, "imports", "exports"), key, messageTarget))
 		}
 		var finalPath string
 		if isPattern {
@@ -693,40 +727,40 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 		return continueSearching()
 
 	case packagejson.JSONValueTypeObject:
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Entering_conditional_exports.Format())
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Entering_conditional_exports.Format())
 		}
 		for condition := range target.AsObject().Keys() {
 			if r.conditionMatches(condition) {
-				if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.Matched_0_condition_1.Format(core.IfElse(isImports, "imports", "exports"), condition))
+				if r.traceEnabled() {
+					r.tracer.trace(TraceEvent{Kind: TraceEventKindCondition, Message: diagnostics.Matched_0_condition_1.Format(core.IfElse(isImports, "imports", "exports"), condition), Condition: condition, Found: true})
 				}
 				subTarget, _ := target.AsObject().Get(condition)
 				if result := r.loadModuleFromTargetExportOrImport(extensions, moduleName, scope, isImports, subTarget, subpath, isPattern, key); !result.shouldContinueSearching() {
-					if r.resolver.traceEnabled() {
-						r.resolver.host.Trace(diagnostics.Resolved_under_condition_0.Format(condition))
+					if r.traceEnabled() {
+						r.tracer.message(diagnostics.Resolved_under_condition_0.Format(condition))
 					}
-					if r.resolver.traceEnabled() {
-						r.resolver.host.Trace(diagnostics.Exiting_conditional_exports.Format())
+					if r.traceEnabled() {
+						r.tracer.message(diagnostics.Exiting_conditional_exports.Format())
 					}
 					return result
-				} else if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.Failed_to_resolve_under_condition_0.Format(condition))
+				} else if r.traceEnabled() {
+					r.tracer.message(diagnostics.Failed_to_resolve_under_condition_0.Format(condition))
 				}
 			} else {
-				if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.Saw_non_matching_condition_0.Format(condition))
+				if r.traceEnabled() {
+					r.tracer.trace(TraceEvent{Kind: TraceEventKindCondition, Message: diagnostics.Saw_non_matching_condition_0.Format(condition), Condition: condition})
 				}
 			}
 		}
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Exiting_conditional_exports.Format())
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Exiting_conditional_exports.Format())
 		}
 		return continueSearching()
 	case packagejson.JSONValueTypeArray:
 		if len(target.AsArray()) == 0 {
-			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+			if r.traceEnabled() {
+				r.tracer.message(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 			}
 			return continueSearching()
 		}
@@ -737,14 +771,14 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 		}
 
 	case packagejson.JSONValueTypeNull:
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.X_package_json_scope_0_explicitly_maps_specifier_1_to_null.Format(scope.PackageDirectory, moduleName))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.X_package_json_scope_0_explicitly_maps_specifier_1_to_null.Format(scope.PackageDirectory, moduleName))
 		}
 		return continueSearching()
 	}
 
-	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 	}
 	return continueSearching()
 }
@@ -769,8 +803,8 @@ func (r *resolutionState) loadModuleFromNearestNodeModulesDirectory(typesScopeOn
 	secondaryExtensions := r.extensions & ^(extensionsTypeScript | extensionsDeclaration)
 	// (1)
 	if priorityExtensions != 0 {
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Searching_all_ancestor_node_modules_directories_for_preferred_extensions_Colon_0.Format(priorityExtensions.String()))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Searching_all_ancestor_node_modules_directories_for_preferred_extensions_Colon_0.Format(priorityExtensions.String()))
 		}
 		if result := r.loadModuleFromNearestNodeModulesDirectoryWorker(priorityExtensions, mode, typesScopeOnly); !result.shouldContinueSearching() {
 			return result
@@ -778,8 +812,8 @@ func (r *resolutionState) loadModuleFromNearestNodeModulesDirectory(typesScopeOn
 	}
 	// (2)
 	if secondaryExtensions != 0 && !typesScopeOnly {
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Searching_all_ancestor_node_modules_directories_for_fallback_extensions_Colon_0.Format(secondaryExtensions.String()))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Searching_all_ancestor_node_modules_directories_for_fallback_extensions_Colon_0.Format(secondaryExtensions.String()))
 		}
 		return r.loadModuleFromNearestNodeModulesDirectoryWorker(secondaryExtensions, mode, typesScopeOnly)
 	}
@@ -809,8 +843,8 @@ func (r *resolutionState) loadModuleFromNearestNodeModulesDirectoryWorker(ext ex
 func (r *resolutionState) loadModuleFromImmediateNodeModulesDirectory(extensions extensions, directory string, typesScopeOnly bool) *resolved {
 	nodeModulesFolder := tspath.CombinePaths(directory, "node_modules")
 	nodeModulesFolderExists := r.resolver.host.FS().DirectoryExists(nodeModulesFolder)
-	if !nodeModulesFolderExists && r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(nodeModulesFolder))
+	if !nodeModulesFolderExists && r.traceEnabled() {
+		r.tracer.trace(TraceEvent{Kind: TraceEventKindLookup, Message: diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(nodeModulesFolder), Path: nodeModulesFolder})
 	}
 
 	if !typesScopeOnly {
@@ -822,8 +856,8 @@ func (r *resolutionState) loadModuleFromImmediateNodeModulesDirectory(extensions
 	if extensions&extensionsDeclaration != 0 {
 		nodeModulesAtTypes := tspath.CombinePaths(nodeModulesFolder, "@types")
 		nodeModulesAtTypesExists := nodeModulesFolderExists && r.resolver.host.FS().DirectoryExists(nodeModulesAtTypes)
-		if !nodeModulesAtTypesExists && r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(nodeModulesAtTypes))
+		if !nodeModulesAtTypesExists && r.traceEnabled() {
+			r.tracer.trace(TraceEvent{Kind: TraceEventKindLookup, Message: diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(nodeModulesAtTypes), Path: nodeModulesAtTypes})
 		}
 		return r.loadModuleFromSpecificNodeModulesDirectory(extensionsDeclaration, r.mangleScopedPackageName(r.name), nodeModulesAtTypes, nodeModulesAtTypesExists)
 	}
@@ -899,8 +933,8 @@ func (r *resolutionState) loadModuleFromSpecificNodeModulesDirectory(ext extensi
 		if rest != "" {
 			versionPaths := packageInfo.Contents.GetVersionPaths(r.getTraceFunc())
 			if versionPaths.Exists() {
-				if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.X_package_json_has_a_typesVersions_entry_0_that_matches_compiler_version_1_looking_for_a_pattern_to_match_module_name_2.Format(versionPaths.Version, core.Version, rest))
+				if r.traceEnabled() {
+					r.tracer.message(diagnostics.X_package_json_has_a_typesVersions_entry_0_that_matches_compiler_version_1_looking_for_a_pattern_to_match_module_name_2.Format(versionPaths.Version, core.Version, rest))
 				}
 				packageDirectoryExists := nodeModulesDirectoryExists && r.resolver.host.FS().DirectoryExists(packageDirectory)
 				pathPatterns := tryParsePatterns(versionPaths.GetPaths())
@@ -915,8 +949,8 @@ func (r *resolutionState) loadModuleFromSpecificNodeModulesDirectory(ext extensi
 
 func (r *resolutionState) tryFindNonRelativeModuleNameInCache(nameAndMode ModeAwareCacheKey, directory string) *resolved {
 	if result, ok := r.resolver.moduleNameCache.getFromNonRelativeNameCache(nameAndMode, directory, r.redirectedReference); ok {
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Resolution_for_module_0_was_found_in_cache_from_location_1.Format(nameAndMode.Name, directory))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Resolution_for_module_0_was_found_in_cache_from_location_1.Format(nameAndMode.Name, directory))
 		}
 		r.resultFromCache = result
 		if result.IsResolved() {
@@ -1018,18 +1052,24 @@ func (r *resolutionState) getOriginalAndResolvedFileName(fileName string) (strin
 }
 
 func (r *resolutionState) tryLoadModuleUsingOptionalResolutionSettings() *resolved {
-	return r.tryLoadModuleUsingPathsIfEligible()
+	if resolved := r.tryLoadModuleUsingPathsIfEligible(); !resolved.shouldContinueSearching() {
+		return resolved
+	}
+	if !tspath.IsExternalModuleNameRelative(r.name) {
+		return r.tryLoadModuleUsingBaseUrl()
+	}
+	return r.tryLoadModuleUsingRootDirs()
 }
 
 func (r *resolutionState) tryLoadModuleUsingPathsIfEligible() *resolved {
 	if r.compilerOptions.Paths.Size() > 0 && !tspath.PathIsRelative(r.name) {
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.X_paths_option_is_specified_looking_for_a_pattern_to_match_module_name_0.Format(r.name))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.X_paths_option_is_specified_looking_for_a_pattern_to_match_module_name_0.Format(r.name))
 		}
 	} else {
 		return continueSearching()
 	}
-	baseDirectory := getPathsBasePath(r.compilerOptions, r.resolver.host.GetCurrentDirectory())
+	baseDirectory := GetPathsBasePath(r.compilerOptions, r.resolver.host.GetCurrentDirectory())
 	pathPatterns := r.resolver.getParsedPatternsForPaths()
 	return r.tryLoadModuleUsingPaths(
 		r.extensions,
@@ -1044,17 +1084,98 @@ func (r *resolutionState) tryLoadModuleUsingPathsIfEligible() *resolved {
 	)
 }
 
+func (r *resolutionState) tryLoadModuleUsingBaseUrl() *resolved {
+	baseUrl := r.compilerOptions.BaseUrl
+	if baseUrl == "" {
+		return continueSearching()
+	}
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.X_baseUrl_option_is_set_to_0_using_this_value_to_resolve_non_relative_module_name_1.Format(baseUrl, r.name))
+	}
+	candidate := tspath.NormalizePath(tspath.CombinePaths(baseUrl, r.name))
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.Resolving_module_name_0_relative_to_base_url_1_2.Format(r.name, baseUrl, candidate))
+	}
+	return r.nodeLoadModuleByRelativeName(r.extensions, candidate, !r.resolver.host.FS().DirectoryExists(tspath.GetDirectoryPath(candidate)), true /*considerPackageJson*/)
+}
+
+// tryLoadModuleUsingRootDirs resolves a relative module name as if the directories in rootDirs were merged into
+// one: the candidate is looked up under the longest rootDirs entry it starts with, then under the other entries.
+func (r *resolutionState) tryLoadModuleUsingRootDirs() *resolved {
+	if len(r.compilerOptions.RootDirs) == 0 {
+		return continueSearching()
+	}
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.X_rootDirs_option_is_set_using_it_to_resolve_relative_module_name_0.Format(r.name))
+	}
+	candidate := tspath.NormalizePath(tspath.CombinePaths(r.containingDirectory, r.name))
+
+	var matchedRootDir string
+	var matchedNormalizedPrefix string
+	for _, rootDir := range r.compilerOptions.RootDirs {
+		// rootDirs are expected to be absolute; in a tsconfig.json, they are made absolute relative to the
+		// directory of the config file
+		normalizedRoot := tspath.EnsureTrailingDirectorySeparator(tspath.NormalizePath(rootDir))
+		isLongestMatchingPrefix := strings.HasPrefix(candidate, normalizedRoot) && len(matchedNormalizedPrefix) < len(normalizedRoot)
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Checking_if_0_is_the_longest_matching_prefix_for_1_2.Format(normalizedRoot, candidate, isLongestMatchingPrefix))
+		}
+		if isLongestMatchingPrefix {
+			matchedNormalizedPrefix = normalizedRoot
+			matchedRootDir = rootDir
+		}
+	}
+	if matchedNormalizedPrefix == "" {
+		return continueSearching()
+	}
+
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.Longest_matching_prefix_for_0_is_1.Format(candidate, matchedNormalizedPrefix))
+	}
+	suffix := candidate[len(matchedNormalizedPrefix):]
+	// first, try to load from the initial location
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.Loading_0_from_the_root_dir_1_candidate_location_2.Format(suffix, matchedNormalizedPrefix, candidate))
+	}
+	baseDirectory := tspath.GetDirectoryPath(candidate)
+	if resolved := r.nodeLoadModuleByRelativeName(r.extensions, candidate, !r.resolver.host.FS().DirectoryExists(baseDirectory), true /*considerPackageJson*/); !resolved.shouldContinueSearching() {
+		return resolved
+	}
+
+	// then, try the same suffix under the other entries in rootDirs
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.Trying_other_entries_in_rootDirs.Format())
+	}
+	for _, rootDir := range r.compilerOptions.RootDirs {
+		if rootDir == matchedRootDir {
+			continue
+		}
+		candidate := tspath.CombinePaths(tspath.NormalizePath(rootDir), suffix)
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Loading_0_from_the_root_dir_1_candidate_location_2.Format(suffix, rootDir, candidate))
+		}
+		baseDirectory := tspath.GetDirectoryPath(candidate)
+		if resolved := r.nodeLoadModuleByRelativeName(r.extensions, candidate, !r.resolver.host.FS().DirectoryExists(baseDirectory), true /*considerPackageJson*/); !resolved.shouldContinueSearching() {
+			return resolved
+		}
+	}
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.Module_resolution_using_rootDirs_has_failed.Format())
+	}
+	return continueSearching()
+}
+
 func (r *resolutionState) tryLoadModuleUsingPaths(extensions extensions, moduleName string, containingDirectory string, paths *collections.OrderedMap[string, []string], pathPatterns *parsedPatterns, loader resolutionKindSpecificLoader, onlyRecordFailures bool) *resolved {
 	if matchedPattern := matchPatternOrExact(pathPatterns, moduleName); matchedPattern.IsValid() {
 		matchedStar := matchedPattern.MatchedText(moduleName)
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Module_name_0_matched_pattern_1.Format(moduleName, matchedPattern.Text))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Module_name_0_matched_pattern_1.Format(moduleName, matchedPattern.Text))
 		}
 		for _, subst := range paths.GetOrZero(matchedPattern.Text) {
 			path := strings.Replace(subst, "*", matchedStar, 1)
 			candidate := tspath.NormalizePath(tspath.CombinePaths(containingDirectory, path))
-			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.Trying_substitution_0_candidate_module_location_Colon_1.Format(subst, path))
+			if r.traceEnabled() {
+				r.tracer.message(diagnostics.Trying_substitution_0_candidate_module_location_Colon_1.Format(subst, path))
 			}
 			// A path mapping may have an extension
 			if extension := tspath.TryGetExtensionFromPath(subst); extension != "" {
@@ -1074,15 +1195,15 @@ func (r *resolutionState) tryLoadModuleUsingPaths(extensions extensions, moduleN
 }
 
 func (r *resolutionState) nodeLoadModuleByRelativeName(extensions extensions, candidate string, onlyRecordFailures bool, considerPackageJson bool) *resolved {
-	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Loading_module_as_file_Slash_folder_candidate_module_location_0_target_file_types_Colon_1.Format(candidate, extensions.String()))
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.Loading_module_as_file_Slash_folder_candidate_module_location_0_target_file_types_Colon_1.Format(candidate, extensions.String()))
 	}
 	if !tspath.HasTrailingDirectorySeparator(candidate) {
 		if !onlyRecordFailures {
 			parentOfCandidate := tspath.GetDirectoryPath(candidate)
 			if !r.resolver.host.FS().DirectoryExists(parentOfCandidate) {
-				if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(parentOfCandidate))
+				if r.traceEnabled() {
+					r.tracer.trace(TraceEvent{Kind: TraceEventKindLookup, Message: diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(parentOfCandidate), Path: parentOfCandidate})
 				}
 				onlyRecordFailures = true
 			}
@@ -1100,8 +1221,8 @@ func (r *resolutionState) nodeLoadModuleByRelativeName(extensions extensions, ca
 	if !onlyRecordFailures {
 		candidateExists := r.resolver.host.FS().DirectoryExists(candidate)
 		if !candidateExists {
-			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(candidate))
+			if r.traceEnabled() {
+				r.tracer.trace(TraceEvent{Kind: TraceEventKindLookup, Message: diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(candidate), Path: candidate})
 			}
 			onlyRecordFailures = true
 		}
@@ -1142,8 +1263,8 @@ func (r *resolutionState) loadModuleFromFileNoImplicitExtensions(extensions exte
 	}
 
 	extension := candidate[len(extensionless):]
-	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.File_name_0_has_a_1_extension_stripping_it.Format(candidate, extension))
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.File_name_0_has_a_1_extension_stripping_it.Format(candidate, extension))
 	}
 	return r.tryAddingExtensions(extensionless, extensions, extension, onlyRecordFailures)
 }
@@ -1296,12 +1417,12 @@ func (r *resolutionState) tryFile(fileName string, onlyRecordFailures bool) (str
 func (r *resolutionState) tryFileLookup(fileName string, onlyRecordFailures bool) bool {
 	if !onlyRecordFailures {
 		if r.resolver.host.FS().FileExists(fileName) {
-			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.File_0_exists_use_it_as_a_name_resolution_result.Format(fileName))
+			if r.traceEnabled() {
+				r.tracer.trace(TraceEvent{Kind: TraceEventKindLookup, Message: diagnostics.File_0_exists_use_it_as_a_name_resolution_result.Format(fileName), Path: fileName, Found: true})
 			}
 			return true
-		} else if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.File_0_does_not_exist.Format(fileName))
+		} else if r.traceEnabled() {
+			r.tracer.trace(TraceEvent{Kind: TraceEventKindLookup, Message: diagnostics.File_0_does_not_exist.Format(fileName), Path: fileName})
 		}
 	}
 	r.failedLookupLocations = append(r.failedLookupLocations, fileName)
@@ -1369,8 +1490,8 @@ func (r *resolutionState) loadNodeModuleFromDirectoryWorker(ext extensions, cand
 		} else {
 			moduleName = tspath.GetRelativePathFromDirectory(candidate, indexPath, tspath.ComparePathsOptions{})
 		}
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.X_package_json_has_a_typesVersions_entry_0_that_matches_compiler_version_1_looking_for_a_pattern_to_match_module_name_2.Format(versionPaths.Version, core.Version, moduleName))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.X_package_json_has_a_typesVersions_entry_0_that_matches_compiler_version_1_looking_for_a_pattern_to_match_module_name_2.Format(versionPaths.Version, core.Version, moduleName))
 		}
 		pathPatterns := tryParsePatterns(versionPaths.GetPaths())
 		if result := r.tryLoadModuleUsingPaths(ext, moduleName, candidate, versionPaths.GetPaths(), pathPatterns, loader, onlyRecordFailuresForPackageFile); !result.shouldContinueSearching() {
@@ -1457,8 +1578,8 @@ func (r *resolutionState) getPackageJsonInfo(packageDirectory string, onlyRecord
 
 	if existing := r.resolver.packageJsonInfoCache.Get(packageJsonPath); existing != nil {
 		if existing.Contents != nil {
-			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.File_0_exists_according_to_earlier_cached_lookups.Format(packageJsonPath))
+			if r.traceEnabled() {
+				r.tracer.trace(TraceEvent{Kind: TraceEventKindPackageJson, Message: diagnostics.File_0_exists_according_to_earlier_cached_lookups.Format(packageJsonPath), Path: packageJsonPath, Found: true})
 			}
 			r.affectingLocations = append(r.affectingLocations, packageJsonPath)
 			if existing.PackageDirectory == packageDirectory {
@@ -1471,8 +1592,8 @@ func (r *resolutionState) getPackageJsonInfo(packageDirectory string, onlyRecord
 				Contents:         existing.Contents,
 			}
 		} else {
-			if existing.DirectoryExists && r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.File_0_does_not_exist_according_to_earlier_cached_lookups.Format(packageJsonPath))
+			if existing.DirectoryExists && r.traceEnabled() {
+				r.tracer.trace(TraceEvent{Kind: TraceEventKindPackageJson, Message: diagnostics.File_0_does_not_exist_according_to_earlier_cached_lookups.Format(packageJsonPath), Path: packageJsonPath})
 			}
 			r.failedLookupLocations = append(r.failedLookupLocations, packageJsonPath)
 			return nil
@@ -1484,8 +1605,8 @@ func (r *resolutionState) getPackageJsonInfo(packageDirectory string, onlyRecord
 		// Ignore error
 		contents, _ := r.resolver.host.FS().ReadFile(packageJsonPath)
 		packageJsonContent, _ := packagejson.Parse([]byte(contents))
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Found_package_json_at_0.Format(packageJsonPath))
+		if r.traceEnabled() {
+			r.tracer.trace(TraceEvent{Kind: TraceEventKindPackageJson, Message: diagnostics.Found_package_json_at_0.Format(packageJsonPath), Path: packageJsonPath, Found: true})
 		}
 		result := &packagejson.InfoCacheEntry{
 			PackageDirectory: packageDirectory,
@@ -1500,8 +1621,8 @@ func (r *resolutionState) getPackageJsonInfo(packageDirectory string, onlyRecord
 		r.affectingLocations = append(r.affectingLocations, packageJsonPath)
 		return result
 	} else {
-		if directoryExists && r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.File_0_does_not_exist.Format(packageJsonPath))
+		if directoryExists && r.traceEnabled() {
+			r.tracer.trace(TraceEvent{Kind: TraceEventKindPackageJson, Message: diagnostics.File_0_does_not_exist.Format(packageJsonPath), Path: packageJsonPath})
 		}
 		if !r.resolver.packageJsonInfoCache.IsReadonly {
 			r.resolver.packageJsonInfoCache.Set(packageJsonPath, &packagejson.InfoCacheEntry{
@@ -1541,8 +1662,8 @@ func (r *resolutionState) readPackageJsonPeerDependencies(packageJsonInfo *packa
 	if !ok || len(peerDependencies.Value) == 0 {
 		return ""
 	}
-	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.X_package_json_has_a_peerDependencies_field.Message())
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.X_package_json_has_a_peerDependencies_field.Message())
 	}
 	packageDirectory := r.realPath(packageJsonInfo.PackageDirectory)
 	nodeModules := packageDirectory[:strings.LastIndex(packageDirectory, "/node_modules")+len("/node_modules")] + "/"
@@ -1555,11 +1676,11 @@ func (r *resolutionState) readPackageJsonPeerDependencies(packageJsonInfo *packa
 			builder.WriteString(name)
 			builder.WriteString("@")
 			builder.WriteString(version)
-			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.Found_peerDependency_0_with_1_version.Format(name, version))
+			if r.traceEnabled() {
+				r.tracer.message(diagnostics.Found_peerDependency_0_with_1_version.Format(name, version))
 			}
-		} else if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Failed_to_find_peerDependency_0.Format(name))
+		} else if r.traceEnabled() {
+			r.tracer.message(diagnostics.Failed_to_find_peerDependency_0.Format(name))
 		}
 	}
 	return builder.String()
@@ -1567,8 +1688,8 @@ func (r *resolutionState) readPackageJsonPeerDependencies(packageJsonInfo *packa
 
 func (r *resolutionState) realPath(path string) string {
 	rp := tspath.NormalizePath(r.resolver.host.FS().Realpath(path))
-	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Resolving_real_path_for_0_result_1.Format(path, rp))
+	if r.traceEnabled() {
+		r.tracer.trace(TraceEvent{Kind: TraceEventKindRealpath, Message: diagnostics.Resolving_real_path_for_0_result_1.Format(path, rp), Path: path, RealPath: rp})
 	}
 	return rp
 }
@@ -1578,12 +1699,12 @@ func (r *resolutionState) validatePackageJSONField(fieldName string, field packa
 		if field.IsValid() {
 			return true
 		}
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Expected_type_of_0_field_in_package_json_to_be_1_got_2.Format(fieldName, field.ExpectedJSONType(), field.ActualJSONType()))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.Expected_type_of_0_field_in_package_json_to_be_1_got_2.Format(fieldName, field.ExpectedJSONType(), field.ActualJSONType()))
 		}
 	}
-	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.X_package_json_does_not_have_a_0_field.Format(fieldName))
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.X_package_json_does_not_have_a_0_field.Format(fieldName))
 	}
 	return false
 }
@@ -1593,14 +1714,14 @@ func (r *resolutionState) getPackageJSONPathField(fieldName string, field *packa
 		return "", false
 	}
 	if field.Value == "" {
-		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.X_package_json_had_a_falsy_0_field.Format(fieldName))
+		if r.traceEnabled() {
+			r.tracer.message(diagnostics.X_package_json_had_a_falsy_0_field.Format(fieldName))
 		}
 		return "", false
 	}
 	path := tspath.NormalizePath(tspath.CombinePaths(directory, field.Value))
-	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.X_package_json_has_0_field_1_that_references_2.Format(fieldName, field.Value, path))
+	if r.traceEnabled() {
+		r.tracer.message(diagnostics.X_package_json_has_0_field_1_that_references_2.Format(fieldName, field.Value, path))
 	}
 	return path, true
 }
@@ -1621,9 +1742,13 @@ func (r *resolutionState) conditionMatches(condition string) bool {
 	return false
 }
 
+func (r *resolutionState) traceEnabled() bool {
+	return r.tracer != nil
+}
+
 func (r *resolutionState) getTraceFunc() func(string) {
-	if r.resolver.traceEnabled() {
-		return r.resolver.host.Trace
+	if r.traceEnabled() {
+		return r.tracer.message
 	}
 	return nil
 }
@@ -1686,10 +1811,14 @@ func moveToNextDirectorySeparatorIfAvailable(path string, prevSeparatorIndex int
 	return nextSeparatorIndex + offset
 }
 
-func getPathsBasePath(options *core.CompilerOptions, currentDirectory string) string {
+// GetPathsBasePath returns the directory that the substitutions of the paths option are relative to.
+func GetPathsBasePath(options *core.CompilerOptions, currentDirectory string) string {
 	if options.Paths.Size() == 0 {
 		return ""
 	}
+	if options.BaseUrl != "" {
+		return options.BaseUrl
+	}
 	if options.PathsBasePath != "" {
 		return options.PathsBasePath
 	}
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/compiler/module"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/repo"
@@ -390,3 +391,167 @@ func TestModuleResolver(t *testing.T) {
 		}
 	}
 }
+
+func TestRootDirs(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"/project/src/main.ts":         `import "./types";`,
+		"/project/src/nested/index.ts": `import "../types";`,
+		"/project/src2/main.ts":        `import "./types";`,
+		"/project/gen/types.ts":        `export {};`,
+		"/project/gen/nested/model.ts": `import "../main";`,
+		"/project/gen/only/types.ts":   `export {};`,
+	}
+	resolve := func(options *core.CompilerOptions, name string, containingFile string) (string, []string) {
+		host := newVFSModuleResolutionHost(files, "/project")
+		resolved := module.NewResolver(host, options).ResolveModuleName(name, containingFile, core.ResolutionModeNone, nil)
+		return resolved.ResolvedFileName, host.traces
+	}
+
+	t.Run("multiple roots", func(t *testing.T) {
+		t.Parallel()
+		options := &core.CompilerOptions{
+			ModuleResolution: core.ModuleResolutionKindBundler,
+			RootDirs:         []string{"/project/src", "/project/gen"},
+		}
+		for _, test := range []struct{ name, containingFile, expected string }{
+			{"./types", "/project/src/main.ts", "/project/gen/types.ts"},
+			{"../types", "/project/src/nested/index.ts", "/project/gen/types.ts"},
+			{"./nested/model", "/project/src/main.ts", "/project/gen/nested/model.ts"},
+			{"../main", "/project/gen/nested/model.ts", "/project/src/main.ts"},
+			{"./missing", "/project/src/main.ts", ""},
+		} {
+			resolvedFileName, _ := resolve(options, test.name, test.containingFile)
+			assert.Equal(t, resolvedFileName, test.expected, "resolving %q from %q", test.name, test.containingFile)
+		}
+	})
+
+	t.Run("longest matching prefix", func(t *testing.T) {
+		t.Parallel()
+		options := &core.CompilerOptions{
+			ModuleResolution: core.ModuleResolutionKindBundler,
+			RootDirs:         []string{"/project", "/project/src", "/project/gen"},
+		}
+		// the suffix is taken relative to /project/src, the longest matching root, so it is found in /project/gen
+		resolvedFileName, _ := resolve(options, "./types", "/project/src/main.ts")
+		assert.Equal(t, resolvedFileName, "/project/gen/types.ts")
+
+		// /project/src2 is not under /project/src
+		options = &core.CompilerOptions{
+			ModuleResolution: core.ModuleResolutionKindBundler,
+			RootDirs:         []string{"/project/src", "/project/gen"},
+		}
+		resolvedFileName, _ = resolve(options, "./types", "/project/src2/main.ts")
+		assert.Equal(t, resolvedFileName, "")
+	})
+
+	t.Run("paths", func(t *testing.T) {
+		t.Parallel()
+		options := &core.CompilerOptions{
+			ModuleResolution: core.ModuleResolutionKindBundler,
+			RootDirs:         []string{"/project/src", "/project/gen"},
+			Paths: collections.NewOrderedMapFromList([]collections.MapEntry[string, []string]{
+				{Key: "@gen/*", Value: []string{"./gen/*"}},
+			}),
+			PathsBasePath: "/project",
+		}
+		// non-relative names are resolved with paths, and never with rootDirs
+		resolvedFileName, _ := resolve(options, "@gen/types", "/project/src/main.ts")
+		assert.Equal(t, resolvedFileName, "/project/gen/types.ts")
+		resolvedFileName, _ = resolve(options, "types", "/project/src/main.ts")
+		assert.Equal(t, resolvedFileName, "")
+		// relative names are resolved with rootDirs, and never with paths
+		resolvedFileName, _ = resolve(options, "./types", "/project/src/main.ts")
+		assert.Equal(t, resolvedFileName, "/project/gen/types.ts")
+	})
+
+	t.Run("trace", func(t *testing.T) {
+		t.Parallel()
+		options := &core.CompilerOptions{
+			ModuleResolution: core.ModuleResolutionKindBundler,
+			RootDirs:         []string{"/project/src", "/project/gen"},
+			TraceResolution:  core.TSTrue,
+		}
+		_, traces := resolve(options, "./types", "/project/src/main.ts")
+		assert.DeepEqual(t, traces, []string{
+			"======== Resolving module './types' from '/project/src/main.ts'. ========",
+			"Explicitly specified module resolution kind: 'Bundler'.",
+			"Resolving in CJS mode with conditions 'import', 'types'.",
+			"'rootDirs' option is set, using it to resolve relative module name './types'.",
+			"Checking if '/project/src/' is the longest matching prefix for '/project/src/types' - 'true'.",
+			"Checking if '/project/gen/' is the longest matching prefix for '/project/src/types' - 'false'.",
+			"Longest matching prefix for '/project/src/types' is '/project/src/'.",
+			"Loading 'types' from the root dir '/project/src/', candidate location '/project/src/types'.",
+			"Loading module as file / folder, candidate module location '/project/src/types', target file types: TypeScript, JavaScript, Declaration, JSON.",
+			"File '/project/src/types.ts' does not exist.",
+			"File '/project/src/types.tsx' does not exist.",
+			"File '/project/src/types.d.ts' does not exist.",
+			"File '/project/src/types.js' does not exist.",
+			"File '/project/src/types.jsx' does not exist.",
+			"Directory '/project/src/types' does not exist, skipping all lookups in it.",
+			"Trying other entries in 'rootDirs'.",
+			"Loading 'types' from the root dir '/project/gen', candidate location '/project/gen/types'.",
+			"Loading module as file / folder, candidate module location '/project/gen/types', target file types: TypeScript, JavaScript, Declaration, JSON.",
+			"File '/project/gen/types.ts' exists - use it as a name resolution result.",
+			"======== Module name './types' was successfully resolved to '/project/gen/types.ts'. ========",
+		})
+	})
+
+	t.Run("trace of a directory that is only under another root", func(t *testing.T) {
+		t.Parallel()
+		options := &core.CompilerOptions{
+			ModuleResolution: core.ModuleResolutionKindBundler,
+			RootDirs:         []string{"/project/src", "/project/gen"},
+			TraceResolution:  core.TSTrue,
+		}
+		// /project/src/only does not exist, so the files in it are not looked up
+		_, traces := resolve(options, "./only/types", "/project/src/main.ts")
+		assert.DeepEqual(t, traces, []string{
+			"======== Resolving module './only/types' from '/project/src/main.ts'. ========",
+			"Explicitly specified module resolution kind: 'Bundler'.",
+			"Resolving in CJS mode with conditions 'import', 'types'.",
+			"'rootDirs' option is set, using it to resolve relative module name './only/types'.",
+			"Checking if '/project/src/' is the longest matching prefix for '/project/src/only/types' - 'true'.",
+			"Checking if '/project/gen/' is the longest matching prefix for '/project/src/only/types' - 'false'.",
+			"Longest matching prefix for '/project/src/only/types' is '/project/src/'.",
+			"Loading 'only/types' from the root dir '/project/src/', candidate location '/project/src/only/types'.",
+			"Loading module as file / folder, candidate module location '/project/src/only/types', target file types: TypeScript, JavaScript, Declaration, JSON.",
+			"Trying other entries in 'rootDirs'.",
+			"Loading 'only/types' from the root dir '/project/gen', candidate location '/project/gen/only/types'.",
+			"Loading module as file / folder, candidate module location '/project/gen/only/types', target file types: TypeScript, JavaScript, Declaration, JSON.",
+			"File '/project/gen/only/types.ts' exists - use it as a name resolution result.",
+			"======== Module name './only/types' was successfully resolved to '/project/gen/only/types.ts'. ========",
+		})
+	})
+}
+
+func TestResolveModuleNameWithTrace(t *testing.T) {
+	t.Parallel()
+
+	host := newVFSModuleResolutionHost(map[string]string{
+		"/project/src/main.ts":                   `import "pad";`,
+		"/project/node_modules/pad/package.json": `{ "name": "pad", "version": "1.0.0", "exports": { ".": { "import": "./index.mjs", "types": "./index.d.ts" } } }`,
+		"/project/node_modules/pad/index.d.ts":   `export {};`,
+	}, "/project")
+	resolver := module.NewResolver(host, &core.CompilerOptions{ModuleResolution: core.ModuleResolutionKindNodeNext})
+	resolved, trace := resolver.ResolveModuleNameWithTrace("pad", "/project/src/main.ts", core.ModuleKindCommonJS, nil)
+	assert.Equal(t, resolved.ResolvedFileName, "/project/node_modules/pad/index.d.ts")
+	// the steps are returned even though traceResolution is not set, and are not written to the host
+	assert.Equal(t, len(host.traces), 0)
+	assert.Equal(t, trace[0].Message, "======== Resolving module 'pad' from '/project/src/main.ts'. ========")
+
+	events := core.Filter(trace, func(event module.TraceEvent) bool { return event.Kind != module.TraceEventKindMessage })
+	assert.DeepEqual(t, events, []module.TraceEvent{
+		{Kind: module.TraceEventKindPackageJson, Message: "File '/project/src/package.json' does not exist.", Path: "/project/src/package.json"},
+		{Kind: module.TraceEventKindPackageJson, Message: "File '/project/package.json' does not exist.", Path: "/project/package.json"},
+		{Kind: module.TraceEventKindPackageJson, Message: "File '/package.json' does not exist.", Path: "/package.json"},
+		{Kind: module.TraceEventKindLookup, Message: "Directory '/project/src/node_modules' does not exist, skipping all lookups in it.", Path: "/project/src/node_modules"},
+		{Kind: module.TraceEventKindLookup, Message: "Directory '/project/src/node_modules/@types' does not exist, skipping all lookups in it.", Path: "/project/src/node_modules/@types"},
+		{Kind: module.TraceEventKindPackageJson, Message: "Found 'package.json' at '/project/node_modules/pad/package.json'.", Path: "/project/node_modules/pad/package.json", Found: true},
+		{Kind: module.TraceEventKindCondition, Message: "Saw non-matching condition 'import'.", Condition: "import"},
+		{Kind: module.TraceEventKindCondition, Message: "Matched 'exports' condition 'types'.", Condition: "types", Found: true},
+		{Kind: module.TraceEventKindLookup, Message: "File '/project/node_modules/pad/index.d.ts' exists - use it as a name resolution result.", Path: "/project/node_modules/pad/index.d.ts", Found: true},
+		{Kind: module.TraceEventKindRealpath, Message: "Resolving real path for '/project/node_modules/pad/index.d.ts', result '/project/node_modules/pad/index.d.ts'.", Path: "/project/node_modules/pad/index.d.ts", RealPath: "/project/node_modules/pad/index.d.ts"},
+	})
+}
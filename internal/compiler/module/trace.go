@@ -0,0 +1,53 @@
+package module
+
+// TraceEventKind is the kind of a step of a resolution.
+type TraceEventKind string
+
+const (
+	// TraceEventKindMessage is a step that is only described by its message.
+	TraceEventKindMessage TraceEventKind = "message"
+	// TraceEventKindLookup is a lookup of a file or a directory.
+	TraceEventKindLookup TraceEventKind = "lookup"
+	// TraceEventKindPackageJson is a read of a package.json file.
+	TraceEventKindPackageJson TraceEventKind = "packageJson"
+	// TraceEventKindCondition is a condition of an "exports" or "imports" field that was tested.
+	TraceEventKindCondition TraceEventKind = "condition"
+	// TraceEventKindRealpath is the resolution of the real path of a file, through symlinks.
+	TraceEventKindRealpath TraceEventKind = "realpath"
+)
+
+// TraceEvent is one step of a module or type reference directive resolution.
+type TraceEvent struct {
+	Kind TraceEventKind `json:"kind"`
+	// Message is the line written for the step by --traceResolution.
+	Message string `json:"message"`
+	// Path is the file or directory looked up, the package.json file read, or the path whose real path was
+	// resolved.
+	Path string `json:"path,omitempty"`
+	// Found reports whether the file, directory or package.json file exists, or whether the condition matched.
+	Found bool `json:"found,omitempty"`
+	// Condition is the condition tested.
+	Condition string `json:"condition,omitempty"`
+	// RealPath is the real path of Path.
+	RealPath string `json:"realPath,omitempty"`
+}
+
+// tracer receives the steps of one resolution. It writes their messages to the host as they are traced,
+// unless the events are collected for the caller of the resolution.
+type tracer struct {
+	host    ResolutionHost
+	collect bool
+	events  []TraceEvent
+}
+
+func (t *tracer) trace(event TraceEvent) {
+	if t.collect {
+		t.events = append(t.events, event)
+	} else {
+		t.host.Trace(event.Message)
+	}
+}
+
+func (t *tracer) message(message string) {
+	t.trace(TraceEvent{Kind: TraceEventKindMessage, Message: message})
+}
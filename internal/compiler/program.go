@@ -15,6 +15,8 @@ import (
 	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/scanner"
 	"github.com/microsoft/typescript-go/internal/sourcemap"
+	"github.com/microsoft/typescript-go/internal/tracing"
+	"github.com/microsoft/typescript-go/internal/transformers"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 )
@@ -27,6 +29,19 @@ type ProgramOptions struct {
 	SingleThreaded               bool
 	ProjectReference             []core.ProjectReference
 	ConfigFileParsingDiagnostics []*ast.Diagnostic
+	// Config is the parsed config file that the root files and options come from, if any. It is used to
+	// explain why root files are included in the program.
+	Config *tsoptions.ParsedCommandLine
+	// OldProgram is a previous program whose resolved modules, bound files and file graph may be reused.
+	OldProgram *Program
+	// HasInvalidatedResolutions reports whether the module resolutions of the file at path in OldProgram must be
+	// resolved again, because a file was created at one of the locations they found no file at (see
+	// Program.GetFilesWithFailedLookupLocation). When it is nil, the resolutions of unchanged files are reused.
+	HasInvalidatedResolutions func(path tspath.Path) bool
+	// Tracer measures the phases of the program, for --diagnostics, and records their events, for --generateTrace.
+	// A program whose events are traced runs on a single goroutine, so that its events nest, and with a single
+	// checker, so that the ids of its types are unique.
+	Tracer *tracing.Tracer
 }
 
 type Program struct {
@@ -34,6 +49,7 @@ type Program struct {
 	programOptions               ProgramOptions
 	compilerOptions              *core.CompilerOptions
 	configFileName               string
+	config                       *tsoptions.ParsedCommandLine
 	nodeModules                  map[string]*ast.SourceFile
 	checkers                     []*checker.Checker
 	checkersOnce                 sync.Once
@@ -43,11 +59,25 @@ type Program struct {
 
 	resolver        *module.Resolver
 	resolvedModules map[tspath.Path]module.ModeAwareCache[*module.ResolvedModule]
+	// failedLookupLocations are the locations the module resolutions of each file found no file at, which
+	// invalidate the resolutions once a file is created at any of them.
+	failedLookupLocations map[tspath.Path][]string
+	// filesByFailedLookupLocation is the inverse of failedLookupLocations, by the path of the location.
+	filesByFailedLookupLocation     map[tspath.Path][]tspath.Path
+	filesByFailedLookupLocationOnce sync.Once
 
 	comparePathsOptions tspath.ComparePathsOptions
 
-	files       []*ast.SourceFile
-	filesByPath map[tspath.Path]*ast.SourceFile
+	rootFiles                   []string
+	libFiles                    []string
+	automaticTypeDirectiveNames []string
+	structureIsReused           StructureIsReused
+
+	files              []*ast.SourceFile
+	filesByPath        map[tspath.Path]*ast.SourceFile
+	fileIncludeReasons map[tspath.Path][]*FileIncludeReason
+
+	fileProcessingDiagnostics []*fileProcessingDiagnostic
 
 	// The below settings are to track if a .js file should be add to the program if loaded via searching under node_modules.
 	// This works as imported modules are discovered recursively in a depth first manner, specifically:
@@ -63,29 +93,41 @@ type Program struct {
 
 	commonSourceDirectory     string
 	commonSourceDirectoryOnce sync.Once
+
+	// blockedEmitPaths are the paths of the output files that would overwrite input files or each other, whose
+	// emit is blocked and reported by emitBlockingDiagnostics.
+	blockedEmitPaths        core.Set[tspath.Path]
+	emitBlockingDiagnostics []*ast.Diagnostic
+	emitBlockingOnce        sync.Once
 }
 
 var extensions = []string{".ts", ".tsx"}
 
 func NewProgram(options ProgramOptions) *Program {
 	p := &Program{}
+	if options.Tracer.TracesEvents() {
+		options.SingleThreaded = true
+	}
 	p.programOptions = options
 	p.compilerOptions = options.Options
 	p.configFileParsingDiagnostics = slices.Clip(options.ConfigFileParsingDiagnostics)
+	p.config = options.Config
 	if p.compilerOptions == nil {
 		p.compilerOptions = &core.CompilerOptions{}
 	}
 
 	// p.maxNodeModuleJsDepth = p.options.MaxNodeModuleJsDepth
 
-	// TODO(ercornel): !!! tracing?
-	// tracing?.push(tracing.Phase.Program, "createProgram", { configFilePath: options.configFilePath, rootDir: options.rootDir }, /*separateBeginAndEnd*/ true);
-	// performance.mark("beforeProgram");
+	defer options.Tracer.Begin(tracing.PhaseProgram, "createProgram", tracing.Args{"configFilePath": p.compilerOptions.ConfigFilePath, "rootDir": p.compilerOptions.RootDir}).End()
 
 	p.host = options.Host
 	if p.host == nil {
 		panic("host required")
 	}
+	p.comparePathsOptions = tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: p.host.FS().UseCaseSensitiveFileNames(),
+		CurrentDirectory:          p.host.GetCurrentDirectory(),
+	}
 
 	rootFiles := options.RootFiles
 
@@ -118,6 +160,7 @@ func NewProgram(options ProgramOptions) *Program {
 		)
 
 		p.compilerOptions = parseConfigFileContent.CompilerOptions()
+		p.config = parseConfigFileContent
 
 		if len(parseConfigFileContent.Errors) > 0 {
 			p.configFileParsingDiagnostics = append(p.configFileParsingDiagnostics, parseConfigFileContent.Errors...)
@@ -149,7 +192,21 @@ func NewProgram(options ProgramOptions) *Program {
 		}
 	}
 
-	p.files, p.resolvedModules = processAllProgramFiles(p.host, p.programOptions, p.compilerOptions, p.resolver, rootFiles, libs)
+	p.rootFiles = rootFiles
+	p.libFiles = libs
+	p.automaticTypeDirectiveNames = module.GetAutomaticTypeDirectiveNames(p.compilerOptions, p.host)
+
+	p.structureIsReused = p.tryReuseStructureFromOldProgram()
+	if p.structureIsReused != StructureIsReusedCompletely {
+		var oldProgram *Program
+		if p.structureIsReused == StructureIsReusedSafeModules {
+			oldProgram = options.OldProgram
+		}
+		p.files, p.resolvedModules, p.failedLookupLocations, p.fileIncludeReasons, p.fileProcessingDiagnostics = processAllProgramFiles(p.host, p.programOptions, p.compilerOptions, p.resolver, rootFiles, libs, p.automaticTypeDirectiveNames, oldProgram)
+	}
+	// The old program is not retained, so that chains of programs can be collected.
+	p.programOptions.OldProgram = nil
+	p.programOptions.HasInvalidatedResolutions = nil
 	p.filesByPath = make(map[tspath.Path]*ast.SourceFile, len(p.files))
 	for _, file := range p.files {
 		p.filesByPath[file.Path()] = file
@@ -158,13 +215,15 @@ func NewProgram(options ProgramOptions) *Program {
 	return p
 }
 
-func NewProgramFromParsedCommandLine(config *tsoptions.ParsedCommandLine, host CompilerHost) *Program {
+func NewProgramFromParsedCommandLine(config *tsoptions.ParsedCommandLine, host CompilerHost, tracer *tracing.Tracer) *Program {
 	programOptions := ProgramOptions{
 		RootFiles: config.FileNames(),
 		Options:   config.CompilerOptions(),
 		Host:      host,
 		// todo: ProjectReferences
 		ConfigFileParsingDiagnostics: config.GetConfigFileParsingDiagnostics(),
+		Config:                       config,
+		Tracer:                       tracer,
 	}
 	return NewProgram(programOptions)
 }
@@ -177,24 +236,38 @@ func (p *Program) GetConfigFileParsingDiagnostics() []*ast.Diagnostic {
 }
 
 func (p *Program) BindSourceFiles() {
+	tracer := p.programOptions.Tracer
 	wg := core.NewWorkGroup(p.programOptions.SingleThreaded)
-	for _, file := range p.files {
+	queued := false
+	// a single-threaded work group runs the function queued last first, so queueing the files in reverse binds
+	// them in order
+	for _, file := range slices.Backward(p.files) {
 		if !file.IsBound() {
+			queued = true
 			wg.Queue(func() {
+				defer tracer.Begin(tracing.PhaseBind, "bindSourceFile", tracing.Args{"path": file.FileName()}).End()
 				binder.BindSourceFile(file, p.compilerOptions)
 			})
 		}
 	}
+	if !queued {
+		return
+	}
+	defer tracer.Begin(tracing.PhaseBind, "bindSourceFiles", nil).End()
 	wg.RunAndWait()
 }
 
 func (p *Program) CheckSourceFiles() {
 	p.createCheckers()
+	tracer := p.programOptions.Tracer
+	defer tracer.Begin(tracing.PhaseCheck, "checkSourceFiles", nil).End()
 	wg := core.NewWorkGroup(p.programOptions.SingleThreaded)
 	for index, checker := range p.checkers {
 		wg.Queue(func() {
 			for i := index; i < len(p.files); i += len(p.checkers) {
+				span := tracer.Begin(tracing.PhaseCheck, "checkSourceFile", tracing.Args{"path": p.files[i].FileName()})
 				checker.CheckSourceFile(p.files[i])
+				span.End()
 			}
 		})
 	}
@@ -235,7 +308,7 @@ func (p *Program) GetTypeCheckerForFile(file *ast.SourceFile) *checker.Checker {
 func (p *Program) GetResolvedModule(file *ast.SourceFile, moduleReference string) *ast.SourceFile {
 	if resolutions, ok := p.resolvedModules[file.Path()]; ok {
 		if resolved, ok := resolutions[module.ModeAwareCacheKey{Name: moduleReference, Mode: core.ModuleKindCommonJS}]; ok {
-			return p.findSourceFile(resolved.ResolvedFileName, FileIncludeReason{FileIncludeKindImport, 0})
+			return p.findSourceFile(resolved.ResolvedFileName, FileIncludeReason{Kind: FileIncludeKindImport})
 		}
 	}
 	return nil
@@ -269,6 +342,14 @@ func (p *Program) GetSemanticDiagnostics(sourceFile *ast.SourceFile) []*ast.Diag
 	return p.getDiagnosticsHelper(sourceFile, true /*ensureBound*/, true /*ensureChecked*/, p.getSemanticDiagnosticsForFile)
 }
 
+func (p *Program) GetSuggestionDiagnostics(sourceFile *ast.SourceFile) []*ast.Diagnostic {
+	return p.getDiagnosticsHelper(sourceFile, true /*ensureBound*/, true /*ensureChecked*/, p.getSuggestionDiagnosticsForFile)
+}
+
+func (p *Program) GetDeclarationDiagnostics(sourceFile *ast.SourceFile) []*ast.Diagnostic {
+	return p.getDiagnosticsHelper(sourceFile, true /*ensureBound*/, false /*ensureChecked*/, p.getDeclarationDiagnosticsForFile)
+}
+
 func (p *Program) GetGlobalDiagnostics() []*ast.Diagnostic {
 	p.createCheckers()
 	var globalDiagnostics []*ast.Diagnostic
@@ -279,7 +360,95 @@ func (p *Program) GetGlobalDiagnostics() []*ast.Diagnostic {
 }
 
 func (p *Program) GetOptionsDiagnostics() []*ast.Diagnostic {
-	return SortAndDeduplicateDiagnostics(append(p.GetGlobalDiagnostics(), p.getOptionsDiagnosticsOfConfigFile()...))
+	return SortAndDeduplicateDiagnostics(slices.Concat(p.GetGlobalDiagnostics(), p.getOptionsDiagnosticsOfConfigFile(), p.getFileProcessingDiagnostics(), p.getProjectFileListDiagnostics(), p.getEmitBlockingDiagnostics()))
+}
+
+// getFileProcessingDiagnostics reports the problems with files found while the files of the program were
+// loaded, such as files referenced by names that differ only in casing.
+func (p *Program) getFileProcessingDiagnostics() []*ast.Diagnostic {
+	return core.Map(p.fileProcessingDiagnostics, func(d *fileProcessingDiagnostic) *ast.Diagnostic {
+		return p.createDiagnosticExplainingFile(p.filesByPath[d.file], d.reason, d.message, d.args...)
+	})
+}
+
+// getProjectFileListDiagnostics reports the emitted files of a composite project that are not root files,
+// as other projects can only reference the files that a composite project lists.
+func (p *Program) getProjectFileListDiagnostics() []*ast.Diagnostic {
+	if !p.compilerOptions.Composite.IsTrue() {
+		return nil
+	}
+	rootPaths := core.NewSetWithSizeHint[tspath.Path](len(p.rootFiles))
+	for _, fileName := range p.rootFiles {
+		rootPaths.Add(tspath.ToPath(fileName, p.host.GetCurrentDirectory(), p.host.FS().UseCaseSensitiveFileNames()))
+	}
+	host := &emitHost{program: p}
+	var result []*ast.Diagnostic
+	for _, file := range p.files {
+		if sourceFileMayBeEmitted(file, host, false /*forceDtsEmit*/) && !rootPaths.Has(file.Path()) {
+			result = append(result, p.createDiagnosticExplainingFile(file, nil /*fileProcessingReason*/, diagnostics.File_0_is_not_listed_within_the_file_list_of_project_1_Projects_must_list_all_files_or_use_an_include_pattern, file.FileName(), p.compilerOptions.ConfigFilePath))
+		}
+	}
+	return result
+}
+
+func (p *Program) getEmitBlockingDiagnostics() []*ast.Diagnostic {
+	p.verifyEmitFilePaths()
+	return p.emitBlockingDiagnostics
+}
+
+// isEmitBlocked reports whether the output file fileName would overwrite an input file or another output file.
+func (p *Program) isEmitBlocked(fileName string) bool {
+	p.verifyEmitFilePaths()
+	return p.blockedEmitPaths.Has(tspath.ToPath(fileName, p.host.GetCurrentDirectory(), p.host.FS().UseCaseSensitiveFileNames()))
+}
+
+// verifyEmitFilePaths blocks the emit of the output files that would overwrite input files, or that more than
+// one input file would be written to.
+func (p *Program) verifyEmitFilePaths() {
+	p.emitBlockingOnce.Do(func() {
+		if p.compilerOptions.NoEmit.IsTrue() || p.compilerOptions.SuppressOutputPathCheck.IsTrue() {
+			return
+		}
+		host := &emitHost{program: p}
+		emitFilesSeen := core.Set[tspath.Path]{}
+		verifyEmitFilePath := func(emitFileName string) {
+			if emitFileName == "" {
+				return
+			}
+			emitFilePath := tspath.ToPath(emitFileName, p.host.GetCurrentDirectory(), p.host.FS().UseCaseSensitiveFileNames())
+			// Report error if the output overwrites input file
+			if _, ok := p.filesByPath[emitFilePath]; ok {
+				diagnostic := ast.NewCompilerDiagnostic(diagnostics.Cannot_write_file_0_because_it_would_overwrite_input_file, emitFileName)
+				if p.compilerOptions.ConfigFilePath == "" {
+					// The program is from either an inferred project or an external project
+					diagnostic.AddMessageChain(ast.NewCompilerDiagnostic(diagnostics.Adding_a_tsconfig_json_file_will_help_organize_projects_that_contain_both_TypeScript_and_JavaScript_files_Learn_more_at_https_Colon_Slash_Slashaka_ms_Slashtsconfig))
+				}
+				p.blockEmittingOfFile(emitFilePath, diagnostic)
+			}
+			// Report error if multiple files write into same file
+			emitFileKey := emitFilePath
+			if !p.host.FS().UseCaseSensitiveFileNames() {
+				emitFileKey = tspath.Path(tspath.ToFileNameLowerCase(string(emitFilePath)))
+			}
+			if emitFilesSeen.Has(emitFileKey) {
+				p.blockEmittingOfFile(emitFilePath, ast.NewCompilerDiagnostic(diagnostics.Cannot_write_file_0_because_it_would_be_overwritten_by_multiple_input_files, emitFileName))
+			} else {
+				emitFilesSeen.Add(emitFileKey)
+			}
+		}
+		forEachEmittedFile(host, func(emitFileNames *outputPaths, _ *ast.SourceFile) bool {
+			if !p.compilerOptions.EmitDeclarationOnly.IsTrue() {
+				verifyEmitFilePath(emitFileNames.jsFilePath)
+			}
+			verifyEmitFilePath(emitFileNames.declarationFilePath)
+			return false
+		}, getSourceFilesToEmit(host, nil /*targetSourceFile*/, false /*forceDtsEmit*/), &EmitOptions{})
+	})
+}
+
+func (p *Program) blockEmittingOfFile(emitFilePath tspath.Path, diagnostic *ast.Diagnostic) {
+	p.blockedEmitPaths.Add(emitFilePath)
+	p.emitBlockingDiagnostics = append(p.emitBlockingDiagnostics, diagnostic)
 }
 
 func (p *Program) getOptionsDiagnosticsOfConfigFile() []*ast.Diagnostic {
@@ -298,6 +467,23 @@ func (p *Program) getBindDiagnosticsForFile(sourceFile *ast.SourceFile) []*ast.D
 	return sourceFile.BindDiagnostics()
 }
 
+func (p *Program) getDeclarationDiagnosticsForFile(sourceFile *ast.SourceFile) []*ast.Diagnostic {
+	if !p.compilerOptions.GetEmitDeclarations() || !sourceFileMayBeEmitted(sourceFile, &emitHost{program: p}, false /*forceDtsEmit*/) {
+		return nil
+	}
+	// !!! declaration diagnostics for JS files and checker-based declaration emit
+	if ast.IsInJSFile(sourceFile.AsNode()) || !p.compilerOptions.IsolatedDeclarations.IsTrue() {
+		return nil
+	}
+	declarationTransformer := transformers.NewDeclarationTransformer(printer.NewEmitContext(), p.compilerOptions)
+	declarationTransformer.TransformSourceFile(sourceFile)
+	return declarationTransformer.Diagnostics()
+}
+
+func (p *Program) getSuggestionDiagnosticsForFile(sourceFile *ast.SourceFile) []*ast.Diagnostic {
+	return p.GetTypeCheckerForFile(sourceFile).GetSuggestionDiagnostics(sourceFile)
+}
+
 func (p *Program) getSemanticDiagnosticsForFile(sourceFile *ast.SourceFile) []*ast.Diagnostic {
 	var fileChecker *checker.Checker
 	if sourceFile != nil {
@@ -398,6 +584,56 @@ func (p *Program) TypeCount() int {
 	return count
 }
 
+// IdentifierCount returns the number of identifiers parsed in the files of the program.
+func (p *Program) IdentifierCount() int {
+	var count int
+	for _, file := range p.files {
+		count += file.IdentifierCount
+	}
+	return count
+}
+
+// SymbolCount returns the number of symbols declared in the files of the program and created by its checkers.
+func (p *Program) SymbolCount() int {
+	var count int
+	for _, file := range p.files {
+		count += file.SymbolCount
+	}
+	for _, checker := range p.checkers {
+		count += checker.SymbolCount()
+	}
+	return count
+}
+
+func (p *Program) InstantiationCount() int {
+	var count int
+	for _, checker := range p.checkers {
+		count += checker.InstantiationCount()
+	}
+	return count
+}
+
+func (p *Program) RelationCacheSizes() checker.RelationCacheSizes {
+	var sizes checker.RelationCacheSizes
+	for _, c := range p.checkers {
+		checkerSizes := c.RelationCacheSizes()
+		sizes.Assignable += checkerSizes.Assignable
+		sizes.Identity += checkerSizes.Identity
+		sizes.Subtype += checkerSizes.Subtype
+		sizes.StrictSubtype += checkerSizes.StrictSubtype
+	}
+	return sizes
+}
+
+// DescribeTypes describes the types created by the checkers of the program, for the types.json of --generateTrace.
+func (p *Program) DescribeTypes() []tracing.TypeDescriptor {
+	var types []tracing.TypeDescriptor
+	for _, checker := range p.checkers {
+		types = append(types, checker.DescribeTypes()...)
+	}
+	return types
+}
+
 func (p *Program) PrintSourceFileWithTypes() {
 	for _, file := range p.files {
 		if tspath.GetBaseFileName(file.FileName()) == "main.ts" {
@@ -518,8 +754,36 @@ type sourceMapEmitResult struct {
 	sourceMap            *sourcemap.RawSourceMap
 }
 
+// handleNoEmitOnError skips the emit when noEmitOnError is set and the program, or sourceFile when it is not
+// nil, has errors. It returns the result of the skipped emit, or nil to continue with the emit.
+func (p *Program) handleNoEmitOnError(sourceFile *ast.SourceFile) *EmitResult {
+	if !p.compilerOptions.NoEmitOnError.IsTrue() {
+		return nil
+	}
+	diagnostics := slices.Concat(
+		p.GetOptionsDiagnostics(),
+		p.GetSyntacticDiagnostics(sourceFile),
+		p.GetGlobalDiagnostics(),
+		p.GetSemanticDiagnostics(sourceFile),
+	)
+	if len(diagnostics) == 0 && p.compilerOptions.GetEmitDeclarations() {
+		diagnostics = p.GetDeclarationDiagnostics(sourceFile)
+	}
+	if len(diagnostics) == 0 {
+		return nil
+	}
+	// !!! emit the build info
+	return &EmitResult{EmitSkipped: true, Diagnostics: diagnostics}
+}
+
 func (p *Program) Emit(options *EmitOptions) *EmitResult {
-	// !!! performance measurement
+	tracer := p.programOptions.Tracer
+	defer tracer.Begin(tracing.PhaseEmit, "emit", nil).End()
+	if !options.forceDtsEmit {
+		if result := p.handleNoEmitOnError(options.TargetSourceFile); result != nil {
+			return result
+		}
+	}
 	p.BindSourceFiles()
 
 	host := &emitHost{program: p}
@@ -534,14 +798,23 @@ func (p *Program) Emit(options *EmitOptions) *EmitResult {
 	sourceFiles := getSourceFilesToEmit(host, options.TargetSourceFile, options.forceDtsEmit)
 
 	for _, sourceFile := range sourceFiles {
+		var emittedFilesList []string
+		if p.compilerOptions.ListEmittedFiles.IsTrue() {
+			emittedFilesList = []string{}
+		}
 		emitter := &emitter{
 			host:              host,
-			emittedFilesList:  nil,
+			emittedFilesList:  emittedFilesList,
 			sourceMapDataList: nil,
 			writer:            nil,
 			sourceFile:        sourceFile,
+			tracer:            tracer,
 		}
 		emitters = append(emitters, emitter)
+	}
+	// a single-threaded work group runs the function queued last first, so queueing the emitters in reverse
+	// emits the files in order
+	for _, emitter := range slices.Backward(emitters) {
 		wg.Queue(func() {
 			// take an unused writer
 			writer := writerPool.Get().(printer.EmitTextWriter)
@@ -549,7 +822,7 @@ func (p *Program) Emit(options *EmitOptions) *EmitResult {
 
 			// attach writer and perform emit
 			emitter.writer = writer
-			emitter.paths = getOutputPathsFor(sourceFile, host, options.forceDtsEmit)
+			emitter.paths = getOutputPathsFor(emitter.sourceFile, host, options.forceDtsEmit)
 			emitter.emit()
 			emitter.writer = nil
 
@@ -591,21 +864,13 @@ func (p *Program) GetSourceFiles() []*ast.SourceFile {
 	return p.files
 }
 
-type FileIncludeKind int
-
-const (
-	FileIncludeKindRootFile FileIncludeKind = iota
-	FileIncludeKindSourceFromProjectReference
-	FileIncludeKindOutputFromProjectReference
-	FileIncludeKindImport
-	FileIncludeKindReferenceFile
-	FileIncludeKindTypeReferenceDirective
-	FileIncludeKindLibFile
-	FileIncludeKindLibReferenceDirective
-	FileIncludeKindAutomaticTypeDirectiveFile
-)
-
-type FileIncludeReason struct {
-	Kind  FileIncludeKind
-	Index int
+// IsSourceFileDefaultLibrary reports whether the file is one of the bundled lib.*.d.ts files.
+func (p *Program) IsSourceFileDefaultLibrary(file *ast.SourceFile) bool {
+	if !file.IsDeclarationFile {
+		return false
+	}
+	return tspath.ContainsPath(p.host.DefaultLibraryPath(), file.FileName(), tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: p.host.FS().UseCaseSensitiveFileNames(),
+		CurrentDirectory:          p.host.GetCurrentDirectory(),
+	})
 }
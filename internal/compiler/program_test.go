@@ -5,8 +5,12 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/compiler/module"
 	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
 	"github.com/microsoft/typescript-go/internal/vfs/vfstest"
 	"gotest.tools/v3/assert"
 )
@@ -238,3 +242,201 @@ func TestProgram(t *testing.T) {
 		})
 	}
 }
+
+// cachingCompilerHost returns the same SourceFile for a file until it is written, as the
+// language service does.
+type cachingCompilerHost struct {
+	CompilerHost
+	files map[tspath.Path]*ast.SourceFile
+}
+
+func (h *cachingCompilerHost) GetSourceFile(fileName string, path tspath.Path, languageVersion core.ScriptTarget) *ast.SourceFile {
+	if file, ok := h.files[path]; ok {
+		return file
+	}
+	file := h.CompilerHost.GetSourceFile(fileName, path, languageVersion)
+	h.files[path] = file
+	return file
+}
+
+func (h *cachingCompilerHost) writeFile(fileName string, contents string) {
+	_ = h.FS().WriteFile(fileName, contents, false)
+	delete(h.files, tspath.Path(fileName))
+}
+
+func TestProgramStructureReuse(t *testing.T) {
+	t.Parallel()
+
+	fs := vfstest.FromMap(map[string]string{
+		"/src/a.ts": `import { b } from "./b"; export const a = b;`,
+		"/src/b.ts": `import { c } from "./c"; export const b = c;`,
+		"/src/c.ts": `export const c = 1;`,
+		"/src/d.ts": `export const d = 1;`,
+	}, true /*useCaseSensitiveFileNames*/)
+	opts := &core.CompilerOptions{NoLib: core.TSTrue}
+	host := &cachingCompilerHost{CompilerHost: NewCompilerHost(opts, "/src", fs, bundled.LibPath()), files: map[tspath.Path]*ast.SourceFile{}}
+	var invalidatedResolutions core.Set[tspath.Path]
+	newProgram := func(oldProgram *Program, options *core.CompilerOptions) *Program {
+		program := NewProgram(ProgramOptions{
+			RootFiles:                 []string{"/src/a.ts"},
+			Host:                      host,
+			Options:                   options,
+			OldProgram:                oldProgram,
+			HasInvalidatedResolutions: invalidatedResolutions.Has,
+		})
+		program.BindSourceFiles()
+		invalidatedResolutions = core.Set[tspath.Path]{}
+		return program
+	}
+	fileNames := func(program *Program) []string {
+		return core.Map(program.SourceFiles(), (*ast.SourceFile).FileName)
+	}
+
+	program := newProgram(nil, opts)
+	assert.Equal(t, program.StructureIsReused(), StructureIsReusedNot)
+	assert.DeepEqual(t, fileNames(program), []string{"/src/c.ts", "/src/b.ts", "/src/a.ts"})
+
+	// Nothing changed.
+	oldProgram := program
+	program = newProgram(oldProgram, opts)
+	assert.Equal(t, program.StructureIsReused(), StructureIsReusedCompletely)
+	assert.Assert(t, slices.Equal(program.SourceFiles(), oldProgram.SourceFiles()))
+
+	// A file changed without changing its imports.
+	oldProgram = program
+	host.writeFile("/src/c.ts", `export const c = 2;`)
+	program = newProgram(oldProgram, opts)
+	assert.Equal(t, program.StructureIsReused(), StructureIsReusedCompletely)
+	assert.DeepEqual(t, fileNames(program), fileNames(oldProgram))
+	assert.Assert(t, program.GetSourceFile("/src/c.ts") != oldProgram.GetSourceFile("/src/c.ts"))
+	assert.Assert(t, program.GetSourceFile("/src/c.ts").IsBound())
+	assert.Equal(t, program.GetSourceFile("/src/a.ts"), oldProgram.GetSourceFile("/src/a.ts"))
+	assert.Equal(t, program.GetResolvedModule(program.GetSourceFile("/src/b.ts"), "./c"), program.GetSourceFile("/src/c.ts"))
+
+	// A file changed its imports; resolutions of unchanged files are kept.
+	oldProgram = program
+	host.writeFile("/src/a.ts", `import { b } from "./b"; import { d } from "./d"; export const a = b + d;`)
+	program = newProgram(oldProgram, opts)
+	assert.Equal(t, program.StructureIsReused(), StructureIsReusedSafeModules)
+	assert.DeepEqual(t, fileNames(program), []string{"/src/c.ts", "/src/b.ts", "/src/d.ts", "/src/a.ts"})
+	key := module.ModeAwareCacheKey{Name: "./c", Mode: core.ModuleKindCommonJS}
+	assert.Equal(t, program.resolvedModules["/src/b.ts"][key], oldProgram.resolvedModules["/src/b.ts"][key])
+
+	// An import that cannot be resolved.
+	diagnosticCodes := func(program *Program, fileName string) []int32 {
+		return core.Map(program.GetSemanticDiagnostics(program.GetSourceFile(fileName)), (*ast.Diagnostic).Code)
+	}
+	oldProgram = program
+	host.writeFile("/src/d.ts", `import { e } from "./e"; export const d = e;`)
+	program = newProgram(oldProgram, opts)
+	assert.Equal(t, program.StructureIsReused(), StructureIsReusedSafeModules)
+	assert.DeepEqual(t, diagnosticCodes(program, "/src/d.ts"), []int32{2307})
+	oldProgram = program
+	program = newProgram(oldProgram, opts)
+	assert.Equal(t, program.StructureIsReused(), StructureIsReusedCompletely)
+
+	// The file the import failed to resolve to is created; the resolutions are kept until they are invalidated.
+	oldProgram = program
+	host.writeFile("/src/e.ts", `export const e = 1;`)
+	program = newProgram(oldProgram, opts)
+	assert.Equal(t, program.StructureIsReused(), StructureIsReusedCompletely)
+	assert.DeepEqual(t, diagnosticCodes(program, "/src/d.ts"), []int32{2307})
+	assert.DeepEqual(t, program.GetFilesWithFailedLookupLocation("/src/e.ts"), []tspath.Path{"/src/d.ts"})
+	assert.Equal(t, len(program.GetFilesWithFailedLookupLocation("/src/c.ts")), 0)
+	oldProgram = program
+	for _, path := range program.GetFilesWithFailedLookupLocation("/src/e.ts") {
+		invalidatedResolutions.Add(path)
+	}
+	program = newProgram(oldProgram, opts)
+	assert.Equal(t, program.StructureIsReused(), StructureIsReusedSafeModules)
+	assert.DeepEqual(t, fileNames(program), []string{"/src/c.ts", "/src/b.ts", "/src/e.ts", "/src/d.ts", "/src/a.ts"})
+	assert.DeepEqual(t, diagnosticCodes(program, "/src/d.ts"), []int32(nil))
+	assert.Equal(t, program.GetResolvedModule(program.GetSourceFile("/src/d.ts"), "./e"), program.GetSourceFile("/src/e.ts"))
+	oldProgram = program
+	program = newProgram(oldProgram, opts)
+	assert.Equal(t, program.StructureIsReused(), StructureIsReusedCompletely)
+
+	// Different options.
+	oldProgram = program
+	program = newProgram(oldProgram, &core.CompilerOptions{NoLib: core.TSTrue})
+	assert.Equal(t, program.StructureIsReused(), StructureIsReusedNot)
+}
+
+func TestProgramFileNameCasing(t *testing.T) {
+	t.Parallel()
+
+	type diagnostic struct {
+		code    int32
+		message string
+		file    string
+	}
+	getDiagnostics := func(files map[string]string, rootFiles []string, options *core.CompilerOptions) []diagnostic {
+		fs := vfstest.FromMap(files, false /*useCaseSensitiveFileNames*/)
+		program := NewProgram(ProgramOptions{
+			RootFiles: rootFiles,
+			Host:      NewCompilerHost(options, "/src", fs, bundled.LibPath()),
+			Options:   options,
+		})
+		assert.Equal(t, len(program.SourceFiles()), len(files))
+		return core.Map(program.getFileProcessingDiagnostics(), func(d *ast.Diagnostic) diagnostic {
+			var file string
+			if d.File() != nil {
+				file = d.File().FileName()
+			}
+			return diagnostic{d.Code(), d.Message(), file}
+		})
+	}
+	options := &core.CompilerOptions{NoLib: core.TSTrue}
+
+	t.Run("imports differing in casing", func(t *testing.T) {
+		t.Parallel()
+		files := map[string]string{
+			"/src/a.ts":     `import { u } from "./utils"; import { U } from "./Utils";`,
+			"/src/utils.ts": `export const u = 1, U = 2;`,
+		}
+		assert.DeepEqual(t, getDiagnostics(files, []string{"/src/a.ts"}, options), []diagnostic{
+			{1149, "File name '/src/Utils.ts' differs from already included file name '/src/utils.ts' only in casing.", "/src/a.ts"},
+		}, cmp.AllowUnexported(diagnostic{}))
+	})
+
+	t.Run("root file differing in casing from an import", func(t *testing.T) {
+		t.Parallel()
+		files := map[string]string{
+			"/src/a.ts":     `import { u } from "./utils";`,
+			"/src/utils.ts": `export const u = 1;`,
+		}
+		assert.DeepEqual(t, getDiagnostics(files, []string{"/src/a.ts", "/src/Utils.ts"}, options), []diagnostic{
+			{1261, "Already included file name '/src/utils.ts' differs from file name '/src/Utils.ts' only in casing.", "/src/a.ts"},
+		}, cmp.AllowUnexported(diagnostic{}))
+	})
+
+	t.Run("import differing in casing from the file on disk", func(t *testing.T) {
+		t.Parallel()
+		files := map[string]string{
+			"/src/a.ts":     `import { u } from "./Utils";`,
+			"/src/utils.ts": `export const u = 1;`,
+		}
+		assert.DeepEqual(t, getDiagnostics(files, []string{"/src/a.ts"}, options), []diagnostic{
+			{1149, "File name '/src/Utils.ts' differs from already included file name '/src/utils.ts' only in casing.", "/src/a.ts"},
+		}, cmp.AllowUnexported(diagnostic{}))
+	})
+
+	t.Run("current directory differing in casing from the directory on disk", func(t *testing.T) {
+		t.Parallel()
+		files := map[string]string{
+			"/Src/a.ts":     `import { u } from "./utils";`,
+			"/Src/utils.ts": `export const u = 1;`,
+		}
+		assert.Equal(t, len(getDiagnostics(files, []string{"/src/a.ts"}, options)), 0)
+	})
+
+	t.Run("forceConsistentCasingInFileNames disabled", func(t *testing.T) {
+		t.Parallel()
+		files := map[string]string{
+			"/src/a.ts":     `import { u } from "./utils"; import { U } from "./Utils";`,
+			"/src/utils.ts": `export const u = 1, U = 2;`,
+		}
+		options := &core.CompilerOptions{NoLib: core.TSTrue, ForceConsistentCasingInFileNames: core.TSFalse}
+		assert.Equal(t, len(getDiagnostics(files, []string{"/src/a.ts"}, options)), 0)
+	})
+}
@@ -0,0 +1,156 @@
+package compiler
+
+import (
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/compiler/module"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+//go:generate go tool golang.org/x/tools/cmd/stringer -type=StructureIsReused -output=reuse_stringer_generated.go
+
+// StructureIsReused describes how much of an old program was reused to build a new one.
+type StructureIsReused int32
+
+const (
+	// StructureIsReusedNot means the program was built from scratch.
+	StructureIsReusedNot StructureIsReused = iota
+	// StructureIsReusedSafeModules means the file graph was rebuilt, but the module resolutions of unchanged files were reused.
+	StructureIsReusedSafeModules
+	// StructureIsReusedCompletely means the file graph, the module resolutions and the bound unchanged files were all reused.
+	StructureIsReusedCompletely
+)
+
+// tryReuseStructureFromOldProgram attempts to build the file list and module resolutions of p from
+// ProgramOptions.OldProgram. Compiler options are compared by identity, as they are never mutated once
+// a program has been created from them. When the result is StructureIsReusedCompletely, p.files,
+// p.resolvedModules, p.failedLookupLocations, p.fileIncludeReasons and p.fileProcessingDiagnostics have been
+// filled in.
+func (p *Program) tryReuseStructureFromOldProgram() StructureIsReused {
+	oldProgram := p.programOptions.OldProgram
+	if oldProgram == nil || oldProgram.compilerOptions != p.compilerOptions {
+		return StructureIsReusedNot
+	}
+	if !slices.Equal(oldProgram.rootFiles, p.rootFiles) || !slices.Equal(oldProgram.libFiles, p.libFiles) {
+		return StructureIsReusedNot
+	}
+
+	structureIsReused := StructureIsReusedCompletely
+	if !slices.Equal(oldProgram.automaticTypeDirectiveNames, p.automaticTypeDirectiveNames) {
+		structureIsReused = StructureIsReusedSafeModules
+	}
+
+	files := make([]*ast.SourceFile, 0, len(oldProgram.files))
+	var modifiedFiles []*ast.SourceFile
+	for _, oldFile := range oldProgram.files {
+		newFile := p.host.GetSourceFile(oldFile.FileName(), oldFile.Path(), p.compilerOptions.GetEmitScriptTarget())
+		if newFile == nil {
+			return StructureIsReusedNot
+		}
+		if newFile == oldFile && p.hasInvalidatedResolutions(oldFile.Path()) {
+			structureIsReused = StructureIsReusedSafeModules
+		}
+		if newFile != oldFile {
+			if !fileReferencesAreEqual(oldFile.ReferencedFiles, newFile.ReferencedFiles) ||
+				!fileReferencesAreEqual(oldFile.TypeReferenceDirectives, newFile.TypeReferenceDirectives) ||
+				!fileReferencesAreEqual(oldFile.LibReferenceDirectives, newFile.LibReferenceDirectives) ||
+				!moduleNamesAreEqual(getModuleNames(oldFile), getModuleNames(newFile)) {
+				structureIsReused = StructureIsReusedSafeModules
+			}
+			modifiedFiles = append(modifiedFiles, newFile)
+		}
+		files = append(files, newFile)
+	}
+	if structureIsReused != StructureIsReusedCompletely {
+		return structureIsReused
+	}
+
+	// Only imports of edited files need to be resolved again; they must resolve exactly as before.
+	resolvedModules := make(map[tspath.Path]module.ModeAwareCache[*module.ResolvedModule], len(oldProgram.resolvedModules))
+	for path, resolutions := range oldProgram.resolvedModules {
+		resolvedModules[path] = resolutions
+	}
+	for _, file := range modifiedFiles {
+		oldResolutions := oldProgram.resolvedModules[file.Path()]
+		newResolutions := make(module.ModeAwareCache[*module.ResolvedModule], len(oldResolutions))
+		for _, name := range getModuleNames(file) {
+			if name.Text() == "" {
+				continue
+			}
+			key := module.ModeAwareCacheKey{Name: name.Text(), Mode: core.ModuleKindCommonJS /* !!! */}
+			resolution := p.resolver.ResolveModuleName(key.Name, file.FileName(), key.Mode, nil)
+			if oldResolution, ok := oldResolutions[key]; !ok || *oldResolution != *resolution {
+				return StructureIsReusedSafeModules
+			}
+			newResolutions[key] = resolution
+		}
+		if len(newResolutions) > 0 {
+			resolvedModules[file.Path()] = newResolutions
+		} else {
+			delete(resolvedModules, file.Path())
+		}
+	}
+
+	p.files = files
+	p.resolvedModules = resolvedModules
+	// the imports of edited files resolved exactly as before, so they found no file at the same locations
+	p.failedLookupLocations = oldProgram.failedLookupLocations
+	p.fileIncludeReasons = oldProgram.fileIncludeReasons
+	p.fileProcessingDiagnostics = oldProgram.fileProcessingDiagnostics
+	return StructureIsReusedCompletely
+}
+
+// hasInvalidatedResolutions reports whether the module resolutions of the file at path in the old program
+// must be resolved again.
+func (p *Program) hasInvalidatedResolutions(path tspath.Path) bool {
+	return p.programOptions.HasInvalidatedResolutions != nil && p.programOptions.HasInvalidatedResolutions(path)
+}
+
+// GetFailedLookupLocations returns the locations the module resolutions of the program found no file at.
+func (p *Program) GetFailedLookupLocations() []string {
+	var locations []string
+	seen := core.Set[string]{}
+	for _, file := range p.files {
+		for _, location := range p.failedLookupLocations[file.Path()] {
+			if !seen.Has(location) {
+				seen.Add(location)
+				locations = append(locations, location)
+			}
+		}
+	}
+	return locations
+}
+
+// GetFilesWithFailedLookupLocation returns the files whose module resolutions found no file at path. Once a
+// file is created there, their resolutions are invalidated with ProgramOptions.HasInvalidatedResolutions.
+func (p *Program) GetFilesWithFailedLookupLocation(path tspath.Path) []tspath.Path {
+	p.filesByFailedLookupLocationOnce.Do(func() {
+		p.filesByFailedLookupLocation = make(map[tspath.Path][]tspath.Path)
+		for file, locations := range p.failedLookupLocations {
+			for _, location := range locations {
+				locationPath := tspath.ToPath(location, p.host.GetCurrentDirectory(), p.host.FS().UseCaseSensitiveFileNames())
+				p.filesByFailedLookupLocation[locationPath] = append(p.filesByFailedLookupLocation[locationPath], file)
+			}
+		}
+	})
+	return p.filesByFailedLookupLocation[path]
+}
+
+// StructureIsReused reports how much of ProgramOptions.OldProgram was reused to build the program.
+func (p *Program) StructureIsReused() StructureIsReused {
+	return p.structureIsReused
+}
+
+func fileReferencesAreEqual(a []*ast.FileReference, b []*ast.FileReference) bool {
+	return slices.EqualFunc(a, b, func(a *ast.FileReference, b *ast.FileReference) bool {
+		return a.FileName == b.FileName && a.ResolutionMode == b.ResolutionMode && a.Preserve == b.Preserve
+	})
+}
+
+func moduleNamesAreEqual(a []*ast.Node, b []*ast.Node) bool {
+	return slices.EqualFunc(a, b, func(a *ast.Node, b *ast.Node) bool {
+		return a.Text() == b.Text()
+	})
+}
@@ -0,0 +1,25 @@
+// Code generated by "stringer -type=StructureIsReused -output=reuse_stringer_generated.go"; DO NOT EDIT.
+
+package compiler
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[StructureIsReusedNot-0]
+	_ = x[StructureIsReusedSafeModules-1]
+	_ = x[StructureIsReusedCompletely-2]
+}
+
+const _StructureIsReused_name = "StructureIsReusedNotStructureIsReusedSafeModulesStructureIsReusedCompletely"
+
+var _StructureIsReused_index = [...]uint8{0, 20, 48, 75}
+
+func (i StructureIsReused) String() string {
+	if i < 0 || i >= StructureIsReused(len(_StructureIsReused_index)-1) {
+		return "StructureIsReused(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _StructureIsReused_name[_StructureIsReused_index[i]:_StructureIsReused_index[i+1]]
+}
@@ -130,6 +130,8 @@ type CompilerOptions struct {
 	NoEmitForJsFiles    Tristate `json:"noEmitForJsFiles"`
 	PreserveWatchOutput Tristate `json:"preserveWatchOutput"`
 	Pretty              Tristate `json:"pretty"`
+	Help                Tristate `json:"help"`
+	All                 Tristate `json:"all"`
 	Version             Tristate `json:"version"`
 	Watch               Tristate `json:"watch"`
 	ShowConfig          Tristate `json:"showConfig"`
@@ -243,8 +245,7 @@ func (options *CompilerOptions) GetEmitStandardClassFields() bool {
 }
 
 func (options *CompilerOptions) GetEmitDeclarations() bool {
-	// !!!
-	return false
+	return options.Declaration.IsTrue() || options.Composite.IsTrue()
 }
 
 func (options *CompilerOptions) GetAreDeclarationMapsEnabled() bool {
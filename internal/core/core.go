@@ -430,6 +430,19 @@ func GetOutputExtension(fileName string, jsx JsxEmit) string {
 	}
 }
 
+func GetDeclarationEmitExtensionForPath(fileName string) string {
+	switch {
+	case tspath.FileExtensionIsOneOf(fileName, []string{tspath.ExtensionMjs, tspath.ExtensionMts}):
+		return tspath.ExtensionDmts
+	case tspath.FileExtensionIsOneOf(fileName, []string{tspath.ExtensionCjs, tspath.ExtensionCts}):
+		return tspath.ExtensionDcts
+	case tspath.FileExtensionIs(fileName, tspath.ExtensionJson):
+		return ".d.json.ts" // Drive-by redefinition of json declaration file output name so if it's ever enabled, it behaves well
+	default:
+		return tspath.ExtensionDts
+	}
+}
+
 // Given a name and a list of names that are *not* equal to the name, return a spelling suggestion if there is one that is close enough.
 // Names less than length 3 only check for case-insensitive equality.
 //
@@ -50,3 +50,15 @@ func (t TextRange) WithPos(pos int) TextRange {
 func (t TextRange) WithEnd(end int) TextRange {
 	return TextRange{pos: t.pos, end: TextPos(end)}
 }
+
+// TextChange
+
+// TextChange replaces the text in a range with new text.
+type TextChange struct {
+	TextRange
+	NewText string
+}
+
+func (t TextChange) ApplyTo(text string) string {
+	return text[:t.Pos()] + t.NewText + text[t.End():]
+}
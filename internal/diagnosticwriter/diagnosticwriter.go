@@ -18,6 +18,8 @@ import (
 type FormattingOptions struct {
 	tspath.ComparePathsOptions
 	NewLine string
+	// Locale is the language messages are written in, or nil for English.
+	Locale *diagnostics.Locale
 }
 
 const (
@@ -54,7 +56,7 @@ func FormatDiagnosticsWithColorAndContext(output io.Writer, diags []*ast.Diagnos
 
 		writeWithStyleAndReset(output, diagnostic.Category().Name(), getCategoryFormat(diagnostic.Category()))
 		fmt.Fprintf(output, "%s TS%d: %s", foregroundColorEscapeGrey, diagnostic.Code(), resetEscapeSequence)
-		WriteFlattenedDiagnosticMessage(output, diagnostic, formatOpts.NewLine)
+		WriteFlattenedDiagnosticMessage(output, diagnostic, formatOpts.NewLine, formatOpts.Locale)
 
 		if diagnostic.File() != nil && diagnostic.Code() != diagnostics.File_appears_to_be_binary.Code() {
 			fmt.Fprint(output, formatOpts.NewLine)
@@ -71,7 +73,7 @@ func FormatDiagnosticsWithColorAndContext(output io.Writer, diags []*ast.Diagnos
 					pos := relatedInformation.Pos()
 					WriteLocation(output, file, pos, formatOpts, writeWithStyleAndReset)
 					fmt.Fprint(output, " - ")
-					WriteFlattenedDiagnosticMessage(output, relatedInformation, formatOpts.NewLine)
+					WriteFlattenedDiagnosticMessage(output, relatedInformation, formatOpts.NewLine, formatOpts.Locale)
 					writeCodeSnippet(output, file, pos, relatedInformation.Len(), foregroundColorEscapeCyan, "    ", formatOpts)
 				}
 				fmt.Fprint(output, formatOpts.NewLine)
@@ -165,27 +167,29 @@ func writeCodeSnippet(writer io.Writer, sourceFile *ast.SourceFile, start int, l
 
 func FlattenDiagnosticMessage(d *ast.Diagnostic, newLine string) string {
 	var output strings.Builder
-	WriteFlattenedDiagnosticMessage(&output, d, newLine)
+	WriteFlattenedDiagnosticMessage(&output, d, newLine, nil /*locale*/)
 	return output.String()
 }
 
-func WriteFlattenedDiagnosticMessage(writer io.Writer, diagnostic *ast.Diagnostic, newline string) {
-	fmt.Fprint(writer, diagnostic.Message())
+// WriteFlattenedDiagnosticMessage writes the message of diagnostic and its message chain in locale, or in
+// English when locale is nil.
+func WriteFlattenedDiagnosticMessage(writer io.Writer, diagnostic *ast.Diagnostic, newline string, locale *diagnostics.Locale) {
+	fmt.Fprint(writer, diagnostic.Localize(locale))
 
 	for _, chain := range diagnostic.MessageChain() {
-		flattenDiagnosticMessageChain(writer, chain, newline, 1 /*level*/)
+		flattenDiagnosticMessageChain(writer, chain, newline, 1 /*level*/, locale)
 	}
 }
 
-func flattenDiagnosticMessageChain(writer io.Writer, chain *ast.Diagnostic, newLine string, level int) {
+func flattenDiagnosticMessageChain(writer io.Writer, chain *ast.Diagnostic, newLine string, level int, locale *diagnostics.Locale) {
 	fmt.Fprint(writer, newLine)
 	for range level {
 		fmt.Fprint(writer, "  ")
 	}
 
-	fmt.Fprint(writer, chain.Message())
+	fmt.Fprint(writer, chain.Localize(locale))
 	for _, child := range chain.MessageChain() {
-		flattenDiagnosticMessageChain(writer, child, newLine, level+1)
+		flattenDiagnosticMessageChain(writer, child, newLine, level+1, locale)
 	}
 }
 
@@ -227,6 +231,27 @@ func WriteLocation(output io.Writer, file *ast.SourceFile, pos int, formatOpts *
 	writeWithStyleAndReset(output, strconv.Itoa(firstChar+1), foregroundColorEscapeYellow)
 }
 
+// WriteWatchStatus writes a status message of watch mode, such as "Starting compilation in watch mode...",
+// stamped with time.
+func WriteWatchStatus(output io.Writer, diagnostic *ast.Diagnostic, time string, pretty bool, newLine string, locale *diagnostics.Locale) {
+	// Roughly corresponds to 'createWatchStatusReporter' from watch.ts
+	if pretty {
+		fmt.Fprint(output, "[")
+		writeWithStyleAndReset(output, time, foregroundColorEscapeGrey)
+		fmt.Fprint(output, "] ")
+		WriteFlattenedDiagnosticMessage(output, diagnostic, newLine, locale)
+		fmt.Fprint(output, newLine, newLine)
+		return
+	}
+	fmt.Fprint(output, newLine, time, " - ")
+	WriteFlattenedDiagnosticMessage(output, diagnostic, newLine, locale)
+	fmt.Fprint(output, newLine)
+	if diagnostic.Code() == diagnostics.Starting_compilation_in_watch_mode.Code() ||
+		diagnostic.Code() == diagnostics.File_change_detected_Starting_incremental_compilation.Code() {
+		fmt.Fprint(output, newLine)
+	}
+}
+
 // Some of these lived in watch.ts, but they're not specific to the watch API.
 
 type ErrorSummary struct {
@@ -256,20 +281,20 @@ func WriteErrorSummaryText(output io.Writer, allDiagnostics []*ast.Diagnostic, f
 	if totalErrorCount == 1 {
 		// Special-case a single error.
 		if len(errorSummary.GlobalErrors) > 0 || firstFileName == "" {
-			message = diagnostics.Found_1_error.Format()
+			message = diagnostics.Found_1_error.Localize(formatOpts.Locale)
 		} else {
-			message = diagnostics.Found_1_error_in_0.Format(firstFileName)
+			message = diagnostics.Found_1_error_in_0.Localize(formatOpts.Locale, firstFileName)
 		}
 	} else {
 		if numErroringFiles == 0 {
 			// No file-specific errors.
-			message = diagnostics.Found_0_errors.Format(totalErrorCount)
+			message = diagnostics.Found_0_errors.Localize(formatOpts.Locale, totalErrorCount)
 		} else if numErroringFiles == 1 {
 			// One file with errors.
-			message = diagnostics.Found_0_errors_in_the_same_file_starting_at_Colon_1.Format(totalErrorCount, firstFileName)
+			message = diagnostics.Found_0_errors_in_the_same_file_starting_at_Colon_1.Localize(formatOpts.Locale, totalErrorCount, firstFileName)
 		} else {
 			// Multiple files with errors.
-			message = diagnostics.Found_0_errors_in_1_files.Format(totalErrorCount, numErroringFiles)
+			message = diagnostics.Found_0_errors_in_1_files.Localize(formatOpts.Locale, totalErrorCount, numErroringFiles)
 		}
 	}
 	fmt.Fprint(output, formatOpts.NewLine)
@@ -328,7 +353,7 @@ func writeTabularErrorsDisplay(output io.Writer, errorSummary *ErrorSummary, for
 	// !!!
 	// TODO (drosen): This was never localized.
 	// Should make this better.
-	headerRow := diagnostics.Errors_Files.Message()
+	headerRow := diagnostics.Errors_Files.Localize(formatOpts.Locale)
 	leftColumnHeadingLength := len(strings.Split(headerRow, " ")[0])
 	lengthOfBiggestErrorCount := len(strconv.Itoa(maxErrors))
 	leftPaddingGoal := max(leftColumnHeadingLength, lengthOfBiggestErrorCount)
@@ -380,6 +405,6 @@ func WriteFormatDiagnostic(output io.Writer, diagnostic *ast.Diagnostic, formatO
 	}
 
 	fmt.Fprintf(output, "%s TS%d: ", diagnostic.Category().Name(), diagnostic.Code())
-	WriteFlattenedDiagnosticMessage(output, diagnostic, formatOpts.NewLine)
+	WriteFlattenedDiagnosticMessage(output, diagnostic, formatOpts.NewLine, formatOpts.Locale)
 	fmt.Fprint(output, formatOpts.NewLine)
 }
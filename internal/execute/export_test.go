@@ -1,7 +1,6 @@
 package execute
 
 import (
-	"github.com/microsoft/typescript-go/internal/compiler"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 )
 
@@ -17,15 +16,14 @@ func CommandLineTestWatch(sys System, cb cbType, commandLineArgs []string) (*tso
 	return parsedCommandLine, w
 }
 
-func RunWatchCycle(w *watcher) {
-	// this function should perform the same stuff as w.doCycle() without printing time-related output
-	if w.hasErrorsInTsConfig() {
-		// these are unrecoverable errors--report them and do not build
-		return
-	}
-	// todo: updateProgram()
-	w.program = compiler.NewProgramFromParsedCommandLine(w.options, w.host)
-	if w.hasBeenModified(w.program) {
-		w.compileAndEmit()
-	}
+const UpdateDelay = updateDelay
+
+// StartWatch performs the initial build of the watch mode. Later builds run on the timers of the system
+// as the watches detect changes, until StopWatch is called.
+func StartWatch(w *watcher) {
+	w.initialBuild()
+}
+
+func StopWatch(w *watcher) {
+	w.close()
 }
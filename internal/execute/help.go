@@ -0,0 +1,412 @@
+package execute
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/stringutil"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+)
+
+func printVersion(sys System) {
+	fmt.Fprint(sys.Writer(), diagnostics.Version_0.Format(core.Version), sys.NewLine())
+	sys.EndWrite()
+}
+
+func printHelp(sys System, commandLine *tsoptions.ParsedCommandLine) {
+	if commandLine.CompilerOptions().All.IsFalseOrUnknown() {
+		printEasyHelp(sys, getOptionsForHelp(commandLine))
+	} else {
+		printAllHelp(sys, getOptionsForHelp(commandLine), tsoptions.OptionsForBuild, tsoptions.OptionsForWatch)
+	}
+}
+
+func getOptionsForHelp(commandLine *tsoptions.ParsedCommandLine) []*tsoptions.CommandLineOption {
+	// Sort our options by their names, (e.g. "--noImplicitAny" comes before "--watch")
+	if commandLine.CompilerOptions().All.IsTrue() {
+		return slices.SortedStableFunc(slices.Values(tsoptions.OptionsDeclarations), func(a, b *tsoptions.CommandLineOption) int {
+			return int(stringutil.CompareStringsCaseInsensitive(a.Name, b.Name))
+		})
+	}
+	return core.Filter(tsoptions.OptionsDeclarations, func(option *tsoptions.CommandLineOption) bool {
+		return option.ShowInSimplifiedHelpView()
+	})
+}
+
+func printEasyHelp(sys System, simpleOptions []*tsoptions.CommandLineOption) {
+	colors := createColors(sys)
+	output := getHeader(sys, diagnostics.X_tsc_Colon_The_TypeScript_Compiler.Message()+" - "+diagnostics.Version_0.Format(core.Version))
+	output = append(output, colors.bold(diagnostics.COMMON_COMMANDS.Message())+sys.NewLine()+sys.NewLine())
+
+	example := func(examples []string, desc *diagnostics.Message) {
+		for _, example := range examples {
+			output = append(output, "  "+colors.blue(example)+sys.NewLine())
+		}
+		output = append(output, "  "+desc.Message()+sys.NewLine()+sys.NewLine())
+	}
+	example([]string{"tsc"}, diagnostics.Compiles_the_current_project_tsconfig_json_in_the_working_directory)
+	example([]string{"tsc app.ts util.ts"}, diagnostics.Ignoring_tsconfig_json_compiles_the_specified_files_with_default_compiler_options)
+	example([]string{"tsc -b"}, diagnostics.Build_a_composite_project_in_the_working_directory)
+	example([]string{"tsc --init"}, diagnostics.Creates_a_tsconfig_json_with_the_recommended_settings_in_the_working_directory)
+	example([]string{"tsc -p ./path/to/tsconfig.json"}, diagnostics.Compiles_the_TypeScript_project_located_at_the_specified_path)
+	example([]string{"tsc --help --all"}, diagnostics.An_expanded_version_of_this_information_showing_all_possible_compiler_options)
+	example([]string{"tsc --noEmit", "tsc --target esnext"}, diagnostics.Compiles_the_current_project_with_additional_settings)
+
+	var cliCommands, configOpts []*tsoptions.CommandLineOption
+	for _, option := range simpleOptions {
+		if option.IsCommandLineOnly || option.Category == diagnostics.Command_line_Options {
+			cliCommands = append(cliCommands, option)
+		} else {
+			configOpts = append(configOpts, option)
+		}
+	}
+
+	output = append(output, generateSectionOptionsOutput(sys, diagnostics.COMMAND_LINE_FLAGS.Message(), cliCommands, false /*subCategory*/, "", "")...)
+	output = append(output, generateSectionOptionsOutput(sys, diagnostics.COMMON_COMPILER_OPTIONS.Message(), configOpts, false /*subCategory*/, "", diagnostics.You_can_learn_about_all_of_the_compiler_options_at_0.Format("https://aka.ms/tsc"))...)
+	writeLines(sys, output)
+}
+
+func printAllHelp(sys System, compilerOptions []*tsoptions.CommandLineOption, buildOptions []*tsoptions.CommandLineOption, watchOptions []*tsoptions.CommandLineOption) {
+	output := getHeader(sys, diagnostics.X_tsc_Colon_The_TypeScript_Compiler.Message()+" - "+diagnostics.Version_0.Format(core.Version))
+	output = append(output, generateSectionOptionsOutput(sys, diagnostics.ALL_COMPILER_OPTIONS.Message(), compilerOptions, true /*subCategory*/, "", diagnostics.You_can_learn_about_all_of_the_compiler_options_at_0.Format("https://aka.ms/tsc"))...)
+	output = append(output, generateSectionOptionsOutput(sys, diagnostics.WATCH_OPTIONS.Message(), watchOptions, false /*subCategory*/, diagnostics.Including_watch_w_will_start_watching_the_current_project_for_the_file_changes_Once_set_you_can_config_watch_mode_with_Colon.Message(), "")...)
+	buildOptions = core.Filter(buildOptions, func(option *tsoptions.CommandLineOption) bool { return option != &tsoptions.TscBuildOption })
+	output = append(output, generateSectionOptionsOutput(sys, diagnostics.BUILD_OPTIONS.Message(), buildOptions, false /*subCategory*/, diagnostics.Using_build_b_will_make_tsc_behave_more_like_a_build_orchestrator_than_a_compiler_This_is_used_to_trigger_building_composite_projects_which_you_can_learn_more_about_at_0.Format("https://aka.ms/tsc-composite-builds"), "")...)
+	writeLines(sys, output)
+}
+
+func writeLines(sys System, lines []string) {
+	for _, line := range lines {
+		fmt.Fprint(sys.Writer(), line)
+	}
+	sys.EndWrite()
+}
+
+func getHeader(sys System, message string) []string {
+	colors := createColors(sys)
+	var header []string
+	terminalWidth := sys.GetWidthOfTerminal()
+	const tsIconLength = 5
+
+	tsIconFirstLine := colors.blueBackground(strings.Repeat(" ", tsIconLength))
+	tsIconSecondLine := colors.blueBackground(colors.brightWhite(padStart("TS ", tsIconLength)))
+	// If we have enough space, print TS icon.
+	if terminalWidth >= len(message)+tsIconLength {
+		// right align of the icon is 120 at most.
+		rightAlign := min(terminalWidth, 120)
+		leftAlign := rightAlign - tsIconLength
+		header = append(header, padEnd(message, leftAlign)+tsIconFirstLine+sys.NewLine())
+		header = append(header, strings.Repeat(" ", leftAlign)+tsIconSecondLine+sys.NewLine())
+	} else {
+		header = append(header, message+sys.NewLine())
+		header = append(header, sys.NewLine())
+	}
+	return header
+}
+
+func generateSectionOptionsOutput(sys System, sectionName string, options []*tsoptions.CommandLineOption, subCategory bool, beforeOptionsDescription string, afterOptionsDescription string) []string {
+	var res []string
+	res = append(res, createColors(sys).bold(sectionName)+sys.NewLine()+sys.NewLine())
+	if beforeOptionsDescription != "" {
+		res = append(res, beforeOptionsDescription+sys.NewLine()+sys.NewLine())
+	}
+	if !subCategory {
+		res = append(res, generateGroupOptionOutput(sys, options)...)
+	} else {
+		var categories []*diagnostics.Message
+		optionsByCategory := map[*diagnostics.Message][]*tsoptions.CommandLineOption{}
+		for _, option := range options {
+			if option.Category == nil {
+				continue
+			}
+			if _, ok := optionsByCategory[option.Category]; !ok {
+				categories = append(categories, option.Category)
+			}
+			optionsByCategory[option.Category] = append(optionsByCategory[option.Category], option)
+		}
+		for _, category := range categories {
+			res = append(res, "### "+category.Message()+sys.NewLine()+sys.NewLine())
+			res = append(res, generateGroupOptionOutput(sys, optionsByCategory[category])...)
+		}
+	}
+	if afterOptionsDescription != "" {
+		res = append(res, afterOptionsDescription+sys.NewLine()+sys.NewLine())
+	}
+	return res
+}
+
+func generateGroupOptionOutput(sys System, optionsList []*tsoptions.CommandLineOption) []string {
+	maxLength := 0
+	for _, option := range optionsList {
+		maxLength = max(maxLength, len(getDisplayNameTextOfOption(option)))
+	}
+
+	// left part should be right align, right part should be left align
+
+	// assume 2 space between left margin and left part.
+	rightAlignOfLeftPart := maxLength + 2
+	// assume 2 space between left and right part
+	leftAlignOfRightPart := rightAlignOfLeftPart + 2
+	var lines []string
+	for _, option := range optionsList {
+		lines = append(lines, generateOptionOutput(sys, option, rightAlignOfLeftPart, leftAlignOfRightPart)...)
+	}
+	// make sure always a blank line in the end.
+	if len(lines) < 2 || lines[len(lines)-2] != sys.NewLine() {
+		lines = append(lines, sys.NewLine())
+	}
+	return lines
+}
+
+func getDisplayNameTextOfOption(option *tsoptions.CommandLineOption) string {
+	if option.ShortName() != "" {
+		return "--" + option.Name + ", -" + option.ShortName()
+	}
+	return "--" + option.Name
+}
+
+type valueCandidate struct {
+	// "one or more" or "any of"
+	valueType      string
+	possibleValues string
+}
+
+func generateOptionOutput(sys System, option *tsoptions.CommandLineOption, rightAlignOfLeft int, leftAlignOfRight int) []string {
+	var text []string
+	colors := createColors(sys)
+
+	// name and description
+	name := getDisplayNameTextOfOption(option)
+
+	// value type and possible value
+	valueCandidates := getValueCandidate(option)
+	var defaultValueDescription string
+	if message, ok := option.DefaultValueDescription.(*diagnostics.Message); ok {
+		defaultValueDescription = message.Message()
+	} else if option.Kind == tsoptions.CommandLineOptionTypeList || option.Kind == tsoptions.CommandLineOptionTypeListOrElement {
+		defaultValueDescription = formatDefaultValue(option.DefaultValueDescription, option.Elements())
+	} else {
+		defaultValueDescription = formatDefaultValue(option.DefaultValueDescription, option)
+	}
+
+	terminalWidth := sys.GetWidthOfTerminal()
+	if terminalWidth >= 80 {
+		description := ""
+		if option.Description != nil {
+			description = option.Description.Message()
+		}
+		text = append(text, getPrettyOutput(colors, name, description, rightAlignOfLeft, leftAlignOfRight, terminalWidth, true /*colorLeft*/)...)
+		text = append(text, sys.NewLine())
+		if showAdditionalInfoOutput(valueCandidates, option) {
+			if valueCandidates != nil {
+				text = append(text, getPrettyOutput(colors, valueCandidates.valueType, valueCandidates.possibleValues, rightAlignOfLeft, leftAlignOfRight, terminalWidth, false /*colorLeft*/)...)
+				text = append(text, sys.NewLine())
+			}
+			if defaultValueDescription != "" {
+				text = append(text, getPrettyOutput(colors, diagnostics.X_default_Colon.Message(), defaultValueDescription, rightAlignOfLeft, leftAlignOfRight, terminalWidth, false /*colorLeft*/)...)
+				text = append(text, sys.NewLine())
+			}
+		}
+		text = append(text, sys.NewLine())
+	} else {
+		text = append(text, colors.blue(name), sys.NewLine())
+		if option.Description != nil {
+			text = append(text, option.Description.Message())
+		}
+		text = append(text, sys.NewLine())
+		if showAdditionalInfoOutput(valueCandidates, option) {
+			if valueCandidates != nil {
+				text = append(text, valueCandidates.valueType+" "+valueCandidates.possibleValues)
+			}
+			if defaultValueDescription != "" {
+				if valueCandidates != nil {
+					text = append(text, sys.NewLine())
+				}
+				text = append(text, diagnostics.X_default_Colon.Message()+" "+defaultValueDescription)
+			}
+			text = append(text, sys.NewLine())
+		}
+		text = append(text, sys.NewLine())
+	}
+	return text
+}
+
+// formatDefaultValue formats the default value of an option, naming the values of enum options, such as
+// "es6/es2015" for ScriptTargetES2015.
+func formatDefaultValue(defaultValue any, option *tsoptions.CommandLineOption) string {
+	if defaultValue == nil || defaultValue == core.TSUnknown {
+		return "undefined"
+	}
+	if option.Kind == tsoptions.CommandLineOptionTypeEnum {
+		var names []string
+		for name, value := range option.EnumMap().Entries() {
+			if value == defaultValue {
+				names = append(names, name)
+			}
+		}
+		return strings.Join(names, "/")
+	}
+	return fmt.Sprint(defaultValue)
+}
+
+func showAdditionalInfoOutput(valueCandidates *valueCandidate, option *tsoptions.CommandLineOption) bool {
+	if option.Category == diagnostics.Command_line_Options {
+		return false
+	}
+	if valueCandidates != nil && valueCandidates.possibleValues == "string" {
+		switch option.DefaultValueDescription {
+		case nil, core.TSUnknown, "false", "n/a":
+			return false
+		}
+	}
+	return true
+}
+
+func getPrettyOutput(colors *colors, left string, right string, rightAlignOfLeft int, leftAlignOfRight int, terminalWidth int, colorLeft bool) []string {
+	var res []string
+	isFirstLine := true
+	remainRight := []rune(right)
+	rightCharacterNumber := terminalWidth - leftAlignOfRight
+	for len(remainRight) > 0 {
+		curLeft := ""
+		if isFirstLine {
+			curLeft = padEnd(padStart(left, rightAlignOfLeft), leftAlignOfRight)
+			if colorLeft {
+				curLeft = colors.blue(curLeft)
+			}
+		} else {
+			curLeft = strings.Repeat(" ", leftAlignOfRight)
+		}
+
+		curRight := remainRight[:min(rightCharacterNumber, len(remainRight))]
+		remainRight = remainRight[len(curRight):]
+		res = append(res, curLeft+string(curRight))
+		isFirstLine = false
+	}
+	return res
+}
+
+func getValueCandidate(option *tsoptions.CommandLineOption) *valueCandidate {
+	// option.Kind might be "string" | "number" | "boolean" | "object" | "list" | "enum"
+	// string -- any of: string
+	// number -- any of: number
+	// boolean -- any of: boolean
+	// object -- null
+	// list -- one or more: , content depends on `option.Elements().Kind`, the same as others
+	// enum -- any of: key1, key2, ....
+	if option.Kind == tsoptions.CommandLineOptionTypeObject {
+		return nil
+	}
+	var valueType string
+	switch option.Kind {
+	case tsoptions.CommandLineOptionTypeString, tsoptions.CommandLineOptionTypeNumber, tsoptions.CommandLineOptionTypeBoolean:
+		valueType = diagnostics.X_type_Colon.Message()
+	case tsoptions.CommandLineOptionTypeList:
+		valueType = diagnostics.X_one_or_more_Colon.Message()
+	default:
+		valueType = diagnostics.X_one_of_Colon.Message()
+	}
+	return &valueCandidate{
+		valueType:      valueType,
+		possibleValues: getPossibleValues(option),
+	}
+}
+
+func getPossibleValues(option *tsoptions.CommandLineOption) string {
+	switch option.Kind {
+	case tsoptions.CommandLineOptionTypeString, tsoptions.CommandLineOptionTypeNumber, tsoptions.CommandLineOptionTypeBoolean:
+		return string(option.Kind)
+	case tsoptions.CommandLineOptionTypeList, tsoptions.CommandLineOptionTypeListOrElement:
+		return getPossibleValues(option.Elements())
+	case tsoptions.CommandLineOptionTypeObject:
+		return ""
+	default:
+		// Group synonyms: es6/es2015
+		var values []any
+		synonyms := map[any][]string{}
+		for name, value := range option.EnumMap().Entries() {
+			if deprecatedKeys := option.DeprecatedKeys(); deprecatedKeys != nil && deprecatedKeys.Has(name) {
+				continue
+			}
+			if _, ok := synonyms[value]; !ok {
+				values = append(values, value)
+			}
+			synonyms[value] = append(synonyms[value], name)
+		}
+		// Numeric values are listed in ascending order, as the keys of a JavaScript object are.
+		slices.SortStableFunc(values, func(a, b any) int {
+			aValue, bValue := reflect.ValueOf(a), reflect.ValueOf(b)
+			if aValue.CanInt() && bValue.CanInt() {
+				return int(aValue.Int() - bValue.Int())
+			}
+			return 0
+		})
+		return strings.Join(core.Map(values, func(value any) string { return strings.Join(synonyms[value], "/") }), ", ")
+	}
+}
+
+type colors struct {
+	showColors          bool
+	isWindows           bool
+	isWindowsTerminal   bool
+	isVSCode            bool
+	supportsRicherColor bool
+}
+
+func createColors(sys System) *colors {
+	return &colors{
+		showColors:          defaultIsPretty(sys),
+		isWindows:           strings.Contains(strings.ToLower(sys.GetEnvironmentVariable("OS")), "windows"),
+		isWindowsTerminal:   sys.GetEnvironmentVariable("WT_SESSION") != "",
+		isVSCode:            sys.GetEnvironmentVariable("TERM_PROGRAM") == "vscode",
+		supportsRicherColor: sys.GetEnvironmentVariable("COLORTERM") == "truecolor" || sys.GetEnvironmentVariable("TERM") == "xterm-256color",
+	}
+}
+
+func (c *colors) bold(str string) string {
+	if !c.showColors {
+		return str
+	}
+	return "\x1b[1m" + str + "\x1b[22m"
+}
+
+func (c *colors) blue(str string) string {
+	if !c.showColors {
+		return str
+	}
+	// Effectively Powershell and Command prompt users use cyan instead
+	// of blue because the default theme doesn't show blue with enough contrast.
+	if c.isWindows && !c.isWindowsTerminal && !c.isVSCode {
+		return c.brightWhite(str)
+	}
+	return "\x1b[94m" + str + "\x1b[39m"
+}
+
+func (c *colors) blueBackground(str string) string {
+	if !c.showColors {
+		return str
+	}
+	if c.supportsRicherColor {
+		return "\x1b[48;5;68m" + str + "\x1b[39;49m"
+	}
+	return "\x1b[44m" + str + "\x1b[39;49m"
+}
+
+func (c *colors) brightWhite(str string) string {
+	if !c.showColors {
+		return str
+	}
+	return "\x1b[97m" + str + "\x1b[39m"
+}
+
+func padStart(str string, length int) string {
+	return fmt.Sprintf("%*s", length, str)
+}
+
+func padEnd(str string, length int) string {
+	return fmt.Sprintf("%-*s", length, str)
+}
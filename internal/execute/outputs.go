@@ -2,12 +2,15 @@ package execute
 
 import (
 	"fmt"
-	"strings"
+	"strconv"
+	"time"
 
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/diagnosticwriter"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/tspath"
 )
 
@@ -23,8 +26,9 @@ func getFormatOptsOfSys(sys System) *diagnosticwriter.FormattingOptions {
 
 type diagnosticReporter = func(*ast.Diagnostic)
 
-func createDiagnosticReporter(sys System, pretty core.Tristate) diagnosticReporter {
+func createDiagnosticReporter(sys System, locale *diagnostics.Locale, pretty core.Tristate) diagnosticReporter {
 	formatOpts := getFormatOptsOfSys(sys)
+	formatOpts.Locale = locale
 	if pretty.IsFalseOrUnknown() {
 		return func(diagnostic *ast.Diagnostic) {
 			diagnosticwriter.WriteFormatDiagnostic(sys.Writer(), diagnostic, formatOpts)
@@ -38,16 +42,21 @@ func createDiagnosticReporter(sys System, pretty core.Tristate) diagnosticReport
 }
 
 func shouldBePretty(sys System, options *core.CompilerOptions) bool {
-	if options == nil || options.Pretty.IsTrueOrUnknown() {
-		// todo: return defaultIsPretty(sys);
-		return true
+	if options == nil || options.Pretty == core.TSUnknown {
+		return defaultIsPretty(sys)
 	}
 	return options.Pretty.IsTrue()
 }
 
-func createReportErrorSummary(sys System, options *core.CompilerOptions) func(diagnostics []*ast.Diagnostic) {
+func defaultIsPretty(sys System) bool {
+	// !!! check that the output is a terminal
+	return sys.GetEnvironmentVariable("NO_COLOR") == ""
+}
+
+func createReportErrorSummary(sys System, locale *diagnostics.Locale, options *core.CompilerOptions) func(diagnostics []*ast.Diagnostic) {
 	if shouldBePretty(sys, options) {
 		formatOpts := getFormatOptsOfSys(sys)
+		formatOpts.Locale = locale
 		return func(diagnostics []*ast.Diagnostic) {
 			diagnosticwriter.WriteErrorSummaryText(sys.Writer(), diagnostics, formatOpts)
 			sys.EndWrite()
@@ -56,26 +65,145 @@ func createReportErrorSummary(sys System, options *core.CompilerOptions) func(di
 	return func(diagnostics []*ast.Diagnostic) {}
 }
 
-func reportStatistics(sys System, program *compiler.Program) {
-	// todo
-	stats := []statistic{
-		newStatistic("Files", len(program.SourceFiles())),
-		// newStatistic("Identifiers", program.IdentifierCount()),
-		// newStatistic("Symbols", program.getSymbolCount()),
-		newStatistic("Types", program.TypeCount()),
-		// newStatistic("Instantiations", program.getInstantiationCount()),
+func createWatchStatusReporter(sys System, locale *diagnostics.Locale, options *core.CompilerOptions) diagnosticReporter {
+	pretty := shouldBePretty(sys, options)
+	return func(diagnostic *ast.Diagnostic) {
+		diagnosticwriter.WriteWatchStatus(sys.Writer(), diagnostic, sys.Now().Format("3:04:05 PM"), pretty, sys.NewLine(), locale)
+		sys.EndWrite()
 	}
+}
 
-	for _, stat := range stats {
-		fmt.Fprintf(sys.Writer(), "%s:"+strings.Repeat(" ", 20-len(stat.name))+"%v\n", stat.name, stat.value)
+func listFiles(sys System, locale *diagnostics.Locale, program *compiler.Program) {
+	options := program.Options()
+	if options.ExplainFiles.IsTrue() {
+		explainFiles(sys, locale, program)
+	} else if options.ListFiles.IsTrue() || options.ListFilesOnly.IsTrue() {
+		for _, file := range program.GetSourceFiles() {
+			fmt.Fprint(sys.Writer(), file.FileName(), sys.NewLine())
+		}
 	}
 }
 
+// explainFiles lists the files of the program, each followed by the reasons it is part of the program.
+func explainFiles(sys System, locale *diagnostics.Locale, program *compiler.Program) {
+	reasons := program.GetFileIncludeReasons()
+	comparePathsOptions := getFormatOptsOfSys(sys).ComparePathsOptions
+	relativeFileName := func(fileName string) string {
+		return tspath.ConvertToRelativePath(fileName, comparePathsOptions)
+	}
+	for _, file := range program.GetSourceFiles() {
+		fmt.Fprint(sys.Writer(), relativeFileName(file.FileName()), sys.NewLine())
+		for _, reason := range reasons[file.Path()] {
+			fmt.Fprint(sys.Writer(), "  ", program.ExplainFileIncludeReason(reason, relativeFileName).Localize(locale), sys.NewLine())
+		}
+		// !!! explain redirects and the implied module format of the file
+	}
+}
+
+// reportStatistics prints the statistics of --diagnostics, or the more detailed ones of --extendedDiagnostics,
+// with the durations of the phases measured by tracer.
+func reportStatistics(sys System, program *compiler.Program, tracer *tracing.Tracer) {
+	options := program.Options()
+	extendedDiagnostics := options.ExtendedDiagnostics.IsTrue()
+	if !extendedDiagnostics && !options.Diagnostics.IsTrue() {
+		return
+	}
+
+	var statistics []statistic
+	reportCount := func(name string, count int) {
+		statistics = append(statistics, statistic{name, strconv.Itoa(count)})
+	}
+	reportTime := func(name string, duration time.Duration) {
+		statistics = append(statistics, statistic{name, fmt.Sprintf("%.2fs", duration.Seconds())})
+	}
+
+	reportCount("Files", len(program.SourceFiles()))
+	lineCounts := countLines(program)
+	if extendedDiagnostics {
+		for _, kind := range lineCountKinds {
+			reportCount("Lines of "+kind, lineCounts[kind])
+		}
+	} else {
+		var lines int
+		for _, count := range lineCounts {
+			lines += count
+		}
+		reportCount("Lines", lines)
+	}
+	reportCount("Identifiers", program.IdentifierCount())
+	reportCount("Symbols", program.SymbolCount())
+	reportCount("Types", program.TypeCount())
+	reportCount("Instantiations", program.InstantiationCount())
+	statistics = append(statistics, statistic{"Memory used", fmt.Sprintf("%dK", sys.GetMemoryUsage()/1000)})
+
+	programTime := tracer.Duration("createProgram")
+	bindTime := tracer.Duration("bindSourceFiles")
+	checkTime := tracer.Duration("checkSourceFiles")
+	emitTime := tracer.Duration("emit")
+	if extendedDiagnostics {
+		cacheSizes := program.RelationCacheSizes()
+		reportCount("Assignability cache size", cacheSizes.Assignable)
+		reportCount("Identity cache size", cacheSizes.Identity)
+		reportCount("Subtype cache size", cacheSizes.Subtype)
+		reportCount("Strict subtype cache size", cacheSizes.StrictSubtype)
+		reportTime("I/O Read time", tracer.Duration(ioReadMeasure))
+		reportTime("Parse time", tracer.Duration("createSourceFile"))
+		reportTime("ResolveModule time", tracer.Duration("resolveModuleNamesWorker"))
+		reportTime("ResolveTypeReference time", tracer.Duration("resolveTypeReferenceDirective"))
+		reportTime("Program time", programTime)
+		reportTime("Bind time", bindTime)
+		reportTime("Check time", checkTime)
+		reportTime("I/O Write time", tracer.Duration(ioWriteMeasure))
+		reportTime("Emit time", emitTime)
+	} else {
+		reportTime("I/O read", tracer.Duration(ioReadMeasure))
+		reportTime("I/O write", tracer.Duration(ioWriteMeasure))
+		reportTime("Parse time", programTime)
+		reportTime("Bind time", bindTime)
+		reportTime("Check time", checkTime)
+		reportTime("Emit time", emitTime)
+	}
+	reportTime("Total time", programTime+bindTime+checkTime+emitTime)
+
+	nameWidth, valueWidth := 0, 0
+	for _, s := range statistics {
+		nameWidth = max(nameWidth, len(s.name))
+		valueWidth = max(valueWidth, len(s.value))
+	}
+	for _, s := range statistics {
+		fmt.Fprintf(sys.Writer(), "%-*s%*s%s", nameWidth+2, s.name+":", valueWidth, s.value, sys.NewLine())
+	}
+	sys.EndWrite()
+}
+
 type statistic struct {
 	name  string
-	value int
+	value string
 }
 
-func newStatistic(name string, count int) statistic {
-	return statistic{name, count}
+// lineCountKinds are the kinds of files whose lines are counted by --extendedDiagnostics, in the order they are
+// reported.
+var lineCountKinds = []string{"Library", "Definitions", "TypeScript", "JavaScript", "JSON", "Other"}
+
+func countLines(program *compiler.Program) map[string]int {
+	counts := make(map[string]int, len(lineCountKinds))
+	for _, file := range program.SourceFiles() {
+		var kind string
+		switch {
+		case program.IsSourceFileDefaultLibrary(file):
+			kind = "Library"
+		case file.IsDeclarationFile:
+			kind = "Definitions"
+		case tspath.FileExtensionIsOneOf(file.FileName(), tspath.SupportedTSExtensionsFlat):
+			kind = "TypeScript"
+		case tspath.FileExtensionIsOneOf(file.FileName(), tspath.SupportedJSExtensionsFlat):
+			kind = "JavaScript"
+		case tspath.FileExtensionIs(file.FileName(), tspath.ExtensionJson):
+			kind = "JSON"
+		default:
+			kind = "Other"
+		}
+		counts[kind] += len(file.LineMap())
+	}
+	return counts
 }
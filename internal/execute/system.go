@@ -4,7 +4,9 @@ import (
 	"io"
 	"time"
 
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/vfs"
+	"github.com/microsoft/typescript-go/internal/vfs/vfswatch"
 )
 
 type System interface {
@@ -13,8 +15,28 @@ type System interface {
 	Now() time.Time
 	FS() vfs.FS
 	DefaultLibraryPath() string
+	// GetExecutingFilePath returns the path of the running compiler, whose directory holds its translations.
+	GetExecutingFilePath() string
 	GetCurrentDirectory() string
 	NewLine() string // #241 eventually we want to use "\n"
+	// GetWidthOfTerminal returns the width of the terminal the output is written to, or 0 if it is unknown.
+	GetWidthOfTerminal() int
+	GetEnvironmentVariable(name string) string
+	// GetMemoryUsage returns the number of bytes of memory in use, for --diagnostics.
+	GetMemoryUsage() uint64
+
+	// AfterFunc calls f on its own goroutine once d has elapsed, unless the returned timer is stopped first.
+	AfterFunc(d time.Duration, f func()) Timer
+
+	WatchFile(fileName string, callback vfswatch.FileWatcherCallback, options *core.WatchOptions) vfswatch.Watcher
+	WatchDirectory(path string, callback vfswatch.DirectoryWatcherCallback, recursive bool, options *core.WatchOptions) vfswatch.Watcher
+}
+
+// Timer is a call scheduled by System.AfterFunc.
+type Timer interface {
+	// Stop prevents the call from happening. It reports false if the call already happened or the timer
+	// was already stopped.
+	Stop() bool
 }
 
 type ExitStatus int
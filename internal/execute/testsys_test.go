@@ -7,25 +7,43 @@ import (
 	"io/fs"
 	"maps"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/execute"
 	"github.com/microsoft/typescript-go/internal/vfs"
 	"github.com/microsoft/typescript-go/internal/vfs/vfstest"
+	"github.com/microsoft/typescript-go/internal/vfs/vfswatch"
 )
 
 type FileMap map[string]string
 
+// tscExecutingFilePath is the path of the compiler in the test system. Translations of diagnostic messages
+// are looked up next to it.
+const tscExecutingFilePath = "/home/src/tslibs/TS/Lib/tsc.js"
+
 func newTestSys(fileOrFolderList FileMap, cwd string, args ...string) *testSys {
+	return newTestSysWithEnv(fileOrFolderList, cwd, nil)
+}
+
+// newTestSysWithEnv creates a test system with the given environment variables. TS_TEST_TERMINAL_WIDTH
+// sets the width of its terminal.
+func newTestSysWithEnv(fileOrFolderList FileMap, cwd string, env map[string]string) *testSys {
 	if cwd == "" {
 		cwd = "/home/src/workspaces/project"
 	}
+	fs := bundled.WrapFS(vfstest.FromMap(fileOrFolderList, true /*useCaseSensitiveFileNames*/))
 	return &testSys{
-		fs:                 bundled.WrapFS(vfstest.FromMap(fileOrFolderList, true /*useCaseSensitiveFileNames*/)),
+		Host:               vfswatch.NewPollingHost(fs, cwd),
+		now:                testTime,
+		fs:                 fs,
 		defaultLibraryPath: bundled.LibPath(),
 		cwd:                cwd,
 		files:              slices.Collect(maps.Keys(fileOrFolderList)),
+		env:                env,
 		output:             []string{},
 		currentWrite:       &strings.Builder{},
 	}
@@ -37,10 +55,19 @@ type testSys struct {
 	currentWrite   *strings.Builder
 	serializedDiff map[string]string
 
+	// Host polls the watches of the watch mode tests when an edit is applied.
+	*vfswatch.Host
+
+	// The clock of the system only moves forward when advanceTime is called.
+	timeMu sync.Mutex
+	now    time.Time
+	timers []*testTimer
+
 	fs                 vfs.FS
 	defaultLibraryPath string
 	cwd                string
 	files              []string
+	env                map[string]string
 }
 
 func (s *testSys) IsTestDone() bool {
@@ -48,9 +75,82 @@ func (s *testSys) IsTestDone() bool {
 	return true
 }
 
+// testTime is the time at which the clock of a test system starts, so that baselines of watch mode are stable.
+var testTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
 func (s *testSys) Now() time.Time {
-	// todo: make a "test time" structure
-	return time.Now()
+	s.timeMu.Lock()
+	defer s.timeMu.Unlock()
+	return s.now
+}
+
+type testTimer struct {
+	sys     *testSys
+	due     time.Time
+	f       func()
+	stopped bool
+}
+
+func (t *testTimer) Stop() bool {
+	t.sys.timeMu.Lock()
+	defer t.sys.timeMu.Unlock()
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	t.sys.timers = slices.DeleteFunc(t.sys.timers, func(timer *testTimer) bool { return timer == t })
+	return true
+}
+
+func (s *testSys) AfterFunc(d time.Duration, f func()) execute.Timer {
+	s.timeMu.Lock()
+	defer s.timeMu.Unlock()
+	timer := &testTimer{sys: s, due: s.now.Add(d), f: f}
+	s.timers = append(s.timers, timer)
+	return timer
+}
+
+// advanceTime moves the clock forward by d, running the timers that become due in order, on the calling
+// goroutine.
+func (s *testSys) advanceTime(d time.Duration) {
+	s.timeMu.Lock()
+	end := s.now.Add(d)
+	for {
+		var next *testTimer
+		for _, timer := range s.timers {
+			if !timer.due.After(end) && (next == nil || timer.due.Before(next.due)) {
+				next = timer
+			}
+		}
+		if next == nil {
+			break
+		}
+		next.stopped = true
+		s.timers = slices.DeleteFunc(s.timers, func(timer *testTimer) bool { return timer == next })
+		s.now = next.due
+		s.timeMu.Unlock()
+		next.f()
+		s.timeMu.Lock()
+	}
+	s.now = end
+	s.timeMu.Unlock()
+}
+
+func (s *testSys) GetWidthOfTerminal() int {
+	width, err := strconv.Atoi(s.env["TS_TEST_TERMINAL_WIDTH"])
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+func (s *testSys) GetEnvironmentVariable(name string) string {
+	return s.env[name]
+}
+
+// GetMemoryUsage returns a fixed amount of memory, so that the baselines of --diagnostics are stable.
+func (s *testSys) GetMemoryUsage() uint64 {
+	return 0
 }
 
 func (s *testSys) FS() vfs.FS {
@@ -61,6 +161,10 @@ func (s *testSys) DefaultLibraryPath() string {
 	return s.defaultLibraryPath
 }
 
+func (s *testSys) GetExecutingFilePath() string {
+	return tscExecutingFilePath
+}
+
 func (s *testSys) GetCurrentDirectory() string {
 	return s.cwd
 }
@@ -79,6 +183,20 @@ func (s *testSys) EndWrite() {
 	s.currentWrite.Reset()
 }
 
+// runWatchUpdate lets the watches detect the edits made to the file system, and advances the clock until
+// the build they cause has run.
+func (s *testSys) runWatchUpdate() {
+	s.Poll()
+	s.advanceTime(execute.UpdateDelay)
+}
+
+// takeOutput returns the writes to the output since the last call, or since the output was last baselined.
+func (s *testSys) takeOutput() []string {
+	output := s.output
+	s.output = []string{}
+	return output
+}
+
 func (s *testSys) serializeState(baseline *strings.Builder) {
 	s.baselineOutput(baseline)
 	s.baselineFSwithDiff(baseline)
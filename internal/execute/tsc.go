@@ -1,31 +1,43 @@
 package execute
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"runtime/pprof"
+	"strings"
 
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/compiler"
 	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
 	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs"
 )
 
 type cbType = func(p any) any
 
-func CommandLine(sys System, cb cbType, commandLineArgs []string) ExitStatus {
+// CommandLine runs tsc with commandLineArgs. In watch mode, it returns once ctx is done.
+func CommandLine(ctx context.Context, sys System, cb cbType, commandLineArgs []string) ExitStatus {
 	parsedCommandLine := tsoptions.ParseCommandLine(commandLineArgs, sys)
 	e, watcher := executeCommandLineWorker(sys, cb, parsedCommandLine)
 	if watcher == nil {
 		return e
 	}
-	return start(watcher)
+	return start(ctx, watcher)
 }
 
 func executeCommandLineWorker(sys System, cb cbType, commandLine *tsoptions.ParsedCommandLine) (ExitStatus, *watcher) {
 	configFileName := ""
-	reportDiagnostic := createDiagnosticReporter(sys, commandLine.CompilerOptions().Pretty)
-	// if commandLine.Options().Locale != nil
+	var locale *diagnostics.Locale
+	if commandLine.CompilerOptions().Locale != "" {
+		var errors []*ast.Diagnostic
+		locale, errors = tsoptions.LoadLocale(commandLine.CompilerOptions().Locale, sys.FS(), tspath.GetDirectoryPath(sys.GetExecutingFilePath()))
+		commandLine.Errors = append(commandLine.Errors, errors...)
+	}
+	reportDiagnostic := createDiagnosticReporter(sys, locale, commandLine.CompilerOptions().Pretty)
 
 	if len(commandLine.Errors) > 0 {
 		for _, e := range commandLine.Errors {
@@ -34,11 +46,21 @@ func executeCommandLineWorker(sys System, cb cbType, commandLine *tsoptions.Pars
 		return ExitStatusDiagnosticsPresent_OutputsSkipped, nil
 	}
 
-	if commandLine.CompilerOptions().Init.IsTrue() ||
-		commandLine.CompilerOptions().Version.IsTrue() ||
-		// commandLine.CompilerOptions().Help != nil ||
-		// commandLine.CompilerOptions().All != nil ||
-		commandLine.CompilerOptions().Watch.IsTrue() && commandLine.CompilerOptions().ListFilesOnly.IsTrue() {
+	if commandLine.CompilerOptions().Init.IsTrue() {
+		return writeConfigFile(sys, reportDiagnostic, commandLine), nil
+	}
+
+	if commandLine.CompilerOptions().Version.IsTrue() {
+		printVersion(sys)
+		return ExitStatusSuccess, nil
+	}
+
+	if commandLine.CompilerOptions().Help.IsTrue() || commandLine.CompilerOptions().All.IsTrue() {
+		printHelp(sys, commandLine)
+		return ExitStatusSuccess, nil
+	}
+
+	if commandLine.CompilerOptions().Watch.IsTrue() && commandLine.CompilerOptions().ListFilesOnly.IsTrue() {
 		return ExitStatusNotImplemented, nil
 	}
 
@@ -71,8 +93,8 @@ func executeCommandLineWorker(sys System, cb cbType, commandLine *tsoptions.Pars
 		if commandLine.CompilerOptions().ShowConfig.IsTrue() {
 			reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.Cannot_find_a_tsconfig_json_file_at_the_current_directory_Colon_0, tspath.NormalizePath(sys.GetCurrentDirectory())))
 		} else {
-			// print version
-			// print help
+			printVersion(sys)
+			printHelp(sys, commandLine)
 		}
 		return ExitStatusDiagnosticsPresent_OutputsSkipped, nil
 	}
@@ -91,11 +113,12 @@ func executeCommandLineWorker(sys System, cb cbType, commandLine *tsoptions.Pars
 			return ExitStatusDiagnosticsPresent_OutputsGenerated, nil
 		}
 		if compilerOptionsFromCommandLine.ShowConfig.IsTrue() {
-			return ExitStatusNotImplemented, nil
+			showConfig(sys, configParseResult, configFileName)
+			return ExitStatusSuccess, nil
 		}
 		// updateReportDiagnostic
 		if isWatchSet(configParseResult.CompilerOptions()) {
-			return ExitStatusSuccess, createWatcher(sys, configParseResult, reportDiagnostic)
+			return ExitStatusSuccess, createWatcher(sys, commandLine, configParseResult, locale, reportDiagnostic)
 		} else if isIncrementalCompilation(configParseResult.CompilerOptions()) {
 			return ExitStatusNotImplementedIncremental, nil
 		}
@@ -103,16 +126,18 @@ func executeCommandLineWorker(sys System, cb cbType, commandLine *tsoptions.Pars
 			sys,
 			cb,
 			configParseResult,
+			locale,
 			reportDiagnostic,
 		), nil
 	} else {
 		if compilerOptionsFromCommandLine.ShowConfig.IsTrue() {
-			return ExitStatusNotImplemented, nil
+			showConfig(sys, commandLine, tspath.CombinePaths(sys.GetCurrentDirectory(), "tsconfig.json"))
+			return ExitStatusSuccess, nil
 		}
 		// todo update reportDiagnostic
 		if isWatchSet(compilerOptionsFromCommandLine) {
 			// !!! reportWatchModeWithoutSysSupport
-			return ExitStatusSuccess, createWatcher(sys, commandLine, reportDiagnostic)
+			return ExitStatusSuccess, createWatcher(sys, commandLine, commandLine, locale, reportDiagnostic)
 		} else if isIncrementalCompilation(compilerOptionsFromCommandLine) {
 			return ExitStatusNotImplementedIncremental, nil
 		}
@@ -121,10 +146,37 @@ func executeCommandLineWorker(sys System, cb cbType, commandLine *tsoptions.Pars
 		sys,
 		cb,
 		commandLine,
+		locale,
 		reportDiagnostic,
 	), nil
 }
 
+// writeConfigFile writes the tsconfig.json of `tsc --init` to the current directory, unless one already
+// exists there.
+func writeConfigFile(sys System, reportDiagnostic diagnosticReporter, commandLine *tsoptions.ParsedCommandLine) ExitStatus {
+	file := tspath.NormalizePath(tspath.CombinePaths(sys.GetCurrentDirectory(), "tsconfig.json"))
+	if sys.FS().FileExists(file) {
+		reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.A_tsconfig_json_file_is_already_defined_at_Colon_0, file))
+		return ExitStatusDiagnosticsPresent_OutputsSkipped
+	}
+	if err := sys.FS().WriteFile(file, tsoptions.GenerateTSConfig(commandLine, getFormatOptsOfSys(sys).ComparePathsOptions, sys.NewLine()), false /*writeByteOrderMark*/); err != nil {
+		reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, file, err.Error()))
+		return ExitStatusDiagnosticsPresent_OutputsSkipped
+	}
+	fmt.Fprint(sys.Writer(), sys.NewLine(), "Created a new tsconfig.json with:", sys.NewLine(), sys.NewLine())
+	fmt.Fprint(sys.Writer(), tsoptions.GetCompilerOptionsDiffValue(commandLine, getFormatOptsOfSys(sys).ComparePathsOptions, sys.NewLine()), sys.NewLine(), sys.NewLine())
+	fmt.Fprint(sys.Writer(), "You can learn more at https://aka.ms/tsconfig", sys.NewLine())
+	sys.EndWrite()
+	return ExitStatusSuccess
+}
+
+// showConfig writes the config of the project as `--showConfig` prints it.
+func showConfig(sys System, config *tsoptions.ParsedCommandLine, configFileName string) {
+	tsConfig := tsoptions.ConvertToTSConfig(config, configFileName, getFormatOptsOfSys(sys).ComparePathsOptions)
+	fmt.Fprint(sys.Writer(), core.Must(core.StringifyJson(tsConfig, "", "    ")), sys.NewLine())
+	sys.EndWrite()
+}
+
 func findConfigFile(searchPath string, fileExists func(string) bool, configName string) string {
 	result, ok := tspath.ForEachAncestorDirectory(searchPath, func(ancestor string) (string, bool) {
 		fullConfigName := tspath.CombinePaths(ancestor, configName)
@@ -164,18 +216,62 @@ func getParsedCommandLineOfConfigFile(configFileName string, options *core.Compi
 	), nil
 }
 
-func performCompilation(sys System, cb cbType, config *tsoptions.ParsedCommandLine, reportDiagnostic diagnosticReporter) ExitStatus {
-	host := compiler.NewCompilerHost(config.CompilerOptions(), sys.GetCurrentDirectory(), sys.FS(), sys.DefaultLibraryPath())
-	// todo: cache, statistics, tracing
-	program := compiler.NewProgramFromParsedCommandLine(config, host)
+// newCompilerHost creates a compiler host for sys that writes traces, such as those of --traceResolution, to the
+// output of sys. The time spent reading and writing files is measured by tracer, unless it is nil.
+func newCompilerHost(sys System, options *core.CompilerOptions, tracer *tracing.Tracer) compiler.CompilerHost {
+	fs := sys.FS()
+	if tracer != nil {
+		fs = &measuredFS{FS: fs, tracer: tracer}
+	}
+	return compiler.NewCompilerHostWithTrace(options, sys.GetCurrentDirectory(), fs, sys.DefaultLibraryPath(), func(msg string) {
+		fmt.Fprint(sys.Writer(), msg, sys.NewLine())
+	})
+}
 
-	diagnostics, emitResult, exitStatus := compileAndEmit(sys, program, reportDiagnostic)
+const (
+	ioReadMeasure  = "I/O Read"
+	ioWriteMeasure = "I/O Write"
+)
+
+// measuredFS measures the time spent reading and writing files, for --diagnostics.
+type measuredFS struct {
+	vfs.FS
+	tracer *tracing.Tracer
+}
+
+func (fs *measuredFS) ReadFile(path string) (contents string, ok bool) {
+	defer fs.tracer.Measure(ioReadMeasure).End()
+	return fs.FS.ReadFile(path)
+}
+
+func (fs *measuredFS) WriteFile(path string, data string, writeByteOrderMark bool) error {
+	defer fs.tracer.Measure(ioWriteMeasure).End()
+	return fs.FS.WriteFile(path, data, writeByteOrderMark)
+}
+
+func performCompilation(sys System, cb cbType, config *tsoptions.ParsedCommandLine, locale *diagnostics.Locale, reportDiagnostic diagnosticReporter) ExitStatus {
+	options := config.CompilerOptions()
+	if options.GenerateCpuProfile != "" {
+		defer startCPUProfile(sys, options.GenerateCpuProfile)()
+	}
+	var tracer *tracing.Tracer
+	if options.Diagnostics.IsTrue() || options.ExtendedDiagnostics.IsTrue() || options.GenerateTrace != "" {
+		tracer = tracing.NewTracer(sys.Now, options.GenerateTrace != "" /*traceEvents*/)
+	}
+	host := newCompilerHost(sys, options, tracer)
+	// todo: cache
+	program := compiler.NewProgramFromParsedCommandLine(config, host, tracer)
+
+	diagnostics, emitResult, exitStatus := compileAndEmit(sys, program, locale, reportDiagnostic, createReportErrorSummary(sys, locale, program.Options()))
 	if exitStatus != ExitStatusSuccess {
 		// compile exited early
 		return exitStatus
 	}
 
-	reportStatistics(sys, program)
+	reportStatistics(sys, program, tracer)
+	if options.GenerateTrace != "" {
+		writeTrace(sys, program, tracer, options.GenerateTrace)
+	}
 	if cb != nil {
 		cb(program)
 	}
@@ -188,7 +284,35 @@ func performCompilation(sys System, cb cbType, config *tsoptions.ParsedCommandLi
 	return ExitStatusSuccess
 }
 
-func compileAndEmit(sys System, program *compiler.Program, reportDiagnostic diagnosticReporter) ([]*ast.Diagnostic, *compiler.EmitResult, ExitStatus) {
+// writeTrace writes the trace.json and types.json of --generateTrace to traceDir. As in tsc, the files are not
+// written if they cannot be.
+func writeTrace(sys System, program *compiler.Program, tracer *tracing.Tracer, traceDir string) {
+	traceDir = tspath.GetNormalizedAbsolutePath(traceDir, sys.GetCurrentDirectory())
+	var trace strings.Builder
+	if err := tracer.WriteTrace(&trace); err == nil {
+		_ = sys.FS().WriteFile(tspath.CombinePaths(traceDir, "trace.json"), trace.String(), false /*writeByteOrderMark*/)
+	}
+	var types strings.Builder
+	if err := tracing.WriteTypes(&types, program.DescribeTypes()); err == nil {
+		_ = sys.FS().WriteFile(tspath.CombinePaths(traceDir, "types.json"), types.String(), false /*writeByteOrderMark*/)
+	}
+}
+
+// startCPUProfile starts the CPU profile of --generateCpuProfile. The returned function stops it and writes it to
+// fileName, in the pprof format rather than the format of V8 that tsc writes.
+func startCPUProfile(sys System, fileName string) (stop func()) {
+	var profile bytes.Buffer
+	if err := pprof.StartCPUProfile(&profile); err != nil {
+		// a profile of the whole process, such as the one of `tsgo -pprofDir`, is already being taken
+		return func() {}
+	}
+	return func() {
+		pprof.StopCPUProfile()
+		_ = sys.FS().WriteFile(tspath.GetNormalizedAbsolutePath(fileName, sys.GetCurrentDirectory()), profile.String(), false /*writeByteOrderMark*/)
+	}
+}
+
+func compileAndEmit(sys System, program *compiler.Program, locale *diagnostics.Locale, reportDiagnostic diagnosticReporter, reportErrorSummary func([]*ast.Diagnostic)) ([]*ast.Diagnostic, *compiler.EmitResult, ExitStatus) {
 	// todo: check if third return needed after execute is fully implemented
 
 	options := program.Options()
@@ -206,10 +330,8 @@ func compileAndEmit(sys System, program *compiler.Program, reportDiagnostic diag
 	if len(diagnostics) == 0 {
 		diagnostics = append(diagnostics, program.GetSemanticDiagnostics(nil)...)
 	}
-	// TODO: declaration diagnostics
-	if len(diagnostics) == 0 && options.NoEmit == core.TSTrue && (options.Declaration.IsTrue() && options.Composite.IsTrue()) {
-		return nil, nil, ExitStatusNotImplemented
-		// addRange(allDiagnostics, program.getDeclarationDiagnostics(/*sourceFile*/ undefined, cancellationToken));
+	if len(diagnostics) == 0 && options.NoEmit == core.TSTrue && options.GetEmitDeclarations() {
+		diagnostics = append(diagnostics, program.GetDeclarationDiagnostics(nil)...)
 	}
 
 	emitResult := &compiler.EmitResult{EmitSkipped: true, Diagnostics: []*ast.Diagnostic{}}
@@ -228,12 +350,12 @@ func compileAndEmit(sys System, program *compiler.Program, reportDiagnostic diag
 	// !!! if (write)
 	if sys.Writer() != nil {
 		for _, file := range emitResult.EmittedFiles {
-			fmt.Fprint(sys.Writer(), "TSFILE: ", tspath.GetNormalizedAbsolutePath(file, sys.GetCurrentDirectory()))
+			fmt.Fprint(sys.Writer(), "TSFILE: ", tspath.GetNormalizedAbsolutePath(file, sys.GetCurrentDirectory()), sys.NewLine())
 		}
-		// todo: listFiles(program, sys.Writer())
+		listFiles(sys, locale, program)
 	}
 
-	createReportErrorSummary(sys, program.Options())(allDiagnostics)
+	reportErrorSummary(allDiagnostics)
 	return allDiagnostics, emitResult, ExitStatusSuccess
 }
 
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/tspath"
 )
 
 func TestTsc(t *testing.T) {
@@ -16,11 +17,8 @@ func TestTsc(t *testing.T) {
 
 	testCases := []*tscInput{
 		{
-			subScenario: "show help with ExitStatus.DiagnosticsPresent_OutputsSkipped",
-			sys:         newTestSys(nil, ""),
-			// , {
-			// 	environmentVariables: new Map([["TS_TEST_TERMINAL_WIDTH", "120"]]),
-			// }),
+			subScenario:     "show help with ExitStatus.DiagnosticsPresent_OutputsSkipped",
+			sys:             newTestSysWithEnv(nil, "", map[string]string{"TS_TEST_TERMINAL_WIDTH": "120"}),
 			commandLineArgs: nil,
 		},
 		{
@@ -29,20 +27,13 @@ func TestTsc(t *testing.T) {
 			commandLineArgs: nil,
 		},
 		{
-			subScenario: "does not add color when NO_COLOR is set",
-			sys:         newTestSys(nil, ""),
-			// , {
-			// 		environmentVariables: new Map([["NO_COLOR", "true"]]),
-			// 	}),
+			subScenario:     "does not add color when NO_COLOR is set",
+			sys:             newTestSysWithEnv(nil, "", map[string]string{"NO_COLOR": "true"}),
 			commandLineArgs: nil,
 		},
 		{
-			subScenario: "does not add color when NO_COLOR is set",
-			sys:         newTestSys(nil, ""),
-			// , {
-			// 	environmentVariables: new Map([["NO_COLOR", "true"]]),
-			// }
-			// ),
+			subScenario:     "does not add color when NO_COLOR is set",
+			sys:             newTestSysWithEnv(nil, "", map[string]string{"NO_COLOR": "true"}),
 			commandLineArgs: nil,
 		},
 		{
@@ -60,6 +51,16 @@ func TestTsc(t *testing.T) {
 			sys:             newTestSys(nil, ""),
 			commandLineArgs: []string{"--help", "--all"},
 		},
+		{
+			subScenario:     "help all with terminal width",
+			sys:             newTestSysWithEnv(nil, "", map[string]string{"TS_TEST_TERMINAL_WIDTH": "120"}),
+			commandLineArgs: []string{"--help", "--all"},
+		},
+		{
+			subScenario:     "version",
+			sys:             newTestSys(nil, ""),
+			commandLineArgs: []string{"--version"},
+		},
 		{
 			subScenario:     "Parse --lib option with file name",
 			sys:             newTestSys(FileMap{"/home/src/workspaces/project/first.ts": `export const Key = Symbol()`}, ""),
@@ -114,6 +115,63 @@ func TestTsc(t *testing.T) {
 	}
 }
 
+func TestShowConfig(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []*tscInput{
+		{
+			subScenario: "shows the resolved config",
+			sys: newTestSys(FileMap{
+				"/home/src/workspaces/project/src/first.ts":  `export const a = 1`,
+				"/home/src/workspaces/project/lib/second.ts": `export const b = 1`,
+				"/home/src/workspaces/project/tsconfig.base.json": `{
+	"compilerOptions": { "strict": true, "target": "es2020", "lib": ["es2020", "dom"], "outDir": "./dist" }
+}`,
+				"/home/src/workspaces/project/tsconfig.json": `{
+	"extends": "./tsconfig.base.json",
+	"compilerOptions": {
+		"module": "nodenext",
+		"noImplicitAny": false,
+		"paths": { "@lib/*": ["./lib/*"] },
+		"maxNodeModuleJsDepth": 2
+	},
+	"files": ["lib/second.ts"],
+	"include": ["src"],
+	"exclude": ["src/**/*.test.ts"],
+	"references": [{ "path": "../other" }]
+}`,
+				"/home/src/workspaces/other/tsconfig.json": `{ "compilerOptions": { "composite": true } }`,
+			}, ""),
+			commandLineArgs: []string{"--showConfig"},
+		},
+		{
+			subScenario: "shows the config with command line options",
+			sys: newTestSys(FileMap{
+				"/home/src/workspaces/project/first.ts":      `export const a = 1`,
+				"/home/src/workspaces/project/tsconfig.json": `{ "compilerOptions": { "strict": true } }`,
+			}, ""),
+			commandLineArgs: []string{"--showConfig", "--declaration", "--moduleResolution", "bundler", "--listFiles"},
+		},
+		{
+			subScenario:     "shows the config of files on the command line",
+			sys:             newTestSys(FileMap{"/home/src/workspaces/project/first.ts": `export const a = 1`}, ""),
+			commandLineArgs: []string{"--showConfig", "--target", "esnext", "--rootDir", "src", "first.ts"},
+		},
+		{
+			subScenario:     "reports a missing tsconfig",
+			sys:             newTestSys(nil, ""),
+			commandLineArgs: []string{"--showConfig"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase.verify(t, "showConfig")
+	}
+}
+
 func TestNoEmit(t *testing.T) {
 	t.Parallel()
 	if !bundled.Embedded {
@@ -137,6 +195,174 @@ func TestNoEmit(t *testing.T) {
 	}).verify(t, "noEmit")
 }
 
+func TestNoEmitOnError(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	noEmitOnErrorSysFiles := func(mainText string) FileMap {
+		return FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "noEmitOnError": true, "outDir": "dist" }
+}`,
+			"/home/src/workspaces/project/main.ts":  mainText,
+			"/home/src/workspaces/project/other.ts": `export const other = 1;`,
+		}
+	}
+
+	cases := []tscInput{{
+		subScenario:     "emits when there are no errors",
+		sys:             newTestSys(noEmitOnErrorSysFiles(`export const x: number = 1;`), ""),
+		commandLineArgs: []string{},
+	}, {
+		subScenario:     "skips emit when there are semantic errors",
+		sys:             newTestSys(noEmitOnErrorSysFiles(`export const x: number = "1";`), ""),
+		commandLineArgs: []string{},
+	}, {
+		subScenario:     "skips emit when there are syntax errors",
+		sys:             newTestSys(noEmitOnErrorSysFiles(`export const x = (1;`), ""),
+		commandLineArgs: []string{},
+	}, {
+		subScenario: "skips emit when there are options errors",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "noEmitOnError": true, "composite": true, "outDir": "dist" },
+	"files": ["main.ts"]
+}`,
+			"/home/src/workspaces/project/main.ts":  `export { other } from "./other";`,
+			"/home/src/workspaces/project/other.ts": `export const other = 1;`,
+		}, ""),
+		commandLineArgs: []string{},
+	}, {
+		subScenario:     "emits with errors without noEmitOnError",
+		sys:             newTestSys(noEmitOnErrorSysFiles(`export const x: number = "1";`), ""),
+		commandLineArgs: []string{"--noEmitOnError", "false"},
+	}, {
+		subScenario: "blocks output that would overwrite an input file",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/main.ts": `import { helper } from "./helper";
+export const x = helper;`,
+			"/home/src/workspaces/project/helper.js": `export const helper = 1;`,
+		}, ""),
+		commandLineArgs: []string{"--allowJs", "main.ts", "helper.js"},
+	}, {
+		subScenario: "blocks output that multiple input files would write",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/main.ts":  `export const x = 1;`,
+			"/home/src/workspaces/project/main.tsx": `export const y = 2;`,
+		}, ""),
+		commandLineArgs: []string{"main.ts", "main.tsx"},
+	}}
+
+	for _, c := range cases {
+		c.verify(t, "noEmitOnError")
+	}
+}
+
+func TestIsolatedDeclarations(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	isolatedDeclarationsSysFiles := func(compilerOptions string, mainText string) FileMap {
+		return FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "declaration": true, "isolatedDeclarations": true, "outDir": "dist"` + compilerOptions + ` }
+}`,
+			"/home/src/workspaces/project/main.ts": mainText,
+			"/home/src/workspaces/project/other.mts": `export class Other {
+	value = 1;
+	constructor(private readonly name: string) {}
+	greet(): string { return "hello " + this.name; }
+}`,
+		}
+	}
+
+	cases := []tscInput{{
+		subScenario: "emits declarations",
+		sys: newTestSys(isolatedDeclarationsSysFiles("", `import { Other } from "./other.mjs";
+export const other: Other = new Other("main");
+export function add(a: number, b = 1): number { return a + b; }
+export enum Kind { A, B = "b" }`), ""),
+		commandLineArgs: []string{},
+	}, {
+		subScenario:     "emits declarations to declarationDir",
+		sys:             newTestSys(isolatedDeclarationsSysFiles(`, "declarationDir": "types"`, `export const x = 1;`), ""),
+		commandLineArgs: []string{},
+	}, {
+		subScenario: "reports declarations that cannot be inferred",
+		sys: newTestSys(isolatedDeclarationsSysFiles("", `export function add(a: number, b: number) { return a + b; }
+export const values = [1, 2];
+export default { add };`), ""),
+		commandLineArgs: []string{},
+	}, {
+		subScenario:     "reports declarations that cannot be inferred with noEmit",
+		sys:             newTestSys(isolatedDeclarationsSysFiles("", `export function add(a: number, b: number) { return a + b; }`), ""),
+		commandLineArgs: []string{"--noEmit"},
+	}, {
+		subScenario:     "skips emit on declaration errors with noEmitOnError",
+		sys:             newTestSys(isolatedDeclarationsSysFiles(`, "noEmitOnError": true`, `export function add(a: number, b: number) { return a + b; }`), ""),
+		commandLineArgs: []string{},
+	}}
+
+	for _, c := range cases {
+		c.verify(t, "isolatedDeclarations")
+	}
+}
+
+func TestConstEnums(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	constEnumsSysFiles := func(compilerOptions string) FileMap {
+		return FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "declaration": true, "isolatedDeclarations": true, "outDir": "dist"` + compilerOptions + ` }
+}`,
+			"/home/src/workspaces/project/enums.ts": `export const enum Direction {
+	Up = 1,
+	Down,
+	/** @internal */
+	Sideways,
+}`,
+			"/home/src/workspaces/project/main.ts": `import { Direction } from "./enums";
+export const up: number = Direction.Up;
+export const down: number = Direction["Down"];
+console.log(Direction.Up.toString());`,
+		}
+	}
+
+	cases := []tscInput{{
+		subScenario:     "inlines const enum references",
+		sys:             newTestSys(constEnumsSysFiles(""), ""),
+		commandLineArgs: []string{},
+	}, {
+		subScenario:     "inlines const enum references without comments",
+		sys:             newTestSys(constEnumsSysFiles(""), ""),
+		commandLineArgs: []string{"--removeComments"},
+	}, {
+		subScenario:     "preserves const enum declarations",
+		sys:             newTestSys(constEnumsSysFiles(`, "preserveConstEnums": true`), ""),
+		commandLineArgs: []string{},
+	}, {
+		subScenario:     "strips internal declarations",
+		sys:             newTestSys(constEnumsSysFiles(`, "stripInternal": true`), ""),
+		commandLineArgs: []string{},
+	}}
+
+	for _, c := range cases {
+		c.verify(t, "constEnums")
+	}
+}
+
 func TestProjectReferences(t *testing.T) {
 	t.Parallel()
 	if !bundled.Embedded {
@@ -244,3 +470,284 @@ func TestExtends(t *testing.T) {
 		c.verify(t, "extends")
 	}
 }
+
+func TestExplainFiles(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	explainFilesSysFiles := FileMap{
+		"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "lib": ["es2020"], "outDir": "dist" },
+	"files": ["src/main.ts"],
+	"include": ["src/**/*.ts"]
+}`,
+		"/home/src/workspaces/project/src/main.ts": `/// <reference path="./globals.d.ts" />
+/// <reference types="helpers" />
+/// <reference lib="dom" />
+import { util } from "./util";
+import { pad } from "pad";
+export const x = util + pad;`,
+		"/home/src/workspaces/project/src/util.ts":                            `export const util = 1;`,
+		"/home/src/workspaces/project/src/globals.d.ts":                       `declare const version: string;`,
+		"/home/src/workspaces/project/node_modules/pad/package.json":          `{ "name": "pad", "version": "1.0.0", "types": "index.d.ts" }`,
+		"/home/src/workspaces/project/node_modules/pad/index.d.ts":            `export declare const pad: number;`,
+		"/home/src/workspaces/project/node_modules/@types/helpers/index.d.ts": `declare function help(): void;`,
+	}
+
+	cases := []tscInput{{
+		subScenario:     "explains why files are included",
+		sys:             newTestSys(explainFilesSysFiles, ""),
+		commandLineArgs: []string{"--explainFiles"},
+	}, {
+		subScenario:     "lists files",
+		sys:             newTestSys(explainFilesSysFiles, ""),
+		commandLineArgs: []string{"--listFiles"},
+	}, {
+		subScenario:     "lists emitted files",
+		sys:             newTestSys(explainFilesSysFiles, ""),
+		commandLineArgs: []string{"--listEmittedFiles"},
+	}, {
+		subScenario: "explains files of the command line",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/first.ts":  `import "./second";`,
+			"/home/src/workspaces/project/second.ts": `export {};`,
+		}, ""),
+		commandLineArgs: []string{"--explainFiles", "first.ts"},
+	}, {
+		subScenario: "reports files not listed in a composite project",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "composite": true },
+	"files": ["main.ts"]
+}`,
+			"/home/src/workspaces/project/main.ts": `import { a } from "./a"; import { b } from "./b";`,
+			"/home/src/workspaces/project/a.ts":    `import { b } from "./b"; export const a = b;`,
+			"/home/src/workspaces/project/b.ts":    `export const b = 1;`,
+		}, ""),
+		commandLineArgs: []string{},
+	}}
+
+	for _, c := range cases {
+		c.verify(t, "explainFiles")
+	}
+}
+
+func TestForceConsistentCasingInFileNames(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	casingSysFiles := FileMap{
+		"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "noEmit": true },
+	"files": ["src/main.ts", "src/other.ts"]
+}`,
+		"/home/src/workspaces/project/src/main.ts":  `import { upper } from "./Utils"; import { lower } from "./utils"; export const x = upper + lower;`,
+		"/home/src/workspaces/project/src/other.ts": `import { upper } from "./Utils"; export const y = upper;`,
+		"/home/src/workspaces/project/src/Utils.ts": `export const upper = 1;`,
+		"/home/src/workspaces/project/src/utils.ts": `export const lower = 1;`,
+	}
+
+	cases := []tscInput{{
+		subScenario:     "reports files differing only in casing",
+		sys:             newTestSys(casingSysFiles, ""),
+		commandLineArgs: []string{},
+	}, {
+		subScenario: "reports files differing only in casing from root files",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "noEmit": true },
+	"files": ["src/main.ts", "src/utils.ts"]
+}`,
+			"/home/src/workspaces/project/src/main.ts":  `import { upper } from "./Utils"; export const x = upper;`,
+			"/home/src/workspaces/project/src/Utils.ts": `export const upper = 1;`,
+			"/home/src/workspaces/project/src/utils.ts": `export const lower = 1;`,
+		}, ""),
+		commandLineArgs: []string{},
+	}, {
+		subScenario:     "allows files differing only in casing when disabled",
+		sys:             newTestSys(casingSysFiles, ""),
+		commandLineArgs: []string{"--forceConsistentCasingInFileNames", "false"},
+	}}
+
+	for _, c := range cases {
+		c.verify(t, "forceConsistentCasingInFileNames")
+	}
+}
+
+func TestTraceResolution(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	traceResolutionSysFiles := FileMap{
+		"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "module": "nodenext", "noEmit": true, "types": [] },
+	"files": ["src/main.ts", "src/other.ts"]
+}`,
+		"/home/src/workspaces/project/src/main.ts":                   `import { util } from "./util.js"; import { pad } from "pad"; export const x = util + pad;`,
+		"/home/src/workspaces/project/src/other.ts":                  `import { util } from "./util.js"; export const y = util;`,
+		"/home/src/workspaces/project/src/util.ts":                   `export const util = 1;`,
+		"/home/src/workspaces/project/node_modules/pad/package.json": `{ "name": "pad", "version": "1.0.0", "exports": { ".": { "import": "./index.mjs", "types": "./index.d.ts" } } }`,
+		"/home/src/workspaces/project/node_modules/pad/index.d.ts":   `export declare const pad: number;`,
+	}
+
+	cases := []tscInput{{
+		subScenario:     "traces module resolution",
+		sys:             newTestSys(traceResolutionSysFiles, ""),
+		commandLineArgs: []string{"--traceResolution"},
+	}}
+
+	for _, c := range cases {
+		c.verify(t, "traceResolution")
+	}
+}
+
+func TestLocale(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	localeSysFiles := func(locales map[string]string) FileMap {
+		files := FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{}`,
+			"/home/src/workspaces/project/index.ts": `declare const b: { c: string };
+const a: { c: number } = b;
+missing;`,
+		}
+		for locale, text := range locales {
+			files[tspath.CombinePaths(tspath.GetDirectoryPath(tscExecutingFilePath), locale, "diagnosticMessages.generated.json")] = text
+		}
+		return files
+	}
+	jaMessages := `{
+	"Type_0_is_not_assignable_to_type_1_2322": "型 '{0}' を型 '{1}' に割り当てることはできません。",
+	"Types_of_property_0_are_incompatible_2326": "プロパティ '{0}' の型に互換性がありません。",
+	"Cannot_find_name_0_2304": "名前 '{0}' が見つかりません。",
+	"Found_0_errors_in_the_same_file_starting_at_Colon_1_6260": "1 つのファイル内に {0} 件のエラーが見つかりました。開始位置: {1}"
+}`
+
+	cases := []tscInput{{
+		subScenario:     "localizes diagnostics",
+		sys:             newTestSys(localeSysFiles(map[string]string{"ja": jaMessages}), ""),
+		commandLineArgs: []string{"--locale", "ja"},
+	}, {
+		subScenario:     "falls back to the language of the locale",
+		sys:             newTestSys(localeSysFiles(map[string]string{"ja": jaMessages}), ""),
+		commandLineArgs: []string{"--locale", "ja-JP", "--pretty", "false"},
+	}, {
+		subScenario:     "falls back to English without translations",
+		sys:             newTestSys(localeSysFiles(nil), ""),
+		commandLineArgs: []string{"--locale", "de"},
+	}, {
+		subScenario:     "reports invalid locale",
+		sys:             newTestSys(localeSysFiles(nil), ""),
+		commandLineArgs: []string{"--locale", "ja.jp"},
+	}, {
+		subScenario:     "reports corrupted locale file",
+		sys:             newTestSys(localeSysFiles(map[string]string{"ja": `{ "Cannot_find_name_0_2304": `}), ""),
+		commandLineArgs: []string{"--locale", "ja"},
+	}}
+
+	for _, c := range cases {
+		c.verify(t, "locale")
+	}
+}
+
+func TestInit(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []*tscInput{
+		{
+			subScenario:     "initializes tsconfig",
+			sys:             newTestSys(FileMap{"/home/src/workspaces/project/first.ts": `export const a = 1`}, ""),
+			commandLineArgs: []string{"--init"},
+		},
+		{
+			subScenario:     "initializes tsconfig with command line options",
+			sys:             newTestSys(FileMap{"/home/src/workspaces/project/first.ts": `export const a = 1`}, ""),
+			commandLineArgs: []string{"--init", "--target", "es2022", "--module", "nodenext", "--lib", "es2022,dom", "--strict", "false", "--outDir", "dist", "--listFiles"},
+		},
+		{
+			subScenario:     "initializes tsconfig with files",
+			sys:             newTestSys(FileMap{"/home/src/workspaces/project/first.ts": `export const a = 1`}, ""),
+			commandLineArgs: []string{"--init", "first.ts", "second.ts"},
+		},
+		{
+			subScenario: "does not overwrite an existing tsconfig",
+			sys: newTestSys(FileMap{
+				"/home/src/workspaces/project/first.ts":      `export const a = 1`,
+				"/home/src/workspaces/project/tsconfig.json": `{ "compilerOptions": { "strict": true } }`,
+			}, ""),
+			commandLineArgs: []string{"--init"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase.verify(t, "init")
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	diagnosticsSysFiles := FileMap{
+		"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "outDir": "dist", "types": [] },
+	"files": ["src/main.ts"]
+}`,
+		"/home/src/workspaces/project/src/main.ts": `import { util } from "./util"; export const x: number[] = [util];`,
+		"/home/src/workspaces/project/src/util.ts": `export const util = 1;`,
+	}
+	// without the default library, so that the list of types is short
+	generateTraceSysFiles := FileMap{
+		"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "noLib": true, "types": [], "outDir": "dist" },
+	"files": ["src/globals.d.ts", "src/main.ts"]
+}`,
+		"/home/src/workspaces/project/src/globals.d.ts": `interface Array<T> { length: number; }
+interface Boolean {}
+interface Function {}
+interface CallableFunction {}
+interface NewableFunction {}
+interface IArguments {}
+interface Number {}
+interface Object {}
+interface RegExp {}
+interface String {}`,
+		"/home/src/workspaces/project/src/main.ts": `export type Pair<T> = [T, T]; export const pair: Pair<string | number> = ["a", 1];`,
+	}
+
+	cases := []tscInput{
+		{
+			subScenario:     "reports statistics",
+			sys:             newTestSys(diagnosticsSysFiles, ""),
+			commandLineArgs: []string{"--diagnostics"},
+		},
+		{
+			subScenario:     "reports extended statistics",
+			sys:             newTestSys(diagnosticsSysFiles, ""),
+			commandLineArgs: []string{"--extendedDiagnostics"},
+		},
+		{
+			subScenario:     "generates a trace",
+			sys:             newTestSys(generateTraceSysFiles, ""),
+			commandLineArgs: []string{"--generateTrace", "trace"},
+		},
+	}
+
+	for _, c := range cases {
+		c.verify(t, "diagnostics")
+	}
+}
@@ -1,6 +1,7 @@
 package execute_test
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/bundled"
 	"github.com/microsoft/typescript-go/internal/execute"
 	"github.com/microsoft/typescript-go/internal/testutil/baseline"
+	"gotest.tools/v3/assert"
 )
 
 func verifyWatch(t *testing.T, test *tscInput, scenario string, edits []*testTscEdit) {
@@ -27,14 +29,15 @@ func verifyWatch(t *testing.T, test *tscInput, scenario string, edits []*testTsc
 			baselineBuilder.WriteString("\n\n")
 
 			// build initial state
-			execute.RunWatchCycle(watcher)
+			execute.StartWatch(watcher)
+			defer execute.StopWatch(watcher)
 			test.sys.serializeState(baselineBuilder)
 
 			for _, do := range edits {
 				do.edit(test.sys)
 				baselineBuilder.WriteString("\n\nEdit:: " + do.caption + "\n")
 
-				execute.RunWatchCycle(watcher)
+				test.sys.runWatchUpdate()
 				test.sys.serializeState(baselineBuilder)
 			}
 
@@ -155,3 +158,113 @@ func TestTscNoEmitWatch(t *testing.T) {
 		})
 	}
 }
+
+func TestTscWatchUpdates(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	sys := newTestSys(FileMap{
+		"/home/src/workspaces/project/a.ts":          `export const a = 1;`,
+		"/home/src/workspaces/project/tsconfig.json": `{ "compilerOptions": { "noEmit": true } }`,
+	}, "")
+	_, watcher := execute.CommandLineTestWatch(sys, nil, []string{"-w", "--pretty", "false"})
+	execute.StartWatch(watcher)
+	defer execute.StopWatch(watcher)
+	assert.DeepEqual(t, sys.takeOutput(), []string{
+		"\n12:00:00 AM - Starting compilation in watch mode...\n\n",
+		"\n12:00:00 AM - Found 0 errors. Watching for file changes.\n",
+	})
+
+	// Changes build once they settle.
+	assert.NilError(t, sys.FS().WriteFile("/home/src/workspaces/project/a.ts", `export const a: string = 1;`, false))
+	sys.Poll()
+	sys.advanceTime(execute.UpdateDelay / 2)
+	assert.DeepEqual(t, sys.takeOutput(), []string{})
+	assert.NilError(t, sys.FS().WriteFile("/home/src/workspaces/project/b.ts", `import { a } from "./a"; export const b: number = a;`, false))
+	sys.Poll()
+	sys.advanceTime(execute.UpdateDelay / 2)
+	assert.DeepEqual(t, sys.takeOutput(), []string{})
+	sys.advanceTime(execute.UpdateDelay / 2)
+	assert.DeepEqual(t, sys.takeOutput(), []string{
+		"\n12:00:00 AM - File change detected. Starting incremental compilation...\n\n",
+		"a.ts(1,14): error TS2322: Type 'number' is not assignable to type 'string'.\n",
+		"b.ts(1,39): error TS2322: Type 'string' is not assignable to type 'number'.\n",
+		"\n12:00:00 AM - Found 2 errors. Watching for file changes.\n",
+	})
+
+	// Config changes are picked up.
+	assert.NilError(t, sys.FS().WriteFile("/home/src/workspaces/project/tsconfig.json", `{ "compilerOptions": { "noEmit": true }, "files": ["a.ts"] }`, false))
+	sys.runWatchUpdate()
+	assert.DeepEqual(t, sys.takeOutput(), []string{
+		"\n12:00:00 AM - File change detected. Starting incremental compilation...\n\n",
+		"a.ts(1,14): error TS2322: Type 'number' is not assignable to type 'string'.\n",
+		"\n12:00:00 AM - Found 1 error. Watching for file changes.\n",
+	})
+
+	// Nothing builds without changes.
+	sys.runWatchUpdate()
+	assert.DeepEqual(t, sys.takeOutput(), []string{})
+}
+
+func TestTscWatchFailedLookupLocations(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	sys := newTestSys(FileMap{
+		"/home/src/workspaces/project/src/a.ts":      `import { b } from "../lib/b"; export const a: number = b;`,
+		"/home/src/workspaces/project/lib/c.ts":      `export const c = 1;`,
+		"/home/src/workspaces/project/tsconfig.json": `{ "compilerOptions": { "noEmit": true }, "include": ["src"] }`,
+	}, "")
+	_, watcher := execute.CommandLineTestWatch(sys, nil, []string{"-w", "--pretty", "false"})
+	execute.StartWatch(watcher)
+	defer execute.StopWatch(watcher)
+	assert.DeepEqual(t, sys.takeOutput(), []string{
+		"\n12:00:00 AM - Starting compilation in watch mode...\n\n",
+		"src/a.ts(1,19): error TS2307: Cannot find module '../lib/b' or its corresponding type declarations.\n",
+		"\n12:00:00 AM - Found 1 error. Watching for file changes.\n",
+	})
+
+	// Files created where no module was found are not in the wildcard directories of the config.
+	assert.NilError(t, sys.FS().WriteFile("/home/src/workspaces/project/lib/d.ts", `export const d = 1;`, false))
+	sys.runWatchUpdate()
+	assert.DeepEqual(t, sys.takeOutput(), []string{})
+	assert.NilError(t, sys.FS().WriteFile("/home/src/workspaces/project/lib/b.ts", `export const b = "b";`, false))
+	sys.runWatchUpdate()
+	assert.DeepEqual(t, sys.takeOutput(), []string{
+		"\n12:00:00 AM - File change detected. Starting incremental compilation...\n\n",
+		"src/a.ts(1,44): error TS2322: Type 'string' is not assignable to type 'number'.\n",
+		"\n12:00:00 AM - Found 1 error. Watching for file changes.\n",
+	})
+}
+
+func TestTscWatchExit(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	sys := newTestSys(FileMap{
+		"/home/src/workspaces/project/a.ts":          `export const a = 1;`,
+		"/home/src/workspaces/project/tsconfig.json": `{ "compilerOptions": { "noEmit": true } }`,
+	}, "")
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan execute.ExitStatus)
+	go func() {
+		done <- execute.CommandLine(ctx, sys, nil, []string{"-w", "--pretty", "false"})
+	}()
+	cancel()
+	assert.Equal(t, <-done, execute.ExitStatusSuccess)
+	assert.DeepEqual(t, sys.takeOutput(), []string{
+		"\n12:00:00 AM - Starting compilation in watch mode...\n\n",
+		"\n12:00:00 AM - Found 0 errors. Watching for file changes.\n",
+	})
+
+	// The watches are closed once the watch mode exits.
+	assert.NilError(t, sys.FS().WriteFile("/home/src/workspaces/project/a.ts", `export const a: string = 1;`, false))
+	sys.runWatchUpdate()
+	assert.DeepEqual(t, sys.takeOutput(), []string{})
+}
@@ -1,40 +1,69 @@
 package execute
 
 import (
-	"fmt"
+	"context"
 	"time"
 
-	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs/vfswatch"
 )
 
-func start(w *watcher) ExitStatus {
-	if w.configFileName == "" {
-		w.host = compiler.NewCompilerHost(w.options.CompilerOptions(), w.sys.GetCurrentDirectory(), w.sys.FS(), w.sys.DefaultLibraryPath())
-	}
-	watchInterval := 1000 * time.Millisecond
-	if w.options.ParsedConfig.WatchOptions != nil {
-		watchInterval = time.Duration(*w.options.ParsedConfig.WatchOptions.Interval) * time.Millisecond
-	}
-	for {
-		w.doCycle()
-		time.Sleep(watchInterval)
-	}
+// updateDelay is how long the watcher waits for more changes after detecting one, so that a burst of
+// changes, such as saving several files at once, causes a single build.
+const updateDelay = 250 * time.Millisecond
+
+// start runs the watch mode until ctx is done. Builds after the initial one run on the timers of the
+// system, as changes are detected.
+func start(ctx context.Context, w *watcher) ExitStatus {
+	w.initialBuild()
+	<-ctx.Done()
+	w.close()
+	return ExitStatusSuccess
+}
+
+func (w *watcher) initialBuild() {
+	w.buildMu.Lock()
+	defer w.buildMu.Unlock()
+	w.reportWatchStatus(ast.NewCompilerDiagnostic(diagnostics.Starting_compilation_in_watch_mode))
+	w.build()
 }
 
-func (w *watcher) doCycle() {
-	if w.hasErrorsInTsConfig() {
-		// these are unrecoverable errors--report them and do not build
+// rebuild builds the program again if a change was detected since the last build.
+func (w *watcher) rebuild() {
+	w.buildMu.Lock()
+	defer w.buildMu.Unlock()
+	w.mu.Lock()
+	pending := w.hasChanges && !w.closed
+	w.mu.Unlock()
+	if !pending {
 		return
 	}
-	// updateProgram()
-	w.program = compiler.NewProgramFromParsedCommandLine(w.options, w.host)
-	if w.hasBeenModified(w.program) {
-		fmt.Fprint(w.sys.Writer(), "build starting at ", w.sys.Now(), w.sys.NewLine())
-		timeStart := w.sys.Now()
-		w.compileAndEmit()
-		fmt.Fprint(w.sys.Writer(), "build finished in", w.sys.Now().Sub(timeStart), w.sys.NewLine())
-	} else {
-		// print something???
-		// fmt.Fprint(w.sys.Writer(), "no changes detected at ", w.sys.Now(), w.sys.NewLine())
+	w.reportWatchStatus(ast.NewCompilerDiagnostic(diagnostics.File_change_detected_Starting_incremental_compilation))
+	w.build()
+}
+
+// close stops the watches and pending builds of the watcher, waiting for a build in progress to finish.
+func (w *watcher) close() {
+	w.mu.Lock()
+	w.closed = true
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	w.mu.Unlock()
+
+	w.buildMu.Lock()
+	defer w.buildMu.Unlock()
+	for _, watchers := range []map[tspath.Path]vfswatch.Watcher{w.fileWatchers, w.configWatchers, w.failedLookupWatchers} {
+		for path, watcher := range watchers {
+			watcher.Close()
+			delete(watchers, path)
+		}
+	}
+	for path, directoryWatcher := range w.directoryWatchers {
+		directoryWatcher.watcher.Close()
+		delete(w.directoryWatchers, path)
 	}
 }
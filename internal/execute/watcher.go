@@ -1,89 +1,377 @@
 package execute
 
 import (
-	"time"
+	"sync"
 
+	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs/vfswatch"
+)
+
+// updateLevel is how much of the program has to be recomputed by the next build.
+type updateLevel int
+
+const (
+	// updateLevelUpdate only parses the changed files again.
+	updateLevelUpdate updateLevel = iota
+	// updateLevelRootNames also collects the root files from the wildcard directories of the config again.
+	updateLevelRootNames
+	// updateLevelFull also parses the config file again.
+	updateLevelFull
 )
 
 type watcher struct {
-	sys              System
-	configFileName   string
-	options          *tsoptions.ParsedCommandLine
-	reportDiagnostic diagnosticReporter
+	sys               System
+	configFileName    string
+	commandLine       *tsoptions.ParsedCommandLine
+	watchOptions      *core.WatchOptions
+	locale            *diagnostics.Locale
+	reportDiagnostic  diagnosticReporter
+	reportWatchStatus diagnosticReporter
+
+	// buildMu is held by builds, which run on the timers of the system.
+	buildMu sync.Mutex
+	host    *watchCompilerHost
+
+	// options and program are only replaced by builds, while holding mu, as watch callbacks read them.
+	options *tsoptions.ParsedCommandLine
+	program *compiler.Program
+
+	fileWatchers      map[tspath.Path]vfswatch.Watcher
+	configWatchers    map[tspath.Path]vfswatch.Watcher
+	directoryWatchers map[string]*directoryWatcher
+	// failedLookupWatchers watch the directories of the locations module resolutions found no file at.
+	failedLookupWatchers map[tspath.Path]vfswatch.Watcher
 
-	host           compiler.CompilerHost
-	program        *compiler.Program
-	prevModified   map[string]time.Time
-	configModified bool
+	mu           sync.Mutex
+	hasChanges   bool
+	updateLevel  updateLevel
+	changedFiles core.Set[tspath.Path]
+	// timer is the pending build, if any.
+	timer  Timer
+	closed bool
+}
+
+type directoryWatcher struct {
+	recursive bool
+	watcher   vfswatch.Watcher
+}
+
+func createWatcher(sys System, commandLine *tsoptions.ParsedCommandLine, configParseResult *tsoptions.ParsedCommandLine, locale *diagnostics.Locale, reportDiagnostic diagnosticReporter) *watcher {
+	configFileName := ""
+	if configParseResult.ConfigFile != nil {
+		configFileName = configParseResult.ConfigFile.SourceFile.FileName()
+	}
+	w := &watcher{
+		sys:                  sys,
+		configFileName:       configFileName,
+		commandLine:          commandLine,
+		watchOptions:         commandLine.ParsedConfig.WatchOptions,
+		locale:               locale,
+		reportDiagnostic:     reportDiagnostic,
+		reportWatchStatus:    createWatchStatusReporter(sys, locale, configParseResult.CompilerOptions()),
+		options:              configParseResult,
+		fileWatchers:         make(map[tspath.Path]vfswatch.Watcher),
+		configWatchers:       make(map[tspath.Path]vfswatch.Watcher),
+		directoryWatchers:    make(map[string]*directoryWatcher),
+		failedLookupWatchers: make(map[tspath.Path]vfswatch.Watcher),
+		hasChanges:           true,
+	}
+	w.host = newWatchCompilerHost(newCompilerHost(sys, w.options.CompilerOptions(), nil /*tracer*/))
+	return w
 }
 
-func createWatcher(sys System, configParseResult *tsoptions.ParsedCommandLine, reportDiagnostic diagnosticReporter) *watcher {
-	return &watcher{
-		sys:              sys,
-		configFileName:   configParseResult.ConfigFile.SourceFile.FileName(),
-		options:          configParseResult,
-		reportDiagnostic: reportDiagnostic,
-		// reportWatchStatus: createWatchStatusReporter(sys, configParseResult.CompilerOptions().Pretty),
+// build updates the program with the changes detected since the last build, and compiles it.
+func (w *watcher) build() {
+	w.mu.Lock()
+	level := w.updateLevel
+	changedFiles := w.changedFiles.Keys()
+	w.hasChanges = false
+	w.updateLevel = updateLevelUpdate
+	w.changedFiles = core.Set[tspath.Path]{}
+	w.mu.Unlock()
+
+	// The module resolutions that found no file where one has been created are resolved again.
+	var invalidatedResolutions core.Set[tspath.Path]
+	for path := range changedFiles {
+		w.host.invalidate(path)
+		if w.program != nil {
+			for _, file := range w.program.GetFilesWithFailedLookupLocation(path) {
+				invalidatedResolutions.Add(file)
+			}
+		}
+	}
+	if !w.updateOptions(level) {
+		return
 	}
+
+	program := compiler.NewProgram(compiler.ProgramOptions{
+		RootFiles:                    w.options.FileNames(),
+		Options:                      w.options.CompilerOptions(),
+		Host:                         w.host,
+		ConfigFileParsingDiagnostics: w.options.GetConfigFileParsingDiagnostics(),
+		OldProgram:                   w.program,
+		HasInvalidatedResolutions:    invalidatedResolutions.Has,
+	})
+	w.mu.Lock()
+	w.program = program
+	w.mu.Unlock()
+
+	w.updateWatches()
+	w.compileAndEmit()
+}
+
+// updateOptions parses the config file again as required by level. It reports false if the config file
+// has errors that prevent a build.
+func (w *watcher) updateOptions(level updateLevel) bool {
+	if w.configFileName == "" || level == updateLevelUpdate {
+		return true
+	}
+	extendedConfigCache := map[tspath.Path]*tsoptions.ExtendedConfigCacheEntry{}
+	configParseResult, errors := getParsedCommandLineOfConfigFile(w.configFileName, w.commandLine.CompilerOptions(), w.sys, extendedConfigCache)
+	if len(errors) > 0 {
+		// these are unrecoverable errors--report them, and parse the config again on the next change
+		for _, e := range errors {
+			w.reportDiagnostic(e)
+		}
+		w.mu.Lock()
+		w.updateLevel = updateLevelFull
+		w.mu.Unlock()
+		return false
+	}
+	if level == updateLevelRootNames {
+		// The config file itself did not change, and keeping the same options lets the program reuse the
+		// structure of the previous one.
+		configParseResult.SetCompilerOptions(w.options.CompilerOptions())
+	} else {
+		w.host = newWatchCompilerHost(newCompilerHost(w.sys, configParseResult.CompilerOptions(), nil /*tracer*/))
+	}
+	w.mu.Lock()
+	w.options = configParseResult
+	w.mu.Unlock()
+	return true
 }
 
 func (w *watcher) compileAndEmit() {
 	// !!! output/error reporting is currently the same as non-watch mode
-	// diagnostics, emitResult, exitStatus :=
-	compileAndEmit(w.sys, w.program, w.reportDiagnostic)
+	compileAndEmit(w.sys, w.program, w.locale, w.reportDiagnostic, w.reportErrorSummary)
+}
+
+func (w *watcher) reportErrorSummary(allDiagnostics []*ast.Diagnostic) {
+	errorCount := core.CountWhere(allDiagnostics, func(d *ast.Diagnostic) bool { return d.Category() == diagnostics.CategoryError })
+	if errorCount == 1 {
+		w.reportWatchStatus(ast.NewCompilerDiagnostic(diagnostics.Found_1_error_Watching_for_file_changes))
+	} else {
+		w.reportWatchStatus(ast.NewCompilerDiagnostic(diagnostics.Found_0_errors_Watching_for_file_changes, errorCount))
+	}
 }
 
-func (w *watcher) hasErrorsInTsConfig() bool {
-	// only need to check and reparse tsconfig options/update host if we are watching a config file
+// scheduleUpdate records that the program has to be updated to at least level, and schedules a build
+// once the changes settle. It must be called while holding mu.
+func (w *watcher) scheduleUpdate(level updateLevel) {
+	if w.closed {
+		return
+	}
+	w.hasChanges = true
+	w.updateLevel = max(w.updateLevel, level)
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = w.sys.AfterFunc(updateDelay, w.rebuild)
+}
+
+// updateWatches watches the files of the program, the config files and the wildcard directories of the
+// config, and stops watching the ones that are no longer needed.
+func (w *watcher) updateWatches() {
+	sourceFiles := make(map[tspath.Path]string)
+	for _, file := range w.program.SourceFiles() {
+		// default library files are not expected to change
+		if !w.program.IsSourceFileDefaultLibrary(file) {
+			sourceFiles[file.Path()] = file.FileName()
+		}
+	}
+	updateFileWatchers(w.fileWatchers, sourceFiles, func(fileName string) vfswatch.Watcher {
+		return w.sys.WatchFile(fileName, w.onSourceFileChanged, w.watchOptions)
+	})
+
+	configFiles := make(map[tspath.Path]string)
 	if w.configFileName != "" {
-		extendedConfigCache := map[tspath.Path]*tsoptions.ExtendedConfigCacheEntry{}
-		// !!! need to check that this merges compileroptions correctly. This differs from non-watch, since we allow overriding of previous options
-		configParseResult, errors := getParsedCommandLineOfConfigFile(w.configFileName, &core.CompilerOptions{}, w.sys, extendedConfigCache)
-		if len(errors) > 0 {
-			for _, e := range errors {
-				w.reportDiagnostic(e)
+		configFiles[w.toPath(w.configFileName)] = w.configFileName
+		for _, fileName := range w.options.ConfigFile.ExtendedSourceFiles() {
+			configFiles[w.toPath(fileName)] = fileName
+		}
+	}
+	updateFileWatchers(w.configWatchers, configFiles, func(fileName string) vfswatch.Watcher {
+		return w.sys.WatchFile(fileName, w.onConfigFileChanged, w.watchOptions)
+	})
+
+	for path, existing := range w.directoryWatchers {
+		if recursive, ok := w.options.WildcardDirectories[path]; !ok || recursive != existing.recursive {
+			existing.watcher.Close()
+			delete(w.directoryWatchers, path)
+		}
+	}
+	for path, recursive := range w.options.WildcardDirectories {
+		if _, ok := w.directoryWatchers[path]; !ok {
+			w.directoryWatchers[path] = &directoryWatcher{
+				recursive: recursive,
+				watcher:   w.sys.WatchDirectory(path, w.onWildcardDirectoryChanged, recursive, w.watchOptions),
 			}
-			return true
 		}
-		if w.options.CompilerOptions() != configParseResult.CompilerOptions() {
-			w.configModified = true
+	}
+
+	// !!! only the directories of failed lookup locations that exist are watched
+	failedLookupDirectories := make(map[tspath.Path]string)
+	checkedDirectories := core.Set[string]{}
+	for _, location := range w.program.GetFailedLookupLocations() {
+		directory := tspath.GetDirectoryPath(location)
+		if checkedDirectories.Has(directory) {
+			continue
+		}
+		checkedDirectories.Add(directory)
+		if w.sys.FS().DirectoryExists(directory) {
+			failedLookupDirectories[w.toPath(directory)] = directory
 		}
-		w.options = configParseResult
-		w.host = compiler.NewCompilerHost(w.options.CompilerOptions(), w.sys.GetCurrentDirectory(), w.sys.FS(), w.sys.DefaultLibraryPath())
 	}
-	return false
+	updateFileWatchers(w.failedLookupWatchers, failedLookupDirectories, func(directory string) vfswatch.Watcher {
+		return w.sys.WatchDirectory(directory, w.onFailedLookupLocationChanged, false /*recursive*/, w.watchOptions)
+	})
 }
 
-func (w *watcher) hasBeenModified(program *compiler.Program) bool {
-	// checks watcher's snapshot against program file modified times
-	currState := map[string]time.Time{}
-	filesModified := w.configModified
-	for _, sourceFile := range program.SourceFiles() {
-		fileName := sourceFile.FileName()
-		s := w.sys.FS().Stat(fileName)
-		if s == nil {
-			// do nothing; if file is in program.SourceFiles() but is not found when calling Stat, file has been very recently deleted.
-			// deleted files are handled outside of this loop
-			continue
+func updateFileWatchers(watchers map[tspath.Path]vfswatch.Watcher, files map[tspath.Path]string, watch func(fileName string) vfswatch.Watcher) {
+	for path, existing := range watchers {
+		if _, ok := files[path]; !ok {
+			existing.Close()
+			delete(watchers, path)
 		}
-		currState[fileName] = s.ModTime()
-		if !filesModified {
-			if currState[fileName] != w.prevModified[fileName] {
-				filesModified = true
-			}
-			delete(w.prevModified, fileName)
+	}
+	for path, fileName := range files {
+		if _, ok := watchers[path]; !ok {
+			watchers[path] = watch(fileName)
+		}
+	}
+}
+
+func (w *watcher) onSourceFileChanged(fileName string, kind vfswatch.EventKind) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.changedFiles.Add(w.toPath(fileName))
+	w.scheduleUpdate(updateLevelUpdate)
+}
+
+func (w *watcher) onConfigFileChanged(fileName string, kind vfswatch.EventKind) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.scheduleUpdate(updateLevelFull)
+}
+
+func (w *watcher) onFailedLookupLocationChanged(fileName string) {
+	path := w.toPath(fileName)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.program == nil || len(w.program.GetFilesWithFailedLookupLocation(path)) == 0 {
+		return
+	}
+	w.changedFiles.Add(path)
+	w.scheduleUpdate(updateLevelUpdate)
+}
+
+func (w *watcher) onWildcardDirectoryChanged(fileName string) {
+	path := w.toPath(fileName)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.isIgnoredFileFromWildcardWatching(fileName, path) {
+		return
+	}
+	w.changedFiles.Add(path)
+	w.scheduleUpdate(updateLevelRootNames)
+}
+
+// isIgnoredFileFromWildcardWatching reports whether an entry added to, removed from or changed in a
+// wildcard directory cannot change the root files of the program. It must be called while holding mu.
+func (w *watcher) isIgnoredFileFromWildcardWatching(fileName string, path tspath.Path) bool {
+	options := w.options.CompilerOptions()
+	if w.configFileName != "" && path == w.toPath(w.configFileName) {
+		return true
+	}
+	if tspath.HasExtension(fileName) {
+		supportedExtensions := tsoptions.GetSupportedExtensionsWithJsonIfResolveJsonModule(options, tsoptions.GetSupportedExtensions(options, nil /*extraFileExtensions*/))
+		if !core.Some(supportedExtensions, func(extensions []string) bool { return tspath.FileExtensionIsOneOf(fileName, extensions) }) {
+			return true
 		}
 	}
-	if len(w.prevModified) > 0 {
-		filesModified = true
+
+	// The outputs of the program are not inputs to it.
+	comparePathsOptions := tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: w.sys.FS().UseCaseSensitiveFileNames(),
+		CurrentDirectory:          w.sys.GetCurrentDirectory(),
+	}
+	if options.OutFile != "" && path == w.toPath(options.OutFile) {
+		return true
+	}
+	if options.OutDir != "" && tspath.ContainsPath(options.OutDir, fileName, comparePathsOptions) ||
+		options.DeclarationDir != "" && tspath.ContainsPath(options.DeclarationDir, fileName, comparePathsOptions) {
+		return true
+	}
+
+	if w.program == nil {
+		return false
+	}
+	if w.program.GetSourceFileByPath(path) != nil && w.sys.FS().FileExists(fileName) {
+		// changes to the file are seen by its own watcher
+		return true
 	}
-	w.prevModified = currState
+	// files emitted next to their source file
+	if tspath.IsDeclarationFileName(fileName) || tspath.FileExtensionIsOneOf(fileName, tspath.SupportedJSExtensionsFlat) {
+		pathWithoutExtension := tspath.RemoveFileExtension(string(path))
+		return w.program.GetSourceFileByPath(tspath.Path(pathWithoutExtension+tspath.ExtensionTs)) != nil ||
+			w.program.GetSourceFileByPath(tspath.Path(pathWithoutExtension+tspath.ExtensionTsx)) != nil
+	}
+	return false
+}
+
+func (w *watcher) toPath(fileName string) tspath.Path {
+	return tspath.ToPath(fileName, w.sys.GetCurrentDirectory(), w.sys.FS().UseCaseSensitiveFileNames())
+}
+
+// watchCompilerHost is a compiler host that keeps the files it parsed until they change, so that a
+// build only parses the files changed since the previous one.
+type watchCompilerHost struct {
+	compiler.CompilerHost
+
+	mu          sync.Mutex
+	sourceFiles map[tspath.Path]*ast.SourceFile
+}
+
+func newWatchCompilerHost(host compiler.CompilerHost) *watchCompilerHost {
+	return &watchCompilerHost{
+		CompilerHost: host,
+		sourceFiles:  make(map[tspath.Path]*ast.SourceFile),
+	}
+}
+
+func (h *watchCompilerHost) GetSourceFile(fileName string, path tspath.Path, languageVersion core.ScriptTarget) *ast.SourceFile {
+	h.mu.Lock()
+	file := h.sourceFiles[path]
+	h.mu.Unlock()
+	if file != nil {
+		return file
+	}
+	file = h.CompilerHost.GetSourceFile(fileName, path, languageVersion)
+	if file != nil {
+		h.mu.Lock()
+		h.sourceFiles[path] = file
+		h.mu.Unlock()
+	}
+	return file
+}
 
-	// reset state for next cycle
-	w.configModified = false
-	return filesModified
+func (h *watchCompilerHost) invalidate(path tspath.Path) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.sourceFiles, path)
 }
@@ -0,0 +1,265 @@
+package ls
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+type CallHierarchyIncomingCall struct {
+	From HierarchyItem
+	// FromRanges are the ranges of the calls in the file of From.
+	FromRanges []core.TextRange
+}
+
+type CallHierarchyOutgoingCall struct {
+	To HierarchyItem
+	// FromRanges are the ranges of the calls in the file of the item the calls were requested for.
+	FromRanges []core.TextRange
+}
+
+// PrepareCallHierarchy returns the callable declarations of the symbol at a position: functions, methods,
+// accessors, constructors, classes and variables initialized with functions. Of overloaded declarations
+// only the implementation is returned.
+func (l *LanguageService) PrepareCallHierarchy(fileName string, position int) []HierarchyItem {
+	program, file := l.getProgramAndFile(fileName)
+	return core.Map(resolveCallHierarchyDeclarations(program.GetTypeChecker(), file, position), createHierarchyItem)
+}
+
+// ProvideCallHierarchyIncomingCalls returns the declarations that call the declaration of an item, grouped
+// by caller. Calls at the top level of a file are attributed to the file.
+func (l *LanguageService) ProvideCallHierarchyIncomingCalls(item HierarchyItem) []CallHierarchyIncomingCall {
+	program := l.GetProgram()
+	c := program.GetTypeChecker()
+	declaration := l.resolveCallHierarchyItem(c, item)
+	if declaration == nil || ast.IsSourceFile(declaration) {
+		return nil
+	}
+	symbol := getSymbolOfCallHierarchyDeclaration(c, declaration)
+	if symbol == nil {
+		return nil
+	}
+
+	var calls []CallHierarchyIncomingCall
+	callIndices := make(map[*ast.Node]int)
+	for _, reference := range findReferences(program, c, symbol) {
+		if !isCalleeName(reference) && symbol.Flags&ast.SymbolFlagsAccessor == 0 {
+			continue
+		}
+		caller := getEnclosingCallHierarchyDeclaration(reference)
+		index, ok := callIndices[caller]
+		if !ok {
+			index = len(calls)
+			callIndices[caller] = index
+			calls = append(calls, CallHierarchyIncomingCall{From: createHierarchyItem(caller)})
+		}
+		calls[index].FromRanges = append(calls[index].FromRanges, getRangeOfName(reference))
+	}
+	return calls
+}
+
+// ProvideCallHierarchyOutgoingCalls returns the declarations called by the declaration of an item, grouped
+// by callee. Calls in nested callable declarations are not included.
+func (l *LanguageService) ProvideCallHierarchyOutgoingCalls(item HierarchyItem) []CallHierarchyOutgoingCall {
+	c := l.GetProgram().GetTypeChecker()
+	declaration := l.resolveCallHierarchyItem(c, item)
+	if declaration == nil {
+		return nil
+	}
+
+	var calls []CallHierarchyOutgoingCall
+	callIndices := make(map[*ast.Node]int)
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if isCallHierarchyDeclaration(node) {
+			return false
+		}
+		if callee := getCalleeName(node); callee != nil {
+			if symbol := getResolvedSymbolAtLocation(c, callee); symbol != nil {
+				for _, target := range getCallHierarchyDeclarationsOfSymbol(symbol) {
+					index, ok := callIndices[target]
+					if !ok {
+						index = len(calls)
+						callIndices[target] = index
+						calls = append(calls, CallHierarchyOutgoingCall{To: createHierarchyItem(target)})
+					}
+					calls[index].FromRanges = append(calls[index].FromRanges, getRangeOfName(callee))
+				}
+			}
+		}
+		return node.ForEachChild(visit)
+	}
+	declaration.ForEachChild(visit)
+	return calls
+}
+
+// resolveCallHierarchyItem returns the declaration of an item returned by PrepareCallHierarchy or by a
+// previous request for calls.
+func (l *LanguageService) resolveCallHierarchyItem(c *checker.Checker, item HierarchyItem) *ast.Node {
+	file := l.GetProgram().GetSourceFile(item.FileName)
+	if file == nil {
+		return nil
+	}
+	if item.Kind == SymbolKindFile {
+		return file.AsNode()
+	}
+	return core.FirstOrNil(resolveCallHierarchyDeclarations(c, file, item.SelectionRange.Pos()))
+}
+
+func resolveCallHierarchyDeclarations(c *checker.Checker, file *ast.SourceFile, position int) []*ast.Node {
+	node := astnav.GetTouchingPropertyName(file, position)
+	if node.Kind == ast.KindSourceFile {
+		return nil
+	}
+	if node.Kind == ast.KindConstructorKeyword && ast.IsConstructorDeclaration(node.Parent) {
+		return []*ast.Node{node.Parent}
+	}
+	symbol := getResolvedSymbolAtLocation(c, node)
+	if symbol == nil {
+		return nil
+	}
+	return getCallHierarchyDeclarationsOfSymbol(symbol)
+}
+
+// getCallHierarchyDeclarationsOfSymbol returns the declarations of a symbol that can appear in a call
+// hierarchy, preferring those with an implementation.
+func getCallHierarchyDeclarationsOfSymbol(symbol *ast.Symbol) []*ast.Node {
+	declarations := core.Filter(symbol.Declarations, isCallHierarchyDeclaration)
+	if implementations := core.Filter(declarations, hasImplementation); len(implementations) != 0 {
+		return implementations[:1]
+	}
+	return declarations
+}
+
+func hasImplementation(declaration *ast.Node) bool {
+	return declaration.Body() != nil || ast.IsClassLike(declaration) || ast.IsVariableDeclaration(declaration)
+}
+
+func isCallHierarchyDeclaration(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindFunctionDeclaration, ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor,
+		ast.KindConstructor, ast.KindClassDeclaration:
+		return true
+	case ast.KindVariableDeclaration:
+		initializer := node.Initializer()
+		if !ast.IsIdentifier(node.Name()) || initializer == nil {
+			return false
+		}
+		initializer = ast.SkipParentheses(initializer)
+		return ast.IsFunctionExpressionOrArrowFunction(initializer) || ast.IsClassExpression(initializer)
+	}
+	return false
+}
+
+func getSymbolOfCallHierarchyDeclaration(c *checker.Checker, declaration *ast.Node) *ast.Symbol {
+	if ast.IsConstructorDeclaration(declaration) {
+		// Constructors are called through the name of their class.
+		declaration = declaration.Parent
+	}
+	return getSymbolOfDeclaration(c, declaration)
+}
+
+// getEnclosingCallHierarchyDeclaration returns the callable declaration containing a node, or the source
+// file if the node is at the top level.
+func getEnclosingCallHierarchyDeclaration(node *ast.Node) *ast.Node {
+	return ast.FindAncestor(node.Parent, func(n *ast.Node) bool {
+		return ast.IsSourceFile(n) || isCallHierarchyDeclaration(n)
+	})
+}
+
+// getCalleeName returns the name a call, `new` expression, tagged template or decorator calls through.
+func getCalleeName(node *ast.Node) *ast.Node {
+	var expression *ast.Node
+	switch node.Kind {
+	case ast.KindCallExpression, ast.KindNewExpression, ast.KindDecorator:
+		expression = node.Expression()
+	case ast.KindTaggedTemplateExpression:
+		expression = node.AsTaggedTemplateExpression().Tag
+	default:
+		return nil
+	}
+	expression = ast.SkipParentheses(expression)
+	switch expression.Kind {
+	case ast.KindIdentifier:
+		return expression
+	case ast.KindPropertyAccessExpression:
+		return expression.Name()
+	}
+	return nil
+}
+
+// isCalleeName reports whether an identifier is the name a call, `new` expression, tagged template or
+// decorator calls through.
+func isCalleeName(node *ast.Node) bool {
+	parent := node.Parent
+	if ast.IsPropertyAccessExpression(parent) && parent.Name() == node {
+		parent = parent.Parent
+	}
+	for ast.IsParenthesizedExpression(parent) {
+		parent = parent.Parent
+	}
+	return getCalleeName(parent) == node
+}
+
+func getRangeOfName(name *ast.Node) core.TextRange {
+	return core.NewTextRange(scanner.GetTokenPosOfNode(name, ast.GetSourceFileOfNode(name), false /*includeJsDoc*/), name.End())
+}
+
+func createHierarchyItem(declaration *ast.Node) HierarchyItem {
+	file := ast.GetSourceFileOfNode(declaration)
+	if ast.IsSourceFile(declaration) {
+		return HierarchyItem{
+			Name:     tspath.GetBaseFileName(file.FileName()),
+			Kind:     SymbolKindFile,
+			FileName: file.FileName(),
+			Range:    core.NewTextRange(0, len(file.Text)),
+		}
+	}
+
+	item := HierarchyItem{
+		Kind:     getHierarchySymbolKind(declaration),
+		FileName: file.FileName(),
+		Range:    core.NewTextRange(scanner.GetTokenPosOfNode(declaration, file, false /*includeJsDoc*/), declaration.End()),
+	}
+	if name := declaration.Name(); name != nil {
+		item.Name = scanner.GetTextOfNode(name)
+		item.SelectionRange = getRangeOfName(name)
+	} else {
+		// Constructors and anonymous default exported classes are named by their first keyword.
+		pos := item.Range.Pos()
+		if modifiers := declaration.Modifiers(); modifiers != nil {
+			pos = scanner.SkipTrivia(file.Text, modifiers.Loc.End())
+		}
+		item.SelectionRange = scanner.GetRangeOfTokenAtPosition(file, pos)
+		item.Name = core.IfElse(ast.IsConstructorDeclaration(declaration), "constructor", "default")
+	}
+	if ast.IsClassElement(declaration) {
+		if class := declaration.Parent; class.Name() != nil {
+			item.Detail = class.Name().Text()
+		}
+	}
+	return item
+}
+
+func getHierarchySymbolKind(declaration *ast.Node) SymbolKind {
+	switch declaration.Kind {
+	case ast.KindClassDeclaration, ast.KindClassExpression:
+		return SymbolKindClass
+	case ast.KindInterfaceDeclaration:
+		return SymbolKindInterface
+	case ast.KindMethodDeclaration:
+		return SymbolKindMethod
+	case ast.KindConstructor:
+		return SymbolKindConstructor
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		return SymbolKindProperty
+	case ast.KindVariableDeclaration:
+		if ast.IsClassExpression(ast.SkipParentheses(declaration.Initializer())) {
+			return SymbolKindClass
+		}
+	}
+	return SymbolKindFunction
+}
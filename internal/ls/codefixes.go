@@ -0,0 +1,215 @@
+package ls
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/binder"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+)
+
+type CodeAction struct {
+	Description string
+	Changes     []FileTextChanges
+}
+
+// CodeFixAction is a code action that fixes a diagnostic.
+type CodeFixAction struct {
+	CodeAction
+	FixName string
+	// FixID identifies the fixes that GetCombinedCodeFix can apply together. It is empty when the
+	// file has no other diagnostic that the same fix applies to.
+	FixID             string
+	FixAllDescription string
+}
+
+// codeFixProvider computes the fixes for a set of diagnostic codes.
+type codeFixProvider struct {
+	errorCodes     []int32
+	fixIDs         []string
+	getCodeActions func(ctx *codeFixContext) []CodeFixAction
+	// getAllCodeActions computes the combined fix for fixID in a file. When nil, the first action with
+	// that fix ID is computed for every diagnostic in the file and the resulting changes are merged.
+	getAllCodeActions func(ctx *codeFixAllContext) []FileTextChanges
+}
+
+type codeFixContext struct {
+	program       *compiler.Program
+	checker       *checker.Checker
+	file          *ast.SourceFile
+	span          core.TextRange
+	errorCode     int32
+	diagnostic    *ast.Diagnostic
+	newLine       string
+	preferences   *UserPreferences
+	exportInfoMap *ExportInfoMap
+}
+
+type codeFixAllContext struct {
+	program       *compiler.Program
+	checker       *checker.Checker
+	file          *ast.SourceFile
+	fixID         string
+	diagnostics   []*ast.Diagnostic
+	newLine       string
+	preferences   *UserPreferences
+	exportInfoMap *ExportInfoMap
+}
+
+var codeFixProviders = []*codeFixProvider{
+	importFixProvider,
+	addMissingAwaitFixProvider,
+	spellingFixProvider,
+	implementInterfaceFixProvider,
+	overrideFixProvider,
+	unusedIdentifierFixProvider,
+}
+
+var (
+	codeFixProvidersByErrorCode = make(map[int32][]*codeFixProvider)
+	codeFixProvidersByFixID     = make(map[string]*codeFixProvider)
+)
+
+func init() {
+	for _, provider := range codeFixProviders {
+		for _, code := range provider.errorCodes {
+			codeFixProvidersByErrorCode[code] = append(codeFixProvidersByErrorCode[code], provider)
+		}
+		for _, fixID := range provider.fixIDs {
+			codeFixProvidersByFixID[fixID] = provider
+		}
+	}
+}
+
+func errorCodes(messages ...*diagnostics.Message) []int32 {
+	return core.Map(messages, (*diagnostics.Message).Code)
+}
+
+// GetSupportedCodeFixes returns the diagnostic codes for which code fixes may be available.
+func GetSupportedCodeFixes() []int32 {
+	return slices.Sorted(maps.Keys(codeFixProvidersByErrorCode))
+}
+
+// GetCodeFixesAtPosition returns the fixes for the diagnostic with the given code and span.
+func (l *LanguageService) GetCodeFixesAtPosition(fileName string, span core.TextRange, errorCode int32, preferences *UserPreferences) []CodeFixAction {
+	providers := codeFixProvidersByErrorCode[errorCode]
+	if len(providers) == 0 {
+		return nil
+	}
+	program, file := l.getProgramAndFile(fileName)
+	fileDiagnostics := l.GetDocumentDiagnostics(fileName)
+	ctx := &codeFixContext{
+		program:       program,
+		checker:       program.GetTypeChecker(),
+		file:          file,
+		span:          span,
+		errorCode:     errorCode,
+		newLine:       l.NewLine(),
+		preferences:   core.Coalesce(preferences, &UserPreferences{}),
+		exportInfoMap: l.host.GetExportInfoMap(),
+	}
+	for _, diagnostic := range fileDiagnostics {
+		if diagnostic.Code() == errorCode && diagnostic.Loc() == span {
+			ctx.diagnostic = diagnostic
+			break
+		}
+	}
+
+	var actions []CodeFixAction
+	for _, provider := range providers {
+		providerActions := provider.getCodeActions(ctx)
+		// Fixing all is only useful if the file has more than one diagnostic the provider handles.
+		if countDiagnosticsForProvider(fileDiagnostics, provider) < 2 {
+			for i := range providerActions {
+				providerActions[i].FixID = ""
+				providerActions[i].FixAllDescription = ""
+			}
+		}
+		actions = append(actions, providerActions...)
+	}
+	return actions
+}
+
+// GetCombinedCodeFix applies the fix identified by fixID to every applicable diagnostic in the file.
+func (l *LanguageService) GetCombinedCodeFix(fileName string, fixID string, preferences *UserPreferences) *CodeAction {
+	provider := codeFixProvidersByFixID[fixID]
+	if provider == nil {
+		return nil
+	}
+	program, file := l.getProgramAndFile(fileName)
+	ctx := &codeFixAllContext{
+		program: program,
+		checker: program.GetTypeChecker(),
+		file:    file,
+		fixID:   fixID,
+		diagnostics: core.Filter(l.GetDocumentDiagnostics(fileName), func(diagnostic *ast.Diagnostic) bool {
+			return slices.Contains(provider.errorCodes, diagnostic.Code())
+		}),
+		newLine:       l.NewLine(),
+		preferences:   core.Coalesce(preferences, &UserPreferences{}),
+		exportInfoMap: l.host.GetExportInfoMap(),
+	}
+	var changes []FileTextChanges
+	if provider.getAllCodeActions != nil {
+		changes = provider.getAllCodeActions(ctx)
+	} else {
+		changes = getAllCodeActionsByDiagnostic(ctx, provider)
+	}
+	return &CodeAction{Changes: changes}
+}
+
+func getAllCodeActionsByDiagnostic(ctx *codeFixAllContext, provider *codeFixProvider) []FileTextChanges {
+	tracker := newChangeTracker(ctx.newLine)
+	for _, diagnostic := range ctx.diagnostics {
+		actions := provider.getCodeActions(&codeFixContext{
+			program:       ctx.program,
+			checker:       ctx.checker,
+			file:          ctx.file,
+			span:          diagnostic.Loc(),
+			errorCode:     diagnostic.Code(),
+			diagnostic:    diagnostic,
+			newLine:       ctx.newLine,
+			preferences:   ctx.preferences,
+			exportInfoMap: ctx.exportInfoMap,
+		})
+		if index := slices.IndexFunc(actions, func(action CodeFixAction) bool { return action.FixID == ctx.fixID }); index >= 0 {
+			tracker.addFileTextChanges(actions[index].Changes)
+		}
+	}
+	return tracker.getChanges()
+}
+
+func countDiagnosticsForProvider(fileDiagnostics []*ast.Diagnostic, provider *codeFixProvider) int {
+	count := 0
+	for _, diagnostic := range fileDiagnostics {
+		if slices.Contains(provider.errorCodes, diagnostic.Code()) {
+			count++
+		}
+	}
+	return count
+}
+
+func newCodeFixAction(fixName string, changes []FileTextChanges, description string, fixID string, fixAllDescription *diagnostics.Message) CodeFixAction {
+	return CodeFixAction{
+		CodeAction:        CodeAction{Description: description, Changes: changes},
+		FixName:           fixName,
+		FixID:             fixID,
+		FixAllDescription: fixAllDescription.Format(),
+	}
+}
+
+// getDiagnosticNode returns the outermost node whose error span is the given span.
+func getDiagnosticNode(file *ast.SourceFile, span core.TextRange) *ast.Node {
+	var result *ast.Node
+	for node := astnav.GetTokenAtPosition(file, span.Pos()); node != nil && !ast.IsSourceFile(node); node = node.Parent {
+		if binder.GetErrorRangeForNode(file, node) == span {
+			result = node
+		}
+	}
+	return result
+}
@@ -0,0 +1,231 @@
+package ls_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"gotest.tools/v3/assert"
+)
+
+func TestGetCodeFixesAtPosition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		files       map[string]string
+		options     *core.CompilerOptions
+		marker      string
+		errorCode   int32
+		description string
+		expected    string
+	}{
+		{
+			name: "add missing import",
+			files: map[string]string{
+				mainFileName:        "const sum = add(1, 2);\n",
+				"/home/src/math.ts": "export function add(a: number, b: number) { return a + b; }\n",
+			},
+			marker:      "add(",
+			errorCode:   2304,
+			description: `Add import from "./math"`,
+			expected:    "import { add } from \"./math\";\n\nconst sum = add(1, 2);\n",
+		},
+		{
+			name: "update existing import",
+			files: map[string]string{
+				mainFileName:        "import { sub } from './math';\nexport const n = add(sub(3, 2), 1);\n",
+				"/home/src/math.ts": "export const add = (a: number, b: number) => a + b;\nexport const sub = (a: number, b: number) => a - b;\n",
+			},
+			marker:      "add(",
+			errorCode:   2304,
+			description: `Update import from "./math"`,
+			expected:    "import { sub, add } from './math';\nexport const n = add(sub(3, 2), 1);\n",
+		},
+		{
+			name: "add default import",
+			files: map[string]string{
+				mainFileName:          "export const w = new Widget();\n",
+				"/home/src/widget.ts": "export default class Widget {}\n",
+			},
+			marker:      "Widget(",
+			errorCode:   2304,
+			description: `Add import from "./widget"`,
+			expected:    "import Widget from \"./widget\";\n\nexport const w = new Widget();\n",
+		},
+		{
+			name: "add missing await",
+			files: map[string]string{
+				mainFileName: "export async function f(p: Promise<{ value: number }>) {\n    return p.value;\n}\n",
+			},
+			marker:      "value;",
+			errorCode:   2339,
+			description: "Add 'await'",
+			expected:    "export async function f(p: Promise<{ value: number }>) {\n    return (await p).value;\n}\n",
+		},
+		{
+			name: "fix misspelled property",
+			files: map[string]string{
+				mainFileName: "const point = { x: 1, length: 2 };\npoint.lenght;\n",
+			},
+			marker:      "lenght",
+			errorCode:   2551,
+			description: "Change spelling to 'length'",
+			expected:    "const point = { x: 1, length: 2 };\npoint.length;\n",
+		},
+		{
+			name: "fix misspelled property that is not an identifier",
+			files: map[string]string{
+				mainFileName: "const o = { \"bell\\u0007x\": 1 };\no.bellx;\n",
+			},
+			marker:      "bellx;",
+			errorCode:   2551,
+			description: "Change spelling to 'bell\ax'",
+			expected:    "const o = { \"bell\\u0007x\": 1 };\no[\"bell\\u0007x\"];\n",
+		},
+		{
+			name: "implement interface",
+			files: map[string]string{
+				mainFileName: "interface Shape {\n    readonly sides: number;\n    name?: string;\n    area(scale: number): number;\n}\nclass Square implements Shape {}\n",
+			},
+			marker:      "Square",
+			errorCode:   2420,
+			description: "Implement interface 'Shape'",
+			expected: "interface Shape {\n    readonly sides: number;\n    name?: string;\n    area(scale: number): number;\n}\nclass Square implements Shape {\n" +
+				"    readonly sides: number;\n    name?: string | undefined;\n    area(scale: number): number {\n        throw new Error(\"Method not implemented.\");\n    }\n}\n",
+		},
+		{
+			name: "implement interface with symbol and quoted names",
+			files: map[string]string{
+				mainFileName: "declare const key: unique symbol;\ninterface Keyed {\n    [key]: number;\n    [Symbol.iterator](): Iterator<string>;\n    \"a-b\": boolean;\n}\nclass K implements Keyed {}\n",
+			},
+			options:     &core.CompilerOptions{Target: core.ScriptTargetESNext},
+			marker:      "K implements",
+			errorCode:   2420,
+			description: "Implement interface 'Keyed'",
+			expected: "declare const key: unique symbol;\ninterface Keyed {\n    [key]: number;\n    [Symbol.iterator](): Iterator<string>;\n    \"a-b\": boolean;\n}\nclass K implements Keyed {\n" +
+				"    [key]: number;\n    [Symbol.iterator](): Iterator<string, any, any> {\n        throw new Error(\"Method not implemented.\");\n    }\n    \"a-b\": boolean;\n}\n",
+		},
+		{
+			name: "implement interface with names that need escaping",
+			files: map[string]string{
+				mainFileName: "interface Escaped {\n    \"a\\u0007b\": number;\n    \"c\\\\d\": string;\n    \"\U0001F600\": boolean;\n}\nclass E implements Escaped {}\n",
+			},
+			marker:      "E implements",
+			errorCode:   2420,
+			description: "Implement interface 'Escaped'",
+			expected: "interface Escaped {\n    \"a\\u0007b\": number;\n    \"c\\\\d\": string;\n    \"\U0001F600\": boolean;\n}\nclass E implements Escaped {\n" +
+				"    \"a\\u0007b\": number;\n    \"c\\\\d\": string;\n    \"\U0001F600\": boolean;\n}\n",
+		},
+		{
+			name: "add override modifier",
+			files: map[string]string{
+				mainFileName: "class Base { protected run() {} }\nclass Derived extends Base {\n    protected run() {}\n}\n",
+			},
+			options:     &core.CompilerOptions{Strict: core.TSTrue, NoImplicitOverride: core.TSTrue},
+			marker:      "run() {}\n}",
+			errorCode:   4114,
+			description: "Add 'override' modifier",
+			expected:    "class Base { protected run() {} }\nclass Derived extends Base {\n    protected override run() {}\n}\n",
+		},
+		{
+			name: "remove unused import specifier",
+			files: map[string]string{
+				mainFileName:       "import { a, b } from \"./lib\";\nexport const c = b;\n",
+				"/home/src/lib.ts": "export const a = 1;\nexport const b = 2;\n",
+			},
+			options:     &core.CompilerOptions{Strict: core.TSTrue, NoUnusedLocals: core.TSTrue},
+			marker:      "a,",
+			errorCode:   6133,
+			description: "Remove unused declaration for: 'a'",
+			expected:    "import { b } from \"./lib\";\nexport const c = b;\n",
+		},
+		{
+			name: "remove unused variable",
+			files: map[string]string{
+				mainFileName: "export function f() {\n    const unused = 1;\n    return 2;\n}\n",
+			},
+			options:     &core.CompilerOptions{Strict: core.TSTrue, NoUnusedLocals: core.TSTrue},
+			marker:      "const unused",
+			errorCode:   6133,
+			description: "Remove unused declaration for: 'unused'",
+			expected:    "export function f() {\n    return 2;\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			service := setup(t, test.files, test.options)
+			content := test.files[mainFileName]
+			diagnostic := findDiagnostic(t, service, markerPosition(t, content, test.marker), test.errorCode)
+			fixes := service.GetCodeFixesAtPosition(mainFileName, diagnostic, test.errorCode, nil /*preferences*/)
+			index := slices.IndexFunc(fixes, func(fix ls.CodeFixAction) bool { return fix.Description == test.description })
+			assert.Assert(t, index >= 0, "fix %q not found in %v", test.description, fixes)
+			assert.Equal(t, applyCodeAction(t, content, &fixes[index].CodeAction), test.expected)
+		})
+	}
+}
+
+func TestGetCombinedCodeFix(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		mainFileName: `import { unusedImport, used } from "./lib";
+export function f(value: number) {
+    const first = 1;
+    const second = 2;
+    return used + value;
+}
+`,
+		"/home/src/lib.ts": "export const unusedImport = 1;\nexport const used = 2;\n",
+	}
+	service := setup(t, files, &core.CompilerOptions{Strict: core.TSTrue, NoUnusedLocals: core.TSTrue})
+	content := files[mainFileName]
+
+	diagnostic := findDiagnostic(t, service, markerPosition(t, content, "const first"), 6133)
+	fixes := service.GetCodeFixesAtPosition(mainFileName, diagnostic, 6133, nil /*preferences*/)
+	assert.Equal(t, len(fixes), 1)
+	assert.Equal(t, fixes[0].FixID, "unusedIdentifier_delete")
+	assert.Equal(t, fixes[0].FixAllDescription, "Delete all unused declarations")
+
+	action := service.GetCombinedCodeFix(mainFileName, fixes[0].FixID, nil /*preferences*/)
+	assert.Equal(t, applyCodeAction(t, content, action), `import { used } from "./lib";
+export function f(value: number) {
+    return used + value;
+}
+`)
+}
+
+// findDiagnostic returns the span of the diagnostic with the given code that starts at pos.
+func findDiagnostic(t *testing.T, service *ls.LanguageService, pos int, code int32) core.TextRange {
+	t.Helper()
+	var codes []int32
+	for _, diagnostic := range service.GetDocumentDiagnostics(mainFileName) {
+		if diagnostic.Code() == code && diagnostic.Pos() == pos {
+			return diagnostic.Loc()
+		}
+		codes = append(codes, diagnostic.Code())
+	}
+	t.Fatalf("diagnostic %d not found at %d; found %v", code, pos, codes)
+	return core.TextRange{}
+}
+
+// applyCodeAction applies the changes of a code action to the main file, which must be the only file changed.
+func applyCodeAction(t *testing.T, content string, action *ls.CodeAction) string {
+	t.Helper()
+	assert.Equal(t, len(action.Changes), 1)
+	assert.Equal(t, action.Changes[0].FileName, mainFileName)
+	changes := action.Changes[0].TextChanges
+	var sb strings.Builder
+	last := 0
+	for _, change := range changes {
+		sb.WriteString(content[last:change.Pos()])
+		sb.WriteString(change.NewText)
+		last = change.End()
+	}
+	sb.WriteString(content[last:])
+	return sb.String()
+}
@@ -3,37 +3,74 @@ package ls
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/scanner"
 )
 
+// ProvideDefinitions returns the declarations of the symbol at a position. Declarations in declaration
+// files that have declaration maps are mapped back to the source files they were generated from.
 func (l *LanguageService) ProvideDefinitions(fileName string, position int) []Location {
-	program, file := l.getProgramAndFile(fileName)
+	locations := l.ProvideDeclarations(fileName, position)
+	for i, location := range locations {
+		locations[i] = l.tryGetSourceLocation(location)
+	}
+	return locations
+}
+
+// ProvideDeclarations returns the declarations of the symbol at a position as they appear in the program.
+func (l *LanguageService) ProvideDeclarations(fileName string, position int) []Location {
+	// Binding sets the parents of names in JSDoc links, which the checker needs to resolve them.
+	program, file := l.getProgramAndBoundFile(fileName)
 	node := astnav.GetTouchingPropertyName(file, position)
 	if node.Kind == ast.KindSourceFile {
 		return nil
 	}
 
 	checker := program.GetTypeChecker()
-	if symbol := checker.GetSymbolAtLocation(node); symbol != nil {
-		if symbol.Flags&ast.SymbolFlagsAlias != 0 {
-			if resolved, ok := checker.ResolveAlias(symbol); ok {
-				symbol = resolved
-			}
+	if symbol := getResolvedSymbolAtLocation(checker, node); symbol != nil {
+		return getLocationsOfDeclarations(symbol.Declarations)
+	}
+	return nil
+}
+
+// getResolvedSymbolAtLocation returns the symbol at a location, following aliases to their targets and
+// instantiated symbols to the symbols they were instantiated from.
+func getResolvedSymbolAtLocation(checker *checker.Checker, node *ast.Node) *ast.Symbol {
+	symbol := checker.GetSymbolAtLocation(node)
+	if symbol == nil {
+		return nil
+	}
+	if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+		if resolved, ok := checker.ResolveAlias(symbol); ok {
+			symbol = resolved
 		}
+	}
+	return checker.GetRootSymbol(symbol)
+}
 
-		locations := make([]Location, 0, len(symbol.Declarations))
-		for _, decl := range symbol.Declarations {
-			file := ast.GetSourceFileOfNode(decl)
-			loc := decl.Loc
-			pos := scanner.GetTokenPosOfNode(decl, file, false /*includeJsDoc*/)
+// getSymbolOfDeclaration returns the symbol a declaration declares, including the merged symbol of a
+// declaration that merges with others.
+func getSymbolOfDeclaration(checker *checker.Checker, declaration *ast.Node) *ast.Symbol {
+	if name := declaration.Name(); name != nil {
+		return checker.GetSymbolAtLocation(name)
+	}
+	return declaration.Symbol()
+}
 
-			locations = append(locations, Location{
-				FileName: file.FileName(),
-				Range:    core.NewTextRange(pos, loc.End()),
-			})
-		}
-		return locations
+func getLocationOfDeclaration(decl *ast.Node) Location {
+	file := ast.GetSourceFileOfNode(decl)
+	pos := scanner.GetTokenPosOfNode(decl, file, false /*includeJsDoc*/)
+	return Location{
+		FileName: file.FileName(),
+		Range:    core.NewTextRange(pos, decl.End()),
 	}
-	return nil
+}
+
+func getLocationsOfDeclarations(declarations []*ast.Node) []Location {
+	locations := make([]Location, 0, len(declarations))
+	for _, decl := range declarations {
+		locations = append(locations, getLocationOfDeclaration(decl))
+	}
+	return locations
 }
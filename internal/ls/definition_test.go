@@ -0,0 +1,166 @@
+package ls_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"gotest.tools/v3/assert"
+)
+
+// locationTexts returns the text of each location.
+func locationTexts(t *testing.T, files map[string]string, locations []ls.Location) []string {
+	t.Helper()
+	texts := make([]string, len(locations))
+	for i, location := range locations {
+		text, ok := files[location.FileName]
+		assert.Assert(t, ok, "unexpected file %s", location.FileName)
+		texts[i] = text[location.Range.Pos():location.Range.End()]
+	}
+	return texts
+}
+
+func TestProvideTypeDefinitions(t *testing.T) {
+	t.Parallel()
+
+	const content = `interface Foo { a: number }
+class Bar { b = 1 }
+declare const union: Foo | Bar;
+declare const array: Foo[];
+declare const promise: Promise<Bar>;
+declare const numbers: number[];
+declare function make(): Foo;
+union; array; promise; make;
+`
+	files := map[string]string{mainFileName: content}
+	service := setup(t, files, nil)
+
+	tests := []struct {
+		marker   string
+		expected []string
+	}{
+		{marker: "union;", expected: []string{"class Bar { b = 1 }", "interface Foo { a: number }"}},
+		{marker: "array;", expected: []string{"interface Foo { a: number }"}},
+		{marker: "promise;", expected: []string{"class Bar { b = 1 }"}},
+		{marker: "make;", expected: []string{"interface Foo { a: number }"}},
+	}
+	for _, test := range tests {
+		locations := service.ProvideTypeDefinitions(mainFileName, markerPosition(t, content, test.marker))
+		assert.DeepEqual(t, locationTexts(t, files, locations), test.expected)
+	}
+
+	// Arrays of types without declarations fall back to the array type.
+	locations := service.ProvideTypeDefinitions(mainFileName, markerPosition(t, content, "numbers"))
+	assert.Assert(t, len(locations) != 0)
+	for _, location := range locations {
+		assert.Assert(t, location.FileName != mainFileName)
+	}
+}
+
+func TestProvideImplementations(t *testing.T) {
+	t.Parallel()
+
+	const content = `interface Shape { area(): number }
+abstract class Base implements Shape { abstract area(): number }
+class Square extends Base { area() { return 1; } }
+class Rect implements Shape { area() { return 2; } }
+class Big extends Rect {}
+const circle: Shape = { area() { return 3; } };
+const notShape = { size: 1 } as Shape;
+function overloaded(x: string): void;
+function overloaded(x: number): void;
+function overloaded(x: any) {}
+`
+	files := map[string]string{mainFileName: content}
+	service := setup(t, files, nil)
+
+	locations := service.ProvideImplementations(mainFileName, markerPosition(t, content, "Shape"))
+	assert.DeepEqual(t, locationTexts(t, files, locations), []string{
+		"class Square extends Base { area() { return 1; } }",
+		"class Rect implements Shape { area() { return 2; } }",
+		"class Big extends Rect {}",
+		"{ area() { return 3; } }",
+	})
+
+	locations = service.ProvideImplementations(mainFileName, markerPosition(t, content, "area(): number }\nabstract"))
+	assert.DeepEqual(t, locationTexts(t, files, locations), []string{
+		"area() { return 1; }",
+		"area() { return 2; }",
+		"area() { return 3; }",
+	})
+
+	locations = service.ProvideImplementations(mainFileName, markerPosition(t, content, "area(): number }\nclass"))
+	assert.DeepEqual(t, locationTexts(t, files, locations), []string{"area() { return 1; }"})
+
+	locations = service.ProvideImplementations(mainFileName, markerPosition(t, content, "overloaded(x: string"))
+	assert.DeepEqual(t, locationTexts(t, files, locations), []string{"function overloaded(x: any) {}"})
+}
+
+func TestProvideDefinitionsWithDeclarationMap(t *testing.T) {
+	t.Parallel()
+
+	const content = `import { greet } from "pkg";
+greet("world");
+`
+	files := map[string]string{
+		mainFileName: content,
+		"/home/src/node_modules/pkg/package.json":        `{ "name": "pkg", "types": "dist/index.d.ts" }`,
+		"/home/src/node_modules/pkg/dist/index.d.ts":     "export declare function greet(name: string): string;\n//# sourceMappingURL=index.d.ts.map\n",
+		"/home/src/node_modules/pkg/dist/index.d.ts.map": `{"version":3,"file":"index.d.ts","sourceRoot":"","sources":["../src/index.ts"],"names":[],"mappings":"AAAA,wBAAgB,KAAK,CAAC,IAAI,EAAE,MAAM,GAAG,MAAM,CAE3C"}`,
+		"/home/src/node_modules/pkg/src/index.ts":        "export function greet(name: string): string {\n    return name;\n}\n",
+	}
+	service := setup(t, files, &core.CompilerOptions{
+		Strict:           core.TSTrue,
+		Target:           core.ScriptTargetESNext,
+		ModuleKind:       core.ModuleKindESNext,
+		ModuleResolution: core.ModuleResolutionKindBundler,
+	})
+	position := markerPosition(t, content, "greet(\"world\")")
+
+	locations := service.ProvideDeclarations(mainFileName, position)
+	assert.Equal(t, len(locations), 1)
+	assert.Equal(t, locations[0].FileName, "/home/src/node_modules/pkg/dist/index.d.ts")
+
+	locations = service.ProvideDefinitions(mainFileName, position)
+	assert.Equal(t, len(locations), 1)
+	assert.Equal(t, locations[0].FileName, "/home/src/node_modules/pkg/src/index.ts")
+	assert.Equal(t, locations[0].Range.Pos(), 0)
+}
+
+func TestProvideDefinitionsInJSDoc(t *testing.T) {
+	t.Parallel()
+
+	const content = `class Server { run() {} }
+/**
+ * Prefer {@link Server} or {@link Server.run | running it}.
+ * @see Server
+ */
+function start() {}
+`
+	files := map[string]string{mainFileName: content}
+	service := setup(t, files, nil)
+
+	tests := []struct {
+		marker   string
+		expected []string
+	}{
+		{marker: "Server} or", expected: []string{"class Server { run() {} }"}},
+		{marker: "run |", expected: []string{"run() {}"}},
+		{marker: "Server\n", expected: []string{"class Server { run() {} }"}},
+	}
+	for _, test := range tests {
+		locations := service.ProvideDefinitions(mainFileName, markerPosition(t, content, test.marker))
+		assert.DeepEqual(t, locationTexts(t, files, locations), test.expected)
+	}
+
+	quickInfo := service.ProvideHover(mainFileName, markerPosition(t, content, "start()"))
+	assert.Assert(t, quickInfo != nil)
+	var targets []string
+	for _, part := range quickInfo.Documentation {
+		if part.Kind == ls.DisplayPartKindLinkName {
+			assert.Assert(t, part.Target != nil, part.Text)
+			targets = append(targets, locationTexts(t, files, []ls.Location{*part.Target})...)
+		}
+	}
+	assert.DeepEqual(t, targets, []string{"class Server { run() {} }", "run() {}"})
+}
@@ -10,5 +10,6 @@ func (l *LanguageService) GetDocumentDiagnostics(fileName string) []*ast.Diagnos
 	program, file := l.getProgramAndFile(fileName)
 	syntaxDiagnostics := program.GetSyntacticDiagnostics(file)
 	semanticDiagnostics := program.GetSemanticDiagnostics(file)
-	return slices.Concat(syntaxDiagnostics, semanticDiagnostics)
+	suggestionDiagnostics := program.GetSuggestionDiagnostics(file)
+	return slices.Concat(syntaxDiagnostics, semanticDiagnostics, suggestionDiagnostics)
 }
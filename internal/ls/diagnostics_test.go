@@ -0,0 +1,37 @@
+package ls_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"gotest.tools/v3/assert"
+)
+
+func TestGetDocumentDiagnosticsDeprecated(t *testing.T) {
+	t.Parallel()
+
+	const content = `/** @deprecated Use bar. */
+function foo() {}
+/** @deprecated */
+function over(x: string): void;
+function over(x: number): void;
+function over(x: any) {}
+foo();
+over("a");
+over(1);
+`
+	service := setup(t, map[string]string{mainFileName: content}, nil)
+
+	// Only the call that resolves to the deprecated overload is reported.
+	var deprecated []int
+	for _, diagnostic := range service.GetDocumentDiagnostics(mainFileName) {
+		if diagnostic.ReportsDeprecated() {
+			assert.Equal(t, diagnostic.Category(), diagnostics.CategorySuggestion)
+			deprecated = append(deprecated, diagnostic.Pos())
+		}
+	}
+	assert.DeepEqual(t, deprecated, []int{
+		markerPosition(t, content, "foo();"),
+		markerPosition(t, content, "over(\"a\")"),
+	})
+}
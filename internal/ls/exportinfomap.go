@@ -0,0 +1,546 @@
+package ls
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/compiler/module"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/parser"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// ExportKind is the form of the import that brings an export into scope.
+type ExportKind int
+
+const (
+	ExportKindNamed ExportKind = iota
+	ExportKindDefault
+	ExportKindExportEquals
+)
+
+// ExportInfo is a name that a module exports and other files can import.
+type ExportInfo struct {
+	// Name is the name the export is imported as. For default and `export =` exports, it is the
+	// local name of the exported declaration or a name derived from the module name.
+	Name string
+	Kind ExportKind
+	// Meaning is the union of the value, type and namespace meanings of the exported symbol.
+	Meaning ast.SymbolFlags
+	// ModuleFileName is the file of the exporting module. It is empty for ambient modules.
+	ModuleFileName string
+	// ModuleName is the name of the exporting ambient module.
+	ModuleName string
+	// PackageName is the name of the package the exporting module belongs to, if any.
+	PackageName string
+	// IsFromPackageJSON reports whether the export comes from a package.json dependency that is
+	// not part of the program.
+	IsFromPackageJSON bool
+}
+
+// ExportInfoMap indexes the exports of the modules a project can import: the modules of its
+// program, the ambient modules it declares, and the dependencies listed in the package.json files
+// of the project directory and its ancestors.
+//
+// The map is updated lazily for the program of each request. Only the module files the program
+// does not share with the previous one, and the modules that re-export others, are collected
+// again. The exports of the package.json dependencies are collected again only when the host reports
+// a change to the package.json files, the node_modules directories or the files the exports were
+// collected from, through OnWatchedFileChanged.
+type ExportInfoMap struct {
+	mu      sync.Mutex
+	program *compiler.Program
+
+	files          map[tspath.Path]*moduleExportInfo
+	ambientModules []*ExportInfo
+	packages       map[string]*packageExportInfo
+	packageInputs  *packageInputs
+	exportsByName  map[string][]*ExportInfo
+}
+
+type moduleExportInfo struct {
+	file *ast.SourceFile
+	// hasReExports is set if the exports of the file depend on other modules.
+	hasReExports bool
+	exports      []*ExportInfo
+}
+
+type packageExportInfo struct {
+	entryFileName string
+	exports       []*ExportInfo
+}
+
+// packageInputs are the files and directories the exports of the package.json dependencies were
+// collected from.
+type packageInputs struct {
+	options *core.CompilerOptions
+	// files are the package.json files and the declaration files.
+	files core.Set[tspath.Path]
+	// directories are the node_modules directories the dependencies are installed in.
+	directories []tspath.Path
+	// stale is set when one of the files or a file in one of the directories changes.
+	stale bool
+}
+
+// changed reports whether the exports of the package.json dependencies must be collected again for program.
+func (p *packageInputs) changed(program *compiler.Program) bool {
+	return p == nil || p.stale || p.options != program.Options()
+}
+
+func (p *packageInputs) onWatchedFileChanged(path tspath.Path) {
+	if p.files.Has(path) {
+		p.stale = true
+		return
+	}
+	for _, directory := range p.directories {
+		if tspath.ContainsPath(string(directory), string(path), tspath.ComparePathsOptions{UseCaseSensitiveFileNames: true}) {
+			p.stale = true
+			return
+		}
+	}
+}
+
+func NewExportInfoMap() *ExportInfoMap {
+	return &ExportInfoMap{}
+}
+
+// get returns the exports named name that can be imported into the files of program.
+func (m *ExportInfoMap) get(program *compiler.Program, name string) []*ExportInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.update(program)
+	return m.exportsByName[name]
+}
+
+// OnWatchedFileChanged is called when the file at path is created, changed or deleted, so that the
+// exports of the package.json dependencies are collected again if they were collected from it.
+func (m *ExportInfoMap) OnWatchedFileChanged(path tspath.Path) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.packageInputs != nil {
+		m.packageInputs.onWatchedFileChanged(path)
+	}
+}
+
+func (m *ExportInfoMap) update(program *compiler.Program) {
+	packagesChanged := m.packageInputs.changed(program)
+	if m.program == program && !packagesChanged {
+		return
+	}
+	m.program = program
+	c := program.GetTypeChecker()
+	if packagesChanged {
+		m.packages, m.packageInputs = getPackageExports(program)
+	}
+
+	var moduleFiles []*ast.SourceFile
+	filesChanged := false
+	for _, file := range program.GetSourceFiles() {
+		if !ast.IsExternalModule(file) || file.Symbol == nil || program.IsSourceFileDefaultLibrary(file) {
+			continue
+		}
+		moduleFiles = append(moduleFiles, file)
+		if existing := m.files[file.Path()]; existing == nil || existing.file != file {
+			filesChanged = true
+		}
+	}
+	filesChanged = filesChanged || len(moduleFiles) != len(m.files)
+	if !filesChanged && m.exportsByName != nil {
+		// The program only differs in files that are not modules, which may declare ambient modules.
+		m.ambientModules = getAmbientModuleExports(c)
+		m.indexExports()
+		return
+	}
+
+	files := make(map[tspath.Path]*moduleExportInfo, len(moduleFiles))
+	for _, file := range moduleFiles {
+		existing := m.files[file.Path()]
+		if existing != nil && existing.file == file && !existing.hasReExports {
+			files[file.Path()] = existing
+			continue
+		}
+		files[file.Path()] = &moduleExportInfo{
+			file:         file,
+			hasReExports: hasReExports(file),
+			exports:      getModuleExports(c, file.Symbol, file.FileName(), ""),
+		}
+	}
+	m.files = files
+	m.ambientModules = getAmbientModuleExports(c)
+	m.indexExports()
+}
+
+func (m *ExportInfoMap) indexExports() {
+	m.exportsByName = make(map[string][]*ExportInfo)
+	add := func(exports []*ExportInfo) {
+		for _, info := range exports {
+			m.exportsByName[info.Name] = append(m.exportsByName[info.Name], info)
+		}
+	}
+	for _, file := range m.program.GetSourceFiles() {
+		if info := m.files[file.Path()]; info != nil {
+			add(info.exports)
+		}
+	}
+	add(m.ambientModules)
+	for _, name := range slices.Sorted(func(yield func(string) bool) {
+		for name := range m.packages {
+			if !yield(name) {
+				return
+			}
+		}
+	}) {
+		// a dependency whose entry file is part of the program is imported like the other modules of the program
+		if info := m.packages[name]; m.program.GetSourceFile(info.entryFileName) == nil {
+			add(info.exports)
+		}
+	}
+}
+
+// getModuleExports returns the importable exports of a module symbol.
+func getModuleExports(c *checker.Checker, moduleSymbol *ast.Symbol, moduleFileName string, moduleName string) []*ExportInfo {
+	packageName := getPackageNameFromFileName(moduleFileName)
+	var exports []*ExportInfo
+	for name, symbol := range c.GetExportsOfModule(moduleSymbol) {
+		kind := ExportKindNamed
+		switch name {
+		case ast.InternalSymbolNameDefault:
+			kind = ExportKindDefault
+			if name = getDefaultExportName(symbol); name == "" {
+				name = moduleSpecifierToValidIdentifier(core.OrElse(moduleName, moduleFileName))
+			}
+		case ast.InternalSymbolNameExportEquals:
+			kind = ExportKindExportEquals
+			name = moduleSpecifierToValidIdentifier(core.OrElse(moduleName, core.OrElse(packageName, moduleFileName)))
+		default:
+			if strings.HasPrefix(name, ast.InternalSymbolNamePrefix) {
+				continue
+			}
+		}
+		if name == "" {
+			continue
+		}
+		if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+			if resolved, ok := c.ResolveAlias(symbol); ok {
+				symbol = resolved
+			}
+		}
+		exports = append(exports, &ExportInfo{
+			Name:           name,
+			Kind:           kind,
+			Meaning:        symbol.Flags & (ast.SymbolFlagsValue | ast.SymbolFlagsType | ast.SymbolFlagsNamespace),
+			ModuleFileName: moduleFileName,
+			ModuleName:     moduleName,
+			PackageName:    packageName,
+		})
+	}
+	slices.SortFunc(exports, compareExportInfo)
+	return exports
+}
+
+func compareExportInfo(a, b *ExportInfo) int {
+	if c := strings.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+	return int(a.Kind) - int(b.Kind)
+}
+
+func getAmbientModuleExports(c *checker.Checker) []*ExportInfo {
+	var exports []*ExportInfo
+	for _, moduleSymbol := range c.GetAmbientModules() {
+		moduleName := strings.Trim(moduleSymbol.Name, "\"")
+		if strings.Contains(moduleName, "*") {
+			continue
+		}
+		exports = append(exports, getModuleExports(c, moduleSymbol, "", moduleName)...)
+	}
+	return exports
+}
+
+// hasReExports reports whether a module re-exports other modules or names it imports from them, in
+// which case its exports must be collected again whenever another file changes.
+func hasReExports(file *ast.SourceFile) bool {
+	var imported core.Set[string]
+	for _, statement := range file.Statements.Nodes {
+		switch {
+		case ast.IsImportDeclaration(statement):
+			clause := statement.AsImportDeclaration().ImportClause
+			if clause == nil {
+				continue
+			}
+			if name := clause.Name(); name != nil {
+				imported.Add(name.Text())
+			}
+			switch bindings := clause.AsImportClause().NamedBindings; {
+			case bindings == nil:
+			case ast.IsNamespaceImport(bindings):
+				imported.Add(bindings.Name().Text())
+			default:
+				for _, specifier := range bindings.AsNamedImports().Elements.Nodes {
+					imported.Add(specifier.Name().Text())
+				}
+			}
+		case ast.IsImportEqualsDeclaration(statement):
+			if ast.HasSyntacticModifier(statement, ast.ModifierFlagsExport) {
+				return true
+			}
+			imported.Add(statement.Name().Text())
+		}
+	}
+	return core.Some(file.Statements.Nodes, func(statement *ast.Node) bool {
+		if ast.IsExportAssignment(statement) {
+			return true
+		}
+		if !ast.IsExportDeclaration(statement) {
+			return false
+		}
+		declaration := statement.AsExportDeclaration()
+		if declaration.ModuleSpecifier != nil {
+			return true
+		}
+		return declaration.ExportClause != nil && ast.IsNamedExports(declaration.ExportClause) &&
+			core.Some(declaration.ExportClause.AsNamedExports().Elements.Nodes, func(specifier *ast.Node) bool {
+				return imported.Has(specifier.PropertyNameOrName().Text())
+			})
+	})
+}
+
+// getPackageExports collects the exports of the package.json dependencies, along with the inputs
+// they were collected from. Since the files of the dependencies are usually not part of the program,
+// the exports are collected from the syntax of their declaration files.
+func getPackageExports(program *compiler.Program) (map[string]*packageExportInfo, *packageInputs) {
+	host := program.Host()
+	options := program.Options()
+	resolver := module.NewResolver(host, options)
+	toPath := func(fileName string) tspath.Path {
+		return tspath.ToPath(fileName, host.GetCurrentDirectory(), host.FS().UseCaseSensitiveFileNames())
+	}
+	inputs := &packageInputs{options: options}
+	for directory := host.GetCurrentDirectory(); ; {
+		inputs.files.Add(toPath(tspath.CombinePaths(directory, "package.json")))
+		inputs.directories = append(inputs.directories, toPath(tspath.CombinePaths(directory, "node_modules")))
+		parent := tspath.GetDirectoryPath(directory)
+		if parent == directory {
+			break
+		}
+		directory = parent
+	}
+	packages := make(map[string]*packageExportInfo)
+	for _, dependency := range getPackageJSONDependencies(resolver, host.GetCurrentDirectory()) {
+		resolved := resolver.ResolveModuleName(dependency, tspath.CombinePaths(host.GetCurrentDirectory(), "index.ts"), core.ModuleKindESNext, nil)
+		if lookupLocations := resolver.GetLookupLocationsForResolvedModule(resolved); lookupLocations != nil {
+			for _, fileName := range lookupLocations.AffectingLocations {
+				inputs.files.Add(toPath(fileName))
+			}
+		}
+		if !resolved.IsResolved() || !tspath.IsDeclarationFileName(resolved.ResolvedFileName) {
+			continue
+		}
+		collector := &declarationExportCollector{
+			host:        host,
+			resolver:    resolver,
+			packageName: dependency,
+			entry:       resolved.ResolvedFileName,
+			seen:        make(map[string]bool),
+		}
+		collector.collect(resolved.ResolvedFileName)
+		for fileName := range collector.seen {
+			inputs.files.Add(toPath(fileName))
+		}
+		slices.SortFunc(collector.exports, compareExportInfo)
+		packages[dependency] = &packageExportInfo{
+			entryFileName: resolved.ResolvedFileName,
+			exports:       collector.exports,
+		}
+	}
+	return packages, inputs
+}
+
+// getPackageJSONDependencies returns the dependencies listed in the package.json files of
+// directory and its ancestors.
+func getPackageJSONDependencies(resolver *module.Resolver, directory string) []string {
+	var dependencies core.Set[string]
+	for directory != "" {
+		scope := resolver.GetPackageScopeForPath(directory)
+		if !scope.Exists() {
+			break
+		}
+		fields := scope.Contents.DependencyFields
+		for _, field := range []map[string]string{fields.Dependencies.Value, fields.DevDependencies.Value, fields.PeerDependencies.Value, fields.OptionalDependencies.Value} {
+			for name := range field {
+				dependencies.Add(name)
+			}
+		}
+		parent := tspath.GetDirectoryPath(scope.PackageDirectory)
+		if parent == scope.PackageDirectory {
+			break
+		}
+		directory = parent
+	}
+	return slices.Sorted(func(yield func(string) bool) {
+		for name := range dependencies.Keys() {
+			if !yield(name) {
+				return
+			}
+		}
+	})
+}
+
+// declarationExportCollector collects the exports of a declaration file from its syntax, following
+// `export *` declarations into the files they re-export.
+type declarationExportCollector struct {
+	host        compiler.CompilerHost
+	resolver    *module.Resolver
+	packageName string
+	entry       string
+	seen        map[string]bool
+	exports     []*ExportInfo
+}
+
+func (d *declarationExportCollector) collect(fileName string) {
+	if d.seen[fileName] {
+		return
+	}
+	d.seen[fileName] = true
+	text, ok := d.host.FS().ReadFile(fileName)
+	if !ok {
+		return
+	}
+	path := tspath.ToPath(fileName, d.host.GetCurrentDirectory(), d.host.FS().UseCaseSensitiveFileNames())
+	file := parser.ParseSourceFile(fileName, path, text, core.ScriptTargetLatest, scanner.JSDocParsingModeParseNone)
+	for _, statement := range file.Statements.Nodes {
+		switch statement.Kind {
+		case ast.KindExportDeclaration:
+			d.collectExportDeclaration(fileName, statement.AsExportDeclaration())
+		case ast.KindExportAssignment:
+			if statement.AsExportAssignment().IsExportEquals {
+				d.add(moduleSpecifierToValidIdentifier(d.packageName), ExportKindExportEquals, ast.SymbolFlagsValue|ast.SymbolFlagsType|ast.SymbolFlagsNamespace)
+			} else if expression := statement.Expression(); ast.IsIdentifier(expression) {
+				d.add(expression.Text(), ExportKindDefault, ast.SymbolFlagsValue|ast.SymbolFlagsType|ast.SymbolFlagsNamespace)
+			}
+		default:
+			if !ast.HasSyntacticModifier(statement, ast.ModifierFlagsExport) {
+				continue
+			}
+			kind := ExportKindNamed
+			if ast.HasSyntacticModifier(statement, ast.ModifierFlagsDefault) {
+				kind = ExportKindDefault
+			}
+			if ast.IsVariableStatement(statement) {
+				for _, declaration := range statement.AsVariableStatement().DeclarationList.AsVariableDeclarationList().Declarations.Nodes {
+					if name := declaration.Name(); ast.IsIdentifier(name) {
+						d.add(name.Text(), kind, ast.SymbolFlagsValue)
+					}
+				}
+			} else if name := statement.Name(); name != nil && ast.IsIdentifier(name) {
+				d.add(name.Text(), kind, getDeclarationMeaning(statement))
+			}
+		}
+	}
+}
+
+func (d *declarationExportCollector) collectExportDeclaration(fileName string, declaration *ast.ExportDeclaration) {
+	meaning := ast.SymbolFlagsValue | ast.SymbolFlagsType | ast.SymbolFlagsNamespace
+	if declaration.IsTypeOnly {
+		meaning = ast.SymbolFlagsType
+	}
+	switch {
+	case declaration.ExportClause == nil:
+		if declaration.ModuleSpecifier != nil && ast.IsStringLiteral(declaration.ModuleSpecifier) && tspath.PathIsRelative(declaration.ModuleSpecifier.Text()) {
+			resolved := d.resolver.ResolveModuleName(declaration.ModuleSpecifier.Text(), fileName, core.ModuleKindESNext, nil)
+			if resolved.IsResolved() {
+				d.collect(resolved.ResolvedFileName)
+			}
+		}
+	case ast.IsNamespaceExport(declaration.ExportClause):
+		d.add(declaration.ExportClause.Name().Text(), ExportKindNamed, ast.SymbolFlagsNamespace|ast.SymbolFlagsValue)
+	default:
+		for _, specifier := range declaration.ExportClause.AsNamedExports().Elements.Nodes {
+			specifierMeaning := meaning
+			if specifier.AsExportSpecifier().IsTypeOnly {
+				specifierMeaning = ast.SymbolFlagsType
+			}
+			if name := specifier.Name(); ast.IsIdentifier(name) {
+				if name.Text() == ast.InternalSymbolNameDefault {
+					if propertyName := specifier.PropertyName(); propertyName != nil && ast.IsIdentifier(propertyName) {
+						d.add(propertyName.Text(), ExportKindDefault, specifierMeaning)
+					}
+					continue
+				}
+				d.add(name.Text(), ExportKindNamed, specifierMeaning)
+			}
+		}
+	}
+}
+
+func (d *declarationExportCollector) add(name string, kind ExportKind, meaning ast.SymbolFlags) {
+	d.exports = append(d.exports, &ExportInfo{
+		Name:              name,
+		Kind:              kind,
+		Meaning:           meaning,
+		ModuleFileName:    d.entry,
+		PackageName:       d.packageName,
+		IsFromPackageJSON: true,
+	})
+}
+
+func getDeclarationMeaning(declaration *ast.Node) ast.SymbolFlags {
+	switch declaration.Kind {
+	case ast.KindFunctionDeclaration:
+		return ast.SymbolFlagsValue
+	case ast.KindClassDeclaration, ast.KindEnumDeclaration:
+		return ast.SymbolFlagsValue | ast.SymbolFlagsType
+	case ast.KindInterfaceDeclaration, ast.KindTypeAliasDeclaration:
+		return ast.SymbolFlagsType
+	case ast.KindModuleDeclaration:
+		return ast.SymbolFlagsValue | ast.SymbolFlagsNamespace
+	}
+	return ast.SymbolFlagsValue | ast.SymbolFlagsType | ast.SymbolFlagsNamespace
+}
+
+// getPackageNameFromFileName returns the name of the package a file in node_modules belongs to.
+// Files of @types packages belong to the package they declare types for.
+func getPackageNameFromFileName(fileName string) string {
+	packageDirectory := module.ParseNodeModuleFromPath(fileName, false /*isFolder*/)
+	if packageDirectory == "" {
+		return ""
+	}
+	packageName := packageDirectory[strings.LastIndex(packageDirectory, "/node_modules/")+len("/node_modules/"):]
+	if typesPackageName, ok := strings.CutPrefix(packageName, "@types/"); ok {
+		return module.UnmangleScopedPackageName(typesPackageName)
+	}
+	return packageName
+}
+
+// moduleSpecifierToValidIdentifier derives an identifier from a module name or file name, as in
+// "lodash.debounce" to "lodashDebounce".
+func moduleSpecifierToValidIdentifier(moduleSpecifier string) string {
+	baseName := tspath.RemoveFileExtension(tspath.GetBaseFileName(moduleSpecifier))
+	if baseName == "index" {
+		baseName = tspath.GetBaseFileName(tspath.GetDirectoryPath(moduleSpecifier))
+	}
+	var sb strings.Builder
+	upper := false
+	for _, ch := range baseName {
+		if !scanner.IsIdentifierText(string(ch), core.ScriptTargetLatest) && !(sb.Len() != 0 && unicode.IsDigit(ch)) {
+			upper = sb.Len() != 0
+			continue
+		}
+		if upper {
+			ch = unicode.ToUpper(ch)
+			upper = false
+		}
+		sb.WriteRune(ch)
+	}
+	name := sb.String()
+	if name == "" || scanner.GetIdentifierToken(name) != ast.KindIdentifier {
+		return "_" + name
+	}
+	return name
+}
@@ -0,0 +1,214 @@
+package ls_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"gotest.tools/v3/assert"
+)
+
+func TestAutoImportModuleSpecifiers(t *testing.T) {
+	t.Parallel()
+
+	paths := collections.NewOrderedMapWithSizeHint[string, []string](1)
+	paths.Set("@lib/*", []string{"./lib/*"})
+
+	packageWithExports := map[string]string{
+		"/home/src/package.json":                       `{ "dependencies": { "pkg": "^1.0.0" } }`,
+		"/home/src/node_modules/pkg/package.json":      `{ "name": "pkg", "exports": { ".": { "types": "./dist/index.d.ts", "default": "./dist/index.js" } } }`,
+		"/home/src/node_modules/pkg/dist/index.d.ts":   `export * from "./helpers";`,
+		"/home/src/node_modules/pkg/dist/helpers.d.ts": "export declare function help(): void;\n",
+	}
+
+	tests := []struct {
+		name        string
+		files       map[string]string
+		options     *core.CompilerOptions
+		preferences *ls.UserPreferences
+		identifier  string
+		expected    string
+	}{
+		{
+			name: "paths",
+			files: map[string]string{
+				"/home/src/lib/deep/util.ts": "export function util() {}\n",
+			},
+			options:    &core.CompilerOptions{Paths: paths, PathsBasePath: "/home/src"},
+			identifier: "util",
+			expected:   `import { util } from "@lib/deep/util";`,
+		},
+		{
+			name: "paths with relative preference",
+			files: map[string]string{
+				"/home/src/lib/deep/util.ts": "export function util() {}\n",
+			},
+			options:     &core.CompilerOptions{Paths: paths, PathsBasePath: "/home/src"},
+			preferences: &ls.UserPreferences{ImportModuleSpecifierPreference: ls.ImportModuleSpecifierPreferenceRelative},
+			identifier:  "util",
+			expected:    `import { util } from "./lib/deep/util";`,
+		},
+		{
+			name: "baseUrl",
+			files: map[string]string{
+				"/home/src/lib/util.ts": "export function util() {}\n",
+			},
+			options:     &core.CompilerOptions{BaseUrl: "/home/src"},
+			preferences: &ls.UserPreferences{ImportModuleSpecifierPreference: ls.ImportModuleSpecifierPreferenceNonRelative},
+			identifier:  "util",
+			expected:    `import { util } from "lib/util";`,
+		},
+		{
+			name: "index file",
+			files: map[string]string{
+				"/home/src/lib/index.ts": "export function util() {}\n",
+			},
+			identifier: "util",
+			expected:   `import { util } from "./lib";`,
+		},
+		{
+			name: "js ending preference",
+			files: map[string]string{
+				"/home/src/lib/index.ts": "export function util() {}\n",
+			},
+			preferences: &ls.UserPreferences{ImportModuleSpecifierEnding: ls.ImportModuleSpecifierEndingJs},
+			identifier:  "util",
+			expected:    `import { util } from "./lib/index.js";`,
+		},
+		{
+			name: "ECMAScript module under nodenext",
+			files: map[string]string{
+				"/home/src/package.json": `{ "type": "module" }`,
+				"/home/src/lib/index.ts": "export function util() {}\n",
+			},
+			options:    &core.CompilerOptions{ModuleKind: core.ModuleKindNodeNext, ModuleResolution: core.ModuleResolutionKindNodeNext},
+			identifier: "util",
+			expected:   `import { util } from "./lib/index.js";`,
+		},
+		{
+			name: "ambient module",
+			files: map[string]string{
+				"/home/src/types.d.ts": "declare module \"ambient-lib\" {\n    export function ambient(): void;\n}\n",
+			},
+			identifier: "ambient",
+			expected:   `import { ambient } from "ambient-lib";`,
+		},
+		{
+			name:       "package.json dependency with exports",
+			files:      packageWithExports,
+			options:    &core.CompilerOptions{ModuleKind: core.ModuleKindESNext, ModuleResolution: core.ModuleResolutionKindBundler},
+			identifier: "help",
+			expected:   `import { help } from "pkg";`,
+		},
+		{
+			name: "export assignment of a package.json dependency",
+			files: map[string]string{
+				"/home/src/package.json":                   `{ "devDependencies": { "legacy": "^1.0.0" } }`,
+				"/home/src/node_modules/legacy/index.d.ts": "declare function legacy(): void;\nexport = legacy;\n",
+			},
+			options:    &core.CompilerOptions{ModuleKind: core.ModuleKindESNext, ModuleResolution: core.ModuleResolutionKindBundler, ESModuleInterop: core.TSTrue},
+			identifier: "legacy",
+			expected:   `import legacy from "legacy";`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			content := "export const value = " + test.identifier + ";\n"
+			files := map[string]string{mainFileName: content}
+			for fileName, text := range test.files {
+				files[fileName] = text
+			}
+			options := core.Coalesce(test.options, &core.CompilerOptions{})
+			options.Strict = core.TSTrue
+			options.Target = core.ScriptTargetESNext
+			service := setup(t, files, options)
+			diagnostic := findDiagnostic(t, service, markerPosition(t, content, test.identifier), 2304)
+			fixes := service.GetCodeFixesAtPosition(mainFileName, diagnostic, 2304, test.preferences)
+			assert.Assert(t, len(fixes) == 1, "expected one fix, got %v", fixes)
+			assert.Equal(t, applyCodeAction(t, content, &fixes[0].CodeAction), test.expected+"\n\n"+content)
+		})
+	}
+
+	t.Run("package.json auto-imports turned off", func(t *testing.T) {
+		t.Parallel()
+		content := "export const value = help;\n"
+		files := map[string]string{mainFileName: content}
+		for fileName, text := range packageWithExports {
+			files[fileName] = text
+		}
+		service := setup(t, files, &core.CompilerOptions{ModuleKind: core.ModuleKindESNext, ModuleResolution: core.ModuleResolutionKindBundler})
+		diagnostic := findDiagnostic(t, service, markerPosition(t, content, "help"), 2304)
+		fixes := service.GetCodeFixesAtPosition(mainFileName, diagnostic, 2304, &ls.UserPreferences{IncludePackageJsonAutoImports: ls.IncludePackageJsonAutoImportsOff})
+		assert.Equal(t, len(fixes), 0)
+	})
+}
+
+func TestExportInfoMapUpdate(t *testing.T) {
+	t.Parallel()
+
+	// getFixes returns the imports the fixes for the missing name at the start of the main file add.
+	getFixes := func(t *testing.T, host *testHost, content string, name string) []string {
+		t.Helper()
+		service := ls.NewLanguageService(host)
+		diagnostic := findDiagnostic(t, service, markerPosition(t, content, "= "+name)+len("= "), 2304)
+		var imports []string
+		for _, fix := range service.GetCodeFixesAtPosition(mainFileName, diagnostic, 2304, nil) {
+			imports = append(imports, strings.TrimSuffix(applyCodeAction(t, content, &fix.CodeAction), "\n\n"+content))
+		}
+		return imports
+	}
+
+	t.Run("import that is re-exported", func(t *testing.T) {
+		t.Parallel()
+		if !bundled.Embedded {
+			t.Skip("bundled files are not embedded")
+		}
+		content := "export const value = x;\n"
+		host := newTestHost(map[string]string{
+			mainFileName:     content,
+			"/home/src/a.ts": "export interface x {}\n",
+			"/home/src/b.ts": "import { x } from \"./a\";\nexport { x };\n",
+		}, &core.CompilerOptions{Strict: core.TSTrue, Target: core.ScriptTargetESNext})
+		assert.DeepEqual(t, getFixes(t, host, content, "x"), []string(nil))
+
+		host.writeFile("/home/src/a.ts", "export const x = 1;\n")
+		assert.DeepEqual(t, getFixes(t, host, content, "x"), []string{`import { x } from "./a";`, `import { x } from "./b";`})
+	})
+
+	t.Run("package.json dependency that is installed", func(t *testing.T) {
+		t.Parallel()
+		if !bundled.Embedded {
+			t.Skip("bundled files are not embedded")
+		}
+		content := "export const value = help;\n"
+		host := newTestHost(map[string]string{
+			mainFileName:             content,
+			"/home/src/package.json": `{ "dependencies": {} }`,
+		}, &core.CompilerOptions{Strict: core.TSTrue, Target: core.ScriptTargetESNext, ModuleKind: core.ModuleKindESNext, ModuleResolution: core.ModuleResolutionKindBundler})
+		assert.DeepEqual(t, getFixes(t, host, content, "help"), []string(nil))
+
+		// The dependency is listed before it is installed.
+		host.writeFile("/home/src/package.json", `{ "dependencies": { "pkg": "^1.0.0" } }`)
+		host.writeFile(mainFileName, content)
+		assert.DeepEqual(t, getFixes(t, host, content, "help"), []string(nil))
+
+		host.writeFile("/home/src/node_modules/pkg/package.json", `{ "name": "pkg", "types": "./index.d.ts" }`)
+		host.writeFile("/home/src/node_modules/pkg/index.d.ts", "export declare function other(): void;\n")
+		host.writeFile(mainFileName, content)
+		assert.DeepEqual(t, getFixes(t, host, content, "help"), []string(nil))
+
+		// A change to a declaration file of the dependency is only seen once it is reported.
+		_ = host.FS().WriteFile("/home/src/node_modules/pkg/index.d.ts", "export declare function help(): void;\n", false)
+		host.writeFile(mainFileName, content)
+		assert.DeepEqual(t, getFixes(t, host, content, "help"), []string(nil))
+
+		host.writeFile("/home/src/node_modules/pkg/index.d.ts", "export declare function help(): void;\n")
+		host.writeFile(mainFileName, content)
+		assert.DeepEqual(t, getFixes(t, host, content, "help"), []string{`import { help } from "pkg";`})
+	})
+}
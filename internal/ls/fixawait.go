@@ -0,0 +1,97 @@
+package ls
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	addMissingAwaitFixName = "addMissingAwait"
+	addMissingAwaitFixID   = "addMissingAwait"
+)
+
+var addMissingAwaitFixProvider = &codeFixProvider{
+	errorCodes: errorCodes(
+		diagnostics.An_arithmetic_operand_must_be_of_type_any_number_bigint_or_an_enum_type,
+		diagnostics.The_left_hand_side_of_an_arithmetic_operation_must_be_of_type_any_number_bigint_or_an_enum_type,
+		diagnostics.The_right_hand_side_of_an_arithmetic_operation_must_be_of_type_any_number_bigint_or_an_enum_type,
+		diagnostics.Operator_0_cannot_be_applied_to_type_1,
+		diagnostics.Operator_0_cannot_be_applied_to_types_1_and_2,
+		diagnostics.This_comparison_appears_to_be_unintentional_because_the_types_0_and_1_have_no_overlap,
+		diagnostics.This_condition_will_always_return_true_since_this_0_is_always_defined,
+		diagnostics.Type_0_is_not_an_array_type,
+		diagnostics.Type_0_is_not_an_array_type_or_a_string_type,
+		diagnostics.Type_0_must_have_a_Symbol_iterator_method_that_returns_an_iterator,
+		diagnostics.Type_0_must_have_a_Symbol_asyncIterator_method_that_returns_an_async_iterator,
+		diagnostics.Argument_of_type_0_is_not_assignable_to_parameter_of_type_1,
+		diagnostics.Type_0_is_not_assignable_to_type_1,
+		diagnostics.Property_0_does_not_exist_on_type_1,
+		diagnostics.This_expression_is_not_callable,
+		diagnostics.This_expression_is_not_constructable,
+	),
+	fixIDs:         []string{addMissingAwaitFixID},
+	getCodeActions: getAddMissingAwaitCodeActions,
+}
+
+// getAddMissingAwaitCodeActions offers to await the expression the checker flagged with
+// "Did you forget to use 'await'?".
+func getAddMissingAwaitCodeActions(ctx *codeFixContext) []CodeFixAction {
+	if ctx.diagnostic == nil {
+		return nil
+	}
+	for _, related := range ctx.diagnostic.RelatedInformation() {
+		if related.Code() != diagnostics.Did_you_forget_to_use_await.Code() || related.File() != ctx.file {
+			continue
+		}
+		expression := findExpressionWithSpan(ctx.file, related.Loc())
+		if expression != nil && ast.IsPropertyAccessExpression(expression.Parent) && expression.Parent.Name() == expression {
+			// The checker flags the missing property; the object is what needs to be awaited.
+			expression = expression.Parent.Expression()
+		}
+		if expression == nil || !isInsideAwaitableBody(ctx.file, expression) {
+			continue
+		}
+		tracker := newChangeTracker(ctx.newLine)
+		start := scanner.GetTokenPosOfNode(expression, ctx.file, false /*includeJsDoc*/)
+		if needsParenthesesForAwait(expression) {
+			tracker.insertText(ctx.file, start, "(await ")
+			tracker.insertText(ctx.file, expression.End(), ")")
+		} else {
+			tracker.insertText(ctx.file, start, "await ")
+		}
+		return []CodeFixAction{newCodeFixAction(addMissingAwaitFixName, tracker.getChanges(), diagnostics.Add_await.Format(), addMissingAwaitFixID, diagnostics.Fix_all_expressions_possibly_missing_await)}
+	}
+	return nil
+}
+
+// findExpressionWithSpan returns the outermost expression that exactly spans textRange.
+func findExpressionWithSpan(file *ast.SourceFile, textRange core.TextRange) *ast.Node {
+	var result *ast.Node
+	for node := astnav.GetTokenAtPosition(file, textRange.Pos()); node != nil && node.End() <= textRange.End(); node = node.Parent {
+		if (ast.IsExpressionNode(node) || ast.IsIdentifier(node)) && node.End() == textRange.End() && scanner.GetTokenPosOfNode(node, file, false /*includeJsDoc*/) == textRange.Pos() {
+			result = node
+		}
+	}
+	return result
+}
+
+func isInsideAwaitableBody(file *ast.SourceFile, node *ast.Node) bool {
+	if node.Flags&ast.NodeFlagsAwaitContext != 0 {
+		return true
+	}
+	return ast.IsInTopLevelContext(node) && ast.IsExternalModule(file)
+}
+
+// needsParenthesesForAwait reports whether an awaited expression must be parenthesized to keep
+// binding to its parent, as in `(await p).value`.
+func needsParenthesesForAwait(node *ast.Node) bool {
+	parent := node.Parent
+	switch parent.Kind {
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression, ast.KindCallExpression, ast.KindNewExpression, ast.KindTaggedTemplateExpression:
+		return parent.Expression() == node
+	}
+	return false
+}
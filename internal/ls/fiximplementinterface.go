@@ -0,0 +1,146 @@
+package ls
+
+import (
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	implementInterfaceFixName = "fixClassIncorrectlyImplementsInterface"
+	implementInterfaceFixID   = "fixClassIncorrectlyImplementsInterface"
+	indentationUnit           = "    "
+)
+
+var implementInterfaceFixProvider = &codeFixProvider{
+	errorCodes: errorCodes(
+		diagnostics.Class_0_incorrectly_implements_interface_1,
+		diagnostics.Class_0_incorrectly_implements_class_1_Did_you_mean_to_extend_1_and_inherit_its_members_as_a_subclass,
+	),
+	fixIDs:         []string{implementInterfaceFixID},
+	getCodeActions: getImplementInterfaceCodeActions,
+}
+
+// getImplementInterfaceCodeActions offers, for each implemented type, to add the members the class is missing.
+func getImplementInterfaceCodeActions(ctx *codeFixContext) []CodeFixAction {
+	classDeclaration := ast.FindAncestor(astnav.GetTokenAtPosition(ctx.file, ctx.span.Pos()), ast.IsClassLike)
+	if classDeclaration == nil || classDeclaration.Symbol() == nil {
+		return nil
+	}
+	classType := ctx.checker.GetDeclaredTypeOfSymbol(classDeclaration.Symbol())
+	var actions []CodeFixAction
+	for _, implementedTypeNode := range ast.GetImplementsHeritageClauseElements(classDeclaration) {
+		implementedType := ctx.checker.GetTypeAtLocation(implementedTypeNode)
+		var missing []*ast.Symbol
+		for _, property := range ctx.checker.GetPropertiesOfType(implementedType) {
+			if ctx.checker.GetPropertyOfType(classType, property.Name) == nil && !isPrivateOrProtectedMember(property) {
+				missing = append(missing, property)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		tracker := newChangeTracker(ctx.newLine)
+		insertClassMembers(tracker, ctx.file, classDeclaration, getMissingMembersText(ctx.checker, missing))
+		typeText := ctx.file.Text[scanner.GetTokenPosOfNode(implementedTypeNode, ctx.file, false /*includeJsDoc*/):implementedTypeNode.End()]
+		actions = append(actions, newCodeFixAction(implementInterfaceFixName, tracker.getChanges(), diagnostics.Implement_interface_0.Format(typeText), implementInterfaceFixID, diagnostics.Implement_all_unimplemented_interfaces))
+	}
+	return actions
+}
+
+func isPrivateOrProtectedMember(symbol *ast.Symbol) bool {
+	return symbol.ValueDeclaration != nil && ast.GetCombinedModifierFlags(symbol.ValueDeclaration)&(ast.ModifierFlagsPrivate|ast.ModifierFlagsProtected) != 0 ||
+		symbol.ValueDeclaration != nil && symbol.ValueDeclaration.Name() != nil && ast.IsPrivateIdentifier(symbol.ValueDeclaration.Name())
+}
+
+// getMissingMembersText returns the declarations of the given members, one line per element.
+// Methods get a body that throws, with the body lines indented by one unit.
+func getMissingMembersText(c *checker.Checker, members []*ast.Symbol) []string {
+	var lines []string
+	for _, member := range members {
+		name := getPropertyNameText(member)
+		if name == "" {
+			continue
+		}
+		memberType := c.GetTypeOfSymbol(member)
+		if member.Flags&ast.SymbolFlagsMethod != 0 {
+			signatures := c.GetSignaturesOfType(memberType, checker.SignatureKindCall)
+			if len(signatures) == 1 {
+				lines = append(lines, name+c.SignatureToString(signatures[0])+" {")
+			} else {
+				for _, signature := range signatures {
+					lines = append(lines, name+c.SignatureToString(signature)+";")
+				}
+				lines = append(lines, name+"(...args: any[]): any {")
+			}
+			lines = append(lines, indentationUnit+`throw new Error("`+diagnostics.Method_not_implemented.Format()+`");`, "}")
+			continue
+		}
+		var declaration strings.Builder
+		if member.ValueDeclaration != nil && ast.GetCombinedModifierFlags(member.ValueDeclaration)&ast.ModifierFlagsReadonly != 0 {
+			declaration.WriteString("readonly ")
+		}
+		declaration.WriteString(name)
+		if member.Flags&ast.SymbolFlagsOptional != 0 {
+			declaration.WriteString("?")
+		}
+		declaration.WriteString(": ")
+		declaration.WriteString(c.TypeToString(memberType))
+		declaration.WriteString(";")
+		lines = append(lines, declaration.String())
+	}
+	return lines
+}
+
+// getPropertyNameText returns the name of a member declaration for the given property, or "" if the
+// property has no name that can be written.
+func getPropertyNameText(symbol *ast.Symbol) string {
+	name := symbol.Name
+	if strings.HasPrefix(name, ast.InternalSymbolNamePrefix) {
+		// Well-known and unique symbols are named by the computed property name they were declared with,
+		// such as [Symbol.iterator].
+		if symbol.ValueDeclaration != nil {
+			if nameNode := ast.GetNameOfDeclaration(symbol.ValueDeclaration); nameNode != nil && ast.IsComputedPropertyName(nameNode) {
+				return scanner.GetTextOfNode(nameNode)
+			}
+		}
+		return ""
+	}
+	if scanner.IsIdentifierText(name, core.ScriptTargetLatest) {
+		return name
+	}
+	return "\"" + printer.EscapeString(name, '"') + "\""
+}
+
+// insertClassMembers inserts the given lines after the last member of a class, indented one unit
+// deeper than the class itself.
+func insertClassMembers(tracker *changeTracker, file *ast.SourceFile, classDeclaration *ast.Node, lines []string) {
+	text := file.Text
+	classIndentation := getIndentationOfPosition(text, scanner.GetTokenPosOfNode(classDeclaration, file, false /*includeJsDoc*/))
+	memberIndentation := classIndentation + indentationUnit
+
+	members := classDeclaration.MemberList()
+	pos := members.Pos()
+	if len(members.Nodes) != 0 {
+		pos = members.Nodes[len(members.Nodes)-1].End()
+	}
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(tracker.newLine)
+		sb.WriteString(memberIndentation)
+		sb.WriteString(line)
+	}
+	closeBrace := scanner.SkipTrivia(text, members.End())
+	if !strings.ContainsAny(text[pos:closeBrace], "\r\n") {
+		// The class body ends on the same line, so move the closing brace to its own line.
+		sb.WriteString(tracker.newLine)
+		sb.WriteString(classIndentation)
+	}
+	tracker.insertText(file, pos, sb.String())
+}
@@ -0,0 +1,272 @@
+package ls
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	importFixName = "import"
+	importFixID   = "fixMissingImport"
+)
+
+var importFixProvider = &codeFixProvider{
+	errorCodes: errorCodes(
+		diagnostics.Cannot_find_name_0,
+		diagnostics.Cannot_find_name_0_Did_you_mean_1,
+		diagnostics.Cannot_find_namespace_0,
+	),
+	fixIDs:            []string{importFixID},
+	getCodeActions:    getImportCodeActions,
+	getAllCodeActions: getAllImportCodeActions,
+}
+
+// importCandidate is an export of another module that can satisfy an unresolved name.
+type importCandidate struct {
+	moduleSpecifier string
+	kind            ExportKind
+}
+
+func getImportCodeActions(ctx *codeFixContext) []CodeFixAction {
+	node := astnav.GetTokenAtPosition(ctx.file, ctx.span.Pos())
+	if !ast.IsIdentifier(node) {
+		return nil
+	}
+	var actions []CodeFixAction
+	for _, candidate := range getImportCandidates(ctx.program, ctx.exportInfoMap, ctx.preferences, ctx.file, node) {
+		adder := newImportAdder(ctx.program, ctx.file, ctx.newLine)
+		adder.add(node.Text(), candidate)
+		tracker := newChangeTracker(ctx.newLine)
+		adder.writeTo(tracker)
+		description := diagnostics.Add_import_from_0
+		if candidate.kind != ExportKindExportEquals && findExistingImport(ctx.file, candidate.moduleSpecifier) != nil {
+			description = diagnostics.Update_import_from_0
+		}
+		actions = append(actions, newCodeFixAction(importFixName, tracker.getChanges(), description.Format(candidate.moduleSpecifier), importFixID, diagnostics.Add_all_missing_imports))
+	}
+	return actions
+}
+
+func getAllImportCodeActions(ctx *codeFixAllContext) []FileTextChanges {
+	adder := newImportAdder(ctx.program, ctx.file, ctx.newLine)
+	for _, diagnostic := range ctx.diagnostics {
+		node := astnav.GetTokenAtPosition(ctx.file, diagnostic.Pos())
+		if !ast.IsIdentifier(node) {
+			continue
+		}
+		if candidates := getImportCandidates(ctx.program, ctx.exportInfoMap, ctx.preferences, ctx.file, node); len(candidates) != 0 {
+			adder.add(node.Text(), candidates[0])
+		}
+	}
+	tracker := newChangeTracker(ctx.newLine)
+	adder.writeTo(tracker)
+	return tracker.getChanges()
+}
+
+// getImportCandidates finds the modules that export a symbol named like node with a meaning that
+// fits node's location, along with the module specifiers with which file can import them.
+func getImportCandidates(program *compiler.Program, exportInfoMap *ExportInfoMap, preferences *UserPreferences, file *ast.SourceFile, node *ast.Node) []importCandidate {
+	meaning := ast.SymbolFlagsValue
+	if ast.IsPartOfTypeNode(node) {
+		meaning = ast.SymbolFlagsType | ast.SymbolFlagsNamespace
+	}
+	generator := newModuleSpecifierGenerator(program, file, preferences)
+	var candidates []importCandidate
+	// Exports of the same package are reachable through several of its files; keep the shortest specifier.
+	candidateIndexByPackage := make(map[string]int)
+	for _, info := range exportInfoMap.get(program, node.Text()) {
+		if info.Meaning&meaning == 0 || info.ModuleFileName == file.FileName() ||
+			info.IsFromPackageJSON && preferences.IncludePackageJsonAutoImports == IncludePackageJsonAutoImportsOff {
+			continue
+		}
+		moduleSpecifier := generator.getModuleSpecifier(info)
+		if moduleSpecifier == "" || core.Some(candidates, func(candidate importCandidate) bool { return candidate.moduleSpecifier == moduleSpecifier }) {
+			continue
+		}
+		candidate := importCandidate{moduleSpecifier: moduleSpecifier, kind: info.Kind}
+		if info.PackageName != "" {
+			if index, ok := candidateIndexByPackage[info.PackageName]; ok {
+				if len(moduleSpecifier) < len(candidates[index].moduleSpecifier) {
+					candidates[index] = candidate
+				}
+				continue
+			}
+			candidateIndexByPackage[info.PackageName] = len(candidates)
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// getDefaultExportName returns the local name of a default export, if it has one.
+func getDefaultExportName(symbol *ast.Symbol) string {
+	for _, declaration := range symbol.Declarations {
+		var name *ast.Node
+		if ast.IsExportAssignment(declaration) {
+			name = declaration.Expression()
+		} else {
+			name = ast.GetNameOfDeclaration(declaration)
+		}
+		if name != nil && ast.IsIdentifier(name) {
+			return name.Text()
+		}
+	}
+	return ""
+}
+
+// importAdder collects the imports to add to a file, grouped by module specifier.
+type importAdder struct {
+	file       *ast.SourceFile
+	newLine    string
+	specifiers []string
+	additions  map[string]*importAddition
+	// useDefaultForExportEquals is set if `export =` modules can be imported with a default import.
+	useDefaultForExportEquals bool
+}
+
+type importAddition struct {
+	defaultName   string
+	namespaceName string
+	namedImports  []string
+}
+
+func newImportAdder(program *compiler.Program, file *ast.SourceFile, newLine string) *importAdder {
+	return &importAdder{
+		file:                      file,
+		newLine:                   newLine,
+		additions:                 make(map[string]*importAddition),
+		useDefaultForExportEquals: program.Options().GetAllowSyntheticDefaultImports(),
+	}
+}
+
+func (a *importAdder) add(name string, candidate importCandidate) {
+	addition := a.additions[candidate.moduleSpecifier]
+	if addition == nil {
+		addition = &importAddition{}
+		a.additions[candidate.moduleSpecifier] = addition
+		a.specifiers = append(a.specifiers, candidate.moduleSpecifier)
+	}
+	switch {
+	case candidate.kind == ExportKindDefault, candidate.kind == ExportKindExportEquals && a.useDefaultForExportEquals:
+		addition.defaultName = name
+	case candidate.kind == ExportKindExportEquals:
+		addition.namespaceName = name
+	case !core.Some(addition.namedImports, func(existing string) bool { return existing == name }):
+		addition.namedImports = append(addition.namedImports, name)
+	}
+}
+
+func (a *importAdder) writeTo(tracker *changeTracker) {
+	var newImports []string
+	for _, specifier := range a.specifiers {
+		addition := a.additions[specifier]
+		if existing := findExistingImport(a.file, specifier); existing != nil && a.tryUpdateImport(tracker, existing, addition) {
+			continue
+		}
+		newImports = append(newImports, a.getImportText(specifier, addition))
+	}
+	if len(newImports) == 0 {
+		return
+	}
+
+	var lastImport *ast.Node
+	for _, statement := range a.file.Statements.Nodes {
+		if ast.IsImportDeclaration(statement) {
+			lastImport = statement
+		}
+	}
+	switch {
+	case lastImport != nil:
+		tracker.insertText(a.file, lastImport.End(), a.newLine+strings.Join(newImports, a.newLine))
+	case len(a.file.Statements.Nodes) != 0:
+		pos := scanner.GetTokenPosOfNode(a.file.Statements.Nodes[0], a.file, true /*includeJsDoc*/)
+		tracker.insertText(a.file, pos, strings.Join(newImports, a.newLine)+a.newLine+a.newLine)
+	default:
+		tracker.insertText(a.file, 0, strings.Join(newImports, a.newLine)+a.newLine)
+	}
+}
+
+// tryUpdateImport adds the names to an existing import declaration, if its form allows it.
+func (a *importAdder) tryUpdateImport(tracker *changeTracker, importDeclaration *ast.Node, addition *importAddition) bool {
+	importClause := importDeclaration.AsImportDeclaration().ImportClause.AsImportClause()
+	namedBindings := importClause.NamedBindings
+	if addition.namespaceName != "" || addition.defaultName != "" && importClause.Name() != nil ||
+		len(addition.namedImports) != 0 && namedBindings != nil && ast.IsNamespaceImport(namedBindings) {
+		return false
+	}
+
+	if addition.defaultName != "" {
+		pos := scanner.GetTokenPosOfNode(namedBindings, a.file, false /*includeJsDoc*/)
+		tracker.insertText(a.file, pos, addition.defaultName+", ")
+	}
+	if len(addition.namedImports) != 0 {
+		names := strings.Join(addition.namedImports, ", ")
+		switch {
+		case namedBindings == nil:
+			tracker.insertText(a.file, importClause.Name().End(), ", { "+names+" }")
+		case len(namedBindings.AsNamedImports().Elements.Nodes) == 0:
+			tracker.replaceNode(a.file, namedBindings, "{ "+names+" }")
+		default:
+			elements := namedBindings.AsNamedImports().Elements.Nodes
+			tracker.insertText(a.file, elements[len(elements)-1].End(), ", "+names)
+		}
+	}
+	return true
+}
+
+func (a *importAdder) getImportText(specifier string, addition *importAddition) string {
+	quote := getQuoteOfFile(a.file)
+	var imports []string
+	if addition.namespaceName != "" {
+		// A namespace import cannot be combined with named imports.
+		imports = append(imports, fmt.Sprintf("import * as %s from %s%s%s;", addition.namespaceName, quote, specifier, quote))
+		if addition.defaultName == "" && len(addition.namedImports) == 0 {
+			return imports[0]
+		}
+	}
+	var clause []string
+	if addition.defaultName != "" {
+		clause = append(clause, addition.defaultName)
+	}
+	if len(addition.namedImports) != 0 {
+		clause = append(clause, "{ "+strings.Join(addition.namedImports, ", ")+" }")
+	}
+	imports = append(imports, fmt.Sprintf("import %s from %s%s%s;", strings.Join(clause, ", "), quote, specifier, quote))
+	return strings.Join(imports, a.newLine)
+}
+
+// findExistingImport returns the first non-type-only import declaration of the file with the given module specifier.
+func findExistingImport(file *ast.SourceFile, moduleSpecifier string) *ast.Node {
+	for _, statement := range file.Statements.Nodes {
+		if !ast.IsImportDeclaration(statement) {
+			continue
+		}
+		decl := statement.AsImportDeclaration()
+		if decl.ImportClause != nil && !decl.ImportClause.AsImportClause().IsTypeOnly &&
+			ast.IsStringLiteral(decl.ModuleSpecifier) && decl.ModuleSpecifier.Text() == moduleSpecifier {
+			return statement
+		}
+	}
+	return nil
+}
+
+// getQuoteOfFile returns the quote character used by the first import of the file, defaulting to a double quote.
+func getQuoteOfFile(file *ast.SourceFile) string {
+	for _, statement := range file.Statements.Nodes {
+		if ast.IsImportDeclaration(statement) {
+			moduleSpecifier := statement.AsImportDeclaration().ModuleSpecifier
+			if file.Text[scanner.GetTokenPosOfNode(moduleSpecifier, file, false /*includeJsDoc*/)] == '\'' {
+				return "'"
+			}
+			break
+		}
+	}
+	return "\""
+}
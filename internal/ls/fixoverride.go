@@ -0,0 +1,99 @@
+package ls
+
+import (
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	overrideFixName          = "fixOverrideModifier"
+	addOverrideModifierID    = "fixAddOverrideModifier"
+	removeOverrideModifierID = "fixRemoveOverrideModifier"
+)
+
+var (
+	addOverrideErrorCodes = errorCodes(
+		diagnostics.This_member_must_have_an_override_modifier_because_it_overrides_a_member_in_the_base_class_0,
+		diagnostics.This_member_must_have_an_override_modifier_because_it_overrides_an_abstract_method_that_is_declared_in_the_base_class_0,
+		diagnostics.This_parameter_property_must_have_an_override_modifier_because_it_overrides_a_member_in_base_class_0,
+	)
+	removeOverrideErrorCodes = errorCodes(
+		diagnostics.This_member_cannot_have_an_override_modifier_because_its_containing_class_0_does_not_extend_another_class,
+		diagnostics.This_member_cannot_have_an_override_modifier_because_it_is_not_declared_in_the_base_class_0,
+		diagnostics.This_member_cannot_have_an_override_modifier_because_it_is_not_declared_in_the_base_class_0_Did_you_mean_1,
+	)
+)
+
+var overrideFixProvider = &codeFixProvider{
+	errorCodes:     core.Concatenate(addOverrideErrorCodes, removeOverrideErrorCodes),
+	fixIDs:         []string{addOverrideModifierID, removeOverrideModifierID},
+	getCodeActions: getOverrideCodeActions,
+}
+
+func getOverrideCodeActions(ctx *codeFixContext) []CodeFixAction {
+	member := ast.FindAncestor(astnav.GetTokenAtPosition(ctx.file, ctx.span.Pos()), func(node *ast.Node) bool {
+		return ast.IsClassElement(node) || ast.IsParameterPropertyDeclaration(node, node.Parent)
+	})
+	if member == nil {
+		return nil
+	}
+	tracker := newChangeTracker(ctx.newLine)
+	if slices.Contains(addOverrideErrorCodes, ctx.errorCode) {
+		addOverrideModifier(tracker, ctx.file, member)
+		return []CodeFixAction{newCodeFixAction(overrideFixName, tracker.getChanges(), diagnostics.Add_override_modifier.Format(), addOverrideModifierID, diagnostics.Add_all_missing_override_modifiers)}
+	}
+	if !removeOverrideModifier(tracker, ctx.file, member) {
+		return nil
+	}
+	return []CodeFixAction{newCodeFixAction(overrideFixName, tracker.getChanges(), diagnostics.Remove_override_modifier.Format(), removeOverrideModifierID, diagnostics.Remove_all_unnecessary_override_modifiers)}
+}
+
+// addOverrideModifier inserts `override` after any accessibility and static modifiers, which must precede it.
+func addOverrideModifier(tracker *changeTracker, file *ast.SourceFile, member *ast.Node) {
+	var modifiers []*ast.Node
+	if modifierList := member.Modifiers(); modifierList != nil {
+		modifiers = modifierList.Nodes
+	}
+	var preceding *ast.Node
+	for _, modifier := range modifiers {
+		switch modifier.Kind {
+		case ast.KindPublicKeyword, ast.KindPrivateKeyword, ast.KindProtectedKeyword, ast.KindStaticKeyword:
+			preceding = modifier
+		}
+	}
+	if preceding != nil {
+		tracker.insertText(file, preceding.End(), " override")
+		return
+	}
+	for _, modifier := range modifiers {
+		if !ast.IsDecorator(modifier) {
+			tracker.insertText(file, scanner.GetTokenPosOfNode(modifier, file, false /*includeJsDoc*/), "override ")
+			return
+		}
+	}
+	pos := scanner.GetTokenPosOfNode(member, file, false /*includeJsDoc*/)
+	if len(modifiers) != 0 {
+		// Only decorators precede the member.
+		pos = scanner.SkipTrivia(file.Text, modifiers[len(modifiers)-1].End())
+	}
+	tracker.insertText(file, pos, "override ")
+}
+
+func removeOverrideModifier(tracker *changeTracker, file *ast.SourceFile, member *ast.Node) bool {
+	if member.Modifiers() == nil {
+		return false
+	}
+	for _, modifier := range member.Modifiers().Nodes {
+		if modifier.Kind == ast.KindOverrideKeyword {
+			start := scanner.GetTokenPosOfNode(modifier, file, false /*includeJsDoc*/)
+			tracker.deleteRange(file, core.NewTextRange(start, scanner.SkipTrivia(file.Text, modifier.End())))
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package ls
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	spellingFixName = "spelling"
+	spellingFixID   = "fixSpelling"
+)
+
+var spellingFixProvider = &codeFixProvider{
+	errorCodes: errorCodes(
+		diagnostics.Property_0_does_not_exist_on_type_1_Did_you_mean_2,
+		diagnostics.Cannot_find_name_0_Did_you_mean_1,
+	),
+	fixIDs:         []string{spellingFixID},
+	getCodeActions: getSpellingCodeActions,
+}
+
+// getSpellingCodeActions replaces a misspelled name with the checker's spelling suggestion.
+func getSpellingCodeActions(ctx *codeFixContext) []CodeFixAction {
+	node := astnav.GetTokenAtPosition(ctx.file, ctx.span.Pos())
+	if !ast.IsIdentifier(node) && !ast.IsPrivateIdentifier(node) {
+		return nil
+	}
+	parent := node.Parent
+	isPropertyName := ast.IsPropertyAccessExpression(parent) && parent.Name() == node
+
+	var suggestion *ast.Symbol
+	if isPropertyName {
+		containingType := ctx.checker.GetTypeAtLocation(parent.Expression())
+		if parent.AsPropertyAccessExpression().QuestionDotToken != nil {
+			containingType = ctx.checker.GetNonNullableType(containingType)
+		}
+		suggestion = ctx.checker.GetSuggestedSymbolForNonexistentProperty(node, containingType)
+	} else {
+		meaning := ast.SymbolFlagsValue
+		if ast.IsPartOfTypeNode(node) {
+			meaning = ast.SymbolFlagsType | ast.SymbolFlagsNamespace
+		}
+		suggestion = ctx.checker.GetSuggestedSymbolForNonexistentSymbol(node, node.Text(), meaning)
+	}
+	if suggestion == nil {
+		return nil
+	}
+
+	suggestedName := ast.SymbolName(suggestion)
+	tracker := newChangeTracker(ctx.newLine)
+	if isPropertyName && !ast.IsPrivateIdentifier(node) && !scanner.IsIdentifierText(suggestedName, core.ScriptTargetLatest) {
+		// The suggested property cannot be accessed with a dot, as in `x["not-an-identifier"]`.
+		dotPos := scanner.SkipTrivia(ctx.file.Text, parent.Expression().End())
+		tracker.replaceRange(ctx.file, core.NewTextRange(dotPos, node.End()), "[\""+printer.EscapeString(suggestedName, '"')+"\"]")
+	} else {
+		tracker.replaceNode(ctx.file, node, suggestedName)
+	}
+	return []CodeFixAction{newCodeFixAction(spellingFixName, tracker.getChanges(), diagnostics.Change_spelling_to_0.Format(suggestedName), spellingFixID, diagnostics.Fix_all_detected_spelling_errors)}
+}
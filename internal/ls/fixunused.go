@@ -0,0 +1,196 @@
+package ls
+
+import (
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	unusedIdentifierFixName  = "unusedIdentifier"
+	unusedIdentifierDeleteID = "unusedIdentifier_delete"
+)
+
+var unusedIdentifierFixProvider = &codeFixProvider{
+	errorCodes: errorCodes(
+		diagnostics.X_0_is_declared_but_its_value_is_never_read,
+		diagnostics.X_0_is_declared_but_never_used,
+		diagnostics.Property_0_is_declared_but_its_value_is_never_read,
+		diagnostics.All_imports_in_import_declaration_are_unused,
+		diagnostics.All_destructured_elements_are_unused,
+		diagnostics.All_variables_are_unused,
+		diagnostics.All_type_parameters_are_unused,
+	),
+	fixIDs:         []string{unusedIdentifierDeleteID},
+	getCodeActions: getUnusedIdentifierCodeActions,
+}
+
+// getUnusedIdentifierCodeActions offers to delete the declaration an unused diagnostic was reported on.
+func getUnusedIdentifierCodeActions(ctx *codeFixContext) []CodeFixAction {
+	tracker := newChangeTracker(ctx.newLine)
+	var description string
+	if typeParameters := getUnusedTypeParameterList(ctx.file, ctx.span); typeParameters != nil {
+		deleteTypeParameterList(tracker, ctx.file, typeParameters)
+		if len(typeParameters.Nodes) == 1 {
+			description = diagnostics.Remove_unused_declaration_for_Colon_0.Format(typeParameters.Nodes[0].Name().Text())
+		} else {
+			description = diagnostics.Remove_type_parameters.Format()
+		}
+	} else if node := getDiagnosticNode(ctx.file, ctx.span); node != nil {
+		description = deleteUnusedDeclaration(tracker, ctx.file, node)
+	}
+	if description == "" {
+		return nil
+	}
+	return []CodeFixAction{newCodeFixAction(unusedIdentifierFixName, tracker.getChanges(), description, unusedIdentifierDeleteID, diagnostics.Delete_all_unused_declarations)}
+}
+
+// deleteUnusedDeclaration deletes the unused declaration node and returns the description of the
+// change, or the empty string if the node cannot be deleted.
+func deleteUnusedDeclaration(tracker *changeTracker, file *ast.SourceFile, node *ast.Node) string {
+	switch node.Kind {
+	case ast.KindImportDeclaration:
+		tracker.deleteNode(file, node)
+		return diagnostics.Remove_import_from_0.Format(node.AsImportDeclaration().ModuleSpecifier.Text())
+	case ast.KindImportClause, ast.KindNamespaceImport, ast.KindImportSpecifier:
+		deleteImportBinding(tracker, file, node)
+	case ast.KindVariableDeclarationList:
+		if !ast.IsVariableStatement(node.Parent) {
+			return ""
+		}
+		tracker.deleteNode(file, node.Parent)
+		declarations := node.AsVariableDeclarationList().Declarations.Nodes
+		if len(declarations) != 1 {
+			return diagnostics.Remove_variable_statement.Format()
+		}
+		node = declarations[0]
+	case ast.KindVariableDeclaration:
+		if ast.IsForInOrOfStatement(node.Parent.Parent) {
+			return ""
+		}
+		if !deleteVariableDeclaration(tracker, file, node) {
+			return ""
+		}
+	case ast.KindParameter:
+		if !deleteParameter(tracker, file, node) {
+			return ""
+		}
+	case ast.KindBindingElement:
+		elements := node.Parent.AsBindingPattern().Elements.Nodes
+		tracker.deleteListElement(file, elements, slices.Index(elements, node))
+	case ast.KindObjectBindingPattern, ast.KindArrayBindingPattern:
+		if !deleteBindingPattern(tracker, file, node) {
+			return ""
+		}
+		return diagnostics.Remove_unused_destructuring_declaration.Format()
+	case ast.KindTypeParameter:
+		typeParameters := node.Parent.TypeParameterList()
+		if len(typeParameters.Nodes) == 1 {
+			deleteTypeParameterList(tracker, file, typeParameters)
+		} else {
+			tracker.deleteListElement(file, typeParameters.Nodes, slices.Index(typeParameters.Nodes, node))
+		}
+	case ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindTypeAliasDeclaration,
+		ast.KindEnumDeclaration, ast.KindModuleDeclaration, ast.KindPropertyDeclaration, ast.KindMethodDeclaration,
+		ast.KindGetAccessor, ast.KindSetAccessor:
+		tracker.deleteNode(file, node)
+	default:
+		return ""
+	}
+	name := ast.GetNameOfDeclaration(node)
+	if name == nil {
+		return ""
+	}
+	return diagnostics.Remove_unused_declaration_for_Colon_0.Format(scanner.GetTextOfNode(name))
+}
+
+// deleteImportBinding deletes one binding of an import declaration that has other, used bindings.
+func deleteImportBinding(tracker *changeTracker, file *ast.SourceFile, node *ast.Node) {
+	switch node.Kind {
+	case ast.KindImportClause:
+		// Delete the default binding, as in `import a, { b } from "c"`.
+		namedBindings := node.AsImportClause().NamedBindings
+		start := scanner.GetTokenPosOfNode(node, file, false /*includeJsDoc*/)
+		tracker.deleteRange(file, core.NewTextRange(start, scanner.GetTokenPosOfNode(namedBindings, file, false /*includeJsDoc*/)))
+	case ast.KindNamespaceImport:
+		// Delete the namespace binding, as in `import a, * as b from "c"`.
+		tracker.deleteRange(file, core.NewTextRange(node.Parent.Name().End(), node.End()))
+	case ast.KindImportSpecifier:
+		namedImports := node.Parent
+		elements := namedImports.AsNamedImports().Elements.Nodes
+		if len(elements) == 1 {
+			// Delete the named bindings, as in `import a, { b } from "c"`.
+			tracker.deleteRange(file, core.NewTextRange(namedImports.Parent.Name().End(), namedImports.End()))
+		} else {
+			tracker.deleteListElement(file, elements, slices.Index(elements, node))
+		}
+	}
+}
+
+func deleteVariableDeclaration(tracker *changeTracker, file *ast.SourceFile, node *ast.Node) bool {
+	declarationList := node.Parent
+	declarations := declarationList.AsVariableDeclarationList().Declarations.Nodes
+	if len(declarations) != 1 {
+		tracker.deleteListElement(file, declarations, slices.Index(declarations, node))
+		return true
+	}
+	if !ast.IsVariableStatement(declarationList.Parent) {
+		return false
+	}
+	tracker.deleteNode(file, declarationList.Parent)
+	return true
+}
+
+// deleteParameter deletes a trailing parameter. Other parameters are kept so that the positions of
+// the parameters after them do not change.
+func deleteParameter(tracker *changeTracker, file *ast.SourceFile, node *ast.Node) bool {
+	function := node.Parent
+	parameters := function.Parameters()
+	index := slices.Index(parameters, node)
+	if index != len(parameters)-1 || ast.IsParameterPropertyDeclaration(node, function) || ast.IsSetAccessorDeclaration(function) {
+		return false
+	}
+	tracker.deleteListElement(file, parameters, index)
+	return true
+}
+
+func deleteBindingPattern(tracker *changeTracker, file *ast.SourceFile, pattern *ast.Node) bool {
+	parent := pattern.Parent
+	switch parent.Kind {
+	case ast.KindVariableDeclaration:
+		return deleteVariableDeclaration(tracker, file, parent)
+	case ast.KindBindingElement:
+		elements := parent.Parent.AsBindingPattern().Elements.Nodes
+		tracker.deleteListElement(file, elements, slices.Index(elements, parent))
+		return true
+	case ast.KindParameter:
+		return deleteParameter(tracker, file, parent)
+	}
+	return false
+}
+
+// getUnusedTypeParameterList returns the type parameter list if span covers it entirely, which is
+// how the checker reports that all type parameters of a declaration are unused.
+func getUnusedTypeParameterList(file *ast.SourceFile, span core.TextRange) *ast.NodeList {
+	if span.Pos() >= len(file.Text) || file.Text[span.Pos()] != '<' {
+		return nil
+	}
+	typeParameter := ast.FindAncestor(astnav.GetTokenAtPosition(file, scanner.SkipTrivia(file.Text, span.Pos()+len("<"))), ast.IsTypeParameterDeclaration)
+	if typeParameter == nil {
+		return nil
+	}
+	return typeParameter.Parent.TypeParameterList()
+}
+
+// deleteTypeParameterList deletes a type parameter list along with its angle brackets.
+func deleteTypeParameterList(tracker *changeTracker, file *ast.SourceFile, typeParameters *ast.NodeList) {
+	end := scanner.SkipTrivia(file.Text, typeParameters.End())
+	if end < len(file.Text) && file.Text[end] == '>' {
+		end++
+	}
+	tracker.deleteRange(file, core.NewTextRange(typeParameters.Pos()-len("<"), end))
+}
@@ -0,0 +1,174 @@
+package ls
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+type FoldingRangeKind int
+
+const (
+	FoldingRangeKindNone FoldingRangeKind = iota
+	FoldingRangeKindComment
+	FoldingRangeKindImports
+	FoldingRangeKindRegion
+)
+
+type FoldingRange struct {
+	// Range spans the folded text, including the delimiters of blocks, such as braces.
+	Range core.TextRange
+	Kind  FoldingRangeKind
+	// CollapsedText is the text an editor may show for a collapsed region: the name of a `// #region`.
+	CollapsedText string
+}
+
+var regionMarkerRegExp = regexp.MustCompile(`^//\s*#(end)?region\b\s*(.*?)\s*$`)
+
+// ProvideFoldingRanges returns the ranges of a file that span multiple lines and can be folded: blocks and
+// other bracketed constructs, groups of imports, comments, `// #region` markers, JSX elements and template
+// literals. The ranges are sorted by position.
+func (l *LanguageService) ProvideFoldingRanges(fileName string) []FoldingRange {
+	_, file := l.getProgramAndFile(fileName)
+	c := &foldingRangeCollector{file: file, seenComments: make(map[int]bool)}
+	c.addImportRanges(file.Statements.Nodes)
+	c.visit(file.AsNode())
+	endOfStatements := 0
+	if len(file.Statements.Nodes) != 0 {
+		endOfStatements = file.Statements.End()
+	}
+	c.collectComments(endOfStatements)
+	c.addCommentRanges()
+	slices.SortStableFunc(c.ranges, func(a, b FoldingRange) int { return a.Range.Pos() - b.Range.Pos() })
+	return c.ranges
+}
+
+type foldingRangeCollector struct {
+	file         *ast.SourceFile
+	ranges       []FoldingRange
+	comments     []ast.CommentRange
+	seenComments map[int]bool
+}
+
+func (c *foldingRangeCollector) visit(node *ast.Node) bool {
+	c.collectComments(node.Pos())
+	switch node.Kind {
+	case ast.KindBlock, ast.KindModuleBlock, ast.KindCaseBlock, ast.KindObjectLiteralExpression,
+		ast.KindArrayLiteralExpression, ast.KindObjectBindingPattern, ast.KindArrayBindingPattern,
+		ast.KindNamedImports, ast.KindNamedExports, ast.KindTypeLiteral, ast.KindMappedType, ast.KindTupleType,
+		ast.KindCaseClause, ast.KindDefaultClause,
+		ast.KindJsxElement, ast.KindJsxFragment, ast.KindJsxSelfClosingElement,
+		ast.KindTemplateExpression, ast.KindNoSubstitutionTemplateLiteral:
+		c.addRange(c.tokenPos(node), node.End(), FoldingRangeKindNone)
+	case ast.KindClassDeclaration, ast.KindClassExpression, ast.KindInterfaceDeclaration, ast.KindEnumDeclaration:
+		// Fold from the open brace, which directly precedes the members.
+		c.addRange(node.MemberList().Pos()-1, node.End(), FoldingRangeKindNone)
+	case ast.KindCallExpression, ast.KindNewExpression:
+		// Fold the arguments, from the open parenthesis that directly precedes them.
+		if arguments := node.ArgumentList(); arguments != nil && len(arguments.Nodes) != 0 {
+			c.addRange(arguments.Pos()-1, node.End(), FoldingRangeKindNone)
+		}
+	case ast.KindModuleDeclaration:
+		if body := node.Body(); body != nil && ast.IsModuleBlock(body) {
+			c.addImportRanges(body.AsModuleBlock().Statements.Nodes)
+		}
+	}
+	return node.ForEachChild(c.visit)
+}
+
+// addImportRanges adds a range for each run of consecutive import declarations in a list of statements.
+func (c *foldingRangeCollector) addImportRanges(statements []*ast.Node) {
+	for i := 0; i < len(statements); {
+		if !isImportForFolding(statements[i]) {
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(statements) && isImportForFolding(statements[j]) {
+			j++
+		}
+		if j-i > 1 {
+			c.addRange(c.tokenPos(statements[i]), statements[j-1].End(), FoldingRangeKindImports)
+		}
+		i = j
+	}
+}
+
+func isImportForFolding(statement *ast.Node) bool {
+	return ast.IsImportDeclaration(statement) || ast.IsImportEqualsDeclaration(statement)
+}
+
+func (c *foldingRangeCollector) tokenPos(node *ast.Node) int {
+	return scanner.GetTokenPosOfNode(node, c.file, false /*includeJsDoc*/)
+}
+
+func (c *foldingRangeCollector) addRange(pos int, end int, kind FoldingRangeKind) {
+	c.addRangeWithText(pos, end, kind, "")
+}
+
+func (c *foldingRangeCollector) addRangeWithText(pos int, end int, kind FoldingRangeKind, collapsedText string) {
+	lineMap := c.file.LineMap()
+	if scanner.ComputeLineOfPosition(lineMap, pos) == scanner.ComputeLineOfPosition(lineMap, end) {
+		return
+	}
+	c.ranges = append(c.ranges, FoldingRange{Range: core.NewTextRange(pos, end), Kind: kind, CollapsedText: collapsedText})
+}
+
+func (c *foldingRangeCollector) collectComments(pos int) {
+	for comment := range scanner.GetLeadingCommentRanges(nil, c.file.Text, pos) {
+		if !c.seenComments[comment.Pos()] {
+			c.seenComments[comment.Pos()] = true
+			c.comments = append(c.comments, comment)
+		}
+	}
+}
+
+// addCommentRanges adds ranges for multi-line comments, for runs of single-line comments on consecutive
+// lines, and for the regions between `// #region` and `// #endregion` comments.
+func (c *foldingRangeCollector) addCommentRanges() {
+	slices.SortFunc(c.comments, func(a, b ast.CommentRange) int { return a.Pos() - b.Pos() })
+	var run []ast.CommentRange
+	flushRun := func() {
+		if len(run) > 1 {
+			c.addRange(run[0].Pos(), run[len(run)-1].End(), FoldingRangeKindComment)
+		}
+		run = nil
+	}
+	var regionStarts []ast.CommentRange
+	var regionNames []string
+	for _, comment := range c.comments {
+		text := c.file.Text[comment.Pos():comment.End()]
+		if comment.Kind == ast.KindMultiLineCommentTrivia {
+			flushRun()
+			c.addRange(comment.Pos(), comment.End(), FoldingRangeKindComment)
+			continue
+		}
+		if match := regionMarkerRegExp.FindStringSubmatch(text); match != nil {
+			flushRun()
+			if match[1] == "" {
+				regionStarts = append(regionStarts, comment)
+				regionNames = append(regionNames, core.OrElse(match[2], "#region"))
+			} else if len(regionStarts) != 0 {
+				start := regionStarts[len(regionStarts)-1]
+				c.addRangeWithText(start.Pos(), comment.End(), FoldingRangeKindRegion, regionNames[len(regionNames)-1])
+				regionStarts = regionStarts[:len(regionStarts)-1]
+				regionNames = regionNames[:len(regionNames)-1]
+			}
+			continue
+		}
+		if len(run) != 0 && !isOnNextLine(c.file.Text[run[len(run)-1].End():comment.Pos()]) {
+			flushRun()
+		}
+		run = append(run, comment)
+	}
+	flushRun()
+}
+
+// isOnNextLine reports whether the text between two comments is whitespace containing one line break.
+func isOnNextLine(text string) bool {
+	return strings.TrimSpace(text) == "" && len(core.ComputeLineStarts(text)) == 2
+}
@@ -0,0 +1,130 @@
+package ls_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"gotest.tools/v3/assert"
+)
+
+func TestProvideFoldingRanges(t *testing.T) {
+	t.Parallel()
+
+	const content = `import { a } from "./a";
+import { b } from "./b";
+
+// #region helpers
+// first line
+// second line
+function f() {
+    /* multi
+       line */
+    return [
+        a,
+        b,
+    ];
+}
+// #endregion
+
+const s = ` + "`" + `x
+y` + "`" + `;
+`
+	files := map[string]string{
+		mainFileName:     content,
+		"/home/src/a.ts": "export const a = 1;\n",
+		"/home/src/b.ts": "export const b = 1;\n",
+	}
+	service := setup(t, files, nil)
+
+	type foldingRange struct {
+		Text          string
+		Kind          ls.FoldingRangeKind
+		CollapsedText string
+	}
+	ranges := core.Map(service.ProvideFoldingRanges(mainFileName), func(r ls.FoldingRange) foldingRange {
+		return foldingRange{Text: content[r.Range.Pos():r.Range.End()], Kind: r.Kind, CollapsedText: r.CollapsedText}
+	})
+	assert.DeepEqual(t, ranges, []foldingRange{
+		{Text: "import { a } from \"./a\";\nimport { b } from \"./b\";", Kind: ls.FoldingRangeKindImports},
+		{Text: content[markerPosition(t, content, "// #region"):markerPosition(t, content, "\n\nconst s")], Kind: ls.FoldingRangeKindRegion, CollapsedText: "helpers"},
+		{Text: "// first line\n// second line", Kind: ls.FoldingRangeKindComment},
+		{Text: content[markerPosition(t, content, "{\n    /*"):markerPosition(t, content, "\n// #endregion")], Kind: ls.FoldingRangeKindNone},
+		{Text: "/* multi\n       line */", Kind: ls.FoldingRangeKindComment},
+		{Text: "[\n        a,\n        b,\n    ]", Kind: ls.FoldingRangeKindNone},
+		{Text: "`x\ny`", Kind: ls.FoldingRangeKindNone},
+	})
+}
+
+func TestProvideFoldingRangesJsx(t *testing.T) {
+	t.Parallel()
+
+	const fileName = "/home/src/app.tsx"
+	const content = `const element = <div>
+    <span />
+</div>;
+`
+	service := setup(t, map[string]string{fileName: content}, nil)
+	ranges := service.ProvideFoldingRanges(fileName)
+	assert.Equal(t, len(ranges), 1)
+	assert.Equal(t, content[ranges[0].Range.Pos():ranges[0].Range.End()], "<div>\n    <span />\n</div>")
+}
+
+func TestProvideSelectionRange(t *testing.T) {
+	t.Parallel()
+
+	const content = `function f(x: number, y: string) {
+    return g("text", x);
+}
+`
+	service := setup(t, map[string]string{mainFileName: content}, nil)
+
+	var texts []string
+	for r := service.ProvideSelectionRange(mainFileName, markerPosition(t, content, "ext")); r != nil; r = r.Parent {
+		texts = append(texts, content[r.Range.Pos():r.Range.End()])
+	}
+	assert.DeepEqual(t, texts, []string{
+		"text",
+		`"text"`,
+		`"text", x`,
+		`g("text", x)`,
+		`return g("text", x);`,
+		"{\n    return g(\"text\", x);\n}",
+		content[:len(content)-1],
+		content,
+	})
+
+	texts = nil
+	for r := service.ProvideSelectionRange(mainFileName, markerPosition(t, content, "y:")); r != nil; r = r.Parent {
+		texts = append(texts, content[r.Range.Pos():r.Range.End()])
+	}
+	assert.DeepEqual(t, texts[:3], []string{"y", "y: string", "x: number, y: string"})
+}
+
+func TestProvideLinkedEditingRanges(t *testing.T) {
+	t.Parallel()
+
+	const fileName = "/home/src/app.tsx"
+	const content = `const a = <div><span></span></div>;
+const b = <>text</>;
+`
+	service := setup(t, map[string]string{fileName: content}, nil)
+
+	rangeTexts := func(ranges *ls.LinkedEditingRanges) []string {
+		return core.Map(ranges.Ranges, func(r core.TextRange) string { return content[r.Pos():r.End()] })
+	}
+
+	ranges := service.ProvideLinkedEditingRanges(fileName, markerPosition(t, content, "v><span>")+1)
+	assert.DeepEqual(t, rangeTexts(ranges), []string{"div", "div"})
+	assert.Equal(t, ranges.Ranges[0].Pos(), markerPosition(t, content, "div"))
+
+	ranges = service.ProvideLinkedEditingRanges(fileName, markerPosition(t, content, "span"))
+	assert.DeepEqual(t, rangeTexts(ranges), []string{"span", "span"})
+
+	assert.Assert(t, service.ProvideLinkedEditingRanges(fileName, markerPosition(t, content, "const a")) == nil)
+
+	ranges = service.ProvideLinkedEditingRanges(fileName, markerPosition(t, content, "<>")+1)
+	assert.Equal(t, len(ranges.Ranges), 2)
+	assert.Equal(t, ranges.Ranges[0], core.NewTextRange(markerPosition(t, content, "<>")+1, markerPosition(t, content, "<>")+1))
+	assert.Equal(t, ranges.Ranges[1], core.NewTextRange(markerPosition(t, content, "</>")+2, markerPosition(t, content, "</>")+2))
+}
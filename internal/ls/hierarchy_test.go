@@ -0,0 +1,91 @@
+package ls_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"gotest.tools/v3/assert"
+)
+
+func hierarchyItemNames(items []ls.HierarchyItem) []string {
+	return core.Map(items, func(item ls.HierarchyItem) string { return item.Name })
+}
+
+func rangeTexts(text string, ranges []core.TextRange) []string {
+	return core.Map(ranges, func(r core.TextRange) string { return text[r.Pos():r.End()] })
+}
+
+func TestCallHierarchy(t *testing.T) {
+	t.Parallel()
+
+	const content = `function leaf() {}
+function overloaded(x: string): void;
+function overloaded(x: any) { leaf(); }
+const arrow = () => { leaf(); overloaded(""); };
+class Service {
+    constructor() { leaf(); }
+    run() {
+        arrow();
+        [1].forEach(() => leaf());
+        function nested() { overloaded(""); }
+    }
+}
+new Service().run();
+`
+	files := map[string]string{mainFileName: content}
+	service := setup(t, files, nil)
+
+	items := service.PrepareCallHierarchy(mainFileName, markerPosition(t, content, "overloaded(x: string"))
+	assert.Equal(t, len(items), 1)
+	assert.Equal(t, items[0].Kind, ls.SymbolKindFunction)
+	assert.Equal(t, content[items[0].Range.Pos():items[0].Range.End()], "function overloaded(x: any) { leaf(); }")
+
+	leaf := service.PrepareCallHierarchy(mainFileName, markerPosition(t, content, "leaf"))[0]
+	incoming := service.ProvideCallHierarchyIncomingCalls(leaf)
+	assert.DeepEqual(t, hierarchyItemNames(core.Map(incoming, func(call ls.CallHierarchyIncomingCall) ls.HierarchyItem { return call.From })),
+		[]string{"overloaded", "arrow", "constructor", "run"})
+	assert.Equal(t, incoming[2].From.Detail, "Service")
+	for _, call := range incoming {
+		assert.DeepEqual(t, rangeTexts(content, call.FromRanges), []string{"leaf"})
+	}
+
+	run := service.PrepareCallHierarchy(mainFileName, markerPosition(t, content, "run();"))[0]
+	assert.Equal(t, run.Kind, ls.SymbolKindMethod)
+	outgoing := service.ProvideCallHierarchyOutgoingCalls(run)
+	assert.DeepEqual(t, hierarchyItemNames(core.Map(outgoing, func(call ls.CallHierarchyOutgoingCall) ls.HierarchyItem { return call.To })),
+		[]string{"arrow", "leaf"})
+
+	incoming = service.ProvideCallHierarchyIncomingCalls(run)
+	assert.Equal(t, len(incoming), 1)
+	assert.Equal(t, incoming[0].From.Kind, ls.SymbolKindFile)
+	assert.Equal(t, incoming[0].From.Name, "main.ts")
+
+	outgoing = service.ProvideCallHierarchyOutgoingCalls(incoming[0].From)
+	assert.DeepEqual(t, hierarchyItemNames(core.Map(outgoing, func(call ls.CallHierarchyOutgoingCall) ls.HierarchyItem { return call.To })),
+		[]string{"run", "Service"})
+}
+
+func TestTypeHierarchy(t *testing.T) {
+	t.Parallel()
+
+	const content = `interface Named { name: string }
+interface Entity extends Named {}
+class Base implements Entity { name = "" }
+class Derived extends Base implements Named {}
+class Other extends Derived {}
+`
+	files := map[string]string{mainFileName: content}
+	service := setup(t, files, nil)
+
+	derived := service.PrepareTypeHierarchy(mainFileName, markerPosition(t, content, "Derived"))
+	assert.DeepEqual(t, hierarchyItemNames(derived), []string{"Derived"})
+	assert.Equal(t, derived[0].Kind, ls.SymbolKindClass)
+	assert.DeepEqual(t, hierarchyItemNames(service.ProvideTypeHierarchySupertypes(derived[0])), []string{"Base", "Named"})
+	assert.DeepEqual(t, hierarchyItemNames(service.ProvideTypeHierarchySubtypes(derived[0])), []string{"Other"})
+
+	named := service.PrepareTypeHierarchy(mainFileName, markerPosition(t, content, "Named"))
+	assert.Equal(t, named[0].Kind, ls.SymbolKindInterface)
+	assert.DeepEqual(t, hierarchyItemNames(service.ProvideTypeHierarchySupertypes(named[0])), []string(nil))
+	assert.DeepEqual(t, hierarchyItemNames(service.ProvideTypeHierarchySubtypes(named[0])), []string{"Entity", "Derived"})
+}
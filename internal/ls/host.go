@@ -24,4 +24,6 @@ type Host interface {
 	// because they were bidirectionally interdependent.
 	GetProgram() *compiler.Program
 	GetDefaultLibraryPath() string
+	// GetExportInfoMap returns the index of the modules and exports that files can auto-import.
+	GetExportInfoMap() *ExportInfoMap
 }
@@ -1,23 +1,341 @@
 package ls
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+type DisplayPartKind int
+
+const (
+	DisplayPartKindText DisplayPartKind = iota
+	DisplayPartKindKeyword
+	DisplayPartKindPunctuation
+	DisplayPartKindSpace
+	DisplayPartKindLineBreak
+	DisplayPartKindSymbolName
+	DisplayPartKindParameterName
+	// DisplayPartKindLink is the `{@link ` that opens an inline JSDoc link or the `}` that closes it.
+	DisplayPartKindLink
+	DisplayPartKindLinkName
+	DisplayPartKindLinkText
 )
 
-func (l *LanguageService) ProvideHover(fileName string, position int) string {
-	program, file := l.getProgramAndFile(fileName)
+// DisplayPart is a piece of the text of quick info, classified so that an editor can render it.
+type DisplayPart struct {
+	Text string
+	Kind DisplayPartKind
+	// Target is the declaration the name of a JSDoc link refers to, if the name resolves.
+	Target *Location
+}
+
+// DisplayPartsToString returns the plain text of a list of display parts.
+func DisplayPartsToString(parts []DisplayPart) string {
+	var sb strings.Builder
+	for _, part := range parts {
+		sb.WriteString(part.Text)
+	}
+	return sb.String()
+}
+
+// JSDocTagInfo is a JSDoc tag, such as `@param` or `@deprecated`, of the declaration quick info is for. Name
+// excludes the `@`. The text of `@param` and `@property` tags starts with a part of kind
+// DisplayPartKindParameterName.
+type JSDocTagInfo struct {
+	Name string
+	Text []DisplayPart
+}
+
+type QuickInfo struct {
+	// Range spans the name or keyword the quick info is for.
+	Range         core.TextRange
+	DisplayParts  []DisplayPart
+	Documentation []DisplayPart
+	Tags          []JSDocTagInfo
+}
+
+// ProvideHover returns quick info for the symbol at a position: its kind, its name qualified by its
+// containing class, interface, enum or namespace, its type or, for calls, the resolved signature, and its
+// JSDoc documentation and tags. It returns nil when there is no symbol at the position.
+func (l *LanguageService) ProvideHover(fileName string, position int) *QuickInfo {
+	program, file := l.getProgramAndBoundFile(fileName)
 	node := astnav.GetTouchingPropertyName(file, position)
 	if node.Kind == ast.KindSourceFile {
 		// Avoid giving quickInfo for the sourceFile as a whole.
-		return ""
+		return nil
+	}
+
+	c := program.GetTypeChecker()
+	symbol := c.GetSymbolAtLocation(node)
+	if symbol == nil {
+		return nil
+	}
+	b := &quickInfoBuilder{checker: c}
+	b.addSymbol(symbol, node)
+	return &QuickInfo{
+		Range:         getRangeOfName(node),
+		DisplayParts:  b.parts,
+		Documentation: b.documentation,
+		Tags:          b.tags,
+	}
+}
+
+type quickInfoBuilder struct {
+	checker       *checker.Checker
+	parts         []DisplayPart
+	documentation []DisplayPart
+	tags          []JSDocTagInfo
+}
+
+func (b *quickInfoBuilder) add(text string, kind DisplayPartKind) {
+	b.parts = append(b.parts, DisplayPart{Text: text, Kind: kind})
+}
+
+func (b *quickInfoBuilder) keyword(text string) {
+	b.add(text, DisplayPartKindKeyword)
+	b.add(" ", DisplayPartKindSpace)
+}
+
+// prefix adds a parenthesized kind, such as `(method)`, followed by a space.
+func (b *quickInfoBuilder) prefix(kind string) {
+	b.add("(", DisplayPartKindPunctuation)
+	b.add(kind, DisplayPartKindText)
+	b.add(")", DisplayPartKindPunctuation)
+	b.add(" ", DisplayPartKindSpace)
+}
+
+func (b *quickInfoBuilder) typeAnnotation(t *checker.Type) {
+	b.add(":", DisplayPartKindPunctuation)
+	b.add(" ", DisplayPartKindSpace)
+	b.add(b.checker.TypeToString(t), DisplayPartKindText)
+}
+
+// qualifiedName adds the name of a symbol, qualified by the name of its parent when that is a class,
+// interface, enum or namespace.
+func (b *quickInfoBuilder) qualifiedName(symbol *ast.Symbol) {
+	parent := symbol.Parent
+	// The symbols of modules that are files are not namespaces.
+	if parent != nil && parent.Flags&(ast.SymbolFlagsClass|ast.SymbolFlagsInterface|ast.SymbolFlagsEnum|ast.SymbolFlagsModule) != 0 &&
+		(parent.ValueDeclaration == nil || !ast.IsSourceFile(parent.ValueDeclaration)) {
+		b.add(b.checker.SymbolToString(parent), DisplayPartKindSymbolName)
+		b.add(".", DisplayPartKindPunctuation)
+	}
+	b.add(b.checker.SymbolToString(symbol), DisplayPartKindSymbolName)
+}
+
+func (b *quickInfoBuilder) addSymbol(symbol *ast.Symbol, node *ast.Node) {
+	c := b.checker
+	if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+		if target, ok := c.ResolveAlias(symbol); ok {
+			b.prefix("alias")
+			b.addSymbol(target, node)
+			b.add("\n", DisplayPartKindLineBreak)
+			b.keyword(core.IfElse(ast.FindAncestor(symbol.Declarations[0], ast.IsExportSpecifier) != nil, "export", "import"))
+			b.add(symbol.Name, DisplayPartKindSymbolName)
+			return
+		}
+	}
+
+	root := c.GetRootSymbol(symbol)
+	flags := root.Flags
+	inTypePosition := ast.IsPartOfTypeNode(node)
+	switch {
+	case flags&ast.SymbolFlagsClass != 0 && isNewCallee(node):
+		b.addSignature(symbol, root, node, "constructor")
+	case flags&ast.SymbolFlagsClass != 0:
+		if root.ValueDeclaration != nil && ast.IsClassExpression(root.ValueDeclaration) {
+			b.prefix("local class")
+			b.add(c.SymbolToString(root), DisplayPartKindSymbolName)
+		} else {
+			b.keyword("class")
+			b.add(c.TypeToString(c.GetDeclaredTypeOfSymbol(root)), DisplayPartKindSymbolName)
+		}
+	case flags&ast.SymbolFlagsConstructor != 0:
+		b.addSignature(root.Parent, root.Parent, node, "constructor")
+	case flags&(ast.SymbolFlagsFunction|ast.SymbolFlagsMethod) != 0 && !inTypePosition:
+		b.addSignature(symbol, root, node, core.IfElse(flags&ast.SymbolFlagsMethod != 0, "method", "function"))
+	case flags&ast.SymbolFlagsEnumMember != 0:
+		b.prefix("enum member")
+		b.qualifiedName(root)
+		if value := c.GetConstantValue(root.ValueDeclaration); value != nil {
+			b.add(" ", DisplayPartKindSpace)
+			b.add("=", DisplayPartKindPunctuation)
+			b.add(" ", DisplayPartKindSpace)
+			b.add(c.ValueToString(value), DisplayPartKindText)
+		}
+		b.addDocumentation(root.Declarations)
+	case flags&(ast.SymbolFlagsProperty|ast.SymbolFlagsAccessor) != 0 && !inTypePosition:
+		b.prefix("property")
+		b.qualifiedName(root)
+		b.typeAnnotation(c.GetTypeOfSymbolAtLocation(symbol, node))
+		b.addDocumentation(root.Declarations)
+	case flags&ast.SymbolFlagsVariable != 0 && !inTypePosition:
+		declaration := root.ValueDeclaration
+		switch {
+		case declaration != nil && ast.IsParameter(declaration):
+			b.prefix("parameter")
+		case declaration != nil && ast.IsVarConst(declaration):
+			b.keyword("const")
+		case declaration != nil && ast.IsVarLet(declaration):
+			b.keyword("let")
+		default:
+			b.keyword("var")
+		}
+		b.add(c.SymbolToString(root), DisplayPartKindSymbolName)
+		// The type at the location is the declared type at the declaration and the narrowed type elsewhere.
+		b.typeAnnotation(c.GetTypeOfSymbolAtLocation(symbol, node))
+		b.addDocumentation(root.Declarations)
+	case flags&ast.SymbolFlagsInterface != 0:
+		b.keyword("interface")
+		b.add(c.TypeToString(c.GetDeclaredTypeOfSymbol(root)), DisplayPartKindSymbolName)
+		b.addDocumentation(root.Declarations)
+	case flags&ast.SymbolFlagsTypeAlias != 0:
+		b.keyword("type")
+		b.add(c.SymbolToString(root), DisplayPartKindSymbolName)
+		declaration := ast.GetDeclarationOfKind(root, ast.KindTypeAliasDeclaration)
+		if declaration != nil && declaration.TypeParameters() != nil {
+			b.add("<", DisplayPartKindPunctuation)
+			for i, typeParameter := range declaration.TypeParameters() {
+				if i != 0 {
+					b.add(",", DisplayPartKindPunctuation)
+					b.add(" ", DisplayPartKindSpace)
+				}
+				b.add(typeParameter.Name().Text(), DisplayPartKindSymbolName)
+			}
+			b.add(">", DisplayPartKindPunctuation)
+		}
+		b.add(" ", DisplayPartKindSpace)
+		b.add("=", DisplayPartKindPunctuation)
+		b.add(" ", DisplayPartKindSpace)
+		b.add(c.TypeToStringEx(c.GetDeclaredTypeOfSymbol(root), nil /*enclosingDeclaration*/, checker.TypeFormatFlagsInTypeAlias), DisplayPartKindText)
+		b.addDocumentation(root.Declarations)
+	case flags&ast.SymbolFlagsEnum != 0:
+		b.keyword(core.IfElse(flags&ast.SymbolFlagsConstEnum != 0, "const enum", "enum"))
+		b.add(c.SymbolToString(root), DisplayPartKindSymbolName)
+		b.addDocumentation(root.Declarations)
+	case flags&ast.SymbolFlagsModule != 0:
+		declaration := core.FirstOrNil(root.Declarations)
+		if declaration != nil && (ast.IsSourceFile(declaration) || ast.IsModuleDeclaration(declaration) && ast.IsStringLiteral(declaration.Name())) {
+			b.keyword("module")
+		} else {
+			b.keyword("namespace")
+		}
+		b.add(c.SymbolToString(root), DisplayPartKindSymbolName)
+		b.addDocumentation(root.Declarations)
+	case flags&ast.SymbolFlagsTypeParameter != 0:
+		b.prefix("type parameter")
+		b.add(c.SymbolToString(root), DisplayPartKindSymbolName)
+	default:
+		b.add(c.TypeToString(c.GetTypeOfSymbolAtLocation(symbol, node)), DisplayPartKindText)
+		b.addDocumentation(root.Declarations)
+	}
+}
+
+// addSignature adds a function, method or constructor with the signature that applies at the location: the
+// resolved overload at a call, the signature of the declaration at a declaration name, or else the first
+// signature. When there are overloads, the count of the other overloads follows.
+func (b *quickInfoBuilder) addSignature(symbol *ast.Symbol, root *ast.Symbol, node *ast.Node, kind string) {
+	c := b.checker
+	signatureKind := core.IfElse(kind == "constructor", checker.SignatureKindConstruct, checker.SignatureKindCall)
+	signatures := c.GetSignaturesOfType(c.GetTypeOfSymbolAtLocation(symbol, node), signatureKind)
+
+	var signature *checker.Signature
+	if call := getCallOfCallee(node); call != nil {
+		signature = c.GetResolvedSignature(call)
+	} else if ast.IsFunctionLike(node.Parent) && (node.Parent.Name() == node || node.Kind == ast.KindConstructorKeyword) {
+		signature = c.GetSignatureFromDeclaration(node.Parent)
+	} else if len(signatures) != 0 {
+		signature = signatures[0]
+	}
+
+	switch kind {
+	case "function":
+		b.keyword("function")
+		b.add(c.SymbolToString(root), DisplayPartKindSymbolName)
+	case "method":
+		b.prefix("method")
+		b.qualifiedName(root)
+	case "constructor":
+		b.keyword("constructor")
+		b.add(c.SymbolToString(root), DisplayPartKindSymbolName)
+	}
+	if signature == nil {
+		b.typeAnnotation(c.GetTypeOfSymbolAtLocation(symbol, node))
+		b.addDocumentation(root.Declarations)
+		return
+	}
+	// SignatureToString writes construct signatures with a leading `new`, which the `constructor` keyword
+	// replaces.
+	b.add(strings.TrimPrefix(c.SignatureToString(signature), "new"), DisplayPartKindText)
+	if len(signatures) > 1 {
+		b.add(" ", DisplayPartKindSpace)
+		b.add("(", DisplayPartKindPunctuation)
+		b.add("+", DisplayPartKindPunctuation)
+		b.add(strconv.Itoa(len(signatures)-1), DisplayPartKindText)
+		b.add(" ", DisplayPartKindSpace)
+		b.add(core.IfElse(len(signatures) == 2, "overload", "overloads"), DisplayPartKindText)
+		b.add(")", DisplayPartKindPunctuation)
 	}
 
-	checker := program.GetTypeChecker()
-	if symbol := checker.GetSymbolAtLocation(node); symbol != nil {
-		if t := checker.GetTypeOfSymbolAtLocation(symbol, node); t != nil {
-			return checker.TypeToString(t)
+	if declaration := signature.Declaration(); declaration != nil && !ast.IsClassLike(declaration) {
+		b.addDocumentation([]*ast.Node{declaration})
+	}
+	if len(b.documentation) == 0 && len(b.tags) == 0 {
+		b.addDocumentation(root.Declarations)
+	}
+}
+
+func (b *quickInfoBuilder) addDocumentation(declarations []*ast.Node) {
+	for _, declaration := range declarations {
+		documentation, tags := getDocumentationAndTags(b.checker, declaration)
+		if len(documentation) != 0 {
+			if len(b.documentation) != 0 {
+				b.documentation = append(b.documentation, DisplayPart{Text: "\n", Kind: DisplayPartKindLineBreak})
+			}
+			b.documentation = append(b.documentation, documentation...)
 		}
+		b.tags = append(b.tags, tags...)
+	}
+}
+
+// isNewCallee reports whether a name is the class a `new` expression constructs.
+func isNewCallee(node *ast.Node) bool {
+	call := getCallOfCallee(node)
+	return call != nil && ast.IsNewExpression(call)
+}
+
+// getCallOfCallee returns the call or `new` expression that calls through a name, or nil.
+func getCallOfCallee(node *ast.Node) *ast.Node {
+	if node.Parent == nil || !isCalleeName(node) {
+		return nil
+	}
+	call := node.Parent
+	if ast.IsPropertyAccessExpression(call) && call.Name() == node {
+		call = call.Parent
+	}
+	for ast.IsParenthesizedExpression(call) {
+		call = call.Parent
+	}
+	if !ast.IsCallExpression(call) && !ast.IsNewExpression(call) {
+		return nil
+	}
+	return call
+}
+
+// getEntityNameText returns the text of an identifier or qualified name, such as the name of a `@param`
+// tag or an inline `{@link}`.
+func getEntityNameText(name *ast.Node) string {
+	if ast.IsQualifiedName(name) {
+		return getEntityNameText(name.AsQualifiedName().Left) + "." + name.AsQualifiedName().Right.Text()
+	}
+	if ast.IsIdentifier(name) || ast.IsPrivateIdentifier(name) {
+		return name.Text()
 	}
-	return ""
+	return scanner.GetTextOfNode(name)
 }
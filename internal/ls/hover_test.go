@@ -0,0 +1,89 @@
+package ls_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/ls"
+	"gotest.tools/v3/assert"
+)
+
+func TestProvideHover(t *testing.T) {
+	t.Parallel()
+
+	const content = `import { helper } from "./helper";
+/** The configuration. */
+interface Config {
+    /** The port to listen on. */
+    port: number;
+}
+class Server<T> {
+    constructor(config: Config) {}
+    /**
+     * Starts the server.
+     * @param delay - milliseconds to wait, see {@link Config}
+     * @deprecated Use {@linkcode Server.run} instead.
+     * @example
+     * server.start(10);
+     */
+    start(delay: number): void;
+    start(delay: string): void;
+    start(delay: any) {}
+}
+type Pair<T> = [T, T];
+enum Color { Red = 1 }
+const config: Config = { port: 80 };
+let value: string | number = config.port;
+value;
+new Server<string>(config).start("soon");
+helper(Color.Red);
+function f(p: Pair<number>) {}
+`
+	files := map[string]string{
+		mainFileName:          content,
+		"/home/src/helper.ts": "/** Helps. */\nexport function helper(x: number): void {}\n",
+	}
+	service := setup(t, files, nil)
+
+	hover := func(marker string) string {
+		t.Helper()
+		quickInfo := service.ProvideHover(mainFileName, markerPosition(t, content, marker))
+		assert.Assert(t, quickInfo != nil, marker)
+		return ls.DisplayPartsToString(quickInfo.DisplayParts)
+	}
+
+	assert.Equal(t, hover("port: number"), "(property) Config.port: number")
+	assert.Equal(t, hover("Config {"), "interface Config")
+	assert.Equal(t, hover("Server<T>"), "class Server<T>")
+	assert.Equal(t, hover("Server<string>"), "constructor Server(config: Config): Server<string>")
+	assert.Equal(t, hover("start(\"soon\")"), `(method) Server.start(delay: string): void (+1 overload)`)
+	assert.Equal(t, hover("Pair<T>"), "type Pair<T> = [T, T]")
+	assert.Equal(t, hover("Color {"), "enum Color")
+	assert.Equal(t, hover("Red)"), "(enum member) Color.Red = 1")
+	assert.Equal(t, hover("config: Config ="), "const config: Config")
+	assert.Equal(t, hover("value: string"), "let value: string | number")
+	assert.Equal(t, hover("value;"), "let value: number")
+	assert.Equal(t, hover("p: Pair"), "(parameter) p: Pair<number>")
+	assert.Equal(t, hover("helper(Color"), "(alias) function helper(x: number): void\nimport helper")
+
+	quickInfo := service.ProvideHover(mainFileName, markerPosition(t, content, "port: number"))
+	assert.Equal(t, ls.DisplayPartsToString(quickInfo.Documentation), "The port to listen on.")
+	assert.Equal(t, content[quickInfo.Range.Pos():quickInfo.Range.End()], "port")
+
+	// The resolved overload has no JSDoc, so the documentation comes from the other declarations.
+	quickInfo = service.ProvideHover(mainFileName, markerPosition(t, content, "start(\"soon\")"))
+	assert.Equal(t, ls.DisplayPartsToString(quickInfo.Documentation), "Starts the server.")
+	var tags []string
+	for _, tag := range quickInfo.Tags {
+		tags = append(tags, tag.Name+" "+ls.DisplayPartsToString(tag.Text))
+	}
+	assert.DeepEqual(t, tags, []string{
+		"param delay - milliseconds to wait, see {@link Config}",
+		"deprecated Use {@linkcode Server.run} instead.",
+		"example server.start(10);",
+	})
+
+	quickInfo = service.ProvideHover(mainFileName, markerPosition(t, content, "helper(Color"))
+	assert.Equal(t, ls.DisplayPartsToString(quickInfo.Documentation), "Helps.")
+
+	assert.Assert(t, service.ProvideHover(mainFileName, markerPosition(t, content, "{ port: 80")) == nil)
+}
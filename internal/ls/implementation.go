@@ -0,0 +1,161 @@
+package ls
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+)
+
+// ProvideImplementations returns the implementations of the symbol at a position. For an interface or class
+// these are the concrete classes that implement or extend it, directly or through other classes and
+// interfaces, and the object literals typed by it. For a member of an interface or class they are the
+// corresponding members of those implementations. For other symbols they are the declarations that have
+// a body, so that the overloads of a function navigate to its implementation.
+func (l *LanguageService) ProvideImplementations(fileName string, position int) []Location {
+	program, file := l.getProgramAndFile(fileName)
+	node := astnav.GetTouchingPropertyName(file, position)
+	if node.Kind == ast.KindSourceFile {
+		return nil
+	}
+
+	c := program.GetTypeChecker()
+	symbol := getResolvedSymbolAtLocation(c, node)
+	if symbol == nil {
+		return nil
+	}
+	finder := &implementationFinder{program: program, checker: c, visited: make(map[*ast.Symbol]bool)}
+
+	var declarations []*ast.Node
+	switch {
+	case symbol.Flags&(ast.SymbolFlagsInterface|ast.SymbolFlagsClass) != 0:
+		finder.collect(symbol)
+		for _, implementation := range finder.implementations {
+			if !ast.HasSyntacticModifier(implementation, ast.ModifierFlagsAbstract) {
+				declarations = append(declarations, implementation)
+			}
+		}
+	case symbol.Flags&(ast.SymbolFlagsProperty|ast.SymbolFlagsMethod|ast.SymbolFlagsAccessor) != 0 && symbol.Parent != nil &&
+		symbol.Parent.Flags&(ast.SymbolFlagsInterface|ast.SymbolFlagsClass) != 0:
+		finder.collect(symbol.Parent)
+		var containers []*ast.Node
+		if symbol.Parent.Flags&ast.SymbolFlagsClass != 0 {
+			containers = append(containers, symbol.Parent.Declarations...)
+		}
+		containers = append(containers, finder.implementations...)
+		for _, container := range containers {
+			for _, member := range finder.getImplementingMembers(container, symbol.Name) {
+				declarations = core.AppendIfUnique(declarations, member)
+			}
+		}
+	default:
+		declarations = core.Filter(symbol.Declarations, func(decl *ast.Node) bool {
+			return decl.Body() != nil || ast.IsVariableDeclaration(decl) && decl.Initializer() != nil
+		})
+		if len(declarations) == 0 {
+			declarations = symbol.Declarations
+		}
+	}
+	locations := getLocationsOfDeclarations(declarations)
+	slices.SortStableFunc(locations, func(a, b Location) int {
+		return cmp.Or(strings.Compare(a.FileName, b.FileName), a.Range.Pos()-b.Range.Pos())
+	})
+	return locations
+}
+
+type implementationFinder struct {
+	program *compiler.Program
+	checker *checker.Checker
+	visited map[*ast.Symbol]bool
+	// implementations are the class-like declarations and object literal expressions found so far.
+	implementations []*ast.Node
+}
+
+// collect finds the implementations of an interface or class by following the references to it in
+// heritage clauses and type annotations.
+func (f *implementationFinder) collect(symbol *ast.Symbol) {
+	if f.visited[symbol] {
+		return
+	}
+	f.visited[symbol] = true
+	for _, reference := range findReferences(f.program, f.checker, symbol) {
+		f.collectFromReference(reference)
+	}
+}
+
+func (f *implementationFinder) collectFromReference(reference *ast.Node) {
+	node := reference
+	if ast.IsPropertyAccessExpression(node.Parent) && node.Parent.Name() == node {
+		node = node.Parent
+	} else if ast.IsQualifiedName(node.Parent) && node.Parent.AsQualifiedName().Right == node {
+		node = node.Parent
+	}
+	parent := node.Parent
+	switch {
+	case ast.IsExpressionWithTypeArguments(parent) && ast.IsHeritageClause(parent.Parent):
+		declaration := parent.Parent.Parent
+		if ast.IsClassLike(declaration) {
+			f.implementations = core.AppendIfUnique(f.implementations, declaration)
+		}
+		if symbol := getSymbolOfDeclaration(f.checker, declaration); symbol != nil {
+			f.collect(symbol)
+		}
+	case ast.IsTypeReferenceNode(parent):
+		if literal := getObjectLiteralTypedBy(parent); literal != nil {
+			if f.checker.IsTypeAssignableTo(f.checker.GetTypeAtLocation(literal), f.checker.GetTypeAtLocation(parent)) {
+				f.implementations = core.AppendIfUnique(f.implementations, literal)
+			}
+		}
+	}
+}
+
+// getObjectLiteralTypedBy returns the object literal whose type is given by a type annotation, `satisfies`
+// or `as` expression, as in `const x: I = {}`, `{} satisfies I` and `{} as I`.
+func getObjectLiteralTypedBy(typeNode *ast.Node) *ast.Node {
+	parent := typeNode.Parent
+	var expression *ast.Node
+	switch parent.Kind {
+	case ast.KindVariableDeclaration, ast.KindPropertyDeclaration, ast.KindParameter:
+		if parent.Type() == typeNode {
+			expression = parent.Initializer()
+		}
+	case ast.KindSatisfiesExpression, ast.KindAsExpression:
+		if parent.Type() == typeNode {
+			expression = parent.Expression()
+		}
+	}
+	if expression != nil {
+		expression = ast.SkipParentheses(expression)
+		if ast.IsObjectLiteralExpression(expression) {
+			return expression
+		}
+	}
+	return nil
+}
+
+// getImplementingMembers returns the declarations of the member with the given name in a class or object
+// literal that provide an implementation, skipping interface members and abstract members.
+func (f *implementationFinder) getImplementingMembers(container *ast.Node, name string) []*ast.Node {
+	var t *checker.Type
+	if ast.IsObjectLiteralExpression(container) {
+		t = f.checker.GetTypeAtLocation(container)
+	} else if symbol := getSymbolOfDeclaration(f.checker, container); symbol != nil {
+		t = f.checker.GetDeclaredTypeOfSymbol(symbol)
+	}
+	if t == nil {
+		return nil
+	}
+	member := f.checker.GetPropertyOfType(t, name)
+	if member == nil {
+		return nil
+	}
+	return core.Filter(member.Declarations, func(decl *ast.Node) bool {
+		return decl.Parent != nil && !ast.IsInterfaceDeclaration(decl.Parent) && !ast.IsTypeLiteralNode(decl.Parent) &&
+			!ast.HasSyntacticModifier(decl, ast.ModifierFlagsAbstract)
+	})
+}
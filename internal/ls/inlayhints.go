@@ -0,0 +1,340 @@
+package ls
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+// maxInlayHintLength is the length at which type hints are truncated.
+const maxInlayHintLength = 30
+
+type InlayHintKind int
+
+const (
+	InlayHintKindParameter InlayHintKind = iota
+	InlayHintKindType
+	InlayHintKindEnum
+)
+
+type InlayHint struct {
+	Text             string
+	Position         int
+	Kind             InlayHintKind
+	WhitespaceBefore bool
+	WhitespaceAfter  bool
+}
+
+// ProvideInlayHints returns the inlay hints for the nodes in span that are enabled by preferences.
+func (l *LanguageService) ProvideInlayHints(fileName string, span core.TextRange, preferences *UserPreferences) []InlayHint {
+	program, file := l.getProgramAndFile(fileName)
+	if preferences == nil {
+		preferences = &UserPreferences{}
+	}
+	c := &inlayHintCollector{
+		checker:     program.GetTypeChecker(),
+		file:        file,
+		span:        span,
+		preferences: preferences,
+	}
+	c.visit(file.AsNode())
+	return c.hints
+}
+
+type inlayHintCollector struct {
+	checker     *checker.Checker
+	file        *ast.SourceFile
+	span        core.TextRange
+	preferences *UserPreferences
+	hints       []InlayHint
+}
+
+func (c *inlayHintCollector) visit(node *ast.Node) bool {
+	if node == nil || node.End() <= c.span.Pos() || node.Pos() >= c.span.End() {
+		return false
+	}
+	if ast.IsTypeNode(node) && !ast.IsExpressionWithTypeArguments(node) {
+		return false
+	}
+
+	switch {
+	case c.preferences.IncludeInlayVariableTypeHints && ast.IsVariableDeclaration(node),
+		c.preferences.IncludeInlayPropertyDeclarationTypeHints && ast.IsPropertyDeclaration(node):
+		c.visitVariableLikeDeclaration(node)
+	case c.preferences.IncludeInlayEnumMemberValueHints && ast.IsEnumMember(node):
+		c.visitEnumMember(node)
+	case c.preferences.shouldShowParameterNameHints() && (ast.IsCallExpression(node) || ast.IsNewExpression(node)):
+		c.visitCallOrNewExpression(node)
+	}
+	if ast.IsFunctionLikeDeclaration(node) {
+		if c.preferences.IncludeInlayFunctionParameterTypeHints && ast.IsFunctionExpressionOrArrowFunction(node) {
+			c.visitFunctionLikeForParameterType(node)
+		}
+		if c.preferences.IncludeInlayFunctionLikeReturnTypeHints && isSignatureSupportingReturnAnnotation(node) {
+			c.visitFunctionDeclarationLikeForReturnType(node)
+		}
+	}
+	node.ForEachChild(c.visit)
+	return false
+}
+
+func (c *inlayHintCollector) addParameterHint(name string, position int, isFirstVariadicArgument bool) {
+	if isFirstVariadicArgument {
+		name = "..." + name
+	}
+	c.hints = append(c.hints, InlayHint{
+		Text:            name + ":",
+		Position:        position,
+		Kind:            InlayHintKindParameter,
+		WhitespaceAfter: true,
+	})
+}
+
+func (c *inlayHintCollector) addTypeHint(text string, position int) {
+	c.hints = append(c.hints, InlayHint{
+		Text:     ": " + truncateInlayHint(text),
+		Position: position,
+		Kind:     InlayHintKindType,
+	})
+}
+
+func (c *inlayHintCollector) addEnumMemberValueHint(text string, position int) {
+	c.hints = append(c.hints, InlayHint{
+		Text:             "= " + text,
+		Position:         position,
+		Kind:             InlayHintKindEnum,
+		WhitespaceBefore: true,
+	})
+}
+
+func (c *inlayHintCollector) visitEnumMember(member *ast.Node) {
+	if member.Initializer() != nil {
+		return
+	}
+	if value := c.checker.GetConstantValue(member); value != nil {
+		c.addEnumMemberValueHint(c.checker.ValueToString(value), member.End())
+	}
+}
+
+func (c *inlayHintCollector) visitVariableLikeDeclaration(decl *ast.Node) {
+	if decl.Initializer() == nil && !ast.IsPropertyDeclaration(decl) ||
+		ast.IsBindingPattern(decl.Name()) ||
+		decl.Type() != nil ||
+		ast.IsVariableDeclaration(decl) && !isHintableDeclaration(decl) {
+		return
+	}
+	symbol := c.checker.GetSymbolAtLocation(decl.Name())
+	if symbol == nil {
+		return
+	}
+	declarationType := c.checker.GetTypeOfSymbol(symbol)
+	if declarationType.Flags()&checker.TypeFlagsAny != 0 && decl.Initializer() == nil || isModuleReferenceType(declarationType) {
+		return
+	}
+	hintText := c.checker.TypeToString(declarationType)
+	if !c.preferences.IncludeInlayVariableTypeHintsWhenTypeMatchesName && strings.EqualFold(decl.Name().Text(), hintText) {
+		return
+	}
+	c.addTypeHint(hintText, decl.Name().End())
+}
+
+func (c *inlayHintCollector) visitCallOrNewExpression(expr *ast.Node) {
+	args := expr.Arguments()
+	if len(args) == 0 {
+		return
+	}
+	signature := c.checker.GetResolvedSignature(expr)
+	if signature == nil || signature.Declaration() == nil {
+		return
+	}
+	parameters := signature.Parameters()
+	paramCount := len(parameters)
+	if signature.HasRestParameter() {
+		paramCount--
+	}
+
+	for pos, originalArg := range args {
+		arg := ast.SkipParentheses(originalArg)
+		if c.preferences.shouldShowLiteralParameterNameHintsOnly() && !isHintableLiteral(arg) {
+			continue
+		}
+		if ast.IsSpreadElement(arg) {
+			// The parameters covered by a spread argument are not known statically.
+			return
+		}
+
+		var parameter *ast.Symbol
+		isFirstVariadicArgument := false
+		switch {
+		case pos < paramCount:
+			parameter = parameters[pos]
+		case signature.HasRestParameter():
+			parameter = parameters[paramCount]
+			isFirstVariadicArgument = pos == paramCount
+		default:
+			return
+		}
+		if parameter.ValueDeclaration == nil || !ast.IsParameter(parameter.ValueDeclaration) || !ast.IsIdentifier(parameter.ValueDeclaration.Name()) {
+			continue
+		}
+		name := c.checker.GetParameterNameAtPosition(signature, pos)
+		if pos >= paramCount && name != parameter.Name {
+			// The rest parameter is a labeled tuple, so each argument has its own name.
+			isFirstVariadicArgument = false
+		} else if pos > paramCount {
+			return
+		}
+		if !c.preferences.IncludeInlayParameterNameHintsWhenArgumentMatchesName && !isFirstVariadicArgument && identifierOrAccessExpressionPostfixMatchesParameterName(arg, name) {
+			continue
+		}
+		if c.leadingCommentsContainParameterName(originalArg, name) {
+			continue
+		}
+		c.addParameterHint(name, scanner.GetTokenPosOfNode(originalArg, c.file, false /*includeJsDoc*/), isFirstVariadicArgument)
+	}
+}
+
+// leadingCommentsContainParameterName reports whether the argument is preceded by a comment such as
+// `/*name*/` or `/* name= */` that already names the parameter.
+func (c *inlayHintCollector) leadingCommentsContainParameterName(node *ast.Node, name string) bool {
+	// Comments on the same line as the previous token are trailing comments of that token.
+	comments := core.Concatenate(
+		slices.Collect(scanner.GetTrailingCommentRanges(nil, c.file.Text, node.Pos())),
+		slices.Collect(scanner.GetLeadingCommentRanges(nil, c.file.Text, node.Pos())),
+	)
+	for _, comment := range comments {
+		if comment.Kind != ast.KindMultiLineCommentTrivia {
+			continue
+		}
+		text := c.file.Text[comment.Pos()+len("/*") : comment.End()-len("*/")]
+		if strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "=")) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *inlayHintCollector) visitFunctionDeclarationLikeForReturnType(decl *ast.Node) {
+	if decl.Type() != nil || decl.Body() == nil {
+		return
+	}
+	position, ok := c.getTypeAnnotationPosition(decl)
+	if !ok {
+		return
+	}
+	signature := c.checker.GetSignatureFromDeclaration(decl)
+	if signature == nil {
+		return
+	}
+	returnType := c.checker.GetReturnTypeOfSignature(signature)
+	if isModuleReferenceType(returnType) {
+		return
+	}
+	c.addTypeHint(c.checker.TypeToString(returnType), position)
+}
+
+// getTypeAnnotationPosition returns the position after the closing parenthesis of the parameter list.
+// Arrow functions with an unparenthesized parameter have no such position.
+func (c *inlayHintCollector) getTypeAnnotationPosition(decl *ast.Node) (int, bool) {
+	pos := scanner.SkipTrivia(c.file.Text, decl.ParameterList().End())
+	if pos < len(c.file.Text) && c.file.Text[pos] == ')' {
+		return pos + 1, true
+	}
+	return 0, false
+}
+
+func (c *inlayHintCollector) visitFunctionLikeForParameterType(node *ast.Node) {
+	signature := c.checker.GetSignatureFromDeclaration(node)
+	if signature == nil {
+		return
+	}
+	pos := 0
+	for _, param := range node.Parameters() {
+		if ast.IsThisParameter(param) {
+			continue
+		}
+		if pos < len(signature.Parameters()) && param.Type() == nil && isHintableDeclaration(param) && !ast.IsBindingPattern(param.Name()) {
+			symbol := signature.Parameters()[pos]
+			t := c.checker.GetTypeOfSymbol(symbol)
+			if !isModuleReferenceType(t) {
+				end := param.Name().End()
+				if questionToken := param.AsParameterDeclaration().QuestionToken; questionToken != nil {
+					end = questionToken.End()
+				}
+				c.addTypeHint(c.checker.TypeToString(t), end)
+			}
+		}
+		pos++
+	}
+}
+
+func isSignatureSupportingReturnAnnotation(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindArrowFunction, ast.KindFunctionExpression, ast.KindFunctionDeclaration, ast.KindMethodDeclaration, ast.KindGetAccessor:
+		return true
+	}
+	return false
+}
+
+func isHintableDeclaration(node *ast.Node) bool {
+	if (ast.IsPartOfParameterDeclaration(node) || ast.IsVariableDeclaration(node) && ast.IsVarConst(node)) && node.Initializer() != nil {
+		initializer := ast.SkipParentheses(node.Initializer())
+		return !(isHintableLiteral(initializer) || ast.IsNewExpression(initializer) || ast.IsObjectLiteralExpression(initializer) || ast.IsAssertionExpression(initializer))
+	}
+	return true
+}
+
+func isHintableLiteral(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindPrefixUnaryExpression:
+		operand := node.AsPrefixUnaryExpression().Operand
+		return ast.IsLiteralExpression(operand) || ast.IsIdentifier(operand) && isInfinityOrNaNString(operand.Text())
+	case ast.KindTrueKeyword, ast.KindFalseKeyword, ast.KindNullKeyword, ast.KindNoSubstitutionTemplateLiteral, ast.KindTemplateExpression:
+		return true
+	case ast.KindIdentifier:
+		name := node.Text()
+		return name == "undefined" || isInfinityOrNaNString(name)
+	}
+	return ast.IsLiteralExpression(node)
+}
+
+func isInfinityOrNaNString(name string) bool {
+	return name == "Infinity" || name == "NaN"
+}
+
+func identifierOrAccessExpressionPostfixMatchesParameterName(expr *ast.Node, parameterName string) bool {
+	switch {
+	case ast.IsIdentifier(expr):
+		return expr.Text() == parameterName
+	case ast.IsPropertyAccessExpression(expr):
+		return expr.Name().Text() == parameterName
+	}
+	return false
+}
+
+func isModuleReferenceType(t *checker.Type) bool {
+	return t.Symbol() != nil && t.Symbol().Flags&ast.SymbolFlagsModule != 0
+}
+
+// truncateInlayHint truncates text that is longer than maxInlayHintLength UTF-16 code units, which is how
+// the length is measured in JavaScript, without splitting a character.
+func truncateInlayHint(text string) string {
+	units := 0
+	end := len(text)
+	for i, r := range text {
+		n := utf16.RuneLen(r)
+		if end == len(text) && units+n > maxInlayHintLength-len("...") {
+			end = i
+		}
+		units += n
+		if units > maxInlayHintLength {
+			return text[:end] + "..."
+		}
+	}
+	return text
+}
@@ -0,0 +1,122 @@
+package ls_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"gotest.tools/v3/assert"
+)
+
+func TestProvideInlayHints(t *testing.T) {
+	t.Parallel()
+
+	const content = `function greet(name: string, times: number, ...rest: boolean[]) { return name.repeat(times); }
+const count = 3;
+greet("hi", count, true, false);
+greet(/*name*/ "hi", 2);
+let inferred = [1, 2].map(n => n * 2);
+class Point { x = 0; y; }
+enum Direction { Up, Down, Left = 10, Right }
+const callback = function (value?) { return value; };`
+
+	service := setup(t, map[string]string{mainFileName: content}, &core.CompilerOptions{Target: core.ScriptTargetESNext})
+	span := core.NewTextRange(0, len(content))
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, len(service.ProvideInlayHints(mainFileName, span, &ls.UserPreferences{})), 0)
+	})
+
+	t.Run("parameter names", func(t *testing.T) {
+		t.Parallel()
+		hints := service.ProvideInlayHints(mainFileName, span, &ls.UserPreferences{
+			IncludeInlayParameterNameHints: ls.IncludeInlayParameterNameHintsAll,
+		})
+		assert.DeepEqual(t, formatInlayHints(content, hints), []string{
+			"count: @times); }",
+			`name: @"hi", count, true, false);`,
+			"times: @count, true, false);",
+			"...rest: @true, false);",
+			"times: @2);",
+			"callbackfn: @n => n * 2);",
+		})
+	})
+
+	t.Run("literal parameter names", func(t *testing.T) {
+		t.Parallel()
+		hints := service.ProvideInlayHints(mainFileName, span, &ls.UserPreferences{
+			IncludeInlayParameterNameHints:                        ls.IncludeInlayParameterNameHintsLiterals,
+			IncludeInlayParameterNameHintsWhenArgumentMatchesName: true,
+		})
+		assert.DeepEqual(t, formatInlayHints(content, hints), []string{
+			`name: @"hi", count, true, false);`,
+			"...rest: @true, false);",
+			"times: @2);",
+		})
+	})
+
+	t.Run("types", func(t *testing.T) {
+		t.Parallel()
+		hints := service.ProvideInlayHints(mainFileName, span, &ls.UserPreferences{
+			IncludeInlayVariableTypeHints:            true,
+			IncludeInlayPropertyDeclarationTypeHints: true,
+			IncludeInlayFunctionLikeReturnTypeHints:  true,
+			IncludeInlayFunctionParameterTypeHints:   true,
+		})
+		assert.DeepEqual(t, formatInlayHints(content, hints), []string{
+			": string @ { return name.repeat(times); }",
+			": number[] @ = [1, 2].map(n => n * 2);",
+			": number @ => n * 2);",
+			": number @ = 0; y; }",
+			": (value?: any) => any @ = function (value?) { return value; };",
+			": any @) { return value; };",
+			": any @ { return value; };",
+		})
+	})
+
+	t.Run("enum member values", func(t *testing.T) {
+		t.Parallel()
+		hints := service.ProvideInlayHints(mainFileName, span, &ls.UserPreferences{
+			IncludeInlayEnumMemberValueHints: true,
+		})
+		assert.DeepEqual(t, formatInlayHints(content, hints), []string{
+			"= 0 @, Down, Left = 10, Right }",
+			"= 1 @, Left = 10, Right }",
+			"= 11 @ }",
+		})
+	})
+}
+
+func TestProvideInlayHintsTruncation(t *testing.T) {
+	t.Parallel()
+
+	const content = `let ascii = { abcdefghijklmnopqrstuvwxyz: 1 };
+let accented = { éééééééééééééééééééééééééééééé: 1 };
+let short = { éééééééééééééé: 1 };
+let emoji = { "😀😀😀😀😀😀😀😀😀😀😀😀😀": 1 };`
+
+	service := setup(t, map[string]string{mainFileName: content}, &core.CompilerOptions{Target: core.ScriptTargetESNext})
+	hints := service.ProvideInlayHints(mainFileName, core.NewTextRange(0, len(content)), &ls.UserPreferences{
+		IncludeInlayVariableTypeHints: true,
+	})
+	// hints are truncated to 30 UTF-16 code units, without splitting characters
+	assert.DeepEqual(t, core.Map(hints, func(hint ls.InlayHint) string { return hint.Text }), []string{
+		": { abcdefghijklmnopqrstuvwxy...",
+		": { ééééééééééééééééééééééééé...",
+		": { éééééééééééééé: number; }",
+		": { \"😀😀😀😀😀😀😀😀😀😀😀😀...",
+	})
+}
+
+// formatInlayHints renders each hint followed by the rest of the line at its position.
+func formatInlayHints(text string, hints []ls.InlayHint) []string {
+	result := make([]string, 0, len(hints))
+	for _, hint := range hints {
+		rest, _, _ := strings.Cut(text[hint.Position:], "\n")
+		result = append(result, fmt.Sprintf("%s @%s", hint.Text, rest))
+	}
+	return result
+}
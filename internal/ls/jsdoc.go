@@ -0,0 +1,129 @@
+package ls
+
+import (
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+)
+
+// getDocumentationAndTags returns the text of the JSDoc comments of a declaration and their tags. The
+// documentation of a parameter is the comment of the `@param` tag of its function that names it.
+func getDocumentationAndTags(c *checker.Checker, declaration *ast.Node) ([]DisplayPart, []JSDocTagInfo) {
+	if ast.IsParameter(declaration) {
+		if name := declaration.Name(); ast.IsIdentifier(name) {
+			for _, jsdoc := range getJSDocsOfDeclaration(declaration.Parent) {
+				for _, tag := range getJSDocTags(jsdoc) {
+					if tag.Kind == ast.KindJSDocParameterTag && getEntityNameText(tag.AsJSDocParameterTag().Name()) == name.Text() {
+						return getJSDocCommentParts(c, tag.CommentList()), nil
+					}
+				}
+			}
+		}
+		return nil, nil
+	}
+
+	var documentation []DisplayPart
+	var tags []JSDocTagInfo
+	for _, jsdoc := range getJSDocsOfDeclaration(declaration) {
+		if parts := getJSDocCommentParts(c, jsdoc.AsJSDoc().Comment); len(parts) != 0 {
+			if len(documentation) != 0 {
+				documentation = append(documentation, DisplayPart{Text: "\n", Kind: DisplayPartKindLineBreak})
+			}
+			documentation = append(documentation, parts...)
+		}
+		for _, tag := range getJSDocTags(jsdoc) {
+			tags = append(tags, getJSDocTagInfo(c, tag))
+		}
+	}
+	return documentation, tags
+}
+
+// getJSDocsOfDeclaration returns the JSDoc comments of a declaration. The parser attaches the JSDoc of a
+// variable to its variable statement.
+func getJSDocsOfDeclaration(declaration *ast.Node) []*ast.Node {
+	jsdocs := declaration.JSDoc(nil)
+	if ast.IsVariableDeclaration(declaration) && ast.IsVariableDeclarationList(declaration.Parent) && ast.IsVariableStatement(declaration.Parent.Parent) {
+		jsdocs = append(jsdocs[:len(jsdocs):len(jsdocs)], declaration.Parent.Parent.JSDoc(nil)...)
+	}
+	return jsdocs
+}
+
+func getJSDocTags(jsdoc *ast.Node) []*ast.Node {
+	if tags := jsdoc.AsJSDoc().Tags; tags != nil {
+		return tags.Nodes
+	}
+	return nil
+}
+
+func getJSDocTagInfo(c *checker.Checker, tag *ast.Node) JSDocTagInfo {
+	var text []DisplayPart
+	switch tag.Kind {
+	case ast.KindJSDocParameterTag, ast.KindJSDocPropertyTag:
+		text = append(text, DisplayPart{Text: getEntityNameText(tag.Name()), Kind: DisplayPartKindParameterName})
+	case ast.KindJSDocSeeTag:
+		if nameExpression := tag.AsJSDocSeeTag().NameExpression; nameExpression != nil {
+			text = append(text, getLinkNamePart(c, nameExpression.Name()))
+		}
+	case ast.KindJSDocTemplateTag:
+		var names []string
+		for _, typeParameter := range tag.AsJSDocTemplateTag().TypeParameters().Nodes {
+			names = append(names, typeParameter.Name().Text())
+		}
+		text = append(text, DisplayPart{Text: strings.Join(names, ", "), Kind: DisplayPartKindSymbolName})
+	}
+	if comment := getJSDocCommentParts(c, tag.CommentList()); len(comment) != 0 {
+		if len(text) != 0 {
+			text = append(text, DisplayPart{Text: " ", Kind: DisplayPartKindSpace})
+		}
+		text = append(text, comment...)
+	}
+	return JSDocTagInfo{Name: tag.TagName().Text(), Text: text}
+}
+
+// getJSDocCommentParts returns the display parts of the text of a JSDoc comment or tag, with inline
+// `{@link}`, `{@linkcode}` and `{@linkplain}` tags split into link parts.
+func getJSDocCommentParts(c *checker.Checker, comment *ast.NodeList) []DisplayPart {
+	if comment == nil {
+		return nil
+	}
+	var parts []DisplayPart
+	for _, node := range comment.Nodes {
+		var text string
+		switch node.Kind {
+		case ast.KindJSDocText:
+			parts = append(parts, DisplayPart{Text: node.AsJSDocText().Text, Kind: DisplayPartKindText})
+			continue
+		case ast.KindJSDocLink:
+			parts = append(parts, DisplayPart{Text: "{@link ", Kind: DisplayPartKindLink})
+			text = node.AsJSDocLink().Text
+		case ast.KindJSDocLinkCode:
+			parts = append(parts, DisplayPart{Text: "{@linkcode ", Kind: DisplayPartKindLink})
+			text = node.AsJSDocLinkCode().Text
+		case ast.KindJSDocLinkPlain:
+			parts = append(parts, DisplayPart{Text: "{@linkplain ", Kind: DisplayPartKindLink})
+			text = node.AsJSDocLinkPlain().Text
+		default:
+			continue
+		}
+		if name := node.Name(); name != nil {
+			parts = append(parts, getLinkNamePart(c, name))
+		}
+		if text != "" {
+			parts = append(parts, DisplayPart{Text: text, Kind: DisplayPartKindLinkText})
+		}
+		parts = append(parts, DisplayPart{Text: "}", Kind: DisplayPartKindLink})
+	}
+	return parts
+}
+
+// getLinkNamePart returns the part for the name of a JSDoc link or `@see` tag, with the declaration the
+// name resolves to as its target.
+func getLinkNamePart(c *checker.Checker, name *ast.Node) DisplayPart {
+	part := DisplayPart{Text: getEntityNameText(name), Kind: DisplayPartKindLinkName}
+	if symbol := getResolvedSymbolAtLocation(c, name); symbol != nil && len(symbol.Declarations) != 0 {
+		target := getLocationOfDeclaration(symbol.Declarations[0])
+		part.Target = &target
+	}
+	return part
+}
@@ -2,6 +2,7 @@ package ls
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/binder"
 	"github.com/microsoft/typescript-go/internal/compiler"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/tspath"
@@ -63,3 +64,11 @@ func (l *LanguageService) getProgramAndFile(fileName string) (*compiler.Program,
 	}
 	return program, file
 }
+
+// getProgramAndBoundFile is like getProgramAndFile, but also binds the file so that the parents of its nodes
+// are set, for features that walk up the syntax tree without using the checker.
+func (l *LanguageService) getProgramAndBoundFile(fileName string) (*compiler.Program, *ast.SourceFile) {
+	program, file := l.getProgramAndFile(fileName)
+	binder.BindSourceFile(file, program.Options())
+	return program, file
+}
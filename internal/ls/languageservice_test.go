@@ -0,0 +1,122 @@
+package ls_test
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/parser"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs/vfstest"
+)
+
+const mainFileName = "/home/src/main.ts"
+
+// setup creates a language service over an in-memory file system. Every file in the map is a root file.
+func setup(t *testing.T, files map[string]string, options *core.CompilerOptions) *ls.LanguageService {
+	t.Helper()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+	if options == nil {
+		options = &core.CompilerOptions{Strict: core.TSTrue, Target: core.ScriptTargetESNext}
+	}
+	return ls.NewLanguageService(newTestHost(files, options))
+}
+
+// markerPosition returns the position of the first occurrence of marker in text.
+func markerPosition(t *testing.T, text string, marker string) int {
+	t.Helper()
+	index := strings.Index(text, marker)
+	if index < 0 {
+		t.Fatalf("marker %q not found", marker)
+	}
+	return index
+}
+
+type testHost struct {
+	compiler.CompilerHost
+	rootFiles     []string
+	options       *core.CompilerOptions
+	program       *compiler.Program
+	exportInfoMap *ls.ExportInfoMap
+
+	// files are the parsed files, which are shared by the programs until they are written.
+	filesMu sync.Mutex
+	files   map[tspath.Path]*ast.SourceFile
+	dirty   bool
+}
+
+var _ ls.Host = (*testHost)(nil)
+
+func newTestHost(files map[string]string, options *core.CompilerOptions) *testHost {
+	fs := bundled.WrapFS(vfstest.FromMap(files, true /*useCaseSensitiveFileNames*/))
+	host := &testHost{
+		CompilerHost:  compiler.NewCompilerHost(options, "/home/src", fs, bundled.LibPath()),
+		options:       options,
+		exportInfoMap: ls.NewExportInfoMap(),
+		files:         make(map[tspath.Path]*ast.SourceFile),
+	}
+	for fileName := range files {
+		if tspath.HasTSFileExtension(fileName) && !strings.Contains(fileName, "/node_modules/") {
+			host.rootFiles = append(host.rootFiles, fileName)
+		}
+	}
+	slices.Sort(host.rootFiles)
+	return host
+}
+
+func (h *testHost) GetDefaultLibraryPath() string             { return h.DefaultLibraryPath() }
+func (h *testHost) GetProjectVersion() int                    { return 0 }
+func (h *testHost) GetRootFileNames() []string                { return h.rootFiles }
+func (h *testHost) GetCompilerOptions() *core.CompilerOptions { return h.options }
+func (h *testHost) GetExportInfoMap() *ls.ExportInfoMap       { return h.exportInfoMap }
+
+// GetSourceFile parses JSDoc comments fully, as the documents of a project do, so that quick info has
+// documentation.
+func (h *testHost) GetSourceFile(fileName string, path tspath.Path, languageVersion core.ScriptTarget) *ast.SourceFile {
+	h.filesMu.Lock()
+	defer h.filesMu.Unlock()
+	if file, ok := h.files[path]; ok {
+		return file
+	}
+	var file *ast.SourceFile
+	if tspath.FileExtensionIs(fileName, tspath.ExtensionJson) {
+		file = h.CompilerHost.GetSourceFile(fileName, path, languageVersion)
+	} else if text, ok := h.FS().ReadFile(fileName); ok {
+		file = parser.ParseSourceFile(fileName, path, text, languageVersion, scanner.JSDocParsingModeParseAll)
+	}
+	h.files[path] = file
+	return file
+}
+
+// writeFile writes a file, so that the next program is created from the old one with the new text of the file,
+// and reports the change to the export info map as a project does.
+func (h *testHost) writeFile(fileName string, text string) {
+	_ = h.FS().WriteFile(fileName, text, false)
+	h.exportInfoMap.OnWatchedFileChanged(tspath.Path(fileName))
+	h.filesMu.Lock()
+	defer h.filesMu.Unlock()
+	delete(h.files, tspath.Path(fileName))
+	h.dirty = true
+}
+
+func (h *testHost) GetProgram() *compiler.Program {
+	if h.program == nil || h.dirty {
+		h.program = compiler.NewProgram(compiler.ProgramOptions{
+			RootFiles:  h.rootFiles,
+			Host:       h,
+			Options:    h.options,
+			OldProgram: h.program,
+		})
+		h.dirty = false
+	}
+	return h.program
+}
@@ -0,0 +1,70 @@
+package ls
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+// jsxTagNameWordPattern matches the characters of a JSX tag name, including namespaced and member names.
+const jsxTagNameWordPattern = `[a-zA-Z0-9:\-\._$]*`
+
+type LinkedEditingRanges struct {
+	Ranges []core.TextRange
+	// WordPattern is a regular expression for the text that may be typed in the ranges.
+	WordPattern string
+}
+
+// ProvideLinkedEditingRanges returns the ranges of the names of the opening and closing tags of a JSX
+// element when the position is in one of them, so that an editor renames both tags together. For fragments
+// the ranges are the empty ranges where a tag name would go.
+func (l *LanguageService) ProvideLinkedEditingRanges(fileName string, position int) *LinkedEditingRanges {
+	_, file := l.getProgramAndBoundFile(fileName)
+	token := astnav.GetTouchingPropertyName(file, position)
+	tag := ast.FindAncestor(token, func(node *ast.Node) bool {
+		switch node.Kind {
+		case ast.KindJsxOpeningElement, ast.KindJsxClosingElement, ast.KindJsxOpeningFragment, ast.KindJsxClosingFragment:
+			return true
+		}
+		return false
+	})
+	if tag == nil {
+		return nil
+	}
+
+	switch tag.Kind {
+	case ast.KindJsxOpeningFragment, ast.KindJsxClosingFragment:
+		fragment := tag.Parent.AsJsxFragment()
+		// The names of fragments go after `<` and `</`.
+		openingPos := scanner.GetTokenPosOfNode(fragment.OpeningFragment, file, false /*includeJsDoc*/) + 1
+		closingPos := scanner.GetTokenPosOfNode(fragment.ClosingFragment, file, false /*includeJsDoc*/) + 2
+		if position != openingPos && position != closingPos {
+			return nil
+		}
+		return &LinkedEditingRanges{
+			Ranges:      []core.TextRange{core.NewTextRange(openingPos, openingPos), core.NewTextRange(closingPos, closingPos)},
+			WordPattern: jsxTagNameWordPattern,
+		}
+	}
+
+	if tag.Parent.Kind != ast.KindJsxElement {
+		return nil
+	}
+	element := tag.Parent.AsJsxElement()
+	openingName := element.OpeningElement.TagName()
+	closingName := element.ClosingElement.TagName()
+	openingRange := getRangeOfName(openingName)
+	closingRange := getRangeOfName(closingName)
+	tagRange := core.IfElse(tag == element.OpeningElement, openingRange, closingRange)
+	if position < tagRange.Pos() || position > tagRange.End() {
+		return nil
+	}
+	if scanner.GetTextOfNode(openingName) != scanner.GetTextOfNode(closingName) {
+		return nil
+	}
+	return &LinkedEditingRanges{
+		Ranges:      []core.TextRange{openingRange, closingRange},
+		WordPattern: jsxTagNameWordPattern,
+	}
+}
@@ -0,0 +1,377 @@
+package ls
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/compiler/module"
+	"github.com/microsoft/typescript-go/internal/compiler/packagejson"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// moduleSpecifierEnding is the form of the end of a generated module specifier.
+type moduleSpecifierEnding int
+
+const (
+	// moduleSpecifierEndingMinimal drops the extension and a trailing "/index", as in "./dir".
+	moduleSpecifierEndingMinimal moduleSpecifierEnding = iota
+	// moduleSpecifierEndingIndex drops the extension only, as in "./dir/index".
+	moduleSpecifierEndingIndex
+	// moduleSpecifierEndingJsExtension uses the extension of the output file, as in "./dir/index.js".
+	moduleSpecifierEndingJsExtension
+	// moduleSpecifierEndingTsExtension keeps the extension of TypeScript files, as in "./dir/index.ts".
+	moduleSpecifierEndingTsExtension
+)
+
+// moduleSpecifierGenerator computes the module specifiers with which a file imports other modules.
+type moduleSpecifierGenerator struct {
+	program             *compiler.Program
+	options             *core.CompilerOptions
+	importingFile       *ast.SourceFile
+	preferences         *UserPreferences
+	resolver            *module.Resolver
+	comparePathsOptions tspath.ComparePathsOptions
+	resolutionMode      core.ResolutionMode
+	ending              moduleSpecifierEnding
+	conditions          []string
+}
+
+func newModuleSpecifierGenerator(program *compiler.Program, importingFile *ast.SourceFile, preferences *UserPreferences) *moduleSpecifierGenerator {
+	host := program.Host()
+	g := &moduleSpecifierGenerator{
+		program:       program,
+		options:       program.Options(),
+		importingFile: importingFile,
+		preferences:   preferences,
+		resolver:      module.NewResolver(host, program.Options()),
+		comparePathsOptions: tspath.ComparePathsOptions{
+			UseCaseSensitiveFileNames: host.FS().UseCaseSensitiveFileNames(),
+			CurrentDirectory:          host.GetCurrentDirectory(),
+		},
+	}
+	g.resolutionMode = g.getResolutionMode()
+	g.ending = g.getEnding()
+	g.conditions = g.getConditions()
+	return g
+}
+
+// getModuleSpecifier returns the module specifier for an export, or the empty string if the
+// importing file cannot import it.
+func (g *moduleSpecifierGenerator) getModuleSpecifier(info *ExportInfo) string {
+	if info.ModuleFileName == "" {
+		return info.ModuleName
+	}
+	return g.getModuleSpecifierForFile(info.ModuleFileName)
+}
+
+func (g *moduleSpecifierGenerator) getModuleSpecifierForFile(moduleFileName string) string {
+	if specifier, ok := g.tryGetNodeModulesSpecifier(moduleFileName); ok {
+		return specifier
+	}
+
+	relative := g.getRelativeSpecifier(moduleFileName)
+	nonRelative := g.tryGetPathsSpecifier(moduleFileName)
+	if nonRelative == "" {
+		nonRelative = g.tryGetBaseUrlSpecifier(moduleFileName)
+	}
+	if nonRelative == "" {
+		return relative
+	}
+	switch g.preferences.ImportModuleSpecifierPreference {
+	case ImportModuleSpecifierPreferenceNonRelative:
+		return nonRelative
+	case ImportModuleSpecifierPreferenceRelative, ImportModuleSpecifierPreferenceProjectRelative:
+		return relative
+	}
+	if strings.HasPrefix(nonRelative, "../") || countPathComponents(relative) < countPathComponents(nonRelative) {
+		return relative
+	}
+	return nonRelative
+}
+
+func (g *moduleSpecifierGenerator) getRelativeSpecifier(moduleFileName string) string {
+	relativePath := tspath.GetRelativePathFromDirectory(tspath.GetDirectoryPath(g.importingFile.FileName()), moduleFileName, g.comparePathsOptions)
+	relativePath = g.processEnding(relativePath, g.ending)
+	if !strings.HasPrefix(relativePath, ".") {
+		relativePath = "./" + relativePath
+	}
+	return relativePath
+}
+
+// tryGetPathsSpecifier maps a file back through the patterns of the paths option.
+func (g *moduleSpecifierGenerator) tryGetPathsSpecifier(moduleFileName string) string {
+	if g.options.Paths.Size() == 0 {
+		return ""
+	}
+	baseDirectory := module.GetPathsBasePath(g.options, g.comparePathsOptions.CurrentDirectory)
+	relativePath := tspath.GetRelativePathFromDirectory(baseDirectory, moduleFileName, g.comparePathsOptions)
+	candidates := []string{
+		g.processEnding(relativePath, g.ending),
+		g.processEnding(relativePath, moduleSpecifierEndingIndex),
+		relativePath,
+	}
+	for key, substitutions := range g.options.Paths.Entries() {
+		for _, substitution := range substitutions {
+			substitution = strings.TrimPrefix(tspath.NormalizePath(substitution), "./")
+			for _, candidate := range candidates {
+				if matched, ok := matchPattern(substitution, candidate); ok {
+					if star := strings.Index(key, "*"); star >= 0 {
+						return key[:star] + matched + key[star+1:]
+					}
+					if matched == "" {
+						return key
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (g *moduleSpecifierGenerator) tryGetBaseUrlSpecifier(moduleFileName string) string {
+	if g.options.BaseUrl == "" {
+		return ""
+	}
+	relativePath := tspath.GetRelativePathFromDirectory(g.options.BaseUrl, moduleFileName, g.comparePathsOptions)
+	if strings.HasPrefix(relativePath, "..") {
+		return ""
+	}
+	return g.processEnding(relativePath, g.ending)
+}
+
+// tryGetNodeModulesSpecifier returns the package specifier of a file in node_modules. The result
+// is empty if the package does not export the file.
+func (g *moduleSpecifierGenerator) tryGetNodeModulesSpecifier(moduleFileName string) (string, bool) {
+	packageDirectory := module.ParseNodeModuleFromPath(moduleFileName, false /*isFolder*/)
+	if packageDirectory == "" {
+		return "", false
+	}
+	packageName := getPackageNameFromFileName(moduleFileName)
+	subpath := moduleFileName[len(packageDirectory)+1:]
+
+	scope := g.resolver.GetPackageScopeForPath(packageDirectory)
+	if scope.Exists() && scope.PackageDirectory == packageDirectory {
+		packageJSON := scope.Contents
+		if packageJSON.Exports.Type != packagejson.JSONValueTypeNotPresent && g.supportsPackageJSONExports() {
+			if key, ok := g.tryGetExportsKey(packageJSON.Exports, "./"+subpath); ok {
+				return packageName + strings.TrimPrefix(key, "."), true
+			}
+			return "", true
+		}
+		for _, field := range []packagejson.Expected[string]{packageJSON.Types, packageJSON.Typings, packageJSON.Main} {
+			if entry, ok := field.GetValue(); ok && tspath.RemoveFileExtension(strings.TrimPrefix(tspath.NormalizePath(entry), "./")) == tspath.RemoveFileExtension(subpath) {
+				return packageName, true
+			}
+		}
+	}
+	if tspath.RemoveFileExtension(subpath) == "index" {
+		return packageName, true
+	}
+	return packageName + "/" + g.processEnding(subpath, g.ending), true
+}
+
+// tryGetExportsKey returns the key of the exports map of a package that maps to target, a path
+// relative to the package directory.
+func (g *moduleSpecifierGenerator) tryGetExportsKey(exports packagejson.ExportsOrImports, target string) (string, bool) {
+	if exports.Type == packagejson.JSONValueTypeObject && exports.IsSubpaths() {
+		for key, value := range exports.AsObject().Entries() {
+			if matched, ok := g.tryMatchExportsTarget(key, value, target); ok {
+				return matched, true
+			}
+		}
+		return "", false
+	}
+	return g.tryMatchExportsTarget(".", exports, target)
+}
+
+func (g *moduleSpecifierGenerator) tryMatchExportsTarget(key string, value packagejson.ExportsOrImports, target string) (string, bool) {
+	switch value.Type {
+	case packagejson.JSONValueTypeString:
+		pattern := "./" + strings.TrimPrefix(tspath.NormalizePath(value.Value.(string)), "./")
+		for _, candidate := range getOutputFileCandidates(target) {
+			matched, ok := matchPattern(pattern, candidate)
+			if !ok {
+				continue
+			}
+			if star := strings.Index(key, "*"); star >= 0 {
+				return key[:star] + matched + key[star+1:], true
+			}
+			if matched == "" {
+				return key, true
+			}
+		}
+	case packagejson.JSONValueTypeArray:
+		for _, element := range value.AsArray() {
+			if matched, ok := g.tryMatchExportsTarget(key, element, target); ok {
+				return matched, true
+			}
+		}
+	case packagejson.JSONValueTypeObject:
+		for condition, conditionValue := range value.AsObject().Entries() {
+			if condition == "default" || slices.Contains(g.conditions, condition) {
+				if matched, ok := g.tryMatchExportsTarget(key, conditionValue, target); ok {
+					return matched, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// getOutputFileCandidates returns a path along with the path of the JavaScript file it declares, if
+// it is a declaration file.
+func getOutputFileCandidates(fileName string) []string {
+	if tspath.IsDeclarationFileName(fileName) {
+		return []string{fileName, tspath.ChangeExtension(fileName, getJSExtensionForFile(fileName, nil))}
+	}
+	return []string{fileName}
+}
+
+// matchPattern matches text against a pattern with at most one "*" and returns the text matched by
+// the "*". Patterns without a "*" must equal text.
+func matchPattern(pattern string, text string) (string, bool) {
+	star := strings.Index(pattern, "*")
+	if star < 0 {
+		return "", pattern == text
+	}
+	prefix, suffix := pattern[:star], pattern[star+1:]
+	if len(text) < len(prefix)+len(suffix) || !strings.HasPrefix(text, prefix) || !strings.HasSuffix(text, suffix) {
+		return "", false
+	}
+	return text[len(prefix) : len(text)-len(suffix)], true
+}
+
+func (g *moduleSpecifierGenerator) processEnding(fileName string, ending moduleSpecifierEnding) string {
+	if tspath.FileExtensionIs(fileName, tspath.ExtensionJson) {
+		return fileName
+	}
+	switch ending {
+	case moduleSpecifierEndingMinimal:
+		return strings.TrimSuffix(tspath.RemoveFileExtension(fileName), "/index")
+	case moduleSpecifierEndingIndex:
+		return tspath.RemoveFileExtension(fileName)
+	case moduleSpecifierEndingTsExtension:
+		if !tspath.IsDeclarationFileName(fileName) {
+			return fileName
+		}
+	}
+	return tspath.ChangeExtension(fileName, getJSExtensionForFile(fileName, g.options))
+}
+
+// getJSExtensionForFile returns the extension of the JavaScript file that a file is emitted to or
+// declares.
+func getJSExtensionForFile(fileName string, options *core.CompilerOptions) string {
+	switch {
+	case tspath.FileExtensionIsOneOf(fileName, []string{tspath.ExtensionMts, tspath.ExtensionDmts, tspath.ExtensionMjs}):
+		return tspath.ExtensionMjs
+	case tspath.FileExtensionIsOneOf(fileName, []string{tspath.ExtensionCts, tspath.ExtensionDcts, tspath.ExtensionCjs}):
+		return tspath.ExtensionCjs
+	case tspath.FileExtensionIs(fileName, tspath.ExtensionJsx),
+		tspath.FileExtensionIs(fileName, tspath.ExtensionTsx) && options != nil && options.Jsx == core.JsxEmitPreserve:
+		return tspath.ExtensionJsx
+	}
+	return tspath.ExtensionJs
+}
+
+// getResolutionMode returns the module format the resolver uses for the imports of the importing file.
+func (g *moduleSpecifierGenerator) getResolutionMode() core.ResolutionMode {
+	fileName := g.importingFile.FileName()
+	switch {
+	case tspath.FileExtensionIsOneOf(fileName, []string{tspath.ExtensionMts, tspath.ExtensionMjs}):
+		return core.ModuleKindESNext
+	case tspath.FileExtensionIsOneOf(fileName, []string{tspath.ExtensionCts, tspath.ExtensionCjs}):
+		return core.ModuleKindCommonJS
+	}
+	switch g.options.GetModuleResolutionKind() {
+	case core.ModuleResolutionKindNode16, core.ModuleResolutionKindNodeNext:
+		if scope := g.resolver.GetPackageScopeForPath(tspath.GetDirectoryPath(fileName)); scope.Exists() {
+			if packageType, _ := scope.Contents.Type.GetValue(); packageType == "module" {
+				return core.ModuleKindESNext
+			}
+		}
+		return core.ModuleKindCommonJS
+	case core.ModuleResolutionKindBundler:
+		return core.ModuleKindESNext
+	}
+	return core.ModuleKindNone
+}
+
+func (g *moduleSpecifierGenerator) getEnding() moduleSpecifierEnding {
+	extensionEnding := moduleSpecifierEndingJsExtension
+	if g.options.AllowImportingTsExtensions == core.TSTrue {
+		extensionEnding = moduleSpecifierEndingTsExtension
+	}
+	moduleResolution := g.options.GetModuleResolutionKind()
+	if (moduleResolution == core.ModuleResolutionKindNode16 || moduleResolution == core.ModuleResolutionKindNodeNext) && g.resolutionMode == core.ModuleKindESNext {
+		// ECMAScript module resolution does not add extensions or look up index files.
+		return extensionEnding
+	}
+	switch g.preferences.ImportModuleSpecifierEnding {
+	case ImportModuleSpecifierEndingMinimal:
+		return moduleSpecifierEndingMinimal
+	case ImportModuleSpecifierEndingIndex:
+		return moduleSpecifierEndingIndex
+	case ImportModuleSpecifierEndingJs:
+		return extensionEnding
+	}
+	// Follow the style of the existing relative imports of the file.
+	for _, statement := range g.importingFile.Statements.Nodes {
+		var moduleSpecifier *ast.Node
+		switch {
+		case ast.IsImportDeclaration(statement):
+			moduleSpecifier = statement.AsImportDeclaration().ModuleSpecifier
+		case ast.IsExportDeclaration(statement):
+			moduleSpecifier = statement.AsExportDeclaration().ModuleSpecifier
+		}
+		if moduleSpecifier == nil || !ast.IsStringLiteral(moduleSpecifier) || !tspath.PathIsRelative(moduleSpecifier.Text()) {
+			continue
+		}
+		switch text := moduleSpecifier.Text(); {
+		case tspath.FileExtensionIsOneOf(text, tspath.SupportedJSExtensionsFlat):
+			return moduleSpecifierEndingJsExtension
+		case tspath.HasImplementationTSFileExtension(text):
+			return moduleSpecifierEndingTsExtension
+		case strings.HasSuffix(text, "/index"):
+			return moduleSpecifierEndingIndex
+		default:
+			return moduleSpecifierEndingMinimal
+		}
+	}
+	return moduleSpecifierEndingMinimal
+}
+
+// getConditions returns the conditions of package.json exports that the importing file matches.
+func (g *moduleSpecifierGenerator) getConditions() []string {
+	conditions := []string{"types"}
+	if g.resolutionMode == core.ModuleKindESNext {
+		conditions = append(conditions, "import")
+	} else {
+		conditions = append(conditions, "require")
+	}
+	if g.options.GetModuleResolutionKind() != core.ModuleResolutionKindBundler {
+		conditions = append(conditions, "node")
+	}
+	return append(conditions, g.options.CustomConditions...)
+}
+
+func (g *moduleSpecifierGenerator) supportsPackageJSONExports() bool {
+	switch g.options.ResolvePackageJsonExports {
+	case core.TSTrue:
+		return true
+	case core.TSFalse:
+		return false
+	}
+	switch g.options.GetModuleResolutionKind() {
+	case core.ModuleResolutionKindNode16, core.ModuleResolutionKindNodeNext, core.ModuleResolutionKindBundler:
+		return true
+	}
+	return false
+}
+
+// countPathComponents counts the directory separators of a module specifier, ignoring a leading "./".
+func countPathComponents(specifier string) int {
+	return strings.Count(strings.TrimPrefix(specifier, "./"), "/")
+}
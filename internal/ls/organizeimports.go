@@ -0,0 +1,461 @@
+package ls
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/stringutil"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// OrganizeImports removes unused imports and coalesces and sorts the remaining ones, depending on mode.
+// Each run of import declarations that is not interrupted by a blank line, another statement or a
+// side effect import is organized on its own, both at the top level and in ambient module declarations.
+func (l *LanguageService) OrganizeImports(fileName string, mode OrganizeImportsMode, preferences *UserPreferences) []FileTextChanges {
+	program, file := l.getProgramAndFile(fileName)
+	preferences = core.Coalesce(preferences, &UserPreferences{})
+	o := &importOrganizer{
+		file:           file,
+		checker:        program.GetTypeCheckerForFile(file),
+		newLine:        l.NewLine(),
+		removeUnused:   mode != OrganizeImportsModeSortAndCombine,
+		sortAndCombine: mode != OrganizeImportsModeRemoveUnused,
+	}
+	var groups [][]*ast.Node
+	groups = appendImportGroups(groups, file, file.Statements.Nodes)
+	for _, statement := range file.Statements.Nodes {
+		if ast.IsAmbientModule(statement) {
+			if body := statement.Body(); body != nil && ast.IsModuleBlock(body) {
+				groups = appendImportGroups(groups, file, body.AsModuleBlock().Statements.Nodes)
+			}
+		}
+	}
+	o.compare = getOrganizeImportsComparer(preferences, groups)
+
+	tracker := newChangeTracker(o.newLine)
+	for _, group := range groups {
+		o.organizeGroup(tracker, group)
+	}
+	return tracker.getChanges()
+}
+
+// appendImportGroups appends the runs of consecutive import declarations in statements to groups.
+// Side effect imports end a run and are left in place, since reordering them could change behavior.
+func appendImportGroups(groups [][]*ast.Node, file *ast.SourceFile, statements []*ast.Node) [][]*ast.Node {
+	var group []*ast.Node
+	for _, statement := range statements {
+		if !ast.IsImportDeclaration(statement) || statement.AsImportDeclaration().ImportClause == nil ||
+			len(group) != 0 && hasBlankLineBefore(file, statement) {
+			if len(group) != 0 {
+				groups = append(groups, group)
+				group = nil
+			}
+			if !ast.IsImportDeclaration(statement) || statement.AsImportDeclaration().ImportClause == nil {
+				continue
+			}
+		}
+		group = append(group, statement)
+	}
+	if len(group) != 0 {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+func hasBlankLineBefore(file *ast.SourceFile, node *ast.Node) bool {
+	lines := strings.Split(file.Text[node.Pos():scanner.GetTokenPosOfNode(node, file, false /*includeJsDoc*/)], "\n")
+	// The first line is the end of the line of the preceding statement and the last one the start of
+	// the line of node, so only the lines in between can be blank.
+	return len(lines) > 2 && slices.ContainsFunc(lines[1:len(lines)-1], func(line string) bool {
+		return strings.TrimSpace(line) == ""
+	})
+}
+
+type importOrganizer struct {
+	file           *ast.SourceFile
+	checker        *checker.Checker
+	newLine        string
+	removeUnused   bool
+	sortAndCombine bool
+	compare        func(a, b string) int
+}
+
+// organizedImport is an import declaration of the result. It is written as the original declaration
+// when that declaration is kept as is.
+type organizedImport struct {
+	original         *ast.Node
+	declaration      *ast.Node
+	isTypeOnly       bool
+	defaultName      string
+	namespaceName    string
+	extraDefaults    []string
+	namedImports     []*ast.Node
+	leadingComments  []ast.CommentRange
+	trailingComments []ast.CommentRange
+}
+
+func (o *importOrganizer) organizeGroup(tracker *changeTracker, group []*ast.Node) {
+	var imports []*organizedImport
+	for i, declaration := range group {
+		if organized := o.removeUnusedBindings(declaration, i == 0); organized != nil {
+			imports = append(imports, organized)
+		}
+	}
+	if o.sortAndCombine {
+		imports = o.coalesceImports(imports)
+		slices.SortStableFunc(imports, func(a, b *organizedImport) int {
+			return o.compareModuleSpecifiers(a.declaration, b.declaration)
+		})
+	}
+
+	start := scanner.GetTokenPosOfNode(group[0], o.file, false /*includeJsDoc*/)
+	end := getTrailingCommentsEnd(o.file, group[len(group)-1])
+	indentation := getIndentationOfPosition(o.file.Text, start)
+	texts := make([]string, len(imports))
+	for i, organized := range imports {
+		texts[i] = o.getImportText(organized, indentation)
+	}
+	newText := strings.Join(texts, o.newLine+indentation)
+	if newText == o.file.Text[start:end] {
+		return
+	}
+	if newText == "" {
+		// Delete the lines of the group along with their line break.
+		if isWhiteSpaceOnly(o.file.Text[getLineStartOfPosition(o.file.Text, start):start]) {
+			start = getLineStartOfPosition(o.file.Text, start)
+		}
+		if strings.HasPrefix(o.file.Text[end:], "\r\n") {
+			end += 2
+		} else if strings.HasPrefix(o.file.Text[end:], "\n") {
+			end++
+		}
+	}
+	tracker.replaceRange(o.file, core.NewTextRange(start, end), newText)
+}
+
+// removeUnusedBindings returns the import declaration without its unused bindings, or nil if no binding
+// is used. The comments on the lines before the first declaration of a group are not attached to it and
+// stay in place, since they often describe the file rather than the import.
+func (o *importOrganizer) removeUnusedBindings(declaration *ast.Node, isFirstInGroup bool) *organizedImport {
+	importClause := declaration.AsImportDeclaration().ImportClause
+	clause := importClause.AsImportClause()
+	result := &organizedImport{
+		original:         declaration,
+		declaration:      declaration,
+		isTypeOnly:       clause.IsTypeOnly,
+		trailingComments: slices.Collect(scanner.GetTrailingCommentRanges(nil, o.file.Text, declaration.End())),
+	}
+	if !isFirstInGroup {
+		result.leadingComments = slices.Collect(scanner.GetLeadingCommentRanges(nil, o.file.Text, declaration.Pos()))
+	}
+	isUsed := func(node *ast.Node) bool {
+		return !o.removeUnused || o.checker.IsAliasDeclarationReferenced(node)
+	}
+	if name := clause.Name(); name != nil {
+		if isUsed(importClause) {
+			result.defaultName = name.Text()
+		} else {
+			result.original = nil
+		}
+	}
+	if namedBindings := clause.NamedBindings; namedBindings != nil {
+		if ast.IsNamespaceImport(namedBindings) {
+			if isUsed(namedBindings) {
+				result.namespaceName = namedBindings.Name().Text()
+			} else {
+				result.original = nil
+			}
+		} else {
+			elements := namedBindings.AsNamedImports().Elements.Nodes
+			result.namedImports = core.Filter(elements, isUsed)
+			if len(result.namedImports) != len(elements) {
+				result.original = nil
+			}
+			if len(elements) == 0 {
+				// Keep `import {} from "mod"`, which is written that way for its side effects.
+				return result
+			}
+		}
+	}
+	if result.defaultName == "" && result.namespaceName == "" && len(result.namedImports) == 0 {
+		return nil
+	}
+	return result
+}
+
+// coalesceImports merges the imports of the same module. A namespace import cannot share a declaration
+// with named imports, and a type-only import cannot have both a default and named bindings.
+func (o *importOrganizer) coalesceImports(imports []*organizedImport) []*organizedImport {
+	var keys []string
+	importsByKey := make(map[string][]*organizedImport)
+	for _, organized := range imports {
+		declaration := organized.declaration.AsImportDeclaration()
+		key := declaration.ModuleSpecifier.Text() + "\x00" + core.IfElse(organized.isTypeOnly, "type", "")
+		if declaration.Attributes != nil {
+			key += "\x00" + scanner.GetTextOfNode(declaration.Attributes)
+		}
+		if _, ok := importsByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		importsByKey[key] = append(importsByKey[key], organized)
+	}
+
+	var result []*organizedImport
+	for _, key := range keys {
+		group := importsByKey[key]
+		first := group[0]
+		var defaultNames, namespaceNames []string
+		var namedImports []*ast.Node
+		var leadingComments, trailingComments []ast.CommentRange
+		for _, organized := range group {
+			if organized.defaultName != "" {
+				defaultNames = append(defaultNames, organized.defaultName)
+			}
+			if organized.namespaceName != "" {
+				namespaceNames = append(namespaceNames, organized.namespaceName)
+			}
+			namedImports = append(namedImports, organized.namedImports...)
+			leadingComments = append(leadingComments, organized.leadingComments...)
+			trailingComments = append(trailingComments, organized.trailingComments...)
+		}
+		sortedNamedImports := o.sortImportSpecifiers(namedImports)
+		if len(group) == 1 && first.original != nil && slices.Equal(sortedNamedImports, namedImports) {
+			result = append(result, first)
+			continue
+		}
+
+		newImport := func(defaultName string, namespaceName string, extraDefaults []string, namedImports []*ast.Node) *organizedImport {
+			return &organizedImport{
+				declaration:   first.declaration,
+				isTypeOnly:    first.isTypeOnly,
+				defaultName:   defaultName,
+				namespaceName: namespaceName,
+				extraDefaults: extraDefaults,
+				namedImports:  namedImports,
+			}
+		}
+		var defaultName string
+		if len(defaultNames) != 0 {
+			defaultName, defaultNames = defaultNames[0], defaultNames[1:]
+		}
+		var merged []*organizedImport
+		if first.isTypeOnly && defaultName != "" && (len(namespaceNames) != 0 || len(namedImports) != 0 || len(defaultNames) != 0) {
+			merged = append(merged, newImport(defaultName, "", nil, nil))
+			defaultName = ""
+		}
+		if len(namespaceNames) != 0 && len(namedImports) == 0 && len(defaultNames) == 0 {
+			merged = append(merged, newImport(defaultName, namespaceNames[0], nil, nil))
+			defaultName, namespaceNames = "", namespaceNames[1:]
+		}
+		if defaultName != "" || len(defaultNames) != 0 || len(namedImports) != 0 {
+			// Additional default imports are written as named imports of `default`.
+			merged = append(merged, newImport(defaultName, "", defaultNames, sortedNamedImports))
+		}
+		for _, namespaceName := range namespaceNames {
+			merged = append(merged, newImport("", namespaceName, nil, nil))
+		}
+		if len(merged) == 0 {
+			merged = append(merged, newImport("", "", nil, nil))
+		}
+		merged[0].leadingComments = leadingComments
+		merged[0].trailingComments = trailingComments
+		result = append(result, merged...)
+	}
+	return result
+}
+
+// sortImportSpecifiers sorts import specifiers by the name of the imported export and removes duplicates.
+func (o *importOrganizer) sortImportSpecifiers(specifiers []*ast.Node) []*ast.Node {
+	sorted := slices.Clone(specifiers)
+	slices.SortStableFunc(sorted, func(a, b *ast.Node) int {
+		return cmp.Or(
+			o.compare(getImportedName(a), getImportedName(b)),
+			o.compare(a.Name().Text(), b.Name().Text()),
+			compareBooleans(a.AsImportSpecifier().IsTypeOnly, b.AsImportSpecifier().IsTypeOnly),
+		)
+	})
+	return slices.CompactFunc(sorted, func(a, b *ast.Node) bool {
+		return scanner.GetTextOfNode(a) == scanner.GetTextOfNode(b)
+	})
+}
+
+func getImportedName(specifier *ast.Node) string {
+	if propertyName := specifier.AsImportSpecifier().PropertyName; propertyName != nil {
+		return propertyName.Text()
+	}
+	return specifier.Name().Text()
+}
+
+// compareModuleSpecifiers orders imports of packages before relative imports.
+func (o *importOrganizer) compareModuleSpecifiers(a, b *ast.Node) int {
+	nameA := a.AsImportDeclaration().ModuleSpecifier.Text()
+	nameB := b.AsImportDeclaration().ModuleSpecifier.Text()
+	return cmp.Or(
+		compareBooleans(tspath.IsExternalModuleNameRelative(nameA), tspath.IsExternalModuleNameRelative(nameB)),
+		o.compare(nameA, nameB),
+	)
+}
+
+func compareBooleans(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+func (o *importOrganizer) getImportText(organized *organizedImport, indentation string) string {
+	var sb strings.Builder
+	for _, comment := range organized.leadingComments {
+		sb.WriteString(o.file.Text[comment.Pos():comment.End()])
+		sb.WriteString(core.IfElse(comment.HasTrailingNewLine, o.newLine+indentation, " "))
+	}
+	if organized.original != nil {
+		sb.WriteString(scanner.GetTextOfNode(organized.original))
+	} else {
+		declaration := organized.declaration.AsImportDeclaration()
+		sb.WriteString("import ")
+		if organized.isTypeOnly {
+			sb.WriteString("type ")
+		}
+		var bindings []string
+		if organized.defaultName != "" {
+			bindings = append(bindings, organized.defaultName)
+		}
+		if organized.namespaceName != "" {
+			bindings = append(bindings, "* as "+organized.namespaceName)
+		}
+		if len(organized.extraDefaults) != 0 || len(organized.namedImports) != 0 || len(bindings) == 0 {
+			names := core.Map(organized.extraDefaults, func(name string) string { return "default as " + name })
+			names = append(names, core.Map(organized.namedImports, scanner.GetTextOfNode)...)
+			bindings = append(bindings, core.IfElse(len(names) == 0, "{}", "{ "+strings.Join(names, ", ")+" }"))
+		}
+		sb.WriteString(strings.Join(bindings, ", "))
+		sb.WriteString(" from ")
+		sb.WriteString(scanner.GetTextOfNode(declaration.ModuleSpecifier))
+		if declaration.Attributes != nil {
+			sb.WriteString(" ")
+			sb.WriteString(scanner.GetTextOfNode(declaration.Attributes))
+		}
+		if strings.HasSuffix(scanner.GetTextOfNode(organized.declaration), ";") {
+			sb.WriteString(";")
+		}
+	}
+	for _, comment := range organized.trailingComments {
+		sb.WriteString(" ")
+		sb.WriteString(o.file.Text[comment.Pos():comment.End()])
+	}
+	return sb.String()
+}
+
+// getTrailingCommentsEnd returns the end of the comments that follow node on the same line.
+func getTrailingCommentsEnd(file *ast.SourceFile, node *ast.Node) int {
+	end := node.End()
+	for comment := range scanner.GetTrailingCommentRanges(nil, file.Text, node.End()) {
+		end = comment.End()
+	}
+	return end
+}
+
+// getOrganizeImportsComparer returns the comparer for module specifiers and import names. When the
+// preferences do not say whether to ignore case, case-sensitive sorting is used only if the file's
+// imports are already sorted that way and not case-insensitively.
+func getOrganizeImportsComparer(preferences *UserPreferences, groups [][]*ast.Node) func(a, b string) int {
+	ignoreCase := preferences.OrganizeImportsIgnoreCase.IsTrue()
+	if preferences.OrganizeImportsIgnoreCase == core.TSUnknown {
+		ignoreCase = detectIgnoreCase(groups, preferences.OrganizeImportsNumericCollation)
+	}
+	return getStringComparer(ignoreCase, preferences.OrganizeImportsNumericCollation)
+}
+
+func getStringComparer(ignoreCase bool, numeric bool) func(a, b string) int {
+	if numeric {
+		return func(a, b string) int {
+			return cmp.Or(compareStringsNaturally(a, b, ignoreCase), stringutil.CompareStringsCaseSensitive(a, b))
+		}
+	}
+	if ignoreCase {
+		return func(a, b string) int {
+			return cmp.Or(stringutil.CompareStringsCaseInsensitive(a, b), stringutil.CompareStringsCaseSensitive(a, b))
+		}
+	}
+	return stringutil.CompareStringsCaseSensitive
+}
+
+func detectIgnoreCase(groups [][]*ast.Node, numeric bool) bool {
+	caseSensitive := getStringComparer(false /*ignoreCase*/, numeric)
+	caseInsensitive := getStringComparer(true /*ignoreCase*/, numeric)
+	sortedCaseSensitively := false
+	for _, group := range groups {
+		lists := [][]string{core.Map(group, func(declaration *ast.Node) string {
+			return declaration.AsImportDeclaration().ModuleSpecifier.Text()
+		})}
+		for _, declaration := range group {
+			if namedBindings := declaration.AsImportDeclaration().ImportClause.AsImportClause().NamedBindings; namedBindings != nil && ast.IsNamedImports(namedBindings) {
+				lists = append(lists, core.Map(namedBindings.AsNamedImports().Elements.Nodes, getImportedName))
+			}
+		}
+		for _, list := range lists {
+			isSortedCaseSensitively := slices.IsSortedFunc(list, caseSensitive)
+			isSortedCaseInsensitively := slices.IsSortedFunc(list, caseInsensitive)
+			if isSortedCaseInsensitively && !isSortedCaseSensitively {
+				return true
+			}
+			if isSortedCaseSensitively && !isSortedCaseInsensitively {
+				sortedCaseSensitively = true
+			}
+		}
+	}
+	return !sortedCaseSensitively
+}
+
+// compareStringsNaturally compares strings with runs of ASCII digits compared by their numeric value.
+func compareStringsNaturally(a, b string, ignoreCase bool) int {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			digitsA, digitsB := leadingDigits(a), leadingDigits(b)
+			numberA, numberB := strings.TrimLeft(digitsA, "0"), strings.TrimLeft(digitsB, "0")
+			if c := cmp.Or(cmp.Compare(len(numberA), len(numberB)), strings.Compare(numberA, numberB)); c != 0 {
+				return c
+			}
+			a, b = a[len(digitsA):], b[len(digitsB):]
+			continue
+		}
+		charA, charB := a[0], b[0]
+		if ignoreCase {
+			charA, charB = toUpper(charA), toUpper(charB)
+		}
+		if charA != charB {
+			return cmp.Compare(charA, charB)
+		}
+		a, b = a[1:], b[1:]
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+func leadingDigits(s string) string {
+	end := 0
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+	return s[:end]
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func toUpper(ch byte) byte {
+	if ch >= 'a' && ch <= 'z' {
+		return ch - 'a' + 'A'
+	}
+	return ch
+}
@@ -0,0 +1,193 @@
+package ls_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"gotest.tools/v3/assert"
+)
+
+func TestOrganizeImports(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"/home/src/a.ts":       "export const a = 1;\nexport const b = 2;\nexport const C = 3;\nexport default function def() {}\nexport type T = number;\n",
+		"/home/src/b.ts":       "export const x = 1;\nexport const item2 = 2;\nexport const item10 = 10;\n",
+		"/home/src/side.ts":    "export {};\n",
+		"/home/src/types.d.ts": "declare module \"pkg\" {\n    export const p: number;\n}\n",
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		mode        ls.OrganizeImportsMode
+		preferences *ls.UserPreferences
+		expected    string
+	}{
+		{
+			name: "coalesce and sort",
+			content: `import { b, a } from "./a";
+import { x } from "./b";
+import def from "./a";
+import { p } from "pkg";
+export const values = [a, b, x, def, p];
+`,
+			expected: `import { p } from "pkg";
+import def, { a, b } from "./a";
+import { x } from "./b";
+export const values = [a, b, x, def, p];
+`,
+		},
+		{
+			name: "remove unused",
+			content: `import { a, b } from "./a";
+import { x } from "./b";
+export const values = [b];
+`,
+			expected: `import { b } from "./a";
+export const values = [b];
+`,
+		},
+		{
+			name: "keep imports used as types",
+			content: `import { T, a } from "./a";
+export const value: T = 1;
+`,
+			expected: `import { T } from "./a";
+export const value: T = 1;
+`,
+		},
+		{
+			name: "remove unused only",
+			content: `import { x } from "./b";
+import { b, a } from "./a";
+export const values = [b];
+`,
+			mode: ls.OrganizeImportsModeRemoveUnused,
+			expected: `import { b } from "./a";
+export const values = [b];
+`,
+		},
+		{
+			name: "sort and combine only",
+			content: `import { x } from "./b";
+import { b, a } from "./a";
+export const values = [b];
+`,
+			mode: ls.OrganizeImportsModeSortAndCombine,
+			expected: `import { a, b } from "./a";
+import { x } from "./b";
+export const values = [b];
+`,
+		},
+		{
+			name: "keep comments attached",
+			content: `// header
+import { x } from "./b"; // x
+// a
+import { a } from "./a";
+export const values = [a, x];
+`,
+			expected: `// header
+// a
+import { a } from "./a";
+import { x } from "./b"; // x
+export const values = [a, x];
+`,
+		},
+		{
+			name: "side effect imports are not reordered",
+			content: `import { x } from "./b";
+import "./side";
+import { b, a } from "./a";
+export const values = [a, b, x];
+`,
+			expected: `import { x } from "./b";
+import "./side";
+import { a, b } from "./a";
+export const values = [a, b, x];
+`,
+		},
+		{
+			name: "blank lines separate groups",
+			content: `import { x } from "./b";
+
+import { a } from "./a";
+export const values = [a, x];
+`,
+			expected: `import { x } from "./b";
+
+import { a } from "./a";
+export const values = [a, x];
+`,
+		},
+		{
+			name: "case-insensitive",
+			content: `import { b, C, a } from "./a";
+export const values = [a, b, C];
+`,
+			preferences: &ls.UserPreferences{OrganizeImportsIgnoreCase: core.TSTrue},
+			expected: `import { a, b, C } from "./a";
+export const values = [a, b, C];
+`,
+		},
+		{
+			name: "case-sensitive",
+			content: `import { b, C, a } from "./a";
+export const values = [a, b, C];
+`,
+			preferences: &ls.UserPreferences{OrganizeImportsIgnoreCase: core.TSFalse},
+			expected: `import { C, a, b } from "./a";
+export const values = [a, b, C];
+`,
+		},
+		{
+			name: "detected case-sensitive",
+			content: `import { C, a, b } from "./a";
+import { x } from "./b";
+export const values = [a, b, C, x];
+`,
+			expected: `import { C, a, b } from "./a";
+import { x } from "./b";
+export const values = [a, b, C, x];
+`,
+		},
+		{
+			name: "natural",
+			content: `import { item10, item2 } from "./b";
+export const values = [item2, item10];
+`,
+			preferences: &ls.UserPreferences{OrganizeImportsNumericCollation: true},
+			expected: `import { item2, item10 } from "./b";
+export const values = [item2, item10];
+`,
+		},
+		{
+			name: "ordinal",
+			content: `import { item2, item10 } from "./b";
+export const values = [item2, item10];
+`,
+			expected: `import { item10, item2 } from "./b";
+export const values = [item2, item10];
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			testFiles := map[string]string{mainFileName: test.content}
+			for fileName, text := range files {
+				testFiles[fileName] = text
+			}
+			service := setup(t, testFiles, nil)
+			changes := service.OrganizeImports(mainFileName, core.OrElse(test.mode, ls.OrganizeImportsModeAll), test.preferences)
+			if test.content == test.expected {
+				assert.Equal(t, len(changes), 0)
+				return
+			}
+			assert.Equal(t, applyCodeAction(t, test.content, &ls.CodeAction{Changes: changes}), test.expected)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package ls
+
+import "github.com/microsoft/typescript-go/internal/core"
+
+type IncludeInlayParameterNameHints string
+
+const (
+	IncludeInlayParameterNameHintsNone     IncludeInlayParameterNameHints = "none"
+	IncludeInlayParameterNameHintsLiterals IncludeInlayParameterNameHints = "literals"
+	IncludeInlayParameterNameHintsAll      IncludeInlayParameterNameHints = "all"
+)
+
+type ImportModuleSpecifierPreference string
+
+const (
+	ImportModuleSpecifierPreferenceShortest        ImportModuleSpecifierPreference = "shortest"
+	ImportModuleSpecifierPreferenceProjectRelative ImportModuleSpecifierPreference = "project-relative"
+	ImportModuleSpecifierPreferenceRelative        ImportModuleSpecifierPreference = "relative"
+	ImportModuleSpecifierPreferenceNonRelative     ImportModuleSpecifierPreference = "non-relative"
+)
+
+type ImportModuleSpecifierEnding string
+
+const (
+	ImportModuleSpecifierEndingAuto    ImportModuleSpecifierEnding = "auto"
+	ImportModuleSpecifierEndingMinimal ImportModuleSpecifierEnding = "minimal"
+	ImportModuleSpecifierEndingIndex   ImportModuleSpecifierEnding = "index"
+	ImportModuleSpecifierEndingJs      ImportModuleSpecifierEnding = "js"
+)
+
+type IncludePackageJsonAutoImports string
+
+const (
+	IncludePackageJsonAutoImportsAuto IncludePackageJsonAutoImports = "auto"
+	IncludePackageJsonAutoImportsOn   IncludePackageJsonAutoImports = "on"
+	IncludePackageJsonAutoImportsOff  IncludePackageJsonAutoImports = "off"
+)
+
+type OrganizeImportsMode string
+
+const (
+	// OrganizeImportsModeAll removes unused imports, then coalesces and sorts the remaining ones.
+	OrganizeImportsModeAll OrganizeImportsMode = "All"
+	// OrganizeImportsModeSortAndCombine coalesces and sorts imports without removing any.
+	OrganizeImportsModeSortAndCombine OrganizeImportsMode = "SortAndCombine"
+	// OrganizeImportsModeRemoveUnused only removes unused imports.
+	OrganizeImportsModeRemoveUnused OrganizeImportsMode = "RemoveUnused"
+)
+
+// UserPreferences are the editor settings that affect language service results.
+// The JSON names match the preferences accepted by tsserver.
+type UserPreferences struct {
+	IncludeInlayParameterNameHints                        IncludeInlayParameterNameHints `json:"includeInlayParameterNameHints,omitzero"`
+	IncludeInlayParameterNameHintsWhenArgumentMatchesName bool                           `json:"includeInlayParameterNameHintsWhenArgumentMatchesName,omitzero"`
+	IncludeInlayFunctionParameterTypeHints                bool                           `json:"includeInlayFunctionParameterTypeHints,omitzero"`
+	IncludeInlayVariableTypeHints                         bool                           `json:"includeInlayVariableTypeHints,omitzero"`
+	IncludeInlayVariableTypeHintsWhenTypeMatchesName      bool                           `json:"includeInlayVariableTypeHintsWhenTypeMatchesName,omitzero"`
+	IncludeInlayPropertyDeclarationTypeHints              bool                           `json:"includeInlayPropertyDeclarationTypeHints,omitzero"`
+	IncludeInlayFunctionLikeReturnTypeHints               bool                           `json:"includeInlayFunctionLikeReturnTypeHints,omitzero"`
+	IncludeInlayEnumMemberValueHints                      bool                           `json:"includeInlayEnumMemberValueHints,omitzero"`
+
+	ImportModuleSpecifierPreference ImportModuleSpecifierPreference `json:"importModuleSpecifierPreference,omitzero"`
+	ImportModuleSpecifierEnding     ImportModuleSpecifierEnding     `json:"importModuleSpecifierEnding,omitzero"`
+	IncludePackageJsonAutoImports   IncludePackageJsonAutoImports   `json:"includePackageJsonAutoImports,omitzero"`
+
+	// OrganizeImportsIgnoreCase selects case-insensitive sorting. When unknown, the ordering already
+	// used by the file is detected.
+	OrganizeImportsIgnoreCase core.Tristate `json:"organizeImportsIgnoreCase,omitzero"`
+	// OrganizeImportsNumericCollation sorts runs of digits by their numeric value, so that "a2" precedes "a10".
+	OrganizeImportsNumericCollation bool `json:"organizeImportsNumericCollation,omitzero"`
+}
+
+func (p *UserPreferences) shouldShowParameterNameHints() bool {
+	return p.IncludeInlayParameterNameHints == IncludeInlayParameterNameHintsLiterals || p.IncludeInlayParameterNameHints == IncludeInlayParameterNameHintsAll
+}
+
+func (p *UserPreferences) shouldShowLiteralParameterNameHintsOnly() bool {
+	return p.IncludeInlayParameterNameHints == IncludeInlayParameterNameHintsLiterals
+}
@@ -0,0 +1,42 @@
+package ls
+
+import (
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+)
+
+// findReferences returns the identifiers in the program that refer to a symbol, either directly or through
+// aliases, including names in JSDoc links and excluding the names of its declarations. Only files whose
+// text contains the name of the symbol are searched.
+func findReferences(program *compiler.Program, checker *checker.Checker, symbol *ast.Symbol) []*ast.Node {
+	var references []*ast.Node
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if ast.IsIdentifier(node) {
+			if node.Text() == symbol.Name && !isDeclarationNameOfReference(node) && getResolvedSymbolAtLocation(checker, node) == symbol {
+				references = append(references, node)
+			}
+			return false
+		}
+		// Names in inline JSDoc links and `@see` tags are references too.
+		for _, jsdoc := range node.JSDoc(nil) {
+			jsdoc.ForEachChild(visit)
+		}
+		return node.ForEachChild(visit)
+	}
+	for _, file := range program.SourceFiles() {
+		if program.IsSourceFileDefaultLibrary(file) || !strings.Contains(file.Text, symbol.Name) {
+			continue
+		}
+		file.AsNode().ForEachChild(visit)
+	}
+	return references
+}
+
+func isDeclarationNameOfReference(node *ast.Node) bool {
+	// Property accesses are declarations in JavaScript expando assignments, but are references otherwise.
+	return ast.IsDeclarationName(node) && !ast.IsPropertyAccessExpression(node.Parent)
+}
@@ -0,0 +1,85 @@
+package ls
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+// SelectionRange is a range that an editor selects when expanding the selection, with the range it
+// expands to next.
+type SelectionRange struct {
+	Range  core.TextRange
+	Parent *SelectionRange
+}
+
+// ProvideSelectionRange returns the innermost selection range at a position. It starts at the token at the
+// position and expands through its ancestors, the lists of siblings they belong to and, for string literals,
+// the text between the quotes, up to the whole file. The result is never nil.
+func (l *LanguageService) ProvideSelectionRange(fileName string, position int) *SelectionRange {
+	_, file := l.getProgramAndBoundFile(fileName)
+	var ranges []core.TextRange
+	add := func(pos int, end int) {
+		// Skip empty ranges and ranges that do not expand the previous one.
+		if pos >= end || len(ranges) != 0 && ranges[len(ranges)-1].Pos() <= pos && end <= ranges[len(ranges)-1].End() {
+			return
+		}
+		ranges = append(ranges, core.NewTextRange(pos, end))
+	}
+
+	for node := astnav.GetTouchingPropertyName(file, position); node != nil && !ast.IsSourceFile(node); node = node.Parent {
+		pos := scanner.GetTokenPosOfNode(node, file, false /*includeJsDoc*/)
+		if position < pos || position > node.End() {
+			continue
+		}
+		if ast.IsStringLiteral(node) || node.Kind == ast.KindNoSubstitutionTemplateLiteral {
+			add(pos+1, node.End()-1)
+		}
+		add(pos, node.End())
+		if list := getContainingNodeList(node); list != nil && len(list.Nodes) > 1 {
+			add(scanner.GetTokenPosOfNode(list.Nodes[0], file, false /*includeJsDoc*/), list.Nodes[len(list.Nodes)-1].End())
+		}
+	}
+	// The whole file ends every chain, even for an empty file.
+	if fileRange := core.NewTextRange(0, len(file.Text)); len(ranges) == 0 || ranges[len(ranges)-1] != fileRange {
+		ranges = append(ranges, fileRange)
+	}
+
+	var result *SelectionRange
+	for i := len(ranges) - 1; i >= 0; i-- {
+		result = &SelectionRange{Range: ranges[i], Parent: result}
+	}
+	return result
+}
+
+// getContainingNodeList returns the list of the parent of a node that contains the node, such as the
+// statements of a block or the arguments of a call.
+func getContainingNodeList(node *ast.Node) *ast.NodeList {
+	if node.Parent == nil {
+		return nil
+	}
+	var result *ast.NodeList
+	visitNodes := func(list *ast.NodeList, _ *ast.NodeVisitor) *ast.NodeList {
+		if list != nil && result == nil && list.Pos() <= node.Pos() && node.End() <= list.End() {
+			for _, element := range list.Nodes {
+				if element == node {
+					result = list
+					break
+				}
+			}
+		}
+		return list
+	}
+	visitor := ast.NewNodeVisitor(core.Identity, nil, ast.NodeVisitorHooks{
+		VisitNodes: visitNodes,
+		VisitModifiers: func(modifiers *ast.ModifierList, visitor *ast.NodeVisitor) *ast.ModifierList {
+			if modifiers != nil {
+				visitNodes(&modifiers.NodeList, visitor)
+			}
+			return modifiers
+		},
+	})
+	node.Parent.VisitEachChild(visitor)
+	return result
+}
@@ -0,0 +1,279 @@
+package ls
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+type SemanticTokenType int
+
+const (
+	SemanticTokenTypeClass SemanticTokenType = iota
+	SemanticTokenTypeEnum
+	SemanticTokenTypeInterface
+	SemanticTokenTypeNamespace
+	SemanticTokenTypeTypeParameter
+	SemanticTokenTypeType
+	SemanticTokenTypeParameter
+	SemanticTokenTypeVariable
+	SemanticTokenTypeEnumMember
+	SemanticTokenTypeProperty
+	SemanticTokenTypeFunction
+	SemanticTokenTypeMethod
+)
+
+// SemanticTokenTypes lists the token type names in the order of SemanticTokenType.
+var SemanticTokenTypes = []string{
+	"class",
+	"enum",
+	"interface",
+	"namespace",
+	"typeParameter",
+	"type",
+	"parameter",
+	"variable",
+	"enumMember",
+	"property",
+	"function",
+	"method",
+}
+
+type SemanticTokenModifier int
+
+const (
+	SemanticTokenModifierDeclaration SemanticTokenModifier = 1 << iota
+	SemanticTokenModifierStatic
+	SemanticTokenModifierAsync
+	SemanticTokenModifierReadonly
+	SemanticTokenModifierDefaultLibrary
+	SemanticTokenModifierLocal
+
+	SemanticTokenModifierNone SemanticTokenModifier = 0
+)
+
+// SemanticTokenModifiers lists the modifier names in bit order of SemanticTokenModifier.
+var SemanticTokenModifiers = []string{
+	"declaration",
+	"static",
+	"async",
+	"readonly",
+	"defaultLibrary",
+	"local",
+}
+
+type SemanticToken struct {
+	core.TextRange
+	Type      SemanticTokenType
+	Modifiers SemanticTokenModifier
+}
+
+// ProvideSemanticTokens classifies the identifiers of a file that intersect the given span.
+// Tokens are returned in document order.
+func (l *LanguageService) ProvideSemanticTokens(fileName string, span core.TextRange) []SemanticToken {
+	program, file := l.getProgramAndFile(fileName)
+	c := &semanticTokenCollector{
+		program: program,
+		checker: program.GetTypeChecker(),
+		file:    file,
+		span:    span,
+	}
+	c.visit(file.AsNode())
+	return c.tokens
+}
+
+type semanticTokenCollector struct {
+	program *compiler.Program
+	checker *checker.Checker
+	file    *ast.SourceFile
+	span    core.TextRange
+	tokens  []SemanticToken
+}
+
+func (c *semanticTokenCollector) visit(node *ast.Node) bool {
+	if node.End() < c.span.Pos() || node.Pos() > c.span.End() {
+		return false
+	}
+	if node.Flags&ast.NodeFlagsJSDoc != 0 {
+		return false
+	}
+	if ast.IsIdentifier(node) {
+		c.classifyIdentifier(node)
+		return false
+	}
+	node.ForEachChild(c.visit)
+	return false
+}
+
+func (c *semanticTokenCollector) classifyIdentifier(node *ast.Node) {
+	if node.Parent == nil || node.Text() == "" {
+		return
+	}
+	symbol := c.checker.GetSymbolAtLocation(node)
+	if symbol == nil {
+		return
+	}
+	if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+		resolved, ok := c.checker.ResolveAlias(symbol)
+		if !ok {
+			return
+		}
+		symbol = resolved
+	}
+	tokenType, ok := c.classifySymbol(symbol, getMeaningFromLocation(node))
+	if !ok {
+		return
+	}
+
+	modifiers := SemanticTokenModifierNone
+	decl := symbol.ValueDeclaration
+	if decl == nil && len(symbol.Declarations) > 0 {
+		decl = symbol.Declarations[0]
+	}
+	if decl != nil {
+		modifierFlags := ast.GetCombinedModifierFlags(decl)
+		if isDeclarationNameOf(node) {
+			modifiers |= SemanticTokenModifierDeclaration
+		}
+		if modifierFlags&ast.ModifierFlagsStatic != 0 {
+			modifiers |= SemanticTokenModifierStatic
+		}
+		if modifierFlags&ast.ModifierFlagsAsync != 0 {
+			modifiers |= SemanticTokenModifierAsync
+		}
+		if modifierFlags&ast.ModifierFlagsReadonly != 0 || symbol.Flags&ast.SymbolFlagsEnumMember != 0 || isConstVariable(decl) {
+			modifiers |= SemanticTokenModifierReadonly
+		}
+		if (tokenType == SemanticTokenTypeVariable || tokenType == SemanticTokenTypeFunction) && isLocalDeclaration(decl) {
+			modifiers |= SemanticTokenModifierLocal
+		}
+		if declFile := ast.GetSourceFileOfNode(decl); declFile != nil && c.program.IsSourceFileDefaultLibrary(declFile) {
+			modifiers |= SemanticTokenModifierDefaultLibrary
+		}
+	}
+
+	tokenType = c.reclassifyByType(node, symbol, tokenType)
+
+	c.tokens = append(c.tokens, SemanticToken{
+		TextRange: core.NewTextRange(scanner.GetTokenPosOfNode(node, c.file, false /*includeJsDoc*/), node.End()),
+		Type:      tokenType,
+		Modifiers: modifiers,
+	})
+}
+
+func (c *semanticTokenCollector) classifySymbol(symbol *ast.Symbol, meaning semanticMeaning) (SemanticTokenType, bool) {
+	flags := symbol.Flags
+	switch {
+	case flags&ast.SymbolFlagsClass != 0:
+		return SemanticTokenTypeClass, true
+	case flags&ast.SymbolFlagsEnum != 0:
+		return SemanticTokenTypeEnum, true
+	case flags&ast.SymbolFlagsTypeAlias != 0:
+		return SemanticTokenTypeType, true
+	case flags&ast.SymbolFlagsInterface != 0:
+		if meaning&semanticMeaningType != 0 {
+			return SemanticTokenTypeInterface, true
+		}
+	case flags&ast.SymbolFlagsTypeParameter != 0:
+		return SemanticTokenTypeTypeParameter, true
+	}
+	switch {
+	case flags&ast.SymbolFlagsEnumMember != 0:
+		return SemanticTokenTypeEnumMember, true
+	case flags&(ast.SymbolFlagsProperty|ast.SymbolFlagsAccessor) != 0:
+		return SemanticTokenTypeProperty, true
+	case flags&ast.SymbolFlagsMethod != 0:
+		return SemanticTokenTypeMethod, true
+	case flags&ast.SymbolFlagsFunction != 0:
+		return SemanticTokenTypeFunction, true
+	case flags&ast.SymbolFlagsVariable != 0:
+		if symbol.ValueDeclaration != nil && ast.IsParameter(symbol.ValueDeclaration) {
+			return SemanticTokenTypeParameter, true
+		}
+		return SemanticTokenTypeVariable, true
+	case flags&ast.SymbolFlagsModule != 0:
+		return SemanticTokenTypeNamespace, true
+	case flags&ast.SymbolFlagsInterface != 0:
+		return SemanticTokenTypeInterface, true
+	}
+	return 0, false
+}
+
+// reclassifyByType upgrades variables and properties whose type is only callable to functions and methods.
+func (c *semanticTokenCollector) reclassifyByType(node *ast.Node, symbol *ast.Symbol, tokenType SemanticTokenType) SemanticTokenType {
+	if tokenType != SemanticTokenTypeVariable && tokenType != SemanticTokenTypeProperty && tokenType != SemanticTokenTypeParameter {
+		return tokenType
+	}
+	if tokenType == SemanticTokenTypeParameter && isDeclarationNameOf(node) {
+		// Keep parameter declarations as parameters regardless of their type.
+		return tokenType
+	}
+	t := c.checker.GetTypeOfSymbolAtLocation(symbol, node)
+	if t == nil || t.Flags()&checker.TypeFlagsObject == 0 {
+		return tokenType
+	}
+	if len(c.checker.GetSignaturesOfType(t, checker.SignatureKindCall)) == 0 {
+		return tokenType
+	}
+	if len(c.checker.GetPropertiesOfType(t)) != 0 && len(c.checker.GetSignaturesOfType(t, checker.SignatureKindConstruct)) != 0 {
+		return tokenType
+	}
+	if tokenType == SemanticTokenTypeProperty {
+		return SemanticTokenTypeMethod
+	}
+	return SemanticTokenTypeFunction
+}
+
+func isDeclarationNameOf(node *ast.Node) bool {
+	return ast.IsDeclaration(node.Parent) && ast.GetNameOfDeclaration(node.Parent) == node
+}
+
+func isConstVariable(decl *ast.Node) bool {
+	return ast.IsVariableDeclaration(decl) && ast.IsVarConst(decl)
+}
+
+func isLocalDeclaration(decl *ast.Node) bool {
+	if ast.IsBindingElement(decl) {
+		decl = ast.FindAncestor(decl, func(n *ast.Node) bool { return !ast.IsBindingElement(n) && !ast.IsBindingPattern(n) })
+	}
+	switch {
+	case decl == nil:
+		return false
+	case ast.IsVariableDeclaration(decl):
+		if ast.IsCatchClause(decl.Parent) {
+			return true
+		}
+		return !isSourceFileOrModuleBlock(decl.Parent.Parent.Parent)
+	case ast.IsFunctionDeclaration(decl):
+		return !isSourceFileOrModuleBlock(decl.Parent)
+	}
+	return false
+}
+
+func isSourceFileOrModuleBlock(node *ast.Node) bool {
+	return node != nil && (ast.IsSourceFile(node) || ast.IsModuleBlock(node))
+}
+
+type semanticMeaning int
+
+const (
+	semanticMeaningValue semanticMeaning = 1 << iota
+	semanticMeaningType
+	semanticMeaningNamespace
+
+	semanticMeaningAll = semanticMeaningValue | semanticMeaningType | semanticMeaningNamespace
+)
+
+func getMeaningFromLocation(node *ast.Node) semanticMeaning {
+	switch {
+	case ast.IsPartOfTypeNode(node):
+		return semanticMeaningType
+	case ast.IsExpressionNode(node):
+		return semanticMeaningValue
+	case ast.IsDeclarationName(node):
+		return semanticMeaningAll
+	}
+	return semanticMeaningAll
+}
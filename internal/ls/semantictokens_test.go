@@ -0,0 +1,92 @@
+package ls_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"gotest.tools/v3/assert"
+)
+
+func TestProvideSemanticTokens(t *testing.T) {
+	t.Parallel()
+
+	const content = `interface Shape { readonly sides: number }
+type Alias = Shape;
+enum Color { Red = 1 }
+namespace NS { export const value = 1; }
+class Square implements Shape {
+    static count = 0;
+    readonly sides = 4;
+    async area<T>(scale: T) { return 1; }
+}
+function make(callback: () => void) {
+    const local = new Square();
+    callback();
+    return local.sides + Color.Red + NS.value + Math.PI;
+}`
+
+	service := setup(t, map[string]string{mainFileName: content}, nil)
+	tokens := service.ProvideSemanticTokens(mainFileName, core.NewTextRange(0, len(content)))
+	assert.DeepEqual(t, formatSemanticTokens(content, tokens), []string{
+		"Shape: interface [declaration]",
+		"sides: property [declaration readonly]",
+		"Alias: type [declaration]",
+		"Shape: interface",
+		"Color: enum [declaration]",
+		"Red: enumMember [declaration readonly]",
+		"NS: namespace [declaration]",
+		"value: variable [declaration readonly]",
+		"Square: class [declaration]",
+		"Shape: interface",
+		"count: property [declaration static]",
+		"sides: property [declaration readonly]",
+		"area: method [declaration async]",
+		"T: typeParameter [declaration]",
+		"scale: parameter [declaration]",
+		"T: typeParameter",
+		"make: function [declaration]",
+		"callback: parameter [declaration]",
+		"local: variable [declaration readonly local]",
+		"Square: class",
+		"callback: function",
+		"local: variable [readonly local]",
+		"sides: property [readonly]",
+		"Color: enum",
+		"Red: enumMember [readonly]",
+		"NS: namespace",
+		"value: variable [readonly]",
+		"Math: variable [defaultLibrary]",
+		"PI: property [readonly defaultLibrary]",
+	})
+
+	t.Run("range", func(t *testing.T) {
+		t.Parallel()
+		start := markerPosition(t, content, "function make")
+		end := markerPosition(t, content, "(callback")
+		tokens := service.ProvideSemanticTokens(mainFileName, core.NewTextRange(start, end))
+		assert.DeepEqual(t, formatSemanticTokens(content, tokens), []string{
+			"make: function [declaration]",
+		})
+	})
+}
+
+func formatSemanticTokens(text string, tokens []ls.SemanticToken) []string {
+	result := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		var modifiers []string
+		for i, name := range ls.SemanticTokenModifiers {
+			if token.Modifiers&(1<<i) != 0 {
+				modifiers = append(modifiers, name)
+			}
+		}
+		line := fmt.Sprintf("%s: %s", text[token.Pos():token.End()], ls.SemanticTokenTypes[token.Type])
+		if len(modifiers) > 0 {
+			line += " [" + strings.Join(modifiers, " ") + "]"
+		}
+		result = append(result, line)
+	}
+	return result
+}
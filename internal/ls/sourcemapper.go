@@ -0,0 +1,104 @@
+package ls
+
+import (
+	"encoding/base64"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/sourcemap"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+const base64SourceMapPrefix = "data:application/json;base64,"
+
+// tryGetSourceLocation maps a location in a declaration file to the source file it was generated from,
+// using the declaration map named by the file's sourceMappingURL comment. The location is returned
+// unchanged if the file has no usable declaration map or the source file cannot be read.
+func (l *LanguageService) tryGetSourceLocation(location Location) Location {
+	if !tspath.IsDeclarationFileName(location.FileName) {
+		return location
+	}
+	file := l.GetProgram().GetSourceFile(location.FileName)
+	if file == nil {
+		return location
+	}
+	mapper := l.getDocumentPositionMapper(location.FileName, file.Text)
+	if mapper == nil {
+		return location
+	}
+
+	startLine, startCharacter := scanner.GetLineAndCharacterOfPosition(file, location.Range.Pos())
+	sourceFileName, sourceStartLine, sourceStartCharacter, ok := mapper.GetSourcePosition(startLine, startCharacter)
+	if !ok {
+		return location
+	}
+	sourceText, ok := l.readSourceText(sourceFileName)
+	if !ok {
+		return location
+	}
+	lineStarts := core.ComputeLineStarts(sourceText)
+	start := getClampedPosition(sourceText, lineStarts, sourceStartLine, sourceStartCharacter)
+	end := start
+	endLine, endCharacter := scanner.GetLineAndCharacterOfPosition(file, location.Range.End())
+	if endFileName, sourceEndLine, sourceEndCharacter, ok := mapper.GetSourcePosition(endLine, endCharacter); ok && endFileName == sourceFileName {
+		end = max(getClampedPosition(sourceText, lineStarts, sourceEndLine, sourceEndCharacter), start)
+	}
+	return Location{
+		FileName: sourceFileName,
+		Range:    core.NewTextRange(start, end),
+	}
+}
+
+func (l *LanguageService) getDocumentPositionMapper(fileName string, text string) *sourcemap.DocumentPositionMapper {
+	url := sourcemap.TryGetSourceMappingURL(text)
+	if url == "" {
+		return nil
+	}
+	var mapFileName, mapText string
+	if data, ok := strings.CutPrefix(url, base64SourceMapPrefix); ok {
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil
+		}
+		mapFileName, mapText = fileName, string(decoded)
+	} else {
+		mapFileName = tspath.GetNormalizedAbsolutePath(url, tspath.GetDirectoryPath(fileName))
+		if mapText, ok = l.host.FS().ReadFile(mapFileName); !ok {
+			return nil
+		}
+	}
+	sourceMap, err := sourcemap.ParseRawSourceMap(mapText)
+	if err != nil {
+		return nil
+	}
+	mapper, err := sourcemap.NewDocumentPositionMapper(sourceMap, mapFileName)
+	if err != nil {
+		return nil
+	}
+	return mapper
+}
+
+func (l *LanguageService) readSourceText(fileName string) (string, bool) {
+	if file := l.GetProgram().GetSourceFile(fileName); file != nil {
+		return file.Text, true
+	}
+	return l.host.FS().ReadFile(fileName)
+}
+
+// getClampedPosition converts a line and character to a position, clamping both to the text so that a
+// source file edited since its declaration map was generated does not produce an invalid location.
+func getClampedPosition(text string, lineStarts []core.TextPos, line int, character int) int {
+	line = min(max(line, 0), len(lineStarts)-1)
+	pos := int(lineStarts[line])
+	lineEnd := len(text)
+	if line+1 < len(lineStarts) {
+		lineEnd = int(lineStarts[line+1])
+	}
+	for ; character > 0 && pos < lineEnd; character-- {
+		_, size := utf8.DecodeRuneInString(text[pos:])
+		pos += size
+	}
+	return pos
+}
@@ -0,0 +1,143 @@
+package ls
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+type FileTextChanges struct {
+	FileName    string
+	TextChanges []TextChange
+}
+
+// changeTracker accumulates text changes across files. Changes may be added in any order;
+// duplicate changes and changes overlapping an earlier one are dropped when the result is computed.
+type changeTracker struct {
+	newLine   string
+	fileNames []string
+	changes   map[string][]TextChange
+}
+
+func newChangeTracker(newLine string) *changeTracker {
+	return &changeTracker{
+		newLine: newLine,
+		changes: make(map[string][]TextChange),
+	}
+}
+
+func (t *changeTracker) add(fileName string, change TextChange) {
+	if _, ok := t.changes[fileName]; !ok {
+		t.fileNames = append(t.fileNames, fileName)
+	}
+	t.changes[fileName] = append(t.changes[fileName], change)
+}
+
+func (t *changeTracker) addFileTextChanges(changes []FileTextChanges) {
+	for _, fileChanges := range changes {
+		for _, change := range fileChanges.TextChanges {
+			t.add(fileChanges.FileName, change)
+		}
+	}
+}
+
+func (t *changeTracker) insertText(file *ast.SourceFile, pos int, text string) {
+	t.add(file.FileName(), TextChange{TextRange: core.NewTextRange(pos, pos), NewText: text})
+}
+
+func (t *changeTracker) replaceRange(file *ast.SourceFile, textRange core.TextRange, text string) {
+	t.add(file.FileName(), TextChange{TextRange: textRange, NewText: text})
+}
+
+func (t *changeTracker) deleteRange(file *ast.SourceFile, textRange core.TextRange) {
+	t.replaceRange(file, textRange, "")
+}
+
+func (t *changeTracker) replaceNode(file *ast.SourceFile, node *ast.Node, text string) {
+	t.replaceRange(file, core.NewTextRange(scanner.GetTokenPosOfNode(node, file, false /*includeJsDoc*/), node.End()), text)
+}
+
+// deleteNode deletes a node along with its JSDoc. If the node is the only thing on its lines,
+// the lines are deleted as well.
+func (t *changeTracker) deleteNode(file *ast.SourceFile, node *ast.Node) {
+	text := file.Text
+	start := scanner.GetTokenPosOfNode(node, file, true /*includeJsDoc*/)
+	end := node.End()
+	lineStart := getLineStartOfPosition(text, start)
+	lineEnd := end
+	for lineEnd < len(text) && (text[lineEnd] == ' ' || text[lineEnd] == '\t') {
+		lineEnd++
+	}
+	if isWhiteSpaceOnly(text[lineStart:start]) && (lineEnd == len(text) || text[lineEnd] == '\r' || text[lineEnd] == '\n') {
+		start = lineStart
+		if strings.HasPrefix(text[lineEnd:], "\r\n") {
+			lineEnd += 2
+		} else if lineEnd < len(text) {
+			lineEnd++
+		}
+		end = lineEnd
+	}
+	t.deleteRange(file, core.NewTextRange(start, end))
+}
+
+// deleteListElement deletes the element at index from a comma-separated list, along with the adjacent comma.
+func (t *changeTracker) deleteListElement(file *ast.SourceFile, elements []*ast.Node, index int) {
+	element := elements[index]
+	switch {
+	case index < len(elements)-1:
+		start := scanner.GetTokenPosOfNode(element, file, false /*includeJsDoc*/)
+		end := scanner.GetTokenPosOfNode(elements[index+1], file, false /*includeJsDoc*/)
+		t.deleteRange(file, core.NewTextRange(start, end))
+	case index > 0:
+		t.deleteRange(file, core.NewTextRange(elements[index-1].End(), element.End()))
+	default:
+		t.deleteRange(file, core.NewTextRange(scanner.GetTokenPosOfNode(element, file, false /*includeJsDoc*/), element.End()))
+	}
+}
+
+// getChanges returns the tracked changes of each file sorted by position.
+func (t *changeTracker) getChanges() []FileTextChanges {
+	result := make([]FileTextChanges, 0, len(t.fileNames))
+	for _, fileName := range t.fileNames {
+		changes := slices.Clone(t.changes[fileName])
+		slices.SortStableFunc(changes, func(a, b TextChange) int {
+			return a.Pos() - b.Pos()
+		})
+		var textChanges []TextChange
+		for _, change := range changes {
+			if len(textChanges) != 0 {
+				last := textChanges[len(textChanges)-1]
+				if last == change || change.Pos() < last.End() {
+					continue
+				}
+			}
+			textChanges = append(textChanges, change)
+		}
+		result = append(result, FileTextChanges{FileName: fileName, TextChanges: textChanges})
+	}
+	return result
+}
+
+func getLineStartOfPosition(text string, pos int) int {
+	for pos > 0 && text[pos-1] != '\n' && text[pos-1] != '\r' {
+		pos--
+	}
+	return pos
+}
+
+// getIndentationOfPosition returns the leading whitespace of the line containing pos.
+func getIndentationOfPosition(text string, pos int) string {
+	lineStart := getLineStartOfPosition(text, pos)
+	end := lineStart
+	for end < len(text) && (text[end] == ' ' || text[end] == '\t') {
+		end++
+	}
+	return text[lineStart:end]
+}
+
+func isWhiteSpaceOnly(text string) bool {
+	return strings.TrimLeft(text, " \t") == ""
+}
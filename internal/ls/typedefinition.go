@@ -0,0 +1,79 @@
+package ls
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+)
+
+// ProvideTypeDefinitions returns the declarations of the type of the symbol at a position. Union members,
+// array elements and promised types are unwrapped, so that `Foo[]` and `Promise<Foo>` navigate to `Foo`.
+// For a function with a single signature the declarations of its return type are returned.
+func (l *LanguageService) ProvideTypeDefinitions(fileName string, position int) []Location {
+	program, file := l.getProgramAndFile(fileName)
+	node := astnav.GetTouchingPropertyName(file, position)
+	if node.Kind == ast.KindSourceFile {
+		return nil
+	}
+
+	c := program.GetTypeChecker()
+	symbol := getResolvedSymbolAtLocation(c, node)
+	if symbol == nil {
+		return nil
+	}
+	var t *checker.Type
+	if symbol.Flags&ast.SymbolFlagsValue == 0 && symbol.Flags&ast.SymbolFlagsType != 0 {
+		t = c.GetDeclaredTypeOfSymbol(symbol)
+	} else {
+		t = c.GetTypeOfSymbolAtLocation(symbol, node)
+	}
+	if t == nil {
+		return nil
+	}
+
+	var declarations []*ast.Node
+	if symbol.Flags&(ast.SymbolFlagsFunction|ast.SymbolFlagsMethod) != 0 {
+		if signatures := c.GetSignaturesOfType(t, checker.SignatureKindCall); len(signatures) == 1 {
+			declarations = getTypeDefinitionDeclarations(c, c.GetReturnTypeOfSignature(signatures[0]))
+		}
+	}
+	if len(declarations) == 0 {
+		declarations = getTypeDefinitionDeclarations(c, t)
+	}
+	var unique []*ast.Node
+	for _, decl := range declarations {
+		unique = core.AppendIfUnique(unique, decl)
+	}
+	locations := getLocationsOfDeclarations(unique)
+	for i, location := range locations {
+		locations[i] = l.tryGetSourceLocation(location)
+	}
+	return locations
+}
+
+func getTypeDefinitionDeclarations(c *checker.Checker, t *checker.Type) []*ast.Node {
+	if t.Flags()&checker.TypeFlagsUnion != 0 {
+		var declarations []*ast.Node
+		for _, member := range t.Types() {
+			declarations = append(declarations, getTypeDefinitionDeclarations(c, member)...)
+		}
+		return declarations
+	}
+	var inner *checker.Type
+	if c.IsArrayType(t) {
+		inner = c.GetElementTypeOfArrayType(t)
+	} else {
+		inner = c.GetPromisedTypeOfPromise(t)
+	}
+	if inner != nil {
+		// Fall back to the container when the contained type has no declarations, as for `number[]`.
+		if declarations := getTypeDefinitionDeclarations(c, inner); len(declarations) != 0 {
+			return declarations
+		}
+	}
+	if symbol := t.Symbol(); symbol != nil {
+		return symbol.Declarations
+	}
+	return nil
+}
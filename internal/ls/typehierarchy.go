@@ -0,0 +1,124 @@
+package ls
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+)
+
+// PrepareTypeHierarchy returns the class or interface declaration of the symbol at a position.
+func (l *LanguageService) PrepareTypeHierarchy(fileName string, position int) []HierarchyItem {
+	program, file := l.getProgramAndFile(fileName)
+	if declaration := resolveTypeHierarchyDeclaration(program.GetTypeChecker(), file, position); declaration != nil {
+		return []HierarchyItem{createHierarchyItem(declaration)}
+	}
+	return nil
+}
+
+// ProvideTypeHierarchySupertypes returns the classes and interfaces the declaration of an item directly
+// extends or implements. Extended types come from the checker, so that base classes given by expressions
+// are found as well; implemented interfaces come from the implements clause.
+func (l *LanguageService) ProvideTypeHierarchySupertypes(item HierarchyItem) []HierarchyItem {
+	c := l.GetProgram().GetTypeChecker()
+	declaration := l.resolveTypeHierarchyItem(c, item)
+	if declaration == nil {
+		return nil
+	}
+	symbol := getSymbolOfDeclaration(c, declaration)
+	if symbol == nil {
+		return nil
+	}
+
+	var supertypes []*ast.Node
+	addSupertype := func(symbol *ast.Symbol) {
+		if symbol == nil {
+			return
+		}
+		if supertype := getTypeHierarchyDeclarationOfSymbol(symbol); supertype != nil {
+			supertypes = core.AppendIfUnique(supertypes, supertype)
+		}
+	}
+	for _, base := range c.GetBaseTypes(c.GetDeclaredTypeOfSymbol(symbol)) {
+		addSupertype(base.Symbol())
+	}
+	for _, element := range ast.GetImplementsHeritageClauseElements(declaration) {
+		if name := getHeritageElementName(element); name != nil {
+			addSupertype(getResolvedSymbolAtLocation(c, name))
+		}
+	}
+	return core.Map(supertypes, createHierarchyItem)
+}
+
+// ProvideTypeHierarchySubtypes returns the classes and interfaces that directly extend or implement the
+// declaration of an item.
+func (l *LanguageService) ProvideTypeHierarchySubtypes(item HierarchyItem) []HierarchyItem {
+	program := l.GetProgram()
+	c := program.GetTypeChecker()
+	declaration := l.resolveTypeHierarchyItem(c, item)
+	if declaration == nil {
+		return nil
+	}
+	symbol := getSymbolOfDeclaration(c, declaration)
+	if symbol == nil {
+		return nil
+	}
+
+	var subtypes []*ast.Node
+	for _, reference := range findReferences(program, c, symbol) {
+		element := reference.Parent
+		if ast.IsPropertyAccessExpression(element) && element.Name() == reference {
+			element = element.Parent
+		}
+		if ast.IsExpressionWithTypeArguments(element) && ast.IsHeritageClause(element.Parent) && isTypeHierarchyDeclaration(element.Parent.Parent) {
+			subtypes = core.AppendIfUnique(subtypes, element.Parent.Parent)
+		}
+	}
+	return core.Map(subtypes, createHierarchyItem)
+}
+
+func (l *LanguageService) resolveTypeHierarchyItem(c *checker.Checker, item HierarchyItem) *ast.Node {
+	file := l.GetProgram().GetSourceFile(item.FileName)
+	if file == nil {
+		return nil
+	}
+	return resolveTypeHierarchyDeclaration(c, file, item.SelectionRange.Pos())
+}
+
+func resolveTypeHierarchyDeclaration(c *checker.Checker, file *ast.SourceFile, position int) *ast.Node {
+	node := astnav.GetTouchingPropertyName(file, position)
+	if node.Kind == ast.KindSourceFile {
+		return nil
+	}
+	if isTypeHierarchyDeclaration(node.Parent) && node.Parent.Name() == node {
+		return node.Parent
+	}
+	if node.Kind == ast.KindClassKeyword && isTypeHierarchyDeclaration(node.Parent) {
+		// An anonymous default exported class.
+		return node.Parent
+	}
+	if symbol := getResolvedSymbolAtLocation(c, node); symbol != nil {
+		return getTypeHierarchyDeclarationOfSymbol(symbol)
+	}
+	return nil
+}
+
+func isTypeHierarchyDeclaration(node *ast.Node) bool {
+	return ast.IsClassLike(node) || ast.IsInterfaceDeclaration(node)
+}
+
+func getTypeHierarchyDeclarationOfSymbol(symbol *ast.Symbol) *ast.Node {
+	return core.Find(symbol.Declarations, isTypeHierarchyDeclaration)
+}
+
+// getHeritageElementName returns the name an element of a heritage clause refers to, as in `A` and `ns.A`.
+func getHeritageElementName(element *ast.Node) *ast.Node {
+	expression := element.Expression()
+	switch expression.Kind {
+	case ast.KindIdentifier:
+		return expression
+	case ast.KindPropertyAccessExpression:
+		return expression.Name()
+	}
+	return nil
+}
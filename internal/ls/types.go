@@ -2,16 +2,32 @@ package ls
 
 import "github.com/microsoft/typescript-go/internal/core"
 
-type TextChange struct {
-	core.TextRange
-	NewText string
-}
-
-func (t TextChange) ApplyTo(text string) string {
-	return text[:t.Pos()] + t.NewText + text[t.End():]
-}
+type TextChange = core.TextChange
 
 type Location struct {
 	FileName string
 	Range    core.TextRange
 }
+
+type SymbolKind int
+
+const (
+	SymbolKindFile SymbolKind = iota
+	SymbolKindClass
+	SymbolKindInterface
+	SymbolKindFunction
+	SymbolKindMethod
+	SymbolKindConstructor
+	SymbolKindProperty
+)
+
+// HierarchyItem is a declaration in a call or type hierarchy. Range spans the declaration and SelectionRange
+// its name.
+type HierarchyItem struct {
+	Name           string
+	Kind           SymbolKind
+	Detail         string
+	FileName       string
+	Range          core.TextRange
+	SelectionRange core.TextRange
+}
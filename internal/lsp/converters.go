@@ -15,10 +15,13 @@ import (
 
 type converters struct {
 	projectService *project.Service
+	// locale is the language of the messages of diagnostics, or nil for English.
+	locale *diagnostics.Locale
 }
 
 func (c *converters) toLspRange(fileName string, textRange core.TextRange) (lsproto.Range, error) {
-	scriptInfo := c.projectService.GetScriptInfo(fileName)
+	// Ranges may be in files that no project contains, such as the sources of declaration maps.
+	scriptInfo := c.projectService.GetOrCreateScriptInfoForFile(fileName)
 	if scriptInfo == nil {
 		return lsproto.Range{}, fmt.Errorf("no script info found for %s", fileName)
 	}
@@ -74,6 +77,101 @@ func (c *converters) fromLspLocation(location lsproto.Location) (ls.Location, er
 	}, nil
 }
 
+func (c *converters) toLspWorkspaceEdit(changes []ls.FileTextChanges) (*lsproto.WorkspaceEdit, error) {
+	documentChanges := make(map[lsproto.DocumentUri][]lsproto.TextEdit, len(changes))
+	for _, fileChanges := range changes {
+		edits := make([]lsproto.TextEdit, 0, len(fileChanges.TextChanges))
+		for _, change := range fileChanges.TextChanges {
+			rng, err := c.toLspRange(fileChanges.FileName, change.TextRange)
+			if err != nil {
+				return nil, fmt.Errorf("error converting text change range: %w", err)
+			}
+			edits = append(edits, lsproto.TextEdit{
+				Range:   rng,
+				NewText: change.NewText,
+			})
+		}
+		uri := fileNameToDocumentUri(fileChanges.FileName)
+		documentChanges[uri] = append(documentChanges[uri], edits...)
+	}
+	return &lsproto.WorkspaceEdit{Changes: &documentChanges}, nil
+}
+
+// toLspHierarchyItem converts an item of a call hierarchy. Type hierarchy items have the same shape and are
+// converted from the result.
+func (c *converters) toLspHierarchyItem(item ls.HierarchyItem) (lsproto.CallHierarchyItem, error) {
+	rng, err := c.toLspRange(item.FileName, item.Range)
+	if err != nil {
+		return lsproto.CallHierarchyItem{}, err
+	}
+	selectionRange, err := c.toLspRange(item.FileName, item.SelectionRange)
+	if err != nil {
+		return lsproto.CallHierarchyItem{}, err
+	}
+	lspItem := lsproto.CallHierarchyItem{
+		Name:           item.Name,
+		Kind:           toLspSymbolKind(item.Kind),
+		Uri:            fileNameToDocumentUri(item.FileName),
+		Range:          rng,
+		SelectionRange: selectionRange,
+	}
+	if item.Detail != "" {
+		lspItem.Detail = &item.Detail
+	}
+	return lspItem, nil
+}
+
+func (c *converters) fromLspHierarchyItem(uri lsproto.DocumentUri, kind lsproto.SymbolKind, selectionRange lsproto.Range) (ls.HierarchyItem, error) {
+	fileName := documentUriToFileName(uri)
+	textRange, err := c.fromLspRange(selectionRange, fileName)
+	if err != nil {
+		return ls.HierarchyItem{}, err
+	}
+	return ls.HierarchyItem{
+		Kind:           fromLspSymbolKind(kind),
+		FileName:       fileName,
+		SelectionRange: textRange,
+	}, nil
+}
+
+func toLspSymbolKind(kind ls.SymbolKind) lsproto.SymbolKind {
+	switch kind {
+	case ls.SymbolKindFile:
+		return lsproto.SymbolKindFile
+	case ls.SymbolKindClass:
+		return lsproto.SymbolKindClass
+	case ls.SymbolKindInterface:
+		return lsproto.SymbolKindInterface
+	case ls.SymbolKindMethod:
+		return lsproto.SymbolKindMethod
+	case ls.SymbolKindConstructor:
+		return lsproto.SymbolKindConstructor
+	case ls.SymbolKindProperty:
+		return lsproto.SymbolKindProperty
+	default:
+		return lsproto.SymbolKindFunction
+	}
+}
+
+func fromLspSymbolKind(kind lsproto.SymbolKind) ls.SymbolKind {
+	switch kind {
+	case lsproto.SymbolKindFile:
+		return ls.SymbolKindFile
+	case lsproto.SymbolKindClass:
+		return ls.SymbolKindClass
+	case lsproto.SymbolKindInterface:
+		return ls.SymbolKindInterface
+	case lsproto.SymbolKindMethod:
+		return ls.SymbolKindMethod
+	case lsproto.SymbolKindConstructor:
+		return ls.SymbolKindConstructor
+	case lsproto.SymbolKindProperty:
+		return ls.SymbolKindProperty
+	default:
+		return ls.SymbolKindFunction
+	}
+}
+
 func (c *converters) toLspDiagnostic(diagnostic *ast.Diagnostic) (lsproto.Diagnostic, error) {
 	textRange, err := c.toLspRange(diagnostic.File().FileName(), diagnostic.Loc())
 	if err != nil {
@@ -103,19 +201,28 @@ func (c *converters) toLspDiagnostic(diagnostic *ast.Diagnostic) (lsproto.Diagno
 				Uri:   fileNameToDocumentUri(related.File().FileName()),
 				Range: relatedRange,
 			},
-			Message: related.Message(),
+			Message: related.Localize(c.locale),
 		})
 	}
 
+	var tags []lsproto.DiagnosticTag
+	if diagnostic.ReportsUnnecessary() {
+		tags = append(tags, lsproto.DiagnosticTagUnnecessary)
+	}
+	if diagnostic.ReportsDeprecated() {
+		tags = append(tags, lsproto.DiagnosticTagDeprecated)
+	}
+
 	return lsproto.Diagnostic{
 		Range: textRange,
 		Code: &lsproto.IntegerOrString{
 			Integer: ptrTo(diagnostic.Code()),
 		},
 		Severity:           &severity,
-		Message:            diagnostic.Message(),
+		Message:            diagnostic.Localize(c.locale),
 		Source:             ptrTo("ts"),
 		RelatedInformation: &relatedInformation,
+		Tags:               core.IfElse(len(tags) != 0, &tags, nil),
 	}, nil
 }
 
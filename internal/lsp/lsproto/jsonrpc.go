@@ -28,6 +28,10 @@ type ID struct {
 	int int32
 }
 
+func NewIDInt(id int32) *ID {
+	return &ID{int: id}
+}
+
 func (id *ID) MarshalJSON() ([]byte, error) {
 	if id.str != "" {
 		return json.Marshal(id.str)
@@ -43,6 +47,32 @@ func (id *ID) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, &id.int)
 }
 
+// Message is a message read from the other end of the connection. Exactly one of Request and
+// Response is set; Response is set for replies to requests sent by this end.
+type Message struct {
+	Request  *RequestMessage
+	Response *ResponseMessage
+}
+
+func (m *Message) UnmarshalJSON(data []byte) error {
+	*m = Message{}
+	var raw struct {
+		Method *Method `json:"method"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if raw.Method == nil {
+		m.Response = &ResponseMessage{}
+		if err := json.Unmarshal(data, m.Response); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+		return nil
+	}
+	m.Request = &RequestMessage{}
+	return json.Unmarshal(data, m.Request)
+}
+
 // TODO(jakebailey): NotificationMessage? Use RequestMessage without ID?
 
 type RequestMessage struct {
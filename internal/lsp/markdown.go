@@ -0,0 +1,105 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ls"
+)
+
+// quickInfoToMarkdown renders quick info as the Markdown of a hover: the display parts in a code block,
+// then the documentation, then each JSDoc tag on its own paragraph.
+func (c *converters) quickInfoToMarkdown(quickInfo *ls.QuickInfo) string {
+	var sb strings.Builder
+	sb.WriteString(codeFence("ts", ls.DisplayPartsToString(quickInfo.DisplayParts)))
+	if documentation := c.displayPartsToMarkdown(quickInfo.Documentation); documentation != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(documentation)
+	}
+	for _, tag := range quickInfo.Tags {
+		sb.WriteString("\n\n")
+		sb.WriteString(c.jsdocTagToMarkdown(tag))
+	}
+	return sb.String()
+}
+
+func (c *converters) jsdocTagToMarkdown(tag ls.JSDocTagInfo) string {
+	label := "*@" + tag.Name + "*"
+	parts := tag.Text
+	if len(parts) != 0 && parts[0].Kind == ls.DisplayPartKindParameterName {
+		label += " `" + parts[0].Text + "`"
+		parts = parts[1:]
+	}
+	text := strings.TrimSpace(c.displayPartsToMarkdown(parts))
+	if len(parts) != len(tag.Text) {
+		// The dash that conventionally separates a parameter from its description duplicates the one below.
+		text = strings.TrimSpace(strings.TrimPrefix(text, "-"))
+	}
+	switch {
+	case text == "":
+		return label
+	case tag.Name == "example":
+		// Examples are code unless they bring their own code blocks.
+		if strings.Contains(text, "```") {
+			return label + "  \n" + text
+		}
+		return label + "  \n" + codeFence("ts", text)
+	case strings.Contains(text, "\n"):
+		return label + " —  \n" + text
+	default:
+		return label + " — " + text
+	}
+}
+
+// displayPartsToMarkdown renders the text of documentation, with inline JSDoc links rendered as code for
+// `{@link}` and `{@linkcode}` and as text for `{@linkplain}`. Links whose names resolve link to the
+// declaration they refer to.
+func (c *converters) displayPartsToMarkdown(parts []ls.DisplayPart) string {
+	var sb strings.Builder
+	for i := 0; i < len(parts); i++ {
+		part := parts[i]
+		if part.Kind != ls.DisplayPartKindLink {
+			sb.WriteString(part.Text)
+			continue
+		}
+		plain := strings.HasPrefix(part.Text, "{@linkplain")
+		code := strings.HasPrefix(part.Text, "{@linkcode")
+		var name, text string
+		var target *ls.Location
+		for i++; i < len(parts) && parts[i].Kind != ls.DisplayPartKindLink; i++ {
+			switch parts[i].Kind {
+			case ls.DisplayPartKindLinkName:
+				name = parts[i].Text
+				target = parts[i].Target
+			case ls.DisplayPartKindLinkText:
+				text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(parts[i].Text), "|"))
+			}
+		}
+		var label string
+		switch {
+		case text != "" && !code:
+			label = text
+		case text != "":
+			label = "`" + text + "`"
+		case plain:
+			label = name
+		default:
+			label = "`" + name + "`"
+		}
+		sb.WriteString(c.linkToMarkdown(label, target))
+	}
+	return sb.String()
+}
+
+// linkToMarkdown renders a link to a declaration, using the `#L<line>,<character>` fragment editors
+// understand for file URIs, or just the label when the link has no target.
+func (c *converters) linkToMarkdown(label string, target *ls.Location) string {
+	if target == nil {
+		return label
+	}
+	location, err := c.toLspLocation(*target)
+	if err != nil {
+		return label
+	}
+	return fmt.Sprintf("[%s](%s#L%d,%d)", label, location.Uri, location.Range.Start.Line+1, location.Range.Start.Character+1)
+}
@@ -0,0 +1,154 @@
+package lsp
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+)
+
+// semanticTokensLegend maps the language service's token types and modifiers onto the
+// subset that was negotiated with the client during initialization.
+type semanticTokensLegend struct {
+	tokenTypes     []string
+	tokenModifiers []string
+	// typeIndices maps an ls.SemanticTokenType to its index in tokenTypes, or -1 if the client does not support it.
+	typeIndices []int
+	// modifierBits maps the bit index of an ls.SemanticTokenModifier to its bit in the encoded modifier set.
+	modifierBits []uint32
+}
+
+func newSemanticTokensLegend(capabilities *lsproto.SemanticTokensClientCapabilities) *semanticTokensLegend {
+	var supportedTypes, supportedModifiers []string
+	if capabilities != nil {
+		supportedTypes = capabilities.TokenTypes
+		supportedModifiers = capabilities.TokenModifiers
+	}
+	// Clients that do not advertise any names accept the full server legend.
+	isSupported := func(supported []string, name string) bool {
+		return len(supported) == 0 || slices.Contains(supported, name)
+	}
+
+	legend := &semanticTokensLegend{
+		typeIndices:  make([]int, len(ls.SemanticTokenTypes)),
+		modifierBits: make([]uint32, len(ls.SemanticTokenModifiers)),
+	}
+	for i, name := range ls.SemanticTokenTypes {
+		if isSupported(supportedTypes, name) {
+			legend.typeIndices[i] = len(legend.tokenTypes)
+			legend.tokenTypes = append(legend.tokenTypes, name)
+		} else {
+			legend.typeIndices[i] = -1
+		}
+	}
+	for i, name := range ls.SemanticTokenModifiers {
+		if isSupported(supportedModifiers, name) {
+			legend.modifierBits[i] = 1 << len(legend.tokenModifiers)
+			legend.tokenModifiers = append(legend.tokenModifiers, name)
+		}
+	}
+	return legend
+}
+
+func (l *semanticTokensLegend) toLspLegend() lsproto.SemanticTokensLegend {
+	return lsproto.SemanticTokensLegend{
+		TokenTypes:     l.tokenTypes,
+		TokenModifiers: l.tokenModifiers,
+	}
+}
+
+func (l *semanticTokensLegend) encodeModifiers(modifiers ls.SemanticTokenModifier) uint32 {
+	var result uint32
+	for i, bit := range l.modifierBits {
+		if modifiers&(1<<i) != 0 {
+			result |= bit
+		}
+	}
+	return result
+}
+
+// encodeSemanticTokens encodes tokens in the LSP relative format: each token is five integers
+// (delta line, delta start character, length, token type, token modifiers), where positions are
+// relative to the previous token.
+func encodeSemanticTokens(tokens []ls.SemanticToken, legend *semanticTokensLegend, lineMap []core.TextPos) []uint32 {
+	data := make([]uint32, 0, len(tokens)*5)
+	var prevLine, prevCharacter uint32
+	for _, token := range tokens {
+		tokenType := legend.typeIndices[token.Type]
+		if tokenType < 0 {
+			continue
+		}
+		start := positionToLineAndCharacter(token.Pos(), lineMap)
+		end := positionToLineAndCharacter(token.End(), lineMap)
+		if start.Line != end.Line {
+			// Multiline tokens are not supported by all clients, and identifiers never span lines.
+			continue
+		}
+		deltaLine := start.Line - prevLine
+		deltaCharacter := start.Character
+		if deltaLine == 0 {
+			deltaCharacter -= prevCharacter
+		}
+		data = append(data, deltaLine, deltaCharacter, end.Character-start.Character, uint32(tokenType), legend.encodeModifiers(token.Modifiers))
+		prevLine, prevCharacter = start.Line, start.Character
+	}
+	return data
+}
+
+// semanticTokensCache remembers the last full result sent for each document so that
+// subsequent delta requests can be answered with edits.
+type semanticTokensCache struct {
+	nextResultID int
+	results      map[lsproto.DocumentUri]semanticTokensResult
+}
+
+type semanticTokensResult struct {
+	resultID string
+	data     []uint32
+}
+
+func (c *semanticTokensCache) set(uri lsproto.DocumentUri, data []uint32) string {
+	if c.results == nil {
+		c.results = make(map[lsproto.DocumentUri]semanticTokensResult)
+	}
+	c.nextResultID++
+	resultID := strconv.Itoa(c.nextResultID)
+	c.results[uri] = semanticTokensResult{resultID: resultID, data: data}
+	return resultID
+}
+
+func (c *semanticTokensCache) get(uri lsproto.DocumentUri, resultID string) ([]uint32, bool) {
+	result, ok := c.results[uri]
+	if !ok || result.resultID != resultID {
+		return nil, false
+	}
+	return result.data, true
+}
+
+func (c *semanticTokensCache) delete(uri lsproto.DocumentUri) {
+	delete(c.results, uri)
+}
+
+// computeSemanticTokensEdits returns the edits transforming oldData into newData. The edit
+// replaces the region between the longest common prefix and suffix of the two arrays.
+func computeSemanticTokensEdits(oldData []uint32, newData []uint32) []lsproto.SemanticTokensEdit {
+	prefix := 0
+	for prefix < len(oldData) && prefix < len(newData) && oldData[prefix] == newData[prefix] {
+		prefix++
+	}
+	if prefix == len(oldData) && prefix == len(newData) {
+		return []lsproto.SemanticTokensEdit{}
+	}
+	suffix := 0
+	for suffix < len(oldData)-prefix && suffix < len(newData)-prefix && oldData[len(oldData)-1-suffix] == newData[len(newData)-1-suffix] {
+		suffix++
+	}
+	inserted := slices.Clone(newData[prefix : len(newData)-suffix])
+	return []lsproto.SemanticTokensEdit{{
+		Start:       uint32(prefix),
+		DeleteCount: uint32(len(oldData) - suffix - prefix),
+		Data:        &inserted,
+	}}
+}
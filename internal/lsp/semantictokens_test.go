@@ -0,0 +1,247 @@
+package lsp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"gotest.tools/v3/assert"
+)
+
+func TestNewSemanticTokensLegend(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		capabilities      *lsproto.SemanticTokensClientCapabilities
+		expectedTypes     []string
+		expectedModifiers []string
+		// modifiers are encoded with the legend and compared to encodedModifiers
+		modifiers        ls.SemanticTokenModifier
+		encodedModifiers uint32
+	}{
+		{
+			name:              "no capabilities",
+			expectedTypes:     ls.SemanticTokenTypes,
+			expectedModifiers: ls.SemanticTokenModifiers,
+			modifiers:         ls.SemanticTokenModifierDeclaration | ls.SemanticTokenModifierReadonly,
+			encodedModifiers:  0b1001,
+		},
+		{
+			name:              "no names",
+			capabilities:      &lsproto.SemanticTokensClientCapabilities{},
+			expectedTypes:     ls.SemanticTokenTypes,
+			expectedModifiers: ls.SemanticTokenModifiers,
+			modifiers:         ls.SemanticTokenModifierLocal,
+			encodedModifiers:  0b100000,
+		},
+		{
+			name: "subset in the order of the server",
+			capabilities: &lsproto.SemanticTokensClientCapabilities{
+				TokenTypes:     []string{"variable", "class", "unknown"},
+				TokenModifiers: []string{"readonly", "declaration"},
+			},
+			expectedTypes:     []string{"class", "variable"},
+			expectedModifiers: []string{"declaration", "readonly"},
+			modifiers:         ls.SemanticTokenModifierStatic | ls.SemanticTokenModifierReadonly,
+			encodedModifiers:  0b10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			legend := newSemanticTokensLegend(test.capabilities)
+			assert.DeepEqual(t, legend.toLspLegend(), lsproto.SemanticTokensLegend{TokenTypes: test.expectedTypes, TokenModifiers: test.expectedModifiers})
+			for i, name := range ls.SemanticTokenTypes {
+				if index := legend.typeIndices[i]; index >= 0 {
+					assert.Equal(t, legend.tokenTypes[index], name)
+				}
+			}
+			assert.Equal(t, legend.encodeModifiers(test.modifiers), test.encodedModifiers)
+		})
+	}
+}
+
+func TestEncodeSemanticTokens(t *testing.T) {
+	t.Parallel()
+
+	// token returns the token of type tokenType for the first occurrence of name in text.
+	token := func(text string, name string, tokenType ls.SemanticTokenType, modifiers ls.SemanticTokenModifier) ls.SemanticToken {
+		pos := strings.Index(text, name)
+		return ls.SemanticToken{TextRange: core.NewTextRange(pos, pos+len(name)), Type: tokenType, Modifiers: modifiers}
+	}
+	const text = "let a = b;\nclass C {\n    m() {}\n}\nconst z = `x\ny`;\n"
+	legend := newSemanticTokensLegend(nil)
+	restricted := newSemanticTokensLegend(&lsproto.SemanticTokensClientCapabilities{TokenTypes: []string{"variable"}})
+
+	tests := []struct {
+		name     string
+		tokens   []ls.SemanticToken
+		legend   *semanticTokensLegend
+		expected []uint32
+	}{
+		{
+			name:     "no tokens",
+			legend:   legend,
+			expected: []uint32{},
+		},
+		{
+			name: "tokens on the same line",
+			tokens: []ls.SemanticToken{
+				token(text, "a", ls.SemanticTokenTypeVariable, ls.SemanticTokenModifierDeclaration),
+				token(text, "b", ls.SemanticTokenTypeVariable, ls.SemanticTokenModifierNone),
+			},
+			legend: legend,
+			expected: []uint32{
+				0, 4, 1, uint32(ls.SemanticTokenTypeVariable), 0b1,
+				0, 4, 1, uint32(ls.SemanticTokenTypeVariable), 0,
+			},
+		},
+		{
+			name: "tokens on different lines",
+			tokens: []ls.SemanticToken{
+				token(text, "a", ls.SemanticTokenTypeVariable, ls.SemanticTokenModifierDeclaration),
+				token(text, "C", ls.SemanticTokenTypeClass, ls.SemanticTokenModifierDeclaration),
+				token(text, "m", ls.SemanticTokenTypeMethod, ls.SemanticTokenModifierDeclaration),
+			},
+			legend: legend,
+			expected: []uint32{
+				0, 4, 1, uint32(ls.SemanticTokenTypeVariable), 0b1,
+				1, 6, 1, uint32(ls.SemanticTokenTypeClass), 0b1,
+				1, 4, 1, uint32(ls.SemanticTokenTypeMethod), 0b1,
+			},
+		},
+		{
+			name: "multiline token",
+			tokens: []ls.SemanticToken{
+				token(text, "C", ls.SemanticTokenTypeClass, ls.SemanticTokenModifierDeclaration),
+				token(text, "`x\ny`", ls.SemanticTokenTypeVariable, ls.SemanticTokenModifierNone),
+				token(text, "z", ls.SemanticTokenTypeVariable, ls.SemanticTokenModifierDeclaration),
+			},
+			legend: legend,
+			expected: []uint32{
+				1, 6, 1, uint32(ls.SemanticTokenTypeClass), 0b1,
+				3, 6, 1, uint32(ls.SemanticTokenTypeVariable), 0b1,
+			},
+		},
+		{
+			name: "token type the client does not support",
+			tokens: []ls.SemanticToken{
+				token(text, "a", ls.SemanticTokenTypeVariable, ls.SemanticTokenModifierDeclaration),
+				token(text, "C", ls.SemanticTokenTypeClass, ls.SemanticTokenModifierDeclaration),
+				token(text, "z", ls.SemanticTokenTypeVariable, ls.SemanticTokenModifierNone),
+			},
+			legend: restricted,
+			expected: []uint32{
+				0, 4, 1, 0, 0b1,
+				4, 6, 1, 0, 0,
+			},
+		},
+	}
+
+	lineMap := core.ComputeLineStarts(text)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, encodeSemanticTokens(test.tokens, test.legend, lineMap), test.expected)
+		})
+	}
+}
+
+func TestComputeSemanticTokensEdits(t *testing.T) {
+	t.Parallel()
+
+	edit := func(start uint32, deleteCount uint32, data ...uint32) lsproto.SemanticTokensEdit {
+		if data == nil {
+			data = []uint32{}
+		}
+		return lsproto.SemanticTokensEdit{Start: start, DeleteCount: deleteCount, Data: &data}
+	}
+
+	tests := []struct {
+		name     string
+		oldData  []uint32
+		newData  []uint32
+		expected []lsproto.SemanticTokensEdit
+	}{
+		{
+			name:     "identical",
+			oldData:  []uint32{0, 4, 1, 7, 1},
+			newData:  []uint32{0, 4, 1, 7, 1},
+			expected: []lsproto.SemanticTokensEdit{},
+		},
+		{
+			name:     "both empty",
+			oldData:  []uint32{},
+			newData:  []uint32{},
+			expected: []lsproto.SemanticTokensEdit{},
+		},
+		{
+			name:     "empty previous result",
+			oldData:  []uint32{},
+			newData:  []uint32{0, 4, 1, 7, 1},
+			expected: []lsproto.SemanticTokensEdit{edit(0, 0, 0, 4, 1, 7, 1)},
+		},
+		{
+			name:     "empty current result",
+			oldData:  []uint32{0, 4, 1, 7, 1},
+			newData:  []uint32{},
+			expected: []lsproto.SemanticTokensEdit{edit(0, 5)},
+		},
+		{
+			name:     "appended",
+			oldData:  []uint32{0, 4, 1, 7, 1},
+			newData:  []uint32{0, 4, 1, 7, 1, 1, 6, 1, 0, 1},
+			expected: []lsproto.SemanticTokensEdit{edit(5, 0, 1, 6, 1, 0, 1)},
+		},
+		{
+			name:     "prepended",
+			oldData:  []uint32{1, 6, 1, 0, 1},
+			newData:  []uint32{0, 4, 1, 7, 1, 1, 6, 1, 0, 1},
+			expected: []lsproto.SemanticTokensEdit{edit(0, 0, 0, 4, 1, 7, 1)},
+		},
+		{
+			name:     "removed from the end",
+			oldData:  []uint32{0, 4, 1, 7, 1, 1, 6, 1, 0, 1},
+			newData:  []uint32{0, 4, 1, 7, 1},
+			expected: []lsproto.SemanticTokensEdit{edit(5, 5)},
+		},
+		{
+			name:     "changed in the middle",
+			oldData:  []uint32{0, 4, 1, 7, 1, 1, 6, 1, 0, 1, 1, 4, 1, 11, 1},
+			newData:  []uint32{0, 4, 1, 7, 1, 1, 6, 3, 0, 1, 1, 4, 1, 11, 1},
+			expected: []lsproto.SemanticTokensEdit{edit(7, 1, 3)},
+		},
+		{
+			name:     "prefix and suffix overlap",
+			oldData:  []uint32{1, 1, 1},
+			newData:  []uint32{1, 1},
+			expected: []lsproto.SemanticTokensEdit{edit(2, 1)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			edits := computeSemanticTokensEdits(test.oldData, test.newData)
+			assert.DeepEqual(t, edits, test.expected)
+			assert.DeepEqual(t, applySemanticTokensEdits(test.oldData, edits), test.newData)
+		})
+	}
+}
+
+// applySemanticTokensEdits applies edits to data, as a client does.
+func applySemanticTokensEdits(data []uint32, edits []lsproto.SemanticTokensEdit) []uint32 {
+	result := append([]uint32{}, data...)
+	for _, edit := range edits {
+		var inserted []uint32
+		if edit.Data != nil {
+			inserted = *edit.Data
+		}
+		result = append(result[:edit.Start], append(inserted, result[edit.Start+edit.DeleteCount:]...)...)
+	}
+	return result
+}
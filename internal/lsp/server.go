@@ -5,13 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/ls"
 	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
 	"github.com/microsoft/typescript-go/internal/project"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
 	"github.com/microsoft/typescript-go/internal/vfs"
 )
 
@@ -24,6 +29,8 @@ type ServerOptions struct {
 	NewLine            core.NewLineKind
 	FS                 vfs.FS
 	DefaultLibraryPath string
+	// ExecutingFilePath is the path of the running server, whose directory holds its translations.
+	ExecutingFilePath string
 }
 
 func NewServer(opts *ServerOptions) *Server {
@@ -38,6 +45,7 @@ func NewServer(opts *ServerOptions) *Server {
 		newLine:            opts.NewLine,
 		fs:                 opts.FS,
 		defaultLibraryPath: opts.DefaultLibraryPath,
+		executingFilePath:  opts.ExecutingFilePath,
 	}
 }
 
@@ -56,12 +64,24 @@ type Server struct {
 	newLine            core.NewLineKind
 	fs                 vfs.FS
 	defaultLibraryPath string
+	executingFilePath  string
 
 	initializeParams *lsproto.InitializeParams
+	// locale is the language of the messages of diagnostics, or nil for English.
+	locale *diagnostics.Locale
 
 	logger         *project.Logger
 	projectService *project.Service
 	converters     *converters
+
+	semanticTokensLegend *semanticTokensLegend
+	semanticTokens       semanticTokensCache
+
+	userPreferences *ls.UserPreferences
+
+	// Requests sent to the client, keyed by ID, awaiting a response.
+	nextRequestID   int32
+	pendingRequests map[lsproto.ID]func(resp *lsproto.ResponseMessage) error
 }
 
 // FS implements project.ProjectServiceHost.
@@ -91,7 +111,7 @@ func (s *Server) Trace(msg string) {
 
 func (s *Server) Run() error {
 	for {
-		req, err := s.read()
+		msg, err := s.read()
 		if err != nil {
 			if errors.Is(err, lsproto.ErrInvalidRequest) {
 				if err := s.sendError(nil, err); err != nil {
@@ -102,6 +122,14 @@ func (s *Server) Run() error {
 			return err
 		}
 
+		if msg.Response != nil {
+			if err := s.handleResponse(msg.Response); err != nil {
+				return err
+			}
+			continue
+		}
+
+		req := msg.Request
 		if s.initializeParams == nil {
 			if req.Method == lsproto.MethodInitialize {
 				if err := s.handleInitialize(req); err != nil {
@@ -121,18 +149,57 @@ func (s *Server) Run() error {
 	}
 }
 
-func (s *Server) read() (*lsproto.RequestMessage, error) {
+func (s *Server) read() (*lsproto.Message, error) {
 	data, err := s.r.Read()
 	if err != nil {
 		return nil, err
 	}
 
-	req := &lsproto.RequestMessage{}
-	if err := json.Unmarshal(data, req); err != nil {
+	msg := &lsproto.Message{}
+	if err := json.Unmarshal(data, msg); err != nil {
 		return nil, fmt.Errorf("%w: %w", lsproto.ErrInvalidRequest, err)
 	}
 
-	return req, nil
+	return msg, nil
+}
+
+// sendRequest sends a request to the client. onResponse, if any, is called when the client responds.
+func (s *Server) sendRequest(method lsproto.Method, params any, onResponse func(resp *lsproto.ResponseMessage) error) error {
+	s.nextRequestID++
+	id := lsproto.NewIDInt(s.nextRequestID)
+	if onResponse == nil {
+		onResponse = func(resp *lsproto.ResponseMessage) error { return nil }
+	}
+	if s.pendingRequests == nil {
+		s.pendingRequests = make(map[lsproto.ID]func(resp *lsproto.ResponseMessage) error)
+	}
+	s.pendingRequests[*id] = onResponse
+	data, err := json.Marshal(&lsproto.RequestMessage{
+		ID:     id,
+		Method: method,
+		Params: params,
+	})
+	if err != nil {
+		return err
+	}
+	return s.w.Write(data)
+}
+
+func (s *Server) handleResponse(resp *lsproto.ResponseMessage) error {
+	if resp.ID == nil {
+		return nil
+	}
+	onResponse, ok := s.pendingRequests[*resp.ID]
+	if !ok {
+		s.Log("response to unknown request", resp.ID)
+		return nil
+	}
+	delete(s.pendingRequests, *resp.ID)
+	if resp.Error != nil {
+		s.Log("request failed:", resp.Error.Message)
+		return nil
+	}
+	return onResponse(resp)
 }
 
 func (s *Server) sendResult(id *lsproto.ID, result any) error {
@@ -186,12 +253,50 @@ func (s *Server) handleMessage(req *lsproto.RequestMessage) error {
 		return s.handleDidSave(req)
 	case *lsproto.DidCloseTextDocumentParams:
 		return s.handleDidClose(req)
+	case *lsproto.DidChangeWatchedFilesParams:
+		return s.handleDidChangeWatchedFiles(req)
 	case *lsproto.DocumentDiagnosticParams:
 		return s.handleDocumentDiagnostic(req)
 	case *lsproto.HoverParams:
 		return s.handleHover(req)
 	case *lsproto.DefinitionParams:
 		return s.handleDefinition(req)
+	case *lsproto.TypeDefinitionParams:
+		return s.handleTypeDefinition(req)
+	case *lsproto.ImplementationParams:
+		return s.handleImplementation(req)
+	case *lsproto.DeclarationParams:
+		return s.handleDeclaration(req)
+	case *lsproto.FoldingRangeParams:
+		return s.handleFoldingRange(req)
+	case *lsproto.SelectionRangeParams:
+		return s.handleSelectionRange(req)
+	case *lsproto.LinkedEditingRangeParams:
+		return s.handleLinkedEditingRange(req)
+	case *lsproto.CallHierarchyPrepareParams:
+		return s.handlePrepareCallHierarchy(req)
+	case *lsproto.CallHierarchyIncomingCallsParams:
+		return s.handleCallHierarchyIncomingCalls(req)
+	case *lsproto.CallHierarchyOutgoingCallsParams:
+		return s.handleCallHierarchyOutgoingCalls(req)
+	case *lsproto.TypeHierarchyPrepareParams:
+		return s.handlePrepareTypeHierarchy(req)
+	case *lsproto.TypeHierarchySupertypesParams:
+		return s.handleTypeHierarchySupertypes(req)
+	case *lsproto.TypeHierarchySubtypesParams:
+		return s.handleTypeHierarchySubtypes(req)
+	case *lsproto.SemanticTokensParams:
+		return s.handleSemanticTokensFull(req)
+	case *lsproto.SemanticTokensRangeParams:
+		return s.handleSemanticTokensRange(req)
+	case *lsproto.SemanticTokensDeltaParams:
+		return s.handleSemanticTokensDelta(req)
+	case *lsproto.InlayHintParams:
+		return s.handleInlayHint(req)
+	case *lsproto.DidChangeConfigurationParams:
+		return s.handleDidChangeConfiguration(req)
+	case *lsproto.CodeActionParams:
+		return s.handleCodeAction(req)
 	default:
 		switch req.Method {
 		case lsproto.MethodShutdown:
@@ -211,6 +316,23 @@ func (s *Server) handleMessage(req *lsproto.RequestMessage) error {
 
 func (s *Server) handleInitialize(req *lsproto.RequestMessage) error {
 	s.initializeParams = req.Params.(*lsproto.InitializeParams)
+	var semanticTokensCapabilities *lsproto.SemanticTokensClientCapabilities
+	if s.initializeParams.Capabilities.TextDocument != nil {
+		semanticTokensCapabilities = s.initializeParams.Capabilities.TextDocument.SemanticTokens
+	}
+	s.semanticTokensLegend = newSemanticTokensLegend(semanticTokensCapabilities)
+	if s.initializeParams.Locale != nil && *s.initializeParams.Locale != "" {
+		var errors []*ast.Diagnostic
+		s.locale, errors = tsoptions.LoadLocale(*s.initializeParams.Locale, s.fs, tspath.GetDirectoryPath(s.executingFilePath))
+		for _, err := range errors {
+			s.Log(err.Message())
+		}
+	}
+	if s.initializeParams.InitializationOptions != nil {
+		if options, ok := (*s.initializeParams.InitializationOptions).(map[string]any); ok {
+			s.setUserPreferences(options["preferences"])
+		}
+	}
 	return s.sendResult(req.ID, &lsproto.InitializeResult{
 		ServerInfo: &lsproto.ServerInfo{
 			Name:    "typescript-go",
@@ -234,11 +356,56 @@ func (s *Server) handleInitialize(req *lsproto.RequestMessage) error {
 			DefinitionProvider: &lsproto.BooleanOrDefinitionOptions{
 				Boolean: ptrTo(true),
 			},
+			TypeDefinitionProvider: &lsproto.BooleanOrTypeDefinitionOptionsOrTypeDefinitionRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			ImplementationProvider: &lsproto.BooleanOrImplementationOptionsOrImplementationRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			DeclarationProvider: &lsproto.BooleanOrDeclarationOptionsOrDeclarationRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			FoldingRangeProvider: &lsproto.BooleanOrFoldingRangeOptionsOrFoldingRangeRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			SelectionRangeProvider: &lsproto.BooleanOrSelectionRangeOptionsOrSelectionRangeRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			LinkedEditingRangeProvider: &lsproto.BooleanOrLinkedEditingRangeOptionsOrLinkedEditingRangeRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			CallHierarchyProvider: &lsproto.BooleanOrCallHierarchyOptionsOrCallHierarchyRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			TypeHierarchyProvider: &lsproto.BooleanOrTypeHierarchyOptionsOrTypeHierarchyRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
 			DiagnosticProvider: &lsproto.DiagnosticOptionsOrDiagnosticRegistrationOptions{
 				DiagnosticOptions: &lsproto.DiagnosticOptions{
 					InterFileDependencies: true,
 				},
 			},
+			SemanticTokensProvider: &lsproto.SemanticTokensOptionsOrSemanticTokensRegistrationOptions{
+				SemanticTokensOptions: &lsproto.SemanticTokensOptions{
+					Legend: s.semanticTokensLegend.toLspLegend(),
+					Range: &lsproto.BooleanOrEmptyObject{
+						Boolean: ptrTo(true),
+					},
+					Full: &lsproto.BooleanOrSemanticTokensFullDelta{
+						SemanticTokensFullDelta: &lsproto.SemanticTokensFullDelta{
+							Delta: ptrTo(true),
+						},
+					},
+				},
+			},
+			InlayHintProvider: &lsproto.BooleanOrInlayHintOptionsOrInlayHintRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			CodeActionProvider: &lsproto.BooleanOrCodeActionOptions{
+				CodeActionOptions: &lsproto.CodeActionOptions{
+					CodeActionKinds: &[]lsproto.CodeActionKind{lsproto.CodeActionKindQuickFix, lsproto.CodeActionKindSourceOrganizeImports},
+				},
+			},
 		},
 	})
 }
@@ -249,8 +416,11 @@ func (s *Server) handleInitialized(req *lsproto.RequestMessage) error {
 		DefaultLibraryPath: s.defaultLibraryPath,
 		Logger:             s.logger,
 	})
-	s.converters = &converters{projectService: s.projectService}
-	return nil
+	s.converters = &converters{projectService: s.projectService, locale: s.locale}
+	if err := s.registerWatchedFiles(); err != nil {
+		return err
+	}
+	return s.requestUserPreferences()
 }
 
 func (s *Server) handleDidOpen(req *lsproto.RequestMessage) error {
@@ -297,6 +467,17 @@ func (s *Server) handleDidSave(req *lsproto.RequestMessage) error {
 func (s *Server) handleDidClose(req *lsproto.RequestMessage) error {
 	params := req.Params.(*lsproto.DidCloseTextDocumentParams)
 	s.projectService.CloseFile(documentUriToFileName(params.TextDocument.Uri))
+	s.semanticTokens.delete(params.TextDocument.Uri)
+	return nil
+}
+
+func (s *Server) handleDidChangeWatchedFiles(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.DidChangeWatchedFilesParams)
+	fileNames := make([]string, len(params.Changes))
+	for i, change := range params.Changes {
+		fileNames[i] = documentUriToFileName(change.Uri)
+	}
+	s.projectService.OnWatchedFilesChanged(fileNames)
 	return nil
 }
 
@@ -330,26 +511,55 @@ func (s *Server) handleHover(req *lsproto.RequestMessage) error {
 		return s.sendError(req.ID, err)
 	}
 
-	hoverText := project.LanguageService().ProvideHover(file.FileName(), pos)
+	quickInfo := project.LanguageService().ProvideHover(file.FileName(), pos)
+	if quickInfo == nil {
+		return s.sendResult(req.ID, nil)
+	}
+	hoverRange, err := s.converters.toLspRange(file.FileName(), quickInfo.Range)
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
 	return s.sendResult(req.ID, &lsproto.Hover{
 		Contents: lsproto.MarkupContentOrMarkedStringOrMarkedStrings{
 			MarkupContent: &lsproto.MarkupContent{
 				Kind:  lsproto.MarkupKindMarkdown,
-				Value: codeFence("ts", hoverText),
+				Value: s.converters.quickInfoToMarkdown(quickInfo),
 			},
 		},
+		Range: &hoverRange,
 	})
 }
 
 func (s *Server) handleDefinition(req *lsproto.RequestMessage) error {
 	params := req.Params.(*lsproto.DefinitionParams)
-	file, project := s.getFileAndProject(params.TextDocument.Uri)
-	pos, err := s.converters.lineAndCharacterToPosition(params.Position, file.FileName())
+	return s.sendLocations(req, params.TextDocument.Uri, params.Position, (*ls.LanguageService).ProvideDefinitions)
+}
+
+func (s *Server) handleTypeDefinition(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.TypeDefinitionParams)
+	return s.sendLocations(req, params.TextDocument.Uri, params.Position, (*ls.LanguageService).ProvideTypeDefinitions)
+}
+
+func (s *Server) handleImplementation(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.ImplementationParams)
+	return s.sendLocations(req, params.TextDocument.Uri, params.Position, (*ls.LanguageService).ProvideImplementations)
+}
+
+func (s *Server) handleDeclaration(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.DeclarationParams)
+	return s.sendLocations(req, params.TextDocument.Uri, params.Position, (*ls.LanguageService).ProvideDeclarations)
+}
+
+// sendLocations responds to a navigation request with the locations a language service method returns
+// for a position.
+func (s *Server) sendLocations(req *lsproto.RequestMessage, uri lsproto.DocumentUri, position lsproto.Position, provide func(*ls.LanguageService, string, int) []ls.Location) error {
+	file, project := s.getFileAndProject(uri)
+	pos, err := s.converters.lineAndCharacterToPosition(position, file.FileName())
 	if err != nil {
 		return s.sendError(req.ID, err)
 	}
 
-	locations := project.LanguageService().ProvideDefinitions(file.FileName(), pos)
+	locations := provide(project.LanguageService(), file.FileName(), pos)
 	lspLocations := make([]lsproto.Location, len(locations))
 	for i, loc := range locations {
 		if lspLocation, err := s.converters.toLspLocation(loc); err != nil {
@@ -359,7 +569,483 @@ func (s *Server) handleDefinition(req *lsproto.RequestMessage) error {
 		}
 	}
 
-	return s.sendResult(req.ID, &lsproto.Definition{Locations: &lspLocations})
+	return s.sendResult(req.ID, &lsproto.LocationOrLocations{Locations: &lspLocations})
+}
+
+func (s *Server) handleFoldingRange(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.FoldingRangeParams)
+	file, project := s.getFileAndProject(params.TextDocument.Uri)
+	lineMap := file.LineMap()
+	text := file.Text()
+
+	lspRanges := []lsproto.FoldingRange{}
+	for _, foldingRange := range project.LanguageService().ProvideFoldingRanges(file.FileName()) {
+		start := positionToLineAndCharacter(foldingRange.Range.Pos(), lineMap)
+		end := positionToLineAndCharacter(foldingRange.Range.End(), lineMap)
+		// Keep a closing delimiter that starts its line visible, as editors do when folding on indentation.
+		lastLine := strings.TrimLeft(text[lineMap[end.Line]:foldingRange.Range.End()], " \t")
+		if strings.HasPrefix(lastLine, "}") || strings.HasPrefix(lastLine, "]") || strings.HasPrefix(lastLine, ")") ||
+			strings.HasPrefix(lastLine, "</") || strings.HasPrefix(lastLine, "`") {
+			end.Line--
+		}
+		if end.Line <= start.Line {
+			continue
+		}
+		lspRange := lsproto.FoldingRange{
+			StartLine:      start.Line,
+			StartCharacter: ptrTo(start.Character),
+			EndLine:        end.Line,
+			Kind:           toLspFoldingRangeKind(foldingRange.Kind),
+		}
+		if foldingRange.CollapsedText != "" {
+			lspRange.CollapsedText = ptrTo(foldingRange.CollapsedText)
+		}
+		lspRanges = append(lspRanges, lspRange)
+	}
+	return s.sendResult(req.ID, lspRanges)
+}
+
+func (s *Server) handleSelectionRange(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.SelectionRangeParams)
+	file, project := s.getFileAndProject(params.TextDocument.Uri)
+	languageService := project.LanguageService()
+
+	lspRanges := make([]lsproto.SelectionRange, len(params.Positions))
+	for i, position := range params.Positions {
+		pos, err := s.converters.lineAndCharacterToPosition(position, file.FileName())
+		if err != nil {
+			return s.sendError(req.ID, err)
+		}
+		lspRange, err := s.toLspSelectionRange(file.FileName(), languageService.ProvideSelectionRange(file.FileName(), pos))
+		if err != nil {
+			return s.sendError(req.ID, err)
+		}
+		lspRanges[i] = *lspRange
+	}
+	return s.sendResult(req.ID, lspRanges)
+}
+
+func (s *Server) toLspSelectionRange(fileName string, selectionRange *ls.SelectionRange) (*lsproto.SelectionRange, error) {
+	rng, err := s.converters.toLspRange(fileName, selectionRange.Range)
+	if err != nil {
+		return nil, err
+	}
+	lspRange := &lsproto.SelectionRange{Range: rng}
+	if selectionRange.Parent != nil {
+		if lspRange.Parent, err = s.toLspSelectionRange(fileName, selectionRange.Parent); err != nil {
+			return nil, err
+		}
+	}
+	return lspRange, nil
+}
+
+func (s *Server) handleLinkedEditingRange(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.LinkedEditingRangeParams)
+	file, project := s.getFileAndProject(params.TextDocument.Uri)
+	pos, err := s.converters.lineAndCharacterToPosition(params.Position, file.FileName())
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+
+	linkedRanges := project.LanguageService().ProvideLinkedEditingRanges(file.FileName(), pos)
+	if linkedRanges == nil {
+		return s.sendResult(req.ID, nil)
+	}
+	ranges, err := s.toLspRanges(file.FileName(), linkedRanges.Ranges)
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+	return s.sendResult(req.ID, &lsproto.LinkedEditingRanges{
+		Ranges:      ranges,
+		WordPattern: ptrTo(linkedRanges.WordPattern),
+	})
+}
+
+func (s *Server) handlePrepareCallHierarchy(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.CallHierarchyPrepareParams)
+	file, project := s.getFileAndProject(params.TextDocument.Uri)
+	pos, err := s.converters.lineAndCharacterToPosition(params.Position, file.FileName())
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+
+	items, err := s.toLspHierarchyItems(project.LanguageService().PrepareCallHierarchy(file.FileName(), pos))
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+	return s.sendResult(req.ID, items)
+}
+
+func (s *Server) handleCallHierarchyIncomingCalls(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.CallHierarchyIncomingCallsParams)
+	_, project := s.getFileAndProject(params.Item.Uri)
+	item, err := s.converters.fromLspHierarchyItem(params.Item.Uri, params.Item.Kind, params.Item.SelectionRange)
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+
+	calls := project.LanguageService().ProvideCallHierarchyIncomingCalls(item)
+	lspCalls := make([]lsproto.CallHierarchyIncomingCall, len(calls))
+	for i, call := range calls {
+		from, err := s.converters.toLspHierarchyItem(call.From)
+		if err != nil {
+			return s.sendError(req.ID, err)
+		}
+		fromRanges, err := s.toLspRanges(call.From.FileName, call.FromRanges)
+		if err != nil {
+			return s.sendError(req.ID, err)
+		}
+		lspCalls[i] = lsproto.CallHierarchyIncomingCall{From: from, FromRanges: fromRanges}
+	}
+	return s.sendResult(req.ID, lspCalls)
+}
+
+func (s *Server) handleCallHierarchyOutgoingCalls(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.CallHierarchyOutgoingCallsParams)
+	_, project := s.getFileAndProject(params.Item.Uri)
+	item, err := s.converters.fromLspHierarchyItem(params.Item.Uri, params.Item.Kind, params.Item.SelectionRange)
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+
+	calls := project.LanguageService().ProvideCallHierarchyOutgoingCalls(item)
+	lspCalls := make([]lsproto.CallHierarchyOutgoingCall, len(calls))
+	for i, call := range calls {
+		to, err := s.converters.toLspHierarchyItem(call.To)
+		if err != nil {
+			return s.sendError(req.ID, err)
+		}
+		fromRanges, err := s.toLspRanges(item.FileName, call.FromRanges)
+		if err != nil {
+			return s.sendError(req.ID, err)
+		}
+		lspCalls[i] = lsproto.CallHierarchyOutgoingCall{To: to, FromRanges: fromRanges}
+	}
+	return s.sendResult(req.ID, lspCalls)
+}
+
+func (s *Server) handlePrepareTypeHierarchy(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.TypeHierarchyPrepareParams)
+	file, project := s.getFileAndProject(params.TextDocument.Uri)
+	pos, err := s.converters.lineAndCharacterToPosition(params.Position, file.FileName())
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+	return s.sendTypeHierarchyItems(req, project.LanguageService().PrepareTypeHierarchy(file.FileName(), pos))
+}
+
+func (s *Server) handleTypeHierarchySupertypes(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.TypeHierarchySupertypesParams)
+	_, project := s.getFileAndProject(params.Item.Uri)
+	item, err := s.converters.fromLspHierarchyItem(params.Item.Uri, params.Item.Kind, params.Item.SelectionRange)
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+	return s.sendTypeHierarchyItems(req, project.LanguageService().ProvideTypeHierarchySupertypes(item))
+}
+
+func (s *Server) handleTypeHierarchySubtypes(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.TypeHierarchySubtypesParams)
+	_, project := s.getFileAndProject(params.Item.Uri)
+	item, err := s.converters.fromLspHierarchyItem(params.Item.Uri, params.Item.Kind, params.Item.SelectionRange)
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+	return s.sendTypeHierarchyItems(req, project.LanguageService().ProvideTypeHierarchySubtypes(item))
+}
+
+func (s *Server) sendTypeHierarchyItems(req *lsproto.RequestMessage, items []ls.HierarchyItem) error {
+	callHierarchyItems, err := s.toLspHierarchyItems(items)
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+	lspItems := make([]lsproto.TypeHierarchyItem, len(callHierarchyItems))
+	for i, item := range callHierarchyItems {
+		lspItems[i] = lsproto.TypeHierarchyItem(item)
+	}
+	return s.sendResult(req.ID, lspItems)
+}
+
+func (s *Server) toLspHierarchyItems(items []ls.HierarchyItem) ([]lsproto.CallHierarchyItem, error) {
+	lspItems := make([]lsproto.CallHierarchyItem, len(items))
+	for i, item := range items {
+		lspItem, err := s.converters.toLspHierarchyItem(item)
+		if err != nil {
+			return nil, err
+		}
+		lspItems[i] = lspItem
+	}
+	return lspItems, nil
+}
+
+func (s *Server) toLspRanges(fileName string, textRanges []core.TextRange) ([]lsproto.Range, error) {
+	ranges := make([]lsproto.Range, len(textRanges))
+	for i, textRange := range textRanges {
+		rng, err := s.converters.toLspRange(fileName, textRange)
+		if err != nil {
+			return nil, err
+		}
+		ranges[i] = rng
+	}
+	return ranges, nil
+}
+
+func (s *Server) handleSemanticTokensFull(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.SemanticTokensParams)
+	data := s.getSemanticTokens(params.TextDocument.Uri, nil /*textRange*/)
+	resultID := s.semanticTokens.set(params.TextDocument.Uri, data)
+	return s.sendResult(req.ID, &lsproto.SemanticTokens{
+		ResultId: &resultID,
+		Data:     data,
+	})
+}
+
+func (s *Server) handleSemanticTokensRange(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.SemanticTokensRangeParams)
+	textRange, err := s.converters.fromLspRange(params.Range, documentUriToFileName(params.TextDocument.Uri))
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+	return s.sendResult(req.ID, &lsproto.SemanticTokens{
+		Data: s.getSemanticTokens(params.TextDocument.Uri, &textRange),
+	})
+}
+
+func (s *Server) handleSemanticTokensDelta(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.SemanticTokensDeltaParams)
+	data := s.getSemanticTokens(params.TextDocument.Uri, nil /*textRange*/)
+	previousData, ok := s.semanticTokens.get(params.TextDocument.Uri, params.PreviousResultId)
+	resultID := s.semanticTokens.set(params.TextDocument.Uri, data)
+	if !ok {
+		// The previous result is unknown, so fall back to sending the full set of tokens.
+		return s.sendResult(req.ID, &lsproto.SemanticTokens{
+			ResultId: &resultID,
+			Data:     data,
+		})
+	}
+	return s.sendResult(req.ID, &lsproto.SemanticTokensDelta{
+		ResultId: &resultID,
+		Edits:    computeSemanticTokensEdits(previousData, data),
+	})
+}
+
+func (s *Server) getSemanticTokens(uri lsproto.DocumentUri, textRange *core.TextRange) []uint32 {
+	file, project := s.getFileAndProject(uri)
+	span := core.NewTextRange(0, len(file.Text()))
+	if textRange != nil {
+		span = *textRange
+	}
+	tokens := project.LanguageService().ProvideSemanticTokens(file.FileName(), span)
+	return encodeSemanticTokens(tokens, s.semanticTokensLegend, file.LineMap())
+}
+
+func (s *Server) handleInlayHint(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.InlayHintParams)
+	file, project := s.getFileAndProject(params.TextDocument.Uri)
+	span, err := s.converters.fromLspRange(params.Range, file.FileName())
+	if err != nil {
+		return s.sendError(req.ID, err)
+	}
+
+	hints := project.LanguageService().ProvideInlayHints(file.FileName(), span, s.userPreferences)
+	lspHints := make([]lsproto.InlayHint, len(hints))
+	for i, hint := range hints {
+		lspHints[i] = lsproto.InlayHint{
+			Position:     positionToLineAndCharacter(hint.Position, file.LineMap()),
+			Label:        lsproto.StringOrInlayHintLabelParts{String: ptrTo(hint.Text)},
+			Kind:         toLspInlayHintKind(hint.Kind),
+			PaddingLeft:  ptrTo(hint.WhitespaceBefore),
+			PaddingRight: ptrTo(hint.WhitespaceAfter),
+		}
+	}
+	return s.sendResult(req.ID, lspHints)
+}
+
+func (s *Server) handleCodeAction(req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.CodeActionParams)
+	file, project := s.getFileAndProject(params.TextDocument.Uri)
+	languageService := project.LanguageService()
+
+	actions := []lsproto.CodeAction{}
+	if isCodeActionKindRequested(params.Context.Only, lsproto.CodeActionKindSourceOrganizeImports) {
+		edit, err := s.converters.toLspWorkspaceEdit(languageService.OrganizeImports(file.FileName(), ls.OrganizeImportsModeAll, s.userPreferences))
+		if err != nil {
+			return s.sendError(req.ID, err)
+		}
+		actions = append(actions, lsproto.CodeAction{
+			Title: "Organize Imports",
+			Kind:  ptrTo(lsproto.CodeActionKindSourceOrganizeImports),
+			Edit:  edit,
+		})
+	}
+	if !isCodeActionKindRequested(params.Context.Only, lsproto.CodeActionKindQuickFix) {
+		return s.sendResult(req.ID, actions)
+	}
+
+	fixAllIDs := map[string]bool{}
+	for _, diagnostic := range params.Context.Diagnostics {
+		if diagnostic.Code == nil || diagnostic.Code.Integer == nil {
+			continue
+		}
+		span, err := s.converters.fromLspRange(diagnostic.Range, file.FileName())
+		if err != nil {
+			return s.sendError(req.ID, err)
+		}
+		for _, fix := range languageService.GetCodeFixesAtPosition(file.FileName(), span, *diagnostic.Code.Integer, s.userPreferences) {
+			action, err := s.toLspCodeAction(&fix.CodeAction, diagnostic)
+			if err != nil {
+				return s.sendError(req.ID, err)
+			}
+			actions = append(actions, action)
+			if fix.FixID == "" || fixAllIDs[fix.FixID] {
+				continue
+			}
+			fixAllIDs[fix.FixID] = true
+			if combined := languageService.GetCombinedCodeFix(file.FileName(), fix.FixID, s.userPreferences); combined != nil {
+				combined.Description = fix.FixAllDescription
+				action, err := s.toLspCodeAction(combined, diagnostic)
+				if err != nil {
+					return s.sendError(req.ID, err)
+				}
+				actions = append(actions, action)
+			}
+		}
+	}
+	return s.sendResult(req.ID, actions)
+}
+
+// isCodeActionKindRequested reports whether the client asked for code actions of the given kind. Kinds
+// are hierarchical, so asking for "source" includes "source.organizeImports". Source actions are only
+// computed when they are asked for explicitly.
+func isCodeActionKindRequested(only *[]lsproto.CodeActionKind, kind lsproto.CodeActionKind) bool {
+	if only == nil {
+		return kind == lsproto.CodeActionKindQuickFix
+	}
+	return slices.ContainsFunc(*only, func(requested lsproto.CodeActionKind) bool {
+		return kind == requested || strings.HasPrefix(string(kind), string(requested)+".")
+	})
+}
+
+func (s *Server) toLspCodeAction(action *ls.CodeAction, diagnostic lsproto.Diagnostic) (lsproto.CodeAction, error) {
+	edit, err := s.converters.toLspWorkspaceEdit(action.Changes)
+	if err != nil {
+		return lsproto.CodeAction{}, err
+	}
+	return lsproto.CodeAction{
+		Title:       action.Description,
+		Kind:        ptrTo(lsproto.CodeActionKindQuickFix),
+		Diagnostics: &[]lsproto.Diagnostic{diagnostic},
+		Edit:        edit,
+	}, nil
+}
+
+func (s *Server) handleDidChangeConfiguration(req *lsproto.RequestMessage) error {
+	if s.supportsConfigurationRequest() {
+		// Clients that support pulling configuration may send an empty notification; fetch the new settings.
+		return s.requestUserPreferences()
+	}
+	params := req.Params.(*lsproto.DidChangeConfigurationParams)
+	if settings, ok := params.Settings.(map[string]any); ok {
+		if typescript, ok := settings["typescript"].(map[string]any); ok {
+			s.setUserPreferences(typescript["preferences"])
+			return s.refreshInlayHints()
+		}
+	}
+	return nil
+}
+
+func (s *Server) supportsConfigurationRequest() bool {
+	workspace := s.initializeParams.Capabilities.Workspace
+	return workspace != nil && workspace.Configuration != nil && *workspace.Configuration
+}
+
+// requestUserPreferences asks the client for the "typescript.preferences" configuration section.
+func (s *Server) requestUserPreferences() error {
+	if !s.supportsConfigurationRequest() {
+		return nil
+	}
+	params := &lsproto.ConfigurationParams{
+		Items: []lsproto.ConfigurationItem{{Section: ptrTo("typescript.preferences")}},
+	}
+	return s.sendRequest(lsproto.MethodWorkspaceConfiguration, params, func(resp *lsproto.ResponseMessage) error {
+		if items, ok := resp.Result.([]any); ok && len(items) == 1 {
+			s.setUserPreferences(items[0])
+			return s.refreshInlayHints()
+		}
+		return nil
+	})
+}
+
+// setUserPreferences replaces the user preferences with the given JSON value, as sent by the client.
+func (s *Server) setUserPreferences(value any) {
+	if value == nil {
+		return
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		s.Log("invalid preferences:", err)
+		return
+	}
+	preferences := &ls.UserPreferences{}
+	if err := json.Unmarshal(data, preferences); err != nil {
+		s.Log("invalid preferences:", err)
+		return
+	}
+	s.userPreferences = preferences
+}
+
+// registerWatchedFiles asks the client to send workspace/didChangeWatchedFiles notifications
+// for the files that can affect module resolution.
+func (s *Server) registerWatchedFiles() error {
+	workspace := s.initializeParams.Capabilities.Workspace
+	if workspace == nil || workspace.DidChangeWatchedFiles == nil || workspace.DidChangeWatchedFiles.DynamicRegistration == nil || !*workspace.DidChangeWatchedFiles.DynamicRegistration {
+		return nil
+	}
+	var options lsproto.LSPAny = &lsproto.DidChangeWatchedFilesRegistrationOptions{
+		Watchers: []lsproto.FileSystemWatcher{
+			{GlobPattern: lsproto.GlobPattern{Pattern: ptrTo("**/*.{ts,tsx,mts,cts,js,jsx,mjs,cjs,json}")}},
+		},
+	}
+	params := &lsproto.RegistrationParams{
+		Registrations: []lsproto.Registration{{
+			Id:              "typescript-go/watchedFiles",
+			Method:          string(lsproto.MethodWorkspaceDidChangeWatchedFiles),
+			RegisterOptions: &options,
+		}},
+	}
+	return s.sendRequest(lsproto.MethodClientRegisterCapability, params, nil)
+}
+
+func (s *Server) refreshInlayHints() error {
+	workspace := s.initializeParams.Capabilities.Workspace
+	if workspace == nil || workspace.InlayHint == nil || workspace.InlayHint.RefreshSupport == nil || !*workspace.InlayHint.RefreshSupport {
+		return nil
+	}
+	return s.sendRequest(lsproto.MethodWorkspaceInlayHintRefresh, nil, nil)
+}
+
+func toLspFoldingRangeKind(kind ls.FoldingRangeKind) *lsproto.FoldingRangeKind {
+	switch kind {
+	case ls.FoldingRangeKindComment:
+		return ptrTo(lsproto.FoldingRangeKindComment)
+	case ls.FoldingRangeKindImports:
+		return ptrTo(lsproto.FoldingRangeKindImports)
+	case ls.FoldingRangeKindRegion:
+		return ptrTo(lsproto.FoldingRangeKindRegion)
+	default:
+		return nil
+	}
+}
+
+func toLspInlayHintKind(kind ls.InlayHintKind) *lsproto.InlayHintKind {
+	switch kind {
+	case ls.InlayHintKindParameter:
+		return ptrTo(lsproto.InlayHintKindParameter)
+	case ls.InlayHintKindType:
+		return ptrTo(lsproto.InlayHintKindType)
+	}
+	// Enum member values have no corresponding LSP kind.
+	return nil
 }
 
 func (s *Server) getFileAndProject(uri lsproto.DocumentUri) (*project.ScriptInfo, *project.Project) {
@@ -0,0 +1,489 @@
+package parser
+
+import (
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+// UpdateSourceFile parses the text that results from applying a change to the text of a source file,
+// reusing the subtrees of the old source file that the change cannot have affected. The positions of
+// reused nodes are adjusted in place, so the old source file must not be used afterwards.
+func UpdateSourceFile(sourceFile *ast.SourceFile, change core.TextChange, jsdocParsingMode scanner.JSDocParsingMode) *ast.SourceFile {
+	if change.Len() == 0 && change.NewText == "" {
+		return sourceFile
+	}
+	newText := change.ApplyTo(sourceFile.Text)
+	if len(sourceFile.Statements.Nodes) == 0 || sourceFile.ScriptKind == core.ScriptKindJSON {
+		// Without statements there is nothing to reuse.
+		return ParseSourceFile(sourceFile.FileName(), sourceFile.Path(), newText, sourceFile.LanguageVersion, jsdocParsingMode)
+	}
+
+	// Make the change larger so that anything whose lookahead may have intersected the change is
+	// reparsed.
+	changeStart := extendToAffectedRange(sourceFile, change.Pos())
+	cursor := &syntaxCursor{
+		sourceFile:   sourceFile,
+		changeStart:  changeStart,
+		oldEnd:       change.End(),
+		newEnd:       change.Pos() + len(change.NewText),
+		delta:        len(change.NewText) - change.Len(),
+		intersecting: &core.Set[*ast.Node]{},
+		// No position has been queried yet.
+		lastQueriedPosition: -1,
+	}
+	cursor.diagnostics = cursor.adjustDiagnostics(sourceFile.Diagnostics())
+	cursor.updatePositionsAndMarkElements()
+
+	p := getParser()
+	defer putParser(p)
+	p.initializeState(sourceFile.FileName(), sourceFile.Path(), newText, sourceFile.LanguageVersion, core.ScriptKindUnknown, jsdocParsingMode)
+	p.syntaxCursor = cursor
+	p.nextToken()
+	result := p.parseSourceFileWorker()
+	result.CommentDirectives = cursor.mergeCommentDirectives(sourceFile.CommentDirectives, result.CommentDirectives)
+	// These flags are never cleared once set; see NodeFlagsPermanentlySetIncrementalFlags.
+	result.Flags |= sourceFile.Flags & ast.NodeFlagsPermanentlySetIncrementalFlags
+	return result
+}
+
+// extendToAffectedRange moves the start of a change back by a token, as an edit may change the token
+// that touches it. For example, inserting `/` before the `;` of `{ /; }` turns the `/` before it into
+// the start of a comment.
+func extendToAffectedRange(sourceFile *ast.SourceFile, start int) int {
+	const maxLookahead = 1
+	// The first iteration aligns us with the change start. Subsequent iterations move us to the left by
+	// maxLookahead tokens.
+	for i := 0; start > 0 && i <= maxLookahead; i++ {
+		nearestNode := findNearestNodeStartingBeforeOrAtPosition(sourceFile, start)
+		start = max(0, nearestNode.Pos()-1)
+	}
+	return start
+}
+
+func findNearestNodeStartingBeforeOrAtPosition(sourceFile *ast.SourceFile, position int) *ast.Node {
+	bestResult := sourceFile.AsNode()
+	var lastNodeEntirelyBeforePosition *ast.Node
+	var visit ast.Visitor
+	visit = func(child *ast.Node) bool {
+		if ast.NodeIsMissing(child) {
+			// Missing nodes are effectively invisible to us.
+			return false
+		}
+		if child.Pos() > position {
+			// This node and all the following ones are past the position.
+			return true
+		}
+		if child.Pos() >= bestResult.Pos() {
+			bestResult = child
+		}
+		if position < child.End() {
+			// The nearest node is either this child or one of its descendants.
+			child.ForEachChild(visit)
+			return true
+		}
+		// The child ends before the position. Remember it, as the nearest node may be its last
+		// descendant, such as in `<expression> ? <expression> $ : <expression>`.
+		lastNodeEntirelyBeforePosition = child
+		return false
+	}
+	sourceFile.AsNode().ForEachChild(visit)
+	if lastNodeEntirelyBeforePosition != nil {
+		if lastDescendant := getLastDescendant(lastNodeEntirelyBeforePosition); lastDescendant.Pos() > bestResult.Pos() {
+			bestResult = lastDescendant
+		}
+	}
+	return bestResult
+}
+
+func getLastDescendant(node *ast.Node) *ast.Node {
+	for {
+		var lastChild *ast.Node
+		node.ForEachChild(func(child *ast.Node) bool {
+			if !ast.NodeIsMissing(child) {
+				lastChild = child
+			}
+			return false
+		})
+		if lastChild == nil {
+			return node
+		}
+		node = lastChild
+	}
+}
+
+// syntaxCursor walks the tree of a source file that is being reparsed, finding the nodes that start at
+// the positions where the parser expects list elements. Before parsing, the positions of the old tree
+// are moved to the new text and the nodes that intersect the change are marked as unusable.
+type syntaxCursor struct {
+	sourceFile   *ast.SourceFile
+	changeStart  int
+	oldEnd       int
+	newEnd       int
+	delta        int
+	intersecting *core.Set[*ast.Node]
+	// diagnostics are the positions of the old parse errors in the new text, in order.
+	diagnostics []int
+	// reused are the ranges of the reused nodes in the new text, in order.
+	reused []core.TextRange
+
+	list                []*ast.Node
+	index               int
+	current             *ast.Node
+	lastQueriedPosition int
+}
+
+// newVisitor returns a visitor that calls visit for each child of a node and visitList for each of its
+// lists.
+func newVisitor(visit func(node *ast.Node), visitList func(list *ast.NodeList)) *ast.NodeVisitor {
+	return ast.NewNodeVisitor(func(node *ast.Node) *ast.Node {
+		visit(node)
+		return node
+	}, nil, ast.NodeVisitorHooks{
+		VisitNodes: func(nodes *ast.NodeList, v *ast.NodeVisitor) *ast.NodeList {
+			if nodes != nil {
+				visitList(nodes)
+			}
+			return nodes
+		},
+		VisitModifiers: func(nodes *ast.ModifierList, v *ast.NodeVisitor) *ast.ModifierList {
+			if nodes != nil {
+				visitList(&nodes.NodeList)
+			}
+			return nodes
+		},
+	})
+}
+
+// updatePositionsAndMarkElements moves the nodes that follow the change by the change in length, and
+// marks the nodes that intersect the change, adjusting their positions so that every node still lies
+// within its parent.
+func (c *syntaxCursor) updatePositionsAndMarkElements() {
+	var v *ast.NodeVisitor
+	v = newVisitor(func(node *ast.Node) {
+		if node.Pos() > c.oldEnd {
+			c.moveNode(node)
+			return
+		}
+		if node.End() >= c.changeStart {
+			c.intersecting.Add(node)
+			node.Loc = c.adjustIntersectingRange(node.Loc)
+			node.VisitEachChild(v)
+		}
+		// Otherwise the node is entirely before the change and stays as it is.
+	}, func(list *ast.NodeList) {
+		if list.Pos() > c.oldEnd {
+			c.moveList(list)
+			return
+		}
+		if list.End() >= c.changeStart {
+			list.Loc = c.adjustIntersectingRange(list.Loc)
+			for _, node := range list.Nodes {
+				v.Visit(node)
+			}
+		}
+	})
+	c.intersecting.Add(c.sourceFile.AsNode())
+	c.sourceFile.Loc = c.adjustIntersectingRange(c.sourceFile.Loc)
+	c.sourceFile.AsNode().VisitEachChild(v)
+}
+
+// adjustIntersectingRange adjusts the range of an element that intersects the change. A position in the
+// replaced text stays where it is if the new text is at least as long, and moves to the end of the new
+// text otherwise; a position after the replaced text moves with the text.
+func (c *syntaxCursor) adjustIntersectingRange(loc core.TextRange) core.TextRange {
+	pos := min(loc.Pos(), c.newEnd)
+	end := min(loc.End(), c.newEnd)
+	if loc.End() >= c.oldEnd {
+		end = loc.End() + c.delta
+	}
+	return core.NewTextRange(pos, end)
+}
+
+// moveNode moves a node that follows the change, its descendants and their JSDoc by the change in length.
+func (c *syntaxCursor) moveNode(node *ast.Node) {
+	var v *ast.NodeVisitor
+	var visit func(node *ast.Node)
+	visit = func(node *ast.Node) {
+		node.Loc = core.NewTextRange(node.Pos()+c.delta, node.End()+c.delta)
+		node.VisitEachChild(v)
+		for _, jsdoc := range node.JSDoc(c.sourceFile) {
+			visit(jsdoc)
+		}
+	}
+	v = newVisitor(visit, func(list *ast.NodeList) {
+		list.Loc = core.NewTextRange(list.Pos()+c.delta, list.End()+c.delta)
+		for _, node := range list.Nodes {
+			visit(node)
+		}
+	})
+	visit(node)
+}
+
+func (c *syntaxCursor) moveList(list *ast.NodeList) {
+	list.Loc = core.NewTextRange(list.Pos()+c.delta, list.End()+c.delta)
+	for _, node := range list.Nodes {
+		c.moveNode(node)
+	}
+}
+
+// adjustDiagnostics returns the positions in the new text of the old parse errors, in order. Errors within
+// the change are moved to its start, as they may have been reported at the token that follows a node
+// before the change.
+func (c *syntaxCursor) adjustDiagnostics(diagnostics []*ast.Diagnostic) []int {
+	positions := make([]int, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		switch {
+		case diagnostic.Pos() < c.changeStart:
+			positions = append(positions, diagnostic.Pos())
+		case diagnostic.Pos() > c.oldEnd:
+			positions = append(positions, diagnostic.Pos()+c.delta)
+		default:
+			positions = append(positions, c.changeStart)
+		}
+	}
+	slices.Sort(positions)
+	return positions
+}
+
+// currentNode returns the node of the old tree that is an element of a list and starts at a position, if
+// there is one.
+func (c *syntaxCursor) currentNode(position int) *ast.Node {
+	if position != c.lastQueriedPosition {
+		if c.current != nil && c.current.End() == position && c.index < len(c.list)-1 {
+			c.index++
+			c.current = c.list[c.index]
+		}
+		if c.current == nil || c.current.Pos() != position {
+			c.findListElementStartingAt(position)
+		}
+	}
+	c.lastQueriedPosition = position
+	return c.current
+}
+
+func (c *syntaxCursor) findListElementStartingAt(position int) {
+	c.list = nil
+	c.index = -1
+	c.current = nil
+	// Once the search descends into a node, the element is within that node or doesn't exist.
+	done := false
+	var v *ast.NodeVisitor
+	v = newVisitor(func(node *ast.Node) {
+		if !done && position >= node.Pos() && position < node.End() {
+			node.VisitEachChild(v)
+			done = true
+		}
+	}, func(list *ast.NodeList) {
+		if done || position < list.Pos() || position >= list.End() {
+			return
+		}
+		for i, child := range list.Nodes {
+			if child.Pos() == position {
+				c.list = list.Nodes
+				c.index = i
+				c.current = child
+				done = true
+				return
+			}
+			if child.Pos() < position && position < child.End() {
+				child.VisitEachChild(v)
+				done = true
+				return
+			}
+		}
+	})
+	c.sourceFile.AsNode().VisitEachChild(v)
+}
+
+// containsParseError reports whether an old parse error lies within a node or at the token that follows
+// it, where errors such as a missing semicolon are reported.
+func (c *syntaxCursor) containsParseError(node *ast.Node, text string) bool {
+	i, _ := slices.BinarySearch(c.diagnostics, node.Pos())
+	return i < len(c.diagnostics) && c.diagnostics[i] <= scanner.SkipTrivia(text, node.End())
+}
+
+// mergeCommentDirectives returns the comment directives of the new text: the directives the scanner found,
+// and the directives of the old text that lie within reused nodes, which the scanner skipped.
+func (c *syntaxCursor) mergeCommentDirectives(oldDirectives []ast.CommentDirective, newDirectives []ast.CommentDirective) []ast.CommentDirective {
+	if len(c.reused) == 0 || len(oldDirectives) == 0 {
+		return newDirectives
+	}
+	reused := mergeRanges(c.reused)
+	var scanned core.Set[core.TextRange]
+	for _, directive := range newDirectives {
+		scanned.Add(directive.Loc)
+	}
+	result := slices.Clone(newDirectives)
+	for _, directive := range oldDirectives {
+		switch {
+		case directive.Loc.End() < c.changeStart:
+		case directive.Loc.Pos() > c.oldEnd:
+			directive.Loc = core.NewTextRange(directive.Loc.Pos()+c.delta, directive.Loc.End()+c.delta)
+		default:
+			continue
+		}
+		// The scanner finds the directives in the leading trivia of a reused node before the node is reused.
+		if scanned.Has(directive.Loc) {
+			continue
+		}
+		i, _ := slices.BinarySearchFunc(reused, directive.Loc.Pos(), func(loc core.TextRange, pos int) int { return loc.End() - pos })
+		if i < len(reused) && reused[i].Pos() <= directive.Loc.Pos() && directive.Loc.End() <= reused[i].End() {
+			result = append(result, directive)
+		}
+	}
+	slices.SortFunc(result, func(a, b ast.CommentDirective) int { return a.Loc.Pos() - b.Loc.Pos() })
+	return result
+}
+
+// mergeRanges returns the union of ranges as disjoint ranges, in order.
+func mergeRanges(ranges []core.TextRange) []core.TextRange {
+	ranges = slices.Clone(ranges)
+	slices.SortFunc(ranges, func(a, b core.TextRange) int { return a.Pos() - b.Pos() })
+	merged := ranges[:1]
+	for _, loc := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if loc.Pos() <= last.End() {
+			*last = core.NewTextRange(last.Pos(), max(last.End(), loc.End()))
+		} else {
+			merged = append(merged, loc)
+		}
+	}
+	return merged
+}
+
+// currentNode returns the node of the old tree that starts at a position and can be reused as the next
+// element of a list, if any.
+func (p *Parser) currentNode(parsingContext ParsingContext, pos int) *ast.Node {
+	if p.syntaxCursor == nil || !isReusableParsingContext(parsingContext) || p.parseErrorBeforeNextFinishedNode {
+		return nil
+	}
+	node := p.syntaxCursor.currentNode(pos)
+	if ast.NodeIsMissing(node) || p.syntaxCursor.intersecting.Has(node) || p.syntaxCursor.containsParseError(node, p.sourceText) {
+		return nil
+	}
+	// A node parsed in a different context, such as outside a generator, may parse differently.
+	if node.Flags&ast.NodeFlagsContextFlags != p.contextFlags {
+		return nil
+	}
+	if !canReuseNode(node, parsingContext) {
+		return nil
+	}
+	return node
+}
+
+// parseListElement reuses the next element of a list from the old tree, or parses it.
+func (p *Parser) parseListElement(parsingContext ParsingContext, parseElement func(p *Parser) *ast.Node) *ast.Node {
+	if node := p.currentNode(parsingContext, p.nodePos()); node != nil {
+		return p.consumeNode(node)
+	}
+	return parseElement(p)
+}
+
+// consumeNode adds a reused node to the new tree and moves the scanner past it. The JSDoc and identifiers
+// of the node are carried over as if the node was parsed.
+func (p *Parser) consumeNode(node *ast.Node) *ast.Node {
+	oldFile := p.syntaxCursor.sourceFile
+	var visit ast.Visitor
+	visit = func(node *ast.Node) bool {
+		switch node.Kind {
+		case ast.KindIdentifier:
+			text := p.internIdentifier(node.Text())
+			if text == "await" {
+				// The statement may need to be reparsed as top-level await; see reparseTopLevelAwait.
+				p.statementHasAwaitIdentifier = true
+			}
+		case ast.KindPrivateIdentifier, ast.KindStringLiteral, ast.KindNumericLiteral, ast.KindNoSubstitutionTemplateLiteral:
+			p.internIdentifier(node.Text())
+		}
+		if jsdoc := node.JSDoc(oldFile); len(jsdoc) != 0 {
+			if p.jsdocCache == nil {
+				p.jsdocCache = make(map[*ast.Node][]*ast.Node)
+			}
+			p.jsdocCache[node] = jsdoc
+			for _, jsdoc := range jsdoc {
+				visit(jsdoc)
+			}
+		}
+		node.ForEachChild(visit)
+		return false
+	}
+	visit(node)
+	p.syntaxCursor.reused = append(p.syntaxCursor.reused, node.Loc)
+	p.scanner.ResetPos(node.End())
+	p.nextToken()
+	return node
+}
+
+// tryReuseAmbientDeclaration reuses a declaration with a `declare` modifier once the modifiers are parsed,
+// as the declaration has the ambient context flag that the parser didn't have at its start.
+func (p *Parser) tryReuseAmbientDeclaration(pos int) *ast.Node {
+	if p.syntaxCursor == nil {
+		return nil
+	}
+	saveContextFlags := p.contextFlags
+	p.setContextFlags(ast.NodeFlagsAmbient, true)
+	node := p.currentNode(PCSourceElements, pos)
+	p.contextFlags = saveContextFlags
+	if node != nil {
+		return p.consumeNode(node)
+	}
+	return nil
+}
+
+func isReusableParsingContext(parsingContext ParsingContext) bool {
+	switch parsingContext {
+	case PCSourceElements, PCBlockStatements, PCSwitchClauses, PCSwitchClauseStatements, PCClassMembers,
+		PCEnumMembers, PCTypeMembers, PCVariableDeclarations, PCParameters, PCJSDocParameters:
+		return true
+	}
+	return false
+}
+
+// canReuseNode reports whether an element of a list of the old tree parses the same way as an element of
+// a list of the given kind. Elements of other lists are never reused: a parenthesized expression may now
+// be the parameters of an arrow function, for example.
+func canReuseNode(node *ast.Node, parsingContext ParsingContext) bool {
+	switch parsingContext {
+	case PCClassMembers:
+		switch node.Kind {
+		case ast.KindConstructor, ast.KindIndexSignature, ast.KindGetAccessor, ast.KindSetAccessor,
+			ast.KindPropertyDeclaration, ast.KindSemicolonClassElement:
+			return true
+		case ast.KindMethodDeclaration:
+			// A method of an object literal named `constructor` must be reparsed as a constructor.
+			name := node.Name()
+			return !(ast.IsIdentifier(name) && name.Text() == "constructor")
+		}
+	case PCSwitchClauses:
+		return node.Kind == ast.KindCaseClause || node.Kind == ast.KindDefaultClause
+	case PCSourceElements, PCBlockStatements, PCSwitchClauseStatements:
+		switch node.Kind {
+		case ast.KindFunctionDeclaration, ast.KindVariableStatement, ast.KindBlock, ast.KindIfStatement,
+			ast.KindExpressionStatement, ast.KindThrowStatement, ast.KindReturnStatement, ast.KindSwitchStatement,
+			ast.KindBreakStatement, ast.KindContinueStatement, ast.KindForInStatement, ast.KindForOfStatement,
+			ast.KindForStatement, ast.KindWhileStatement, ast.KindWithStatement, ast.KindEmptyStatement,
+			ast.KindTryStatement, ast.KindLabeledStatement, ast.KindDoStatement, ast.KindDebuggerStatement,
+			ast.KindImportDeclaration, ast.KindImportEqualsDeclaration, ast.KindExportDeclaration,
+			ast.KindExportAssignment, ast.KindModuleDeclaration, ast.KindClassDeclaration,
+			ast.KindInterfaceDeclaration, ast.KindEnumDeclaration, ast.KindTypeAliasDeclaration:
+			return true
+		}
+	case PCEnumMembers:
+		return node.Kind == ast.KindEnumMember
+	case PCTypeMembers:
+		switch node.Kind {
+		case ast.KindConstructSignature, ast.KindMethodSignature, ast.KindIndexSignature,
+			ast.KindPropertySignature, ast.KindCallSignature:
+			return true
+		}
+	case PCVariableDeclarations:
+		// An initializer may extend past the end of the declaration, as in `var a = b\n, c`.
+		return node.Kind == ast.KindVariableDeclaration && node.Initializer() == nil
+	case PCParameters, PCJSDocParameters:
+		return node.Kind == ast.KindParameter && node.Initializer() == nil
+	}
+	return false
+}
@@ -0,0 +1,320 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"gotest.tools/v3/assert"
+)
+
+var incrementalParseSources = []struct {
+	fileName string
+	text     string
+}{
+	{"/a.ts", `import { a, b } from "./b";
+/**
+ * Adds numbers.
+ * @param x - the first number
+ * @deprecated
+ */
+export function add(x: number, y = 1): number {
+    // @ts-ignore
+    return x + y;
+}
+declare const c: string;
+declare module "m" {
+    export let d: number;
+}
+class C<T> extends Base implements I {
+    private p: T;
+    static s = 1;
+    constructor(p: T) { super(); this.p = p; }
+    get g() { return this.p }
+    *gen() { yield 1; }
+    async m() { await f(); }
+}
+interface I { x: number; m(): void; [k: string]: any; new (): I; (): void }
+enum E { A = 1, B, C = A | B }
+type U = string | { a: number }[];
+let v1, v2 = 2, v3: string;
+switch (v1) {
+    case 1: f(); break;
+    default: g();
+}
+for (const x of [1, 2]) { if (x) { continue } else throw x }
+label: while (true) { break label }
+const r = /ab+c/g, t = ` + "`a${b}c`" + `;
+const arrow = (a, b) => a + b;
+// @ts-expect-error
+let missing =
+const o = { a: 1, m() {}, constructor() {} };
+`},
+	{"/b.ts", `const x = await y;
+function f() { await(1) }
+export {};
+`},
+	{"/c.tsx", `const e = <div className="a">{x}<span /></div>;
+function F<T,>(p: T) { return <F p={p} /> }
+`},
+	{"/d.js", `/** @type {number} */
+var a = 1;
+/**
+ * @param {string} s
+ * @returns {string}
+ */
+function f(s) { return s }
+/** @typedef {{ x: number }} T */
+module.exports = { f };
+`},
+}
+
+func TestIncrementalParse(t *testing.T) {
+	t.Parallel()
+	for _, source := range incrementalParseSources {
+		t.Run(source.fileName, func(t *testing.T) {
+			t.Parallel()
+			text := source.text
+			edits := []struct {
+				length int
+				insert string
+			}{
+				{1, ""}, {5, ""}, {0, "/"}, {0, "{"}, {1, "}"}, {0, "\n"}, {0, "`"}, {0, "await "},
+			}
+			for pos := range len(text) + 1 {
+				for _, edit := range edits {
+					if pos+edit.length <= len(text) {
+						checkIncrementalParse(t, source.fileName, text, core.TextChange{TextRange: core.NewTextRange(pos, pos+edit.length), NewText: edit.insert})
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestIncrementalParseTyping(t *testing.T) {
+	t.Parallel()
+	for _, source := range incrementalParseSources {
+		t.Run(source.fileName, func(t *testing.T) {
+			t.Parallel()
+			// Type the second half of the file in the middle of the first half, one character at a time.
+			half := len(source.text) / 2
+			prefix, typed, suffix := source.text[:half/2], source.text[half:], source.text[half/2:half]
+			text := prefix + suffix
+			sourceFile := parseSourceFile(source.fileName, text)
+			for i := range len(typed) {
+				change := core.TextChange{TextRange: core.NewTextRange(len(prefix)+i, len(prefix)+i), NewText: typed[i : i+1]}
+				text = change.ApplyTo(text)
+				sourceFile = UpdateSourceFile(sourceFile, change, scanner.JSDocParsingModeParseAll)
+				assertSameParse(t, sourceFile, parseSourceFile(source.fileName, text), change)
+			}
+		})
+	}
+}
+
+func TestIncrementalParseUnterminatedAtEnd(t *testing.T) {
+	t.Parallel()
+	// The trivia after the last reused node runs to the end of the text.
+	for _, text := range []string{
+		"a;\nb;\n/* *",
+		"a;\nb;\n/*",
+		"a;\nb;\n/",
+		"a;\nb;\n// c",
+		"a;\nb;\r",
+		"a;\nb;\n`c",
+		"a;\nb;\n`c${",
+		"a;\nb;\n`c${d}",
+		"a;\nb;\n\"c",
+	} {
+		for pos := range len(text) + 1 {
+			for _, insert := range []string{"", "c", "*/"} {
+				checkIncrementalParse(t, "/a.ts", text, core.TextChange{TextRange: core.NewTextRange(pos, pos), NewText: insert})
+				if pos < len(text) {
+					checkIncrementalParse(t, "/a.ts", text, core.TextChange{TextRange: core.NewTextRange(pos, pos+1), NewText: insert})
+				}
+			}
+		}
+	}
+}
+
+func TestIncrementalParseChainedEdits(t *testing.T) {
+	t.Parallel()
+	// Each edit is applied to the result of the previous one, so that the old tree was itself parsed
+	// incrementally.
+	for _, test := range []struct {
+		text  string
+		edits []core.TextChange
+	}{
+		{"a;\nb;\n/* *", []core.TextChange{
+			{TextRange: core.NewTextRange(0, 1), NewText: "c"},
+			{TextRange: core.NewTextRange(3, 4), NewText: "d"},
+			{TextRange: core.NewTextRange(9, 9), NewText: "/"},
+			{TextRange: core.NewTextRange(0, 0), NewText: "`"},
+			{TextRange: core.NewTextRange(0, 1), NewText: ""},
+		}},
+		{"let x = `a${b}c`;\nf();\n", []core.TextChange{
+			{TextRange: core.NewTextRange(16, 17), NewText: ""},
+			{TextRange: core.NewTextRange(11, 11), NewText: "}`"},
+			{TextRange: core.NewTextRange(0, 3), NewText: "const"},
+			{TextRange: core.NewTextRange(20, 20), NewText: "/*"},
+			{TextRange: core.NewTextRange(20, 22), NewText: ""},
+		}},
+		{"function f() {\n    return 1;\n}\n", []core.TextChange{
+			{TextRange: core.NewTextRange(13, 14), NewText: ""},
+			{TextRange: core.NewTextRange(13, 13), NewText: "{ {"},
+			{TextRange: core.NewTextRange(0, 0), NewText: "/* */"},
+			{TextRange: core.NewTextRange(0, 5), NewText: ""},
+		}},
+	} {
+		text := test.text
+		sourceFile := parseSourceFile("/a.ts", text)
+		for _, change := range test.edits {
+			text = change.ApplyTo(text)
+			sourceFile = UpdateSourceFile(sourceFile, change, scanner.JSDocParsingModeParseAll)
+			assertSameParse(t, sourceFile, parseSourceFile("/a.ts", text), change)
+		}
+	}
+}
+
+func TestIncrementalParseReusesNodes(t *testing.T) {
+	t.Parallel()
+	text := "function f() { return 1; }\nlet x = 1;\nclass C { m() {} }\n"
+	oldFile := parseSourceFile("/a.ts", text)
+	oldStatements := slices.Clone(oldFile.Statements.Nodes)
+	pos := strings.Index(text, "1;")
+	newFile := UpdateSourceFile(oldFile, core.TextChange{TextRange: core.NewTextRange(pos, pos+1), NewText: "100"}, scanner.JSDocParsingModeParseAll)
+
+	assert.Assert(t, newFile.Statements.Nodes[0] != oldStatements[0])
+	assert.Equal(t, newFile.Statements.Nodes[1], oldStatements[1])
+	assert.Equal(t, newFile.Statements.Nodes[2], oldStatements[2])
+	assert.Equal(t, newFile.Statements.Nodes[2].Pos(), oldStatements[2].Pos())
+	assert.Equal(t, newFile.Statements.Nodes[2].End(), len(text)+1)
+}
+
+func FuzzIncrementalParse(f *testing.F) {
+	for _, source := range incrementalParseSources {
+		extension := tspath.TryGetExtensionFromPath(source.fileName)
+		f.Add(extension, source.text, uint(len(source.text)/3), uint(2), "x")
+		f.Add(extension, source.text, uint(len(source.text)/2), uint(0), "/*")
+		f.Add(extension, source.text, uint(len(source.text)/4), uint(10), "}\n{")
+	}
+	f.Add(".ts", "a;\nb;\n/* *", uint(0), uint(1), "c")
+	f.Add(".ts", "a;\nb;\n`c${", uint(0), uint(1), "c")
+	f.Fuzz(func(t *testing.T, extension string, text string, pos uint, length uint, insert string) {
+		if !slices.Contains([]string{".ts", ".tsx", ".js", ".jsx", ".mts", ".cts"}, extension) {
+			return
+		}
+		pos = min(pos, uint(len(text)))
+		length = min(length, uint(len(text))-pos)
+		checkIncrementalParse(t, "/file"+extension, text, core.TextChange{TextRange: core.NewTextRange(int(pos), int(pos+length)), NewText: insert})
+	})
+}
+
+func parseSourceFile(fileName string, text string) *ast.SourceFile {
+	return ParseSourceFile(fileName, tspath.Path(fileName), text, core.ScriptTargetESNext, scanner.JSDocParsingModeParseAll)
+}
+
+func checkIncrementalParse(t *testing.T, fileName string, text string, change core.TextChange) {
+	t.Helper()
+	incremental := UpdateSourceFile(parseSourceFile(fileName, text), change, scanner.JSDocParsingModeParseAll)
+	assertSameParse(t, incremental, parseSourceFile(fileName, change.ApplyTo(text)), change)
+}
+
+func assertSameParse(t *testing.T, incremental *ast.SourceFile, full *ast.SourceFile, change core.TextChange) {
+	t.Helper()
+	assert.Equal(t, incremental.Text, full.Text)
+	message := fmt.Sprintf("replacing [%d, %d) with %q", change.Pos(), change.End(), change.NewText)
+	assert.Equal(t, dumpTree(incremental), dumpTree(full), message)
+	assert.DeepEqual(t, dumpDiagnostics(incremental.Diagnostics()), dumpDiagnostics(full.Diagnostics()))
+	assert.DeepEqual(t, dumpCommentDirectives(incremental.CommentDirectives), dumpCommentDirectives(full.CommentDirectives))
+	var checkIdentifiers ast.Visitor
+	checkIdentifiers = func(node *ast.Node) bool {
+		if ast.IsIdentifier(node) || ast.IsPrivateIdentifier(node) {
+			if _, interned := full.Identifiers[node.Text()]; interned {
+				_, ok := incremental.Identifiers[node.Text()]
+				assert.Assert(t, ok, "%s: identifier %q", message, node.Text())
+			}
+		}
+		return node.ForEachChild(checkIdentifiers)
+	}
+	full.AsNode().ForEachChild(checkIdentifiers)
+}
+
+func dumpCommentDirectives(directives []ast.CommentDirective) []string {
+	var result []string
+	for _, directive := range directives {
+		result = append(result, fmt.Sprintf("%d-%d %d", directive.Loc.Pos(), directive.Loc.End(), directive.Kind))
+	}
+	return result
+}
+
+func dumpDiagnostics(diagnostics []*ast.Diagnostic) []string {
+	var result []string
+	for _, diagnostic := range diagnostics {
+		result = append(result, fmt.Sprintf("%d-%d %d %s", diagnostic.Pos(), diagnostic.End(), diagnostic.Code(), diagnostic.Message()))
+	}
+	return result
+}
+
+// dumpTree returns a description of the nodes, lists and JSDoc of a source file.
+func dumpTree(file *ast.SourceFile) string {
+	var sb strings.Builder
+	depth := 0
+	writeLine := func(label string, loc core.TextRange, flags int) {
+		sb.WriteString("\n")
+		sb.WriteString(strings.Repeat("  ", depth))
+		sb.WriteString(label)
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Itoa(loc.Pos()))
+		sb.WriteString("-")
+		sb.WriteString(strconv.Itoa(loc.End()))
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Itoa(flags))
+	}
+	writeText := func(text string) {
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Quote(text))
+	}
+	var v *ast.NodeVisitor
+	var visit func(node *ast.Node)
+	visit = func(node *ast.Node) {
+		flags := node.Flags
+		if node.Kind == ast.KindSourceFile {
+			flags &^= ast.NodeFlagsPermanentlySetIncrementalFlags
+		}
+		writeLine(node.Kind.String(), node.Loc, int(flags))
+		switch node.Kind {
+		case ast.KindIdentifier, ast.KindPrivateIdentifier, ast.KindStringLiteral, ast.KindNumericLiteral,
+			ast.KindBigIntLiteral, ast.KindRegularExpressionLiteral, ast.KindNoSubstitutionTemplateLiteral,
+			ast.KindTemplateHead, ast.KindTemplateMiddle, ast.KindTemplateTail:
+			writeText(node.Text())
+		case ast.KindJsxText:
+			writeText(node.AsJsxText().Text)
+		case ast.KindJSDocText:
+			writeText(node.AsJSDocText().Text)
+		}
+		depth++
+		for _, jsdoc := range node.JSDoc(file) {
+			writeLine("@jsdoc", core.UndefinedTextRange(), 0)
+			visit(jsdoc)
+		}
+		node.VisitEachChild(v)
+		depth--
+	}
+	v = newVisitor(visit, func(list *ast.NodeList) {
+		writeLine("[]", list.Loc, 0)
+		depth++
+		for _, node := range list.Nodes {
+			visit(node)
+		}
+		depth--
+	})
+	visit(file.AsNode())
+	return sb.String()
+}
@@ -67,7 +67,11 @@ type Parser struct {
 	statementHasAwaitIdentifier bool
 	hasDeprecatedTag            bool
 
+	syntaxCursor                     *syntaxCursor
+	parseErrorBeforeNextFinishedNode bool
+
 	identifiers             map[string]string
+	identifierCount         int
 	notParenthesizedArrow   core.Set[int]
 	nodeSlicePool           core.Pool[*ast.Node]
 	jsdocCache              map[*ast.Node][]*ast.Node
@@ -221,6 +225,7 @@ func (p *Parser) parseErrorAtRange(loc core.TextRange, message *diagnostics.Mess
 	if len(p.diagnostics) == 0 || p.diagnostics[len(p.diagnostics)-1].Loc() != loc {
 		result := ast.NewDiagnostic(nil, loc, message, args...)
 		p.diagnostics = append(p.diagnostics, result)
+		p.parseErrorBeforeNextFinishedNode = true
 		return result
 	}
 	return nil
@@ -324,9 +329,11 @@ func (p *Parser) finishSourceFile(result *ast.SourceFile, isDeclarationFile bool
 	result.ScriptKind = p.scriptKind
 	result.Flags |= p.sourceFlags
 	result.Identifiers = p.identifiers
+	result.IdentifierCount = p.identifierCount
 	result.SetJSDocCache(p.jsdocCache)
 	p.jsdocCache = nil
 	p.identifiers = nil
+	p.identifierCount = 0
 }
 
 func (p *Parser) parseToplevelStatement(i int) *ast.Node {
@@ -439,9 +446,13 @@ func (p *Parser) parseListIndex(kind ParsingContext, parseElement func(p *Parser
 	saveParsingContexts := p.parsingContexts
 	p.parsingContexts |= 1 << kind
 	list := make([]*ast.Node, 0, 16)
-	for i := 0; !p.isListTerminator(kind); i++ {
+	for !p.isListTerminator(kind) {
 		if p.isListElement(kind, false /*inErrorRecovery*/) {
-			list = append(list, parseElement(p, i))
+			if node := p.currentNode(kind, p.nodePos()); node != nil {
+				list = append(list, p.consumeNode(node))
+			} else {
+				list = append(list, parseElement(p, len(list)))
+			}
 			continue
 		}
 		if p.abortParsingListOrMoveToNextToken(kind) {
@@ -467,7 +478,7 @@ func (p *Parser) parseDelimitedList(kind ParsingContext, parseElement func(p *Pa
 	for {
 		if p.isListElement(kind, false /*inErrorRecovery*/) {
 			startPos := p.nodePos()
-			element := parseElement(p)
+			element := p.parseListElement(kind, parseElement)
 			if element == nil {
 				p.parsingContexts = saveParsingContexts
 				// Return nil to indicate parseElement failed
@@ -628,6 +639,9 @@ func (p *Parser) parsingContextErrors(context ParsingContext) {
 }
 
 func (p *Parser) isListElement(parsingContext ParsingContext, inErrorRecovery bool) bool {
+	if p.currentNode(parsingContext, p.nodePos()) != nil {
+		return true
+	}
 	switch parsingContext {
 	case PCSourceElements, PCBlockStatements, PCSwitchClauseStatements:
 		// If we're in error recovery, then we don't want to treat ';' as an empty statement.
@@ -940,11 +954,9 @@ func (p *Parser) parseDeclaration() *ast.Statement {
 	modifiers := p.parseModifiersEx( /*allowDecorators*/ true, false /*permitConstAsModifier*/, false /*stopOnStartOfClassStaticBlock*/)
 	isAmbient := modifiers != nil && core.Some(modifiers.Nodes, isDeclareModifier)
 	if isAmbient {
-		// !!! incremental parsing
-		// node := p.tryReuseAmbientDeclaration(pos)
-		// if node {
-		// 	return node
-		// }
+		if node := p.tryReuseAmbientDeclaration(pos); node != nil {
+			return node
+		}
 		for _, m := range modifiers.Nodes {
 			m.Flags |= ast.NodeFlagsAmbient
 		}
@@ -5757,6 +5769,7 @@ func (p *Parser) createIdentifierWithDiagnostic(isIdentifier bool, diagnosticMes
 		} else {
 			pos = p.nodePos()
 		}
+		p.identifierCount++
 		text := p.scanner.TokenValue()
 		p.nextToken()
 		result := p.newIdentifier(p.internIdentifier(text))
@@ -5812,6 +5825,7 @@ func (p *Parser) finishNode(node *ast.Node, pos int) {
 func (p *Parser) finishNodeWithEnd(node *ast.Node, pos int, end int) {
 	node.Loc = core.NewTextRange(pos, end)
 	node.Flags |= p.contextFlags
+	p.parseErrorBeforeNextFinishedNode = false
 }
 
 func (p *Parser) nextTokenIsSlash() bool {
@@ -6495,13 +6509,13 @@ func processPragmasIntoFields(context *ast.SourceFile /* !!! reportDiagnostic fu
 				})
 			} else if libOk {
 				context.LibReferenceDirectives = append(context.LibReferenceDirectives, &ast.FileReference{
-					TextRange: types.TextRange,
+					TextRange: lib.TextRange,
 					FileName:  lib.Value,
 					Preserve:  preserveOk && preserve.Value == "true",
 				})
 			} else if pathOk {
 				context.ReferencedFiles = append(context.ReferencedFiles, &ast.FileReference{
-					TextRange: types.TextRange,
+					TextRange: path.TextRange,
 					FileName:  path.Value,
 					Preserve:  preserveOk && preserve.Value == "true",
 				})
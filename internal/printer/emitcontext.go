@@ -704,6 +704,8 @@ type emitNode struct {
 	tokenSourceMapRanges      map[ast.Kind]core.TextRange
 	helpers                   []*EmitHelper
 	externalHelpersModuleName *ast.IdentifierNode
+	leadingComments           []SynthesizedComment
+	trailingComments          []SynthesizedComment
 }
 
 // NOTE: This method is not guaranteed to be thread-safe
@@ -715,6 +717,8 @@ func (e *emitNode) copyFrom(source *emitNode) {
 	e.tokenSourceMapRanges = maps.Clone(source.tokenSourceMapRanges)
 	e.helpers = slices.Clone(source.helpers)
 	e.externalHelpersModuleName = source.externalHelpersModuleName
+	e.leadingComments = slices.Clone(source.leadingComments)
+	e.trailingComments = slices.Clone(source.trailingComments)
 }
 
 func (c *EmitContext) EmitFlags(node *ast.Node) EmitFlags {
@@ -782,6 +786,42 @@ func (c *EmitContext) AssignCommentAndSourceMapRanges(to *ast.Node, from *ast.No
 	emitNode.flags |= hasCommentRange | hasSourceMapRange
 }
 
+// A comment that is not part of the source text, emitted along with the node it is attached to.
+type SynthesizedComment struct {
+	Kind               ast.Kind // KindSingleLineCommentTrivia or KindMultiLineCommentTrivia
+	Text               string   // The text of the comment, without the comment delimiters
+	HasTrailingNewLine bool
+	HasLeadingNewLine  bool
+}
+
+// Gets the synthesized comments to emit before a node.
+func (c *EmitContext) SyntheticLeadingComments(node *ast.Node) []SynthesizedComment {
+	if emitNode := c.emitNodes.TryGet(node); emitNode != nil {
+		return emitNode.leadingComments
+	}
+	return nil
+}
+
+// Adds a synthesized comment to emit before a node.
+func (c *EmitContext) AddSyntheticLeadingComment(node *ast.Node, kind ast.Kind, text string, hasTrailingNewLine bool) {
+	emitNode := c.emitNodes.Get(node)
+	emitNode.leadingComments = append(emitNode.leadingComments, SynthesizedComment{Kind: kind, Text: text, HasTrailingNewLine: hasTrailingNewLine})
+}
+
+// Gets the synthesized comments to emit after a node.
+func (c *EmitContext) SyntheticTrailingComments(node *ast.Node) []SynthesizedComment {
+	if emitNode := c.emitNodes.TryGet(node); emitNode != nil {
+		return emitNode.trailingComments
+	}
+	return nil
+}
+
+// Adds a synthesized comment to emit after a node.
+func (c *EmitContext) AddSyntheticTrailingComment(node *ast.Node, kind ast.Kind, text string, hasTrailingNewLine bool) {
+	emitNode := c.emitNodes.Get(node)
+	emitNode.trailingComments = append(emitNode.trailingComments, SynthesizedComment{Kind: kind, Text: text, HasTrailingNewLine: hasTrailingNewLine})
+}
+
 // Gets the range for a token of a node when emitting source maps.
 func (c *EmitContext) TokenSourceMapRange(node *ast.Node, kind ast.Kind) (core.TextRange, bool) {
 	if emitNode := c.emitNodes.TryGet(node); emitNode != nil && emitNode.tokenSourceMapRanges != nil {
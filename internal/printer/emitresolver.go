@@ -12,4 +12,5 @@ type EmitResolver interface {
 	IsTopLevelValueImportEqualsWithEntityName(node *ast.Node) bool
 	MarkLinkedReferencesRecursively(file *ast.SourceFile)
 	GetExternalModuleFileFromDeclaration(node *ast.Node) *ast.SourceFile
+	GetConstantValue(node *ast.Node) any
 }
@@ -30,8 +30,8 @@ import (
 )
 
 type PrinterOptions struct {
-	// RemoveComments                bool
-	NewLine core.NewLineKind
+	RemoveComments bool
+	NewLine        core.NewLineKind
 	// OmitTrailingSemicolon         bool
 	NoEmitHelpers bool
 	// Module                        core.ModuleKind
@@ -586,7 +586,8 @@ func (p *Printer) getClosingLineTerminatorCount(parentNode *ast.Node, lastChild
 //
 
 func (p *Printer) getConstantValue(node *ast.Node) any {
-	// !!! Const-enum inlining (low priority)
+	// References to const enum members are replaced with their values by the ConstEnumInliningTransformer before
+	// printing, so no access expression that remains has a constant value.
 	return nil
 }
 
@@ -3352,12 +3353,10 @@ func (p *Printer) emitModuleBlock(node *ast.ModuleBlock) {
 	p.enterNode(node.AsNode())
 	p.generateNames(node.AsNode())
 	p.emitTokenWithComment(ast.KindOpenBraceToken, node.Pos(), WriteKindPunctuation, node.AsNode())
-	p.increaseIndent()
 	format := core.IfElse(p.isEmptyBlock(node.AsNode(), node.Statements) || p.shouldEmitOnSingleLine(node.AsNode()),
 		LFSingleLineBlockStatements,
 		LFMultiLineBlockStatements)
 	p.emitList((*Printer).emitStatement, node.AsNode(), node.Statements, format)
-	p.decreaseIndent()
 	p.emitTokenWithCommentEx(ast.KindCloseBraceToken, node.Statements.End(), WriteKindPunctuation, node.AsNode(), format&LFMultiLine != 0)
 	p.exitNode(node.AsNode())
 }
@@ -4719,11 +4718,53 @@ func (p *Printer) Write(node *ast.Node, sourceFile *ast.SourceFile, writer EmitT
 //
 
 func (p *Printer) emitCommentsBeforeNode(node *ast.Node) {
-	// !!!
+	// !!! comments from the source text
+	if p.Options.RemoveComments {
+		return
+	}
+	for _, comment := range p.emitContext.SyntheticLeadingComments(node) {
+		p.emitLeadingSynthesizedComment(comment)
+	}
 }
 
 func (p *Printer) emitCommentsAfterNode(node *ast.Node) {
-	// !!!
+	// !!! comments from the source text
+	if p.Options.RemoveComments {
+		return
+	}
+	for _, comment := range p.emitContext.SyntheticTrailingComments(node) {
+		p.emitTrailingSynthesizedComment(comment)
+	}
+}
+
+func (p *Printer) emitLeadingSynthesizedComment(comment SynthesizedComment) {
+	if comment.HasLeadingNewLine || comment.Kind == ast.KindSingleLineCommentTrivia {
+		p.writer.WriteLine()
+	}
+	p.writeSynthesizedComment(comment)
+	if comment.HasTrailingNewLine || comment.Kind == ast.KindSingleLineCommentTrivia {
+		p.writer.WriteLine()
+	} else {
+		p.writer.WriteSpace(" ")
+	}
+}
+
+func (p *Printer) emitTrailingSynthesizedComment(comment SynthesizedComment) {
+	if !p.writer.IsAtStartOfLine() {
+		p.writer.WriteSpace(" ")
+	}
+	p.writeSynthesizedComment(comment)
+	if comment.HasTrailingNewLine {
+		p.writer.WriteLine()
+	}
+}
+
+func (p *Printer) writeSynthesizedComment(comment SynthesizedComment) {
+	if comment.Kind == ast.KindMultiLineCommentTrivia {
+		p.writer.WriteComment("/*" + comment.Text + "*/")
+	} else {
+		p.writer.WriteComment("//" + comment.Text)
+	}
 }
 
 func (p *Printer) emitLeadingCommentsOfPosition(pos int, prefixSpace bool) {
@@ -44,7 +44,7 @@ var escapedCharsMap = map[rune]string{
 	'\b':     `\b`,
 	'\r':     `\r`,
 	'\n':     `\n`,
-	'\\':     `\`,
+	'\\':     `\\`,
 	'"':      `\"`,
 	'\'':     `\'`,
 	'`':      "\\`",
@@ -170,6 +170,11 @@ func escapeString(s string, quoteChar quoteChar) string {
 	return b.String()
 }
 
+// EscapeString escapes s for use in a string literal delimited by quote, which is either ' or ".
+func EscapeString(s string, quote rune) string {
+	return escapeString(s, quoteChar(quote))
+}
+
 func escapeNonAsciiString(s string, quoteChar quoteChar) string {
 	var b strings.Builder
 	b.Grow(len(s) + 2)
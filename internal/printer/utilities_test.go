@@ -23,6 +23,8 @@ func TestEscapeString(t *testing.T) {
 		{s: "ab'c", quoteChar: quoteCharSingleQuote, expected: `ab\'c`},
 		{s: "ab\"c", quoteChar: quoteCharSingleQuote, expected: `ab"c`},
 		{s: "ab`c", quoteChar: quoteCharBacktick, expected: "ab\\`c"},
+		{s: "ab\\c", quoteChar: quoteCharDoubleQuote, expected: `ab\\c`},
+		{s: "ab\ac", quoteChar: quoteCharDoubleQuote, expected: `ab\u0007c`},
 	}
 	for i, rec := range data {
 		t.Run(fmt.Sprintf("[%d] escapeString(%q, %v)", i, rec.s, rec.quoteChar), func(t *testing.T) {
@@ -49,11 +49,9 @@ func newDocumentRegistry(options tspath.ComparePathsOptions) *documentRegistry {
 // will decrement its reference count and remove it from the registry if the count reaches 0.
 // (If the old file and new file have the same key, this results in a no-op to the ref count.)
 //
-// This code is greatly simplified compared to the old TS codebase because of the lack of
-// incremental parsing. Previously, source files could be updated and reused by the same
-// LanguageService instance over time, as well as across multiple instances. Here, we still
-// reuse files across multiple LanguageServices, but we only reuse them across Program updates
-// when the files haven't changed.
+// When the ScriptInfo has only been edited since the stored SourceFile was parsed, and no other
+// Program references that SourceFile, it is updated with incremental parsing, which reuses the
+// nodes of the old SourceFile and so invalidates it.
 func (r *documentRegistry) acquireDocument(scriptInfo *ScriptInfo, compilerOptions *core.CompilerOptions, oldSourceFile *ast.SourceFile, oldCompilerOptions *core.CompilerOptions) *ast.SourceFile {
 	key := newRegistryKey(compilerOptions, scriptInfo.path, scriptInfo.scriptKind)
 	document := r.getDocumentWorker(scriptInfo, compilerOptions, key)
@@ -91,11 +89,18 @@ func (r *documentRegistry) getDocumentWorker(
 		// We have an entry for this file. However, it may be for a different version of
 		// the script snapshot. If so, update it appropriately.
 		entry := entryAny.(*registryEntry)
+		entry.mu.Lock()
+		defer entry.mu.Unlock()
 		if entry.sourceFile.Version != scriptInfo.version {
-			sourceFile := parser.ParseSourceFile(scriptInfo.fileName, scriptInfo.path, scriptInfo.text, scriptTarget, scanner.JSDocParsingModeParseAll)
+			var sourceFile *ast.SourceFile
+			if change, ok := scriptInfo.getChangeSince(entry.sourceFile.Version); ok && entry.refCount <= 1 {
+				// No other Program can observe the old file being invalidated: the one that references it is
+				// out of date, and releases it when it is updated.
+				sourceFile = parser.UpdateSourceFile(entry.sourceFile, change, scanner.JSDocParsingModeParseAll)
+			} else {
+				sourceFile = parser.ParseSourceFile(scriptInfo.fileName, scriptInfo.path, scriptInfo.text, scriptTarget, scanner.JSDocParsingModeParseAll)
+			}
 			sourceFile.Version = scriptInfo.version
-			entry.mu.Lock()
-			defer entry.mu.Unlock()
 			entry.sourceFile = sourceFile
 		}
 		entry.refCount++
@@ -56,6 +56,10 @@ type Project struct {
 	compilerOptions *core.CompilerOptions
 	languageService *ls.LanguageService
 	program         *compiler.Program
+	exportInfoMap   *ls.ExportInfoMap
+	// invalidatedResolutions is the set of files whose failed module resolutions
+	// have to be redone when the program is next updated.
+	invalidatedResolutions core.Set[tspath.Path]
 }
 
 func NewConfiguredProject(configFileName string, configFilePath tspath.Path, projectService *Service) *Project {
@@ -81,6 +85,7 @@ func NewProject(name string, kind Kind, currentDirectory string, projectService
 		kind:             kind,
 		currentDirectory: currentDirectory,
 		rootFileNames:    &collections.OrderedMap[tspath.Path, string]{},
+		exportInfoMap:    ls.NewExportInfoMap(),
 	}
 	project.languageService = ls.NewLanguageService(project)
 	project.markAsDirty()
@@ -161,6 +166,11 @@ func (p *Project) GetDefaultLibraryPath() string {
 	return p.projectService.options.DefaultLibraryPath
 }
 
+// GetExportInfoMap implements ls.Host.
+func (p *Project) GetExportInfoMap() *ls.ExportInfoMap {
+	return p.exportInfoMap
+}
+
 func (p *Project) Name() string {
 	return p.name
 }
@@ -205,7 +215,6 @@ func (p *Project) markAsDirty() {
 }
 
 func (p *Project) updateIfDirty() bool {
-	// !!! p.invalidateResolutionsOfFailedLookupLocations()
 	return p.dirty && p.updateGraph()
 }
 
@@ -244,6 +253,9 @@ func (p *Project) updateGraph() bool {
 	} else if p.program != oldProgram {
 		p.log("Different program with same set of files")
 	}
+	if oldProgram != nil {
+		p.log(fmt.Sprintf("Program structure reuse: %s", p.program.StructureIsReused()))
+	}
 
 	if p.program != oldProgram && oldProgram != nil {
 		for _, oldSourceFile := range oldProgram.GetSourceFiles() {
@@ -260,15 +272,41 @@ func (p *Project) updateProgram() {
 	rootFileNames := p.GetRootFileNames()
 	compilerOptions := p.GetCompilerOptions()
 
+	p.mu.Lock()
+	invalidatedResolutions := p.invalidatedResolutions
+	p.invalidatedResolutions = core.Set[tspath.Path]{}
+	p.mu.Unlock()
+
 	p.program = compiler.NewProgram(compiler.ProgramOptions{
-		RootFiles: rootFileNames,
-		Host:      p,
-		Options:   compilerOptions,
+		RootFiles:                 rootFileNames,
+		Host:                      p,
+		Options:                   compilerOptions,
+		OldProgram:                p.program,
+		HasInvalidatedResolutions: invalidatedResolutions.Has,
 	})
 
 	p.program.BindSourceFiles()
 }
 
+// onWatchedFileChanged invalidates the resolutions that failed to find a file at path
+// and marks the project as dirty if there were any.
+func (p *Project) onWatchedFileChanged(path tspath.Path) {
+	p.exportInfoMap.OnWatchedFileChanged(path)
+	if p.program == nil {
+		return
+	}
+	files := p.program.GetFilesWithFailedLookupLocation(path)
+	if len(files) == 0 {
+		return
+	}
+	p.mu.Lock()
+	for _, file := range files {
+		p.invalidatedResolutions.Add(file)
+	}
+	p.mu.Unlock()
+	p.markAsDirty()
+}
+
 func (p *Project) isOrphan() bool {
 	switch p.kind {
 	case KindInferred:
@@ -18,6 +18,8 @@ type ScriptInfo struct {
 	text       string
 	version    int
 	lineMap    []core.TextPos
+	// changes are the edits that produced the last versions of the text, oldest first.
+	changes []ls.TextChange
 
 	isOpen                bool
 	pendingReloadFromDisk bool
@@ -87,6 +89,7 @@ func (s *ScriptInfo) setText(newText string) {
 	s.text = newText
 	s.version++
 	s.lineMap = nil
+	s.changes = nil
 }
 
 func (s *ScriptInfo) markContainingProjectsAsDirty() {
@@ -131,10 +134,41 @@ func (s *ScriptInfo) isOrphan() bool {
 }
 
 func (s *ScriptInfo) editContent(change ls.TextChange) {
+	changes := append(s.changes, change)
+	if len(changes) > maxTrackedChanges {
+		changes = changes[len(changes)-maxTrackedChanges:]
+	}
 	s.setText(change.ApplyTo(s.text))
+	s.changes = changes
 	s.markContainingProjectsAsDirty()
 }
 
+// maxTrackedChanges is the number of edits kept to update source files incrementally. A source file
+// more edits behind than this is parsed again.
+const maxTrackedChanges = 100
+
+// getChangeSince returns a single change that turns the text at a version into the current text, if the
+// text has changed by edits alone since then.
+func (s *ScriptInfo) getChangeSince(version int) (ls.TextChange, bool) {
+	count := s.version - version
+	if count <= 0 || count > len(s.changes) {
+		return ls.TextChange{}, false
+	}
+	// Collapse the changes into one, tracking the range they replace in the old text and the end of the
+	// replacement in the new text.
+	changes := s.changes[len(s.changes)-count:]
+	oldStart := changes[0].Pos()
+	oldEnd := changes[0].End()
+	newEnd := oldStart + len(changes[0].NewText)
+	for _, change := range changes[1:] {
+		changeNewEnd := change.Pos() + len(change.NewText)
+		oldStart = min(oldStart, change.Pos())
+		oldEnd = max(oldEnd, oldEnd+change.End()-newEnd)
+		newEnd = max(changeNewEnd, changeNewEnd+newEnd-change.End())
+	}
+	return ls.TextChange{TextRange: core.NewTextRange(oldStart, oldEnd), NewText: s.text[oldStart:newEnd]}, true
+}
+
 func (s *ScriptInfo) ensureRealpath(fs vfs.FS) {
 	if s.realpath == "" {
 		if len(s.containingProjects) == 0 {
@@ -92,6 +92,12 @@ func (s *Service) GetScriptInfo(fileName string) *ScriptInfo {
 	return s.getScriptInfo(s.toPath(fileName))
 }
 
+// GetOrCreateScriptInfoForFile returns the script info of a file, reading the file from disk if no
+// project references it, as happens for the sources that declaration maps point to.
+func (s *Service) GetOrCreateScriptInfoForFile(fileName string) *ScriptInfo {
+	return s.getOrCreateScriptInfoNotOpenedByClient(fileName, s.toPath(fileName), core.GetScriptKindFromFileName(fileName))
+}
+
 func (s *Service) getScriptInfo(path tspath.Path) *ScriptInfo {
 	s.scriptInfosMu.RLock()
 	defer s.scriptInfosMu.RUnlock()
@@ -145,6 +151,19 @@ func (s *Service) MarkFileSaved(fileName string, text string) {
 	}
 }
 
+// OnWatchedFilesChanged is called when files are created, changed or deleted on disk.
+func (s *Service) OnWatchedFilesChanged(fileNames []string) {
+	for _, fileName := range fileNames {
+		path := s.toPath(fileName)
+		for _, project := range s.configuredProjects {
+			project.onWatchedFileChanged(path)
+		}
+		for _, project := range s.inferredProjects {
+			project.onWatchedFileChanged(path)
+		}
+	}
+}
+
 func (s *Service) EnsureDefaultProjectForFile(fileName string) (*ScriptInfo, *Project) {
 	path := s.toPath(fileName)
 	if info := s.getScriptInfo(path); info != nil && !info.isOrphan() {
@@ -103,6 +103,20 @@ func TestService(t *testing.T) {
 			assert.Equal(t, proj.GetProgram().GetSourceFile("/home/projects/TS/p1/src/index.ts"), indexFileBefore)
 		})
 
+		t.Run("edited source files are parsed incrementally", func(t *testing.T) {
+			t.Parallel()
+			service, _ := setup(files)
+			service.OpenFile("/home/projects/TS/p1/config.ts", files["/home/projects/TS/p1/config.ts"]+"\nlet z = 3;", core.ScriptKindTS, "")
+			_, proj := service.EnsureDefaultProjectForFile("/home/projects/TS/p1/config.ts")
+			statement := proj.GetProgram().GetSourceFile("/home/projects/TS/p1/config.ts").Statements.Nodes[1]
+			service.ChangeFile("/home/projects/TS/p1/config.ts", []ls.TextChange{{TextRange: core.NewTextRange(8, 9), NewText: "10"}})
+			service.ChangeFile("/home/projects/TS/p1/config.ts", []ls.TextChange{{TextRange: core.NewTextRange(0, 0), NewText: "\n"}})
+			sourceFile := proj.GetProgram().GetSourceFile("/home/projects/TS/p1/config.ts")
+			assert.Equal(t, sourceFile.Text, "\nlet x = 10, y = 2;\nlet z = 3;")
+			assert.Equal(t, sourceFile.Statements.Nodes[1], statement)
+			assert.Equal(t, statement.Pos(), 19)
+		})
+
 		t.Run("change can pull in new files", func(t *testing.T) {
 			t.Parallel()
 			filesCopy := maps.Clone(files)
@@ -114,6 +128,23 @@ func TestService(t *testing.T) {
 			service.ChangeFile("/home/projects/TS/p1/src/index.ts", []ls.TextChange{{TextRange: core.NewTextRange(0, 0), NewText: `import { y } from "../y";\n`}})
 			service.EnsureDefaultProjectForFile("/home/projects/TS/p1/y.ts")
 		})
+
+		t.Run("auto-import exports follow changes", func(t *testing.T) {
+			t.Parallel()
+			service, _ := setup(files)
+			indexText := files["/home/projects/TS/p1/src/index.ts"] + "\nexport const z = y;"
+			service.OpenFile("/home/projects/TS/p1/src/index.ts", indexText, core.ScriptKindTS, "")
+			service.OpenFile("/home/projects/TS/p1/src/x.ts", files["/home/projects/TS/p1/src/x.ts"], core.ScriptKindTS, "")
+			_, proj := service.EnsureDefaultProjectForFile("/home/projects/TS/p1/src/index.ts")
+			yPos := strings.LastIndex(indexText, "y")
+			fixes := proj.LanguageService().GetCodeFixesAtPosition("/home/projects/TS/p1/src/index.ts", core.NewTextRange(yPos, yPos+1), 2304, nil)
+			assert.Equal(t, len(fixes), 0)
+
+			service.ChangeFile("/home/projects/TS/p1/src/x.ts", []ls.TextChange{{TextRange: core.NewTextRange(19, 19), NewText: "\nexport const y = 2;"}})
+			fixes = proj.LanguageService().GetCodeFixesAtPosition("/home/projects/TS/p1/src/index.ts", core.NewTextRange(yPos, yPos+1), 2304, nil)
+			assert.Equal(t, len(fixes), 1)
+			assert.Equal(t, fixes[0].Description, `Update import from "./x"`)
+		})
 	})
 
 	t.Run("CloseFile", func(t *testing.T) {
@@ -172,6 +203,26 @@ func TestService(t *testing.T) {
 		})
 	})
 
+	t.Run("OnWatchedFilesChanged", func(t *testing.T) {
+		t.Parallel()
+		t.Run("create a file that failed to resolve", func(t *testing.T) {
+			t.Parallel()
+			filesCopy := maps.Clone(files)
+			filesCopy["/home/projects/TS/p1/src/index.ts"] = `import { y } from "../y";`
+			service, host := setup(filesCopy)
+			service.OpenFile("/home/projects/TS/p1/src/index.ts", filesCopy["/home/projects/TS/p1/src/index.ts"], core.ScriptKindTS, "")
+			_, proj := service.EnsureDefaultProjectForFile("/home/projects/TS/p1/src/index.ts")
+			assert.Check(t, proj.GetProgram().GetSourceFile("/home/projects/TS/p1/y.ts") == nil)
+
+			filesCopy["/home/projects/TS/p1/y.ts"] = `export const y = 2;`
+			host.replaceFS(filesCopy)
+			assert.Check(t, proj.GetProgram().GetSourceFile("/home/projects/TS/p1/y.ts") == nil)
+
+			service.OnWatchedFilesChanged([]string{"/home/projects/TS/p1/y.ts"})
+			assert.Check(t, proj.GetProgram().GetSourceFile("/home/projects/TS/p1/y.ts") != nil)
+		})
+	})
+
 	t.Run("Source file sharing", func(t *testing.T) {
 		t.Parallel()
 		t.Run("projects with similar options share source files", func(t *testing.T) {
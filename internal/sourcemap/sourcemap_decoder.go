@@ -0,0 +1,208 @@
+package sourcemap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// Mapping is a decoded source map mapping. The source fields of a mapping without source information
+// are not set (-1).
+type Mapping struct {
+	GeneratedLine      int
+	GeneratedCharacter int
+	SourceIndex        SourceIndex
+	SourceLine         int
+	SourceCharacter    int
+	NameIndex          NameIndex
+}
+
+func (m *Mapping) HasSource() bool {
+	return m.SourceIndex != sourceIndexNotSet
+}
+
+// ParseRawSourceMap parses the text of a version 3 source map.
+func ParseRawSourceMap(text string) (*RawSourceMap, error) {
+	var sourceMap RawSourceMap
+	if err := json.Unmarshal([]byte(text), &sourceMap); err != nil {
+		return nil, err
+	}
+	if sourceMap.Version != 3 {
+		return nil, fmt.Errorf("unsupported source map version %d", sourceMap.Version)
+	}
+	return &sourceMap, nil
+}
+
+// TryGetSourceMappingURL returns the URL of the `//# sourceMappingURL=` comment on the last non-empty
+// lines of a generated file, or the empty string if there is none.
+func TryGetSourceMappingURL(text string) string {
+	for len(text) != 0 {
+		lineStart := strings.LastIndexAny(text, "\r\n") + 1
+		line := strings.TrimSpace(text[lineStart:])
+		text = strings.TrimRight(text[:lineStart], "\r\n")
+		if url, ok := strings.CutPrefix(line, "//# sourceMappingURL="); ok {
+			return strings.TrimSpace(url)
+		}
+		if line != "" && !strings.HasPrefix(line, "//") {
+			break
+		}
+	}
+	return ""
+}
+
+// DecodeMappings decodes the base64 VLQ encoded mappings of a source map.
+func DecodeMappings(mappings string) ([]Mapping, error) {
+	var result []Mapping
+	var sourceIndex, sourceLine, sourceCharacter, nameIndex int
+	generatedLine := 0
+	pos := 0
+	for pos < len(mappings) {
+		generatedCharacter := 0
+		for pos < len(mappings) && mappings[pos] != ';' {
+			if mappings[pos] == ',' {
+				pos++
+				continue
+			}
+			var fields [5]int
+			count := 0
+			for pos < len(mappings) && mappings[pos] != ',' && mappings[pos] != ';' {
+				if count == len(fields) {
+					return nil, errors.New("invalid format: too many fields in a mapping")
+				}
+				value, next, err := decodeBase64VLQ(mappings, pos)
+				if err != nil {
+					return nil, err
+				}
+				fields[count] = value
+				count++
+				pos = next
+			}
+
+			generatedCharacter += fields[0]
+			mapping := Mapping{
+				GeneratedLine:      generatedLine,
+				GeneratedCharacter: generatedCharacter,
+				SourceIndex:        sourceIndexNotSet,
+				SourceLine:         notSet,
+				SourceCharacter:    notSet,
+				NameIndex:          nameIndexNotSet,
+			}
+			switch count {
+			case 1:
+			case 4, 5:
+				sourceIndex += fields[1]
+				sourceLine += fields[2]
+				sourceCharacter += fields[3]
+				mapping.SourceIndex = SourceIndex(sourceIndex)
+				mapping.SourceLine = sourceLine
+				mapping.SourceCharacter = sourceCharacter
+				if count == 5 {
+					nameIndex += fields[4]
+					mapping.NameIndex = NameIndex(nameIndex)
+				}
+			default:
+				return nil, fmt.Errorf("invalid format: a mapping cannot have %d fields", count)
+			}
+			if generatedCharacter < 0 || sourceIndex < 0 || sourceLine < 0 || sourceCharacter < 0 || nameIndex < 0 {
+				return nil, errors.New("invalid format: negative position")
+			}
+			result = append(result, mapping)
+		}
+		// Skip the line delimiter.
+		pos++
+		generatedLine++
+	}
+	return result, nil
+}
+
+func decodeBase64VLQ(text string, pos int) (value int, next int, err error) {
+	shift := 0
+	for {
+		if pos >= len(text) {
+			return 0, pos, errors.New("invalid format: unterminated base64 VLQ")
+		}
+		digit := base64FormatDecode(text[pos])
+		if digit < 0 {
+			return 0, pos, fmt.Errorf("invalid format: %q is not a base64 character", text[pos])
+		}
+		pos++
+		value |= (digit & 31) << shift
+		shift += 5
+		if digit&32 == 0 {
+			break
+		}
+	}
+	// The least significant bit is the sign.
+	if value&1 != 0 {
+		return -(value >> 1), pos, nil
+	}
+	return value >> 1, pos, nil
+}
+
+func base64FormatDecode(ch byte) int {
+	switch {
+	case ch >= 'A' && ch <= 'Z':
+		return int(ch - 'A')
+	case ch >= 'a' && ch <= 'z':
+		return int(ch-'a') + 26
+	case ch >= '0' && ch <= '9':
+		return int(ch-'0') + 52
+	case ch == '+':
+		return 62
+	case ch == '/':
+		return 63
+	default:
+		return -1
+	}
+}
+
+// DocumentPositionMapper maps positions of a generated file to the positions of its sources.
+type DocumentPositionMapper struct {
+	sourceFileNames []string
+	mappings        []Mapping
+}
+
+// NewDocumentPositionMapper creates a mapper from a source map whose file is mapFileName. The sources are
+// resolved against the directory of the map and its source root.
+func NewDocumentPositionMapper(sourceMap *RawSourceMap, mapFileName string) (*DocumentPositionMapper, error) {
+	mappings, err := DecodeMappings(sourceMap.Mappings)
+	if err != nil {
+		return nil, err
+	}
+	mappings = slices.DeleteFunc(mappings, func(mapping Mapping) bool {
+		return !mapping.HasSource() || int(mapping.SourceIndex) >= len(sourceMap.Sources)
+	})
+	// Mappings are decoded in generated order already; keep the sort stable for maps that are not.
+	slices.SortStableFunc(mappings, compareGeneratedPositions)
+	sourceRoot := tspath.GetNormalizedAbsolutePath(sourceMap.SourceRoot, tspath.GetDirectoryPath(mapFileName))
+	sourceFileNames := make([]string, len(sourceMap.Sources))
+	for i, source := range sourceMap.Sources {
+		sourceFileNames[i] = tspath.GetNormalizedAbsolutePath(source, sourceRoot)
+	}
+	return &DocumentPositionMapper{sourceFileNames: sourceFileNames, mappings: mappings}, nil
+}
+
+func compareGeneratedPositions(a, b Mapping) int {
+	if a.GeneratedLine != b.GeneratedLine {
+		return a.GeneratedLine - b.GeneratedLine
+	}
+	return a.GeneratedCharacter - b.GeneratedCharacter
+}
+
+// GetSourcePosition returns the source file, line and character of the last mapping at or before the
+// given generated line and character.
+func (m *DocumentPositionMapper) GetSourcePosition(line int, character int) (fileName string, sourceLine int, sourceCharacter int, ok bool) {
+	index, found := slices.BinarySearchFunc(m.mappings, Mapping{GeneratedLine: line, GeneratedCharacter: character}, compareGeneratedPositions)
+	if !found {
+		index--
+	}
+	if index < 0 {
+		return "", 0, 0, false
+	}
+	mapping := m.mappings[index]
+	return m.sourceFileNames[mapping.SourceIndex], mapping.SourceLine, mapping.SourceCharacter, true
+}
@@ -0,0 +1,65 @@
+package sourcemap
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"gotest.tools/v3/assert"
+)
+
+func TestDecodeMappings(t *testing.T) {
+	t.Parallel()
+	gen := NewSourceMapGenerator("main.js", "", "/", tspath.ComparePathsOptions{})
+	sourceIndex := gen.AddSource("/main.ts")
+	nameIndex := gen.AddName("foo")
+	assert.NilError(t, gen.AddSourceMapping(0, 0, sourceIndex, 0, 0))
+	assert.NilError(t, gen.AddNamedSourceMapping(0, 9, sourceIndex, 0, 16, nameIndex))
+	assert.NilError(t, gen.AddGeneratedMapping(0, 12))
+	assert.NilError(t, gen.AddSourceMapping(2, 4, sourceIndex, 3, 2))
+
+	mappings, err := DecodeMappings(gen.RawSourceMap().Mappings)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, mappings, []Mapping{
+		{GeneratedLine: 0, GeneratedCharacter: 0, SourceIndex: 0, SourceLine: 0, SourceCharacter: 0, NameIndex: nameIndexNotSet},
+		{GeneratedLine: 0, GeneratedCharacter: 9, SourceIndex: 0, SourceLine: 0, SourceCharacter: 16, NameIndex: 0},
+		{GeneratedLine: 0, GeneratedCharacter: 12, SourceIndex: sourceIndexNotSet, SourceLine: notSet, SourceCharacter: notSet, NameIndex: nameIndexNotSet},
+		{GeneratedLine: 2, GeneratedCharacter: 4, SourceIndex: 0, SourceLine: 3, SourceCharacter: 2, NameIndex: nameIndexNotSet},
+	})
+}
+
+func TestDecodeMappings_Invalid(t *testing.T) {
+	t.Parallel()
+	_, err := DecodeMappings("AA")
+	assert.ErrorContains(t, err, "cannot have 2 fields")
+	_, err = DecodeMappings("A*")
+	assert.ErrorContains(t, err, "not a base64 character")
+	_, err = DecodeMappings("g")
+	assert.ErrorContains(t, err, "unterminated")
+}
+
+func TestDocumentPositionMapper(t *testing.T) {
+	t.Parallel()
+	sourceMap, err := ParseRawSourceMap(`{"version":3,"file":"index.d.ts","sourceRoot":"","sources":["../src/index.ts"],"names":[],"mappings":"AAAA,wBAAgB,GAAG;AAEnB,eAAO,MAAM,CAAC"}`)
+	assert.NilError(t, err)
+	mapper, err := NewDocumentPositionMapper(sourceMap, "/project/dist/index.d.ts.map")
+	assert.NilError(t, err)
+
+	fileName, line, character, ok := mapper.GetSourcePosition(0, 24)
+	assert.Assert(t, ok)
+	assert.Equal(t, fileName, "/project/src/index.ts")
+	assert.Equal(t, line, 0)
+	assert.Equal(t, character, 16)
+
+	// Positions between mappings use the preceding mapping.
+	_, line, character, ok = mapper.GetSourcePosition(1, 25)
+	assert.Assert(t, ok)
+	assert.Equal(t, line, 2)
+	assert.Equal(t, character, 14)
+}
+
+func TestTryGetSourceMappingURL(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, TryGetSourceMappingURL("export declare const a: number;\n//# sourceMappingURL=index.d.ts.map\n"), "index.d.ts.map")
+	assert.Equal(t, TryGetSourceMappingURL("//# sourceMappingURL=index.d.ts.map\nexport declare const a: number;\n"), "")
+	assert.Equal(t, TryGetSourceMappingURL("export declare const a: number;\n"), "")
+}
@@ -0,0 +1,173 @@
+// Package tracing records the phases of a compilation, for the statistics of --diagnostics and
+// --extendedDiagnostics, and for the event trace of --generateTrace.
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Phase is the category of an event of the trace.
+type Phase string
+
+const (
+	PhaseParse   Phase = "parse"
+	PhaseProgram Phase = "program"
+	PhaseBind    Phase = "bind"
+	PhaseCheck   Phase = "check"
+	PhaseEmit    Phase = "emit"
+)
+
+// Args are the details of an event of the trace, such as the file it is about.
+type Args map[string]any
+
+// Tracer measures the time spent in the phases of a compilation and, when it traces events, records them in
+// the Chrome trace event format. A nil *Tracer measures and records nothing, so that it can be used whether
+// tracing is enabled or not.
+type Tracer struct {
+	now         func() time.Time
+	start       time.Time
+	traceEvents bool
+
+	mu        sync.Mutex
+	events    []traceEvent
+	durations map[string]time.Duration
+}
+
+// NewTracer creates a tracer that reads the time with now. The events are recorded for WriteTrace only if
+// traceEvents is set.
+func NewTracer(now func() time.Time, traceEvents bool) *Tracer {
+	return &Tracer{
+		now:         now,
+		start:       now(),
+		traceEvents: traceEvents,
+		durations:   make(map[string]time.Duration),
+	}
+}
+
+// TracesEvents reports whether the events are recorded for WriteTrace. Events nest only within a goroutine,
+// so the work that is traced is expected to run on a single goroutine.
+func (t *Tracer) TracesEvents() bool {
+	return t != nil && t.traceEvents
+}
+
+// Span is an event that has begun and ends when End is called.
+type Span struct {
+	tracer *Tracer
+	phase  Phase
+	name   string
+	args   Args
+	start  time.Time
+}
+
+// Begin begins an event of phase named name. The duration of the event is added to the duration of the events
+// named name, and the event is recorded when the tracer traces events.
+func (t *Tracer) Begin(phase Phase, name string, args Args) Span {
+	if t == nil {
+		return Span{}
+	}
+	return Span{tracer: t, phase: phase, name: name, args: args, start: t.now()}
+}
+
+// Measure begins measuring work named name, such as reading files, that is not recorded as an event of the trace.
+func (t *Tracer) Measure(name string) Span {
+	return t.Begin("", name, nil)
+}
+
+// End ends the span, and records the event it is for.
+func (s Span) End() {
+	t := s.tracer
+	if t == nil {
+		return
+	}
+	end := t.now()
+	duration := end.Sub(s.start)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.durations[s.name] += duration
+	if t.traceEvents && s.phase != "" {
+		t.events = append(t.events, traceEvent{
+			Pid:       1,
+			Tid:       1,
+			Phase:     "X",
+			Category:  string(s.phase),
+			Timestamp: microseconds(s.start.Sub(t.start)),
+			Name:      s.name,
+			Duration:  microseconds(duration),
+			Args:      s.args,
+		})
+	}
+}
+
+// Duration returns the time spent in the events named name. The durations of events that run concurrently
+// are summed.
+func (t *Tracer) Duration(name string) time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.durations[name]
+}
+
+// traceEvent is an event of the Chrome trace event format, which is read by chrome://tracing, Perfetto and
+// @typescript/analyze-trace.
+type traceEvent struct {
+	Pid       int     `json:"pid"`
+	Tid       int     `json:"tid"`
+	Phase     string  `json:"ph"`
+	Category  string  `json:"cat"`
+	Timestamp float64 `json:"ts"`
+	Name      string  `json:"name"`
+	Duration  float64 `json:"dur,omitempty"`
+	Args      Args    `json:"args,omitempty"`
+}
+
+func microseconds(d time.Duration) float64 {
+	return float64(d.Nanoseconds()) / 1000
+}
+
+// WriteTrace writes the events that ended to w as the trace.json of --generateTrace, one event per line as tsc
+// does.
+func (t *Tracer) WriteTrace(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	metadata := []traceEvent{
+		{Pid: 1, Tid: 1, Phase: "M", Category: "__metadata", Name: "process_name", Args: Args{"name": "tsc"}},
+		{Pid: 1, Tid: 1, Phase: "M", Category: "__metadata", Name: "thread_name", Args: Args{"name": "Main"}},
+		{Pid: 1, Tid: 1, Phase: "M", Category: "disabled-by-default-devtools.timeline", Name: "TracingStartedInBrowser"},
+	}
+	return writeLines(w, append(metadata, t.events...))
+}
+
+// writeLines writes values to w as a JSON array with one value per line.
+func writeLines[T any](w io.Writer, values []T) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	for i, value := range values {
+		buffer.Reset()
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		separator := ",\n"
+		if i == 0 {
+			separator = "\n"
+		}
+		if _, err := io.WriteString(w, separator); err != nil {
+			return err
+		}
+		// Encode ends the value with a newline
+		if _, err := w.Write(bytes.TrimSuffix(buffer.Bytes(), []byte("\n"))); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]\n")
+	return err
+}
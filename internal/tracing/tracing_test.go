@@ -0,0 +1,56 @@
+package tracing_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/microsoft/typescript-go/internal/tracing"
+	"gotest.tools/v3/assert"
+)
+
+// clock is a clock that moves forward by a millisecond every time it is read.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) read() time.Time {
+	c.now = c.now.Add(time.Millisecond)
+	return c.now
+}
+
+func TestTracer(t *testing.T) {
+	t.Parallel()
+
+	tracer := tracing.NewTracer((&clock{}).read, true /*traceEvents*/)
+	program := tracer.Begin(tracing.PhaseProgram, "createProgram", nil)
+	for _, path := range []string{"/a.ts", "/b.ts"} {
+		tracer.Begin(tracing.PhaseParse, "createSourceFile", tracing.Args{"path": path}).End()
+	}
+	tracer.Measure("I/O Read").End()
+	program.End()
+
+	assert.Equal(t, tracer.Duration("createSourceFile"), 2*time.Millisecond)
+	assert.Equal(t, tracer.Duration("I/O Read"), time.Millisecond)
+	assert.Equal(t, tracer.Duration("createProgram"), 7*time.Millisecond)
+
+	var trace strings.Builder
+	assert.NilError(t, tracer.WriteTrace(&trace))
+	assert.Equal(t, trace.String(), `[
+{"pid":1,"tid":1,"ph":"M","cat":"__metadata","ts":0,"name":"process_name","args":{"name":"tsc"}},
+{"pid":1,"tid":1,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Main"}},
+{"pid":1,"tid":1,"ph":"M","cat":"disabled-by-default-devtools.timeline","ts":0,"name":"TracingStartedInBrowser"},
+{"pid":1,"tid":1,"ph":"X","cat":"parse","ts":2000,"name":"createSourceFile","dur":1000,"args":{"path":"/a.ts"}},
+{"pid":1,"tid":1,"ph":"X","cat":"parse","ts":4000,"name":"createSourceFile","dur":1000,"args":{"path":"/b.ts"}},
+{"pid":1,"tid":1,"ph":"X","cat":"program","ts":1000,"name":"createProgram","dur":7000}]
+`)
+}
+
+func TestNilTracer(t *testing.T) {
+	t.Parallel()
+
+	var tracer *tracing.Tracer
+	tracer.Begin(tracing.PhaseCheck, "checkSourceFile", nil).End()
+	assert.Assert(t, !tracer.TracesEvents())
+	assert.Equal(t, tracer.Duration("checkSourceFile"), time.Duration(0))
+}
@@ -0,0 +1,39 @@
+package tracing
+
+import "io"
+
+// TypeDescriptor describes a type created by a checker, for the types.json of --generateTrace. Types refer to
+// each other by id.
+type TypeDescriptor struct {
+	Id                      uint32    `json:"id"`
+	IntrinsicName           string    `json:"intrinsicName,omitempty"`
+	SymbolName              string    `json:"symbolName,omitempty"`
+	IsTuple                 bool      `json:"isTuple,omitempty"`
+	UnionTypes              []uint32  `json:"unionTypes,omitempty"`
+	IntersectionTypes       []uint32  `json:"intersectionTypes,omitempty"`
+	AliasTypeArguments      []uint32  `json:"aliasTypeArguments,omitempty"`
+	KeyofType               uint32    `json:"keyofType,omitempty"`
+	IndexedAccessObjectType uint32    `json:"indexedAccessObjectType,omitempty"`
+	IndexedAccessIndexType  uint32    `json:"indexedAccessIndexType,omitempty"`
+	InstantiatedType        uint32    `json:"instantiatedType,omitempty"`
+	TypeArguments           []uint32  `json:"typeArguments,omitempty"`
+	FirstDeclaration        *Location `json:"firstDeclaration,omitempty"`
+	Flags                   []string  `json:"flags"`
+}
+
+// Location is a range of a file, with zero-based lines and characters.
+type Location struct {
+	Path  string   `json:"path"`
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// WriteTypes writes types to w as the types.json of --generateTrace, one type per line as tsc does.
+func WriteTypes(w io.Writer, types []TypeDescriptor) error {
+	return writeLines(w, types)
+}
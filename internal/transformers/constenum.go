@@ -0,0 +1,70 @@
+package transformers
+
+import (
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/jsnum"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+// ConstEnumInliningTransformer replaces property and element accesses of const enum members with the values of the
+// members, as const enums do not exist at runtime unless `preserveConstEnums` or `isolatedModules` is set.
+type ConstEnumInliningTransformer struct {
+	Transformer
+	compilerOptions *core.CompilerOptions
+	emitResolver    printer.EmitResolver
+}
+
+func NewConstEnumInliningTransformer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, resolver printer.EmitResolver) *Transformer {
+	tx := &ConstEnumInliningTransformer{compilerOptions: compilerOptions, emitResolver: resolver}
+	return tx.newTransformer(tx.visit, emitContext)
+}
+
+func (tx *ConstEnumInliningTransformer) visit(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+		if replacement := tx.substituteConstantValue(node); replacement != nil {
+			return replacement
+		}
+	}
+	return tx.visitor.VisitEachChild(node)
+}
+
+func (tx *ConstEnumInliningTransformer) substituteConstantValue(node *ast.Node) *ast.Node {
+	original := tx.emitContext.ParseNode(node)
+	if original == nil {
+		return nil
+	}
+	var replacement *ast.Node
+	switch value := tx.emitResolver.GetConstantValue(original).(type) {
+	case jsnum.Number:
+		switch {
+		case value.IsNaN():
+			replacement = tx.factory.NewIdentifier("NaN")
+		case value.IsInf() && value > 0:
+			replacement = tx.factory.NewIdentifier("Infinity")
+		case value.IsInf():
+			replacement = tx.factory.NewPrefixUnaryExpression(ast.KindMinusToken, tx.factory.NewIdentifier("Infinity"))
+		case value < 0:
+			replacement = tx.factory.NewPrefixUnaryExpression(ast.KindMinusToken, tx.factory.NewNumericLiteral((-value).String()))
+		default:
+			replacement = tx.factory.NewNumericLiteral(value.String())
+		}
+	case string:
+		replacement = tx.factory.NewStringLiteral(value)
+	default:
+		return nil
+	}
+	tx.emitContext.SetOriginal(replacement, node)
+	if !tx.compilerOptions.RemoveComments.IsTrue() {
+		tx.emitContext.AddSyntheticTrailingComment(replacement, ast.KindMultiLineCommentTrivia, " "+safeMultiLineComment(scanner.GetTextOfNode(original))+" ", false /*hasTrailingNewLine*/)
+	}
+	return replacement
+}
+
+func safeMultiLineComment(value string) string {
+	return strings.ReplaceAll(value, "*/", "*_/")
+}
@@ -0,0 +1,85 @@
+package transformers
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/testutil/emittestutil"
+	"github.com/microsoft/typescript-go/internal/testutil/parsetestutil"
+)
+
+func TestConstEnumInlining(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title   string
+		input   string
+		output  string
+		other   string
+		options *core.CompilerOptions
+	}{
+		{title: "PropertyAccess", input: "const enum E { A = 1, B = \"b\" }\nE.A;\nE.B;", output: "1 /* E.A */;\n\"b\" /* E.B */;"},
+		{title: "ElementAccess", input: "const enum E { A = 1 }\nE[\"A\"];", output: "1 /* E[\"A\"] */;"},
+		{title: "AutoNumbered", input: "const enum E { A, B }\nlet x = E.B;", output: "let x = 1 /* E.B */;"},
+		{title: "Negative", input: "const enum E { A = -1 }\nE.A;", output: "-1 /* E.A */;"},
+		{title: "NaN", input: "const enum E { A = 0 / 0, B = 1 / 0, C = -1 / 0 }\nE.A;\nE.B;\nE.C;", output: "NaN /* E.A */;\nInfinity /* E.B */;\n-Infinity /* E.C */;"},
+		{title: "DotDot", input: "const enum E { A = 1 }\nE.A.toString();", output: "1 /* E.A */.toString();"},
+		{title: "NamespaceMember", input: "namespace N { export const enum E { A = 2 } }\nN.E.A;", output: "2 /* N.E.A */;"},
+		{title: "Imported", input: "import { E } from \"other\";\nE.A;", other: "export const enum E { A = 2 }", output: "2 /* E.A */;"},
+		{title: "CommentTerminator", input: "const enum E { \"*/\" = 1 }\nE[\"*/\"];", output: "1 /* E[\"*_/\"] */;"},
+		{title: "RemoveComments", input: "const enum E { A = 1 }\nE.A;", output: "1;", options: &core.CompilerOptions{RemoveComments: core.TSTrue}},
+		{title: "RegularEnum", input: "enum E { A = 1 }\nE.A;", output: "var E;\n(function (E) {\n    E[E[\"A\"] = 1] = \"A\";\n})(E || (E = {}));\nE.A;"},
+	}
+
+	for _, rec := range data {
+		t.Run(rec.title, func(t *testing.T) {
+			t.Parallel()
+
+			file := parsetestutil.ParseTypeScript(rec.input, false /*jsx*/)
+			parsetestutil.CheckDiagnostics(t, file)
+			files := []*ast.SourceFile{file}
+
+			var other *ast.SourceFile
+			if len(rec.other) > 0 {
+				other = parsetestutil.ParseTypeScript(rec.other, false /*jsx*/)
+				parsetestutil.CheckDiagnostics(t, other)
+				files = append(files, other)
+			}
+
+			compilerOptions := rec.options
+			if compilerOptions == nil {
+				compilerOptions = &core.CompilerOptions{}
+			}
+
+			c := checker.NewChecker(&fakeProgram{
+				singleThreaded:  true,
+				compilerOptions: compilerOptions,
+				files:           files,
+				getEmitModuleFormatOfFile: func(sourceFile *ast.SourceFile) core.ModuleKind {
+					return core.ModuleKindESNext
+				},
+				getImpliedNodeFormatForEmit: func(sourceFile *ast.SourceFile) core.ModuleKind {
+					return core.ModuleKindESNext
+				},
+				getResolvedModule: func(currentSourceFile *ast.SourceFile, moduleReference string) *ast.SourceFile {
+					if currentSourceFile == file && moduleReference == "other" {
+						return other
+					}
+					return nil
+				},
+			})
+
+			emitResolver := c.GetEmitResolver(file, false /*skipDiagnostics*/)
+			emitResolver.MarkLinkedReferencesRecursively(file)
+
+			emitContext := printer.NewEmitContext()
+			file = NewTypeEraserTransformer(emitContext, compilerOptions).TransformSourceFile(file)
+			file = NewConstEnumInliningTransformer(emitContext, compilerOptions, emitResolver).TransformSourceFile(file)
+			file = NewImportElisionTransformer(emitContext, compilerOptions, emitResolver).TransformSourceFile(file)
+			file = NewRuntimeSyntaxTransformer(emitContext, compilerOptions, emitResolver).TransformSourceFile(file)
+			emittestutil.CheckEmit(t, emitContext, file, rec.output)
+		})
+	}
+}
@@ -0,0 +1,1162 @@
+package transformers
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/binder"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/jsnum"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+// DeclarationTransformer transforms a TypeScript source file into its declaration file from its syntax alone,
+// without a type checker, as is possible for files that are valid under `isolatedDeclarations`. Declarations
+// whose types can't be determined syntactically are reported with isolatedDeclarations errors and are given
+// the type `any`.
+type DeclarationTransformer struct {
+	Transformer
+	compilerOptions *core.CompilerOptions
+	sourceFile      *ast.SourceFile
+	diagnostics     []*ast.Diagnostic
+	// The variable, parameter, property or export assignment whose type is being inferred from its initializer.
+	currentDeclaration *ast.Node
+}
+
+func NewDeclarationTransformer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions) *DeclarationTransformer {
+	tx := &DeclarationTransformer{compilerOptions: compilerOptions}
+	tx.newTransformer(tx.visit, emitContext)
+	return tx
+}
+
+// Diagnostics returns the isolatedDeclarations errors reported while transforming source files.
+func (tx *DeclarationTransformer) Diagnostics() []*ast.Diagnostic {
+	return tx.diagnostics
+}
+
+func (tx *DeclarationTransformer) visit(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	default:
+		return node
+	}
+}
+
+func (tx *DeclarationTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+
+	tx.sourceFile = node
+	diagnosticsStart := len(tx.diagnostics)
+	isExternalModule := ast.IsExternalModule(node)
+	statements, needsScopeMarker := tx.transformStatements(node.Statements.Nodes, isExternalModule, true /*isTopLevel*/)
+	if isExternalModule && (needsScopeMarker || !core.Some(statements, ast.IsExternalModuleIndicator)) {
+		// A module whose declarations are all local must still be a module, and local declarations must not
+		// become exports of the declaration file:
+		//  export {};
+		statements = append(statements, tx.newEmptyExports())
+	}
+	// Declarations are transformed in the order they are found to be needed, so sort their errors by position.
+	slices.SortStableFunc(tx.diagnostics[diagnosticsStart:], ast.CompareDiagnostics)
+
+	statementList := tx.factory.NewNodeList(statements)
+	statementList.Loc = node.Statements.Loc
+	return tx.factory.UpdateSourceFile(node, statementList)
+}
+
+// Transforms the statements of a source file or namespace body into declarations. In a module or namespace, only
+// the exported declarations and the local declarations they reference are kept. Also returns whether local
+// declarations were kept without an export declaration or assignment that prevents them from becoming exports.
+func (tx *DeclarationTransformer) transformStatements(statements []*ast.Statement, isModule bool, isTopLevel bool) ([]*ast.Statement, bool) {
+	results := make([][]*ast.Statement, len(statements))
+	visited := make([]bool, len(statements))
+	referenced := core.Set[string]{}
+	hasLocalDeclarations := false
+
+	// Transform the visible declarations, then the local declarations they reference, until no more are needed.
+	for changed := true; changed; {
+		changed = false
+		for i, statement := range statements {
+			if visited[i] || ast.IsImportDeclaration(statement) {
+				continue
+			}
+			isVisible := !isModule || isVisibleStatement(statement)
+			if !isVisible && !core.Some(getDeclaredNames(statement), referenced.Has) {
+				continue
+			}
+			visited[i] = true
+			changed = true
+			results[i] = tx.transformStatement(statement, statements, isTopLevel)
+			if !isVisible && len(results[i]) != 0 && !ast.IsImportEqualsDeclaration(statement) {
+				hasLocalDeclarations = true
+			}
+			for _, result := range results[i] {
+				collectReferences(result, &referenced)
+			}
+		}
+	}
+
+	var result []*ast.Statement
+	for i, statement := range statements {
+		if ast.IsImportDeclaration(statement) {
+			if importDeclaration := tx.transformImportDeclaration(statement.AsImportDeclaration(), &referenced); importDeclaration != nil {
+				result = append(result, importDeclaration)
+			}
+			continue
+		}
+		result = append(result, results[i]...)
+	}
+	return result, hasLocalDeclarations && !core.Some(result, isScopeMarker)
+}
+
+// Transforms a statement into the statements of its declaration, if any.
+func (tx *DeclarationTransformer) transformStatement(node *ast.Statement, statements []*ast.Statement, isTopLevel bool) []*ast.Statement {
+	if tx.shouldStripInternal(node) {
+		return nil
+	}
+	if ast.HasSyntacticModifier(node, ast.ModifierFlagsAmbient) {
+		// Ambient declarations are already declarations.
+		return []*ast.Statement{node}
+	}
+
+	var result *ast.Statement
+	switch node.Kind {
+	case ast.KindInterfaceDeclaration:
+		result = tx.transformInterfaceDeclaration(node.AsInterfaceDeclaration())
+	case ast.KindTypeAliasDeclaration,
+		ast.KindImportEqualsDeclaration,
+		ast.KindExportDeclaration,
+		ast.KindNamespaceExportDeclaration:
+		result = node
+	case ast.KindFunctionDeclaration:
+		if node.Body() != nil && hasOverloads(node, statements) {
+			// Only the overloads of a function are part of its declaration.
+			return nil
+		}
+		result = tx.transformFunctionDeclaration(node.AsFunctionDeclaration(), isTopLevel)
+	case ast.KindClassDeclaration:
+		result = tx.transformClassDeclaration(node.AsClassDeclaration(), isTopLevel)
+	case ast.KindVariableStatement:
+		result = tx.transformVariableStatement(node.AsVariableStatement(), isTopLevel)
+	case ast.KindEnumDeclaration:
+		result = tx.transformEnumDeclaration(node.AsEnumDeclaration(), isTopLevel)
+	case ast.KindModuleDeclaration:
+		result = tx.transformModuleDeclaration(node.AsModuleDeclaration(), isTopLevel)
+	case ast.KindExportAssignment:
+		return tx.transformExportAssignment(node.AsExportAssignment())
+	}
+	if result == nil {
+		return nil
+	}
+	return []*ast.Statement{result}
+}
+
+func (tx *DeclarationTransformer) transformInterfaceDeclaration(node *ast.InterfaceDeclaration) *ast.Statement {
+	if !tx.compilerOptions.StripInternal.IsTrue() || !core.Some(node.Members.Nodes, tx.shouldStripInternal) {
+		return node.AsNode()
+	}
+	memberList := tx.factory.NewNodeList(core.Filter(node.Members.Nodes, func(member *ast.Node) bool { return !tx.shouldStripInternal(member) }))
+	memberList.Loc = node.Members.Loc
+	return tx.factory.UpdateInterfaceDeclaration(node, node.Modifiers(), node.Name(), node.TypeParameters, node.HeritageClauses, memberList)
+}
+
+func (tx *DeclarationTransformer) transformFunctionDeclaration(node *ast.FunctionDeclaration, isTopLevel bool) *ast.Statement {
+	return tx.factory.UpdateFunctionDeclaration(
+		node,
+		tx.ensureModifiers(node.AsNode(), isTopLevel),
+		nil, /*asteriskToken*/
+		node.Name(),
+		node.TypeParameters,
+		tx.transformParameters(node.Parameters),
+		tx.ensureReturnType(node.AsNode()),
+		nil, /*body*/
+	)
+}
+
+func (tx *DeclarationTransformer) transformClassDeclaration(node *ast.ClassDeclaration, isTopLevel bool) *ast.Statement {
+	var heritageClauses *ast.NodeList
+	if node.HeritageClauses != nil {
+		var clauses []*ast.Node
+		for _, clause := range node.HeritageClauses.Nodes {
+			if clause.AsHeritageClause().Token == ast.KindExtendsKeyword {
+				if expression := clause.AsHeritageClause().Types.Nodes[0].Expression(); !ast.IsEntityNameExpression(expression) {
+					tx.addDiagnostic(tx.newDiagnosticForNode(expression, diagnostics.Extends_clause_can_t_contain_an_expression_with_isolatedDeclarations))
+					continue
+				}
+			}
+			clauses = append(clauses, clause)
+		}
+		heritageClauses = tx.factory.NewNodeList(clauses)
+		heritageClauses.Loc = node.HeritageClauses.Loc
+	}
+
+	var members []*ast.Node
+	var parameterProperties []*ast.Node
+	hasPrivateIdentifier := false
+	for _, member := range node.Members.Nodes {
+		if tx.shouldStripInternal(member) {
+			continue
+		}
+		if name := member.Name(); name != nil && ast.IsPrivateIdentifier(name) {
+			hasPrivateIdentifier = true
+			continue
+		}
+		switch member.Kind {
+		case ast.KindConstructor:
+			if member.Body() == nil {
+				if member := tx.transformConstructor(member.AsConstructorDeclaration()); member != nil {
+					members = append(members, member)
+				}
+				continue
+			}
+			var declaration *ast.Node
+			if !hasOverloads(member, node.Members.Nodes) {
+				declaration = tx.transformConstructor(member.AsConstructorDeclaration())
+				members = append(members, declaration)
+			}
+			parameterProperties = tx.transformParameterProperties(member, declaration)
+		case ast.KindPropertyDeclaration:
+			members = append(members, tx.transformPropertyDeclaration(member.AsPropertyDeclaration()))
+		case ast.KindMethodDeclaration:
+			if member.Body() != nil && hasOverloads(member, node.Members.Nodes) {
+				continue
+			}
+			members = append(members, tx.transformMethodDeclaration(member.AsMethodDeclaration()))
+		case ast.KindGetAccessor, ast.KindSetAccessor:
+			members = append(members, tx.transformAccessorDeclaration(member, node.Members.Nodes))
+		case ast.KindIndexSignature:
+			members = append(members, member)
+		}
+	}
+
+	// Private names are not visible outside of the class, but they still make the class nominal:
+	//  #private;
+	var privateIdentifier []*ast.Node
+	if hasPrivateIdentifier {
+		privateIdentifier = []*ast.Node{tx.factory.NewPropertyDeclaration(nil, tx.factory.NewPrivateIdentifier("#private"), nil, nil, nil)}
+	}
+	memberList := tx.factory.NewNodeList(slices.Concat(privateIdentifier, parameterProperties, members))
+	memberList.Loc = node.Members.Loc
+
+	return tx.factory.UpdateClassDeclaration(
+		node,
+		tx.ensureModifiers(node.AsNode(), isTopLevel),
+		node.Name(),
+		node.TypeParameters,
+		heritageClauses,
+		memberList,
+	)
+}
+
+func (tx *DeclarationTransformer) transformConstructor(node *ast.ConstructorDeclaration) *ast.Node {
+	var parameters *ast.NodeList
+	if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsPrivate) {
+		// The parameters of a private constructor are not visible.
+		parameters = tx.factory.NewNodeList([]*ast.Node{})
+	} else {
+		parameters = tx.transformParameters(node.Parameters)
+	}
+	return tx.factory.UpdateConstructorDeclaration(node, tx.ensureModifiers(node.AsNode(), false /*isTopLevel*/), nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*body*/)
+}
+
+// Transforms the parameter properties of a constructor into property declarations. The types of the properties are
+// those of the parameters in declaration, the transformed constructor, if it was emitted.
+func (tx *DeclarationTransformer) transformParameterProperties(node *ast.Node, declaration *ast.Node) []*ast.Node {
+	var result []*ast.Node
+	for i, parameter := range node.Parameters() {
+		if !ast.HasSyntacticModifier(parameter, ast.ModifierFlagsParameterPropertyModifier) || !ast.IsIdentifier(parameter.Name()) || tx.shouldStripInternal(parameter) {
+			continue
+		}
+		var typeNode *ast.TypeNode
+		if !ast.HasSyntacticModifier(parameter, ast.ModifierFlagsPrivate) {
+			if declaration != nil && len(declaration.Parameters()) > i {
+				typeNode = declaration.Parameters()[i].Type()
+			} else {
+				typeNode = tx.ensureType(parameter, parameter.Initializer())
+			}
+		}
+		result = append(result, tx.factory.NewPropertyDeclaration(
+			tx.ensureModifiers(parameter, false /*isTopLevel*/),
+			parameter.Name(),
+			parameter.AsParameterDeclaration().QuestionToken,
+			typeNode,
+			nil, /*initializer*/
+		))
+	}
+	return result
+}
+
+func (tx *DeclarationTransformer) transformPropertyDeclaration(node *ast.PropertyDeclaration) *ast.Node {
+	modifiers := tx.ensureModifiers(node.AsNode(), false /*isTopLevel*/)
+	postfixToken := node.PostfixToken
+	if postfixToken != nil && postfixToken.Kind != ast.KindQuestionToken {
+		postfixToken = nil
+	}
+	if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsPrivate) {
+		// The types of private members are not visible:
+		//  private x;
+		return tx.factory.UpdatePropertyDeclaration(node, modifiers, node.Name(), postfixToken, nil, nil)
+	}
+	if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsReadonly) && node.Type == nil && isPrimitiveLiteralValue(node.Initializer) {
+		//  readonly x = 1;
+		return tx.factory.UpdatePropertyDeclaration(node, modifiers, node.Name(), postfixToken, nil, tx.newLiteralInitializer(node.Initializer))
+	}
+	return tx.factory.UpdatePropertyDeclaration(node, modifiers, node.Name(), postfixToken, tx.ensureType(node.AsNode(), node.Initializer), nil)
+}
+
+func (tx *DeclarationTransformer) transformMethodDeclaration(node *ast.MethodDeclaration) *ast.Node {
+	modifiers := tx.ensureModifiers(node.AsNode(), false /*isTopLevel*/)
+	if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsPrivate) {
+		// The signatures of private methods are not visible:
+		//  private m;
+		return tx.factory.NewPropertyDeclaration(modifiers, node.Name(), nil, nil, nil)
+	}
+	return tx.factory.UpdateMethodDeclaration(
+		node,
+		modifiers,
+		nil, /*asteriskToken*/
+		node.Name(),
+		node.PostfixToken,
+		node.TypeParameters,
+		tx.transformParameters(node.Parameters),
+		tx.ensureReturnType(node.AsNode()),
+		nil, /*body*/
+	)
+}
+
+func (tx *DeclarationTransformer) transformAccessorDeclaration(node *ast.Node, members []*ast.Node) *ast.Node {
+	modifiers := tx.ensureModifiers(node, false /*isTopLevel*/)
+	isPrivate := ast.HasSyntacticModifier(node, ast.ModifierFlagsPrivate)
+	var typeNode *ast.TypeNode
+	if !isPrivate {
+		typeNode = getAccessorType(node, members)
+		if typeNode == nil {
+			tx.reportInferenceFallback(node)
+			typeNode = tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword)
+		}
+	}
+	if node.Kind == ast.KindGetAccessor {
+		return tx.factory.UpdateGetAccessorDeclaration(node.AsGetAccessorDeclaration(), modifiers, node.Name(), nil /*typeParameters*/, tx.factory.NewNodeList([]*ast.Node{}), typeNode, nil /*body*/)
+	}
+	var parameterName *ast.Node
+	if parameters := node.Parameters(); len(parameters) != 0 && !isPrivate {
+		parameterName = tx.stripBindingInitializers(parameters[0].Name())
+	} else {
+		parameterName = tx.factory.NewIdentifier("value")
+	}
+	parameter := tx.factory.NewParameterDeclaration(nil, nil, parameterName, nil, typeNode, nil)
+	return tx.factory.UpdateSetAccessorDeclaration(node.AsSetAccessorDeclaration(), modifiers, node.Name(), nil /*typeParameters*/, tx.factory.NewNodeList([]*ast.Node{parameter}), nil /*returnType*/, nil /*body*/)
+}
+
+func (tx *DeclarationTransformer) transformVariableStatement(node *ast.VariableStatement, isTopLevel bool) *ast.Statement {
+	declarationList := node.DeclarationList.AsVariableDeclarationList()
+	flags := declarationList.Flags & ast.NodeFlagsBlockScoped
+	if flags&ast.NodeFlagsUsing != 0 {
+		// `using` and `await using` declarations are declared as `const`.
+		flags = ast.NodeFlagsConst
+	}
+	isExported := ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport)
+
+	var declarations []*ast.Node
+	for _, declaration := range declarationList.Declarations.Nodes {
+		if tx.shouldStripInternal(declaration) {
+			continue
+		}
+		declarations = tx.appendVariableDeclarations(declarations, declaration.AsVariableDeclaration(), flags == ast.NodeFlagsConst, isExported)
+	}
+	if len(declarations) == 0 {
+		return nil
+	}
+	return tx.factory.UpdateVariableStatement(
+		node,
+		tx.ensureModifiers(node.AsNode(), isTopLevel),
+		tx.factory.NewVariableDeclarationList(flags, tx.factory.NewNodeList(declarations)),
+	)
+}
+
+func (tx *DeclarationTransformer) appendVariableDeclarations(declarations []*ast.Node, node *ast.VariableDeclaration, isConst bool, isExported bool) []*ast.Node {
+	name := node.Name()
+	if ast.IsBindingPattern(name) {
+		// Each name bound by a destructuring declaration is declared separately, but their types can't be inferred.
+		for _, element := range name.AsBindingPattern().Elements.Nodes {
+			if element.Name() == nil {
+				continue
+			}
+			if ast.IsBindingPattern(element.Name()) {
+				declarations = tx.appendVariableDeclarations(declarations, tx.factory.NewVariableDeclaration(element.Name(), nil, nil, nil).AsVariableDeclaration(), isConst, isExported)
+				continue
+			}
+			if isExported {
+				tx.addDiagnostic(tx.newDiagnosticForNode(element, diagnostics.Binding_elements_can_t_be_exported_directly_with_isolatedDeclarations))
+			}
+			declarations = append(declarations, tx.factory.NewVariableDeclaration(element.Name(), nil, tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword), nil))
+		}
+		return declarations
+	}
+	if isConst && node.Type == nil && isPrimitiveLiteralValue(node.Initializer) {
+		//  const x = 1;
+		return append(declarations, tx.factory.UpdateVariableDeclaration(node, name, nil, nil, tx.newLiteralInitializer(node.Initializer)))
+	}
+	return append(declarations, tx.factory.UpdateVariableDeclaration(node, name, nil, tx.ensureType(node.AsNode(), node.Initializer), nil))
+}
+
+func (tx *DeclarationTransformer) transformEnumDeclaration(node *ast.EnumDeclaration, isTopLevel bool) *ast.Statement {
+	values := make(map[string]any)
+	var autoValue any = jsnum.Number(0)
+	var members []*ast.Node
+	for _, memberNode := range node.Members.Nodes {
+		member := memberNode.AsEnumMember()
+		value := autoValue
+		if member.Initializer != nil {
+			hasExternalReferences := false
+			value = evaluateConstantValue(member.Initializer, func(expression *ast.Expression) any {
+				if name, ok := getEnumMemberReferenceName(node, expression); ok {
+					if value, ok := values[name]; ok {
+						return value
+					}
+				}
+				hasExternalReferences = true
+				return nil
+			})
+			if hasExternalReferences {
+				tx.addDiagnostic(tx.newDiagnosticForNode(member.Initializer, diagnostics.Enum_member_initializers_must_be_computable_without_references_to_external_symbols_with_isolatedDeclarations))
+			}
+		}
+		if number, ok := value.(jsnum.Number); ok {
+			autoValue = number + 1
+		} else {
+			autoValue = nil
+		}
+		values[ast.GetTextOfPropertyName(member.Name())] = value
+		if tx.shouldStripInternal(memberNode) {
+			// The member is not declared, but the members that follow it are still numbered after it.
+			continue
+		}
+		members = append(members, tx.factory.UpdateEnumMember(member, member.Name(), constantExpression(value, tx.factory)))
+	}
+	memberList := tx.factory.NewNodeList(members)
+	memberList.Loc = node.Members.Loc
+	return tx.factory.UpdateEnumDeclaration(node, tx.ensureModifiers(node.AsNode(), isTopLevel), node.Name(), memberList)
+}
+
+// Gets the name of the member of enum that expression refers to, as in `A`, `E.A` or `E["A"]`.
+func getEnumMemberReferenceName(enum *ast.EnumDeclaration, expression *ast.Expression) (string, bool) {
+	switch expression.Kind {
+	case ast.KindIdentifier:
+		return expression.Text(), true
+	case ast.KindPropertyAccessExpression:
+		access := expression.AsPropertyAccessExpression()
+		if ast.IsIdentifier(access.Expression) && access.Expression.Text() == enum.Name().Text() {
+			return access.Name().Text(), true
+		}
+	case ast.KindElementAccessExpression:
+		access := expression.AsElementAccessExpression()
+		if ast.IsIdentifier(access.Expression) && access.Expression.Text() == enum.Name().Text() && ast.IsStringLiteralLike(access.ArgumentExpression) {
+			return access.ArgumentExpression.Text(), true
+		}
+	}
+	return "", false
+}
+
+func (tx *DeclarationTransformer) transformModuleDeclaration(node *ast.ModuleDeclaration, isTopLevel bool) *ast.Statement {
+	return tx.factory.UpdateModuleDeclaration(node, tx.ensureModifiers(node.AsNode(), isTopLevel), node.Name(), tx.transformModuleBody(node.Body))
+}
+
+func (tx *DeclarationTransformer) transformModuleBody(body *ast.ModuleBody) *ast.ModuleBody {
+	switch {
+	case body == nil:
+		return nil
+	case ast.IsModuleDeclaration(body):
+		// The inner namespace of a dotted namespace, as in `namespace A.B {}`.
+		inner := body.AsModuleDeclaration()
+		return tx.factory.UpdateModuleDeclaration(inner, inner.Modifiers(), inner.Name(), tx.transformModuleBody(inner.Body))
+	default:
+		block := body.AsModuleBlock()
+		statements, needsScopeMarker := tx.transformStatements(block.Statements.Nodes, true /*isModule*/, false /*isTopLevel*/)
+		if needsScopeMarker {
+			statements = append(statements, tx.newEmptyExports())
+		} else if !core.Some(statements, isScopeMarker) {
+			// All declarations of an ambient namespace without export declarations are exported, so the `export`
+			// modifiers are redundant.
+			statements = core.Map(statements, tx.stripExportModifiers)
+		}
+		statementList := tx.factory.NewNodeList(statements)
+		statementList.Loc = block.Statements.Loc
+		return tx.factory.UpdateModuleBlock(block, statementList)
+	}
+}
+
+func (tx *DeclarationTransformer) stripExportModifiers(node *ast.Statement) *ast.Statement {
+	if !ast.HasSyntacticModifier(node, ast.ModifierFlagsExport) || ast.IsImportEqualsDeclaration(node) {
+		return node
+	}
+	modifiers := extractModifiers(tx.emitContext, node.Modifiers(), ast.ModifierFlagsAll&^ast.ModifierFlagsExport)
+	switch node.Kind {
+	case ast.KindVariableStatement:
+		n := node.AsVariableStatement()
+		return tx.factory.UpdateVariableStatement(n, modifiers, n.DeclarationList)
+	case ast.KindFunctionDeclaration:
+		n := node.AsFunctionDeclaration()
+		return tx.factory.UpdateFunctionDeclaration(n, modifiers, n.AsteriskToken, n.Name(), n.TypeParameters, n.Parameters, n.Type, n.Body)
+	case ast.KindClassDeclaration:
+		n := node.AsClassDeclaration()
+		return tx.factory.UpdateClassDeclaration(n, modifiers, n.Name(), n.TypeParameters, n.HeritageClauses, n.Members)
+	case ast.KindInterfaceDeclaration:
+		n := node.AsInterfaceDeclaration()
+		return tx.factory.UpdateInterfaceDeclaration(n, modifiers, n.Name(), n.TypeParameters, n.HeritageClauses, n.Members)
+	case ast.KindTypeAliasDeclaration:
+		n := node.AsTypeAliasDeclaration()
+		return tx.factory.UpdateTypeAliasDeclaration(n, modifiers, n.Name(), n.TypeParameters, n.Type)
+	case ast.KindEnumDeclaration:
+		n := node.AsEnumDeclaration()
+		return tx.factory.UpdateEnumDeclaration(n, modifiers, n.Name(), n.Members)
+	case ast.KindModuleDeclaration:
+		n := node.AsModuleDeclaration()
+		return tx.factory.UpdateModuleDeclaration(n, modifiers, n.Name(), n.Body)
+	default:
+		return node
+	}
+}
+
+func (tx *DeclarationTransformer) transformExportAssignment(node *ast.ExportAssignment) []*ast.Statement {
+	if ast.IsIdentifier(node.Expression) {
+		return []*ast.Statement{node.AsNode()}
+	}
+
+	// Any other exported expression is declared as a variable:
+	//  declare const _default: T;
+	//  export default _default;
+	savedCurrentDeclaration := tx.currentDeclaration
+	tx.currentDeclaration = node.AsNode()
+	typeNode := tx.typeFromExpression(node.Expression, false /*isConstContext*/)
+	if typeNode == nil {
+		tx.reportInferenceFallback(node.AsNode())
+		typeNode = tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword)
+	}
+	tx.currentDeclaration = savedCurrentDeclaration
+
+	name := tx.emitContext.NewUniqueName("_default", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic})
+	declaration := tx.factory.NewVariableStatement(
+		tx.newModifierList(ast.ModifierFlagsAmbient),
+		tx.factory.NewVariableDeclarationList(ast.NodeFlagsConst, tx.factory.NewNodeList([]*ast.Node{
+			tx.factory.NewVariableDeclaration(name, nil, typeNode, nil),
+		})),
+	)
+	return []*ast.Statement{declaration, tx.factory.UpdateExportAssignment(node, node.Modifiers(), name)}
+}
+
+// Removes the bindings of an import declaration that are not referenced by the declarations.
+func (tx *DeclarationTransformer) transformImportDeclaration(node *ast.ImportDeclaration, referenced *core.Set[string]) *ast.Statement {
+	if node.ImportClause == nil {
+		// Side-effect imports are always kept:
+		//  import "foo";
+		return node.AsNode()
+	}
+	importClause := node.ImportClause.AsImportClause()
+	name := importClause.Name()
+	if name != nil && !referenced.Has(name.Text()) {
+		name = nil
+	}
+	namedBindings := importClause.NamedBindings
+	if namedBindings != nil {
+		if ast.IsNamespaceImport(namedBindings) {
+			if !referenced.Has(namedBindings.Name().Text()) {
+				namedBindings = nil
+			}
+		} else {
+			namedImports := namedBindings.AsNamedImports()
+			elements := core.Filter(namedImports.Elements.Nodes, func(element *ast.Node) bool {
+				return referenced.Has(element.Name().Text())
+			})
+			if len(elements) == 0 {
+				namedBindings = nil
+			} else if len(elements) != len(namedImports.Elements.Nodes) {
+				elementList := tx.factory.NewNodeList(elements)
+				elementList.Loc = namedImports.Elements.Loc
+				namedBindings = tx.factory.UpdateNamedImports(namedImports, elementList)
+			}
+		}
+	}
+	if name == nil && namedBindings == nil {
+		return nil
+	}
+	return tx.factory.UpdateImportDeclaration(
+		node,
+		node.Modifiers(),
+		tx.factory.UpdateImportClause(importClause, importClause.IsTypeOnly, name, namedBindings),
+		node.ModuleSpecifier,
+		node.Attributes,
+	)
+}
+
+func (tx *DeclarationTransformer) transformParameters(parameters *ast.NodeList) *ast.NodeList {
+	if parameters == nil {
+		return nil
+	}
+	result := make([]*ast.Node, len(parameters.Nodes))
+	for i, parameter := range parameters.Nodes {
+		result[i] = tx.transformParameter(parameter.AsParameterDeclaration(), parameters.Nodes[i+1:])
+	}
+	parameterList := tx.factory.NewNodeList(result)
+	parameterList.Loc = parameters.Loc
+	return parameterList
+}
+
+func (tx *DeclarationTransformer) transformParameter(node *ast.ParameterDeclaration, followingParameters []*ast.Node) *ast.Node {
+	if ast.IsThisParameter(node.AsNode()) {
+		return node.AsNode()
+	}
+	questionToken := node.QuestionToken
+	if questionToken == nil && node.Initializer != nil && core.Every(followingParameters, isOptionalParameter) {
+		// A parameter with an initializer is optional, unless a required parameter follows it.
+		questionToken = tx.factory.NewToken(ast.KindQuestionToken)
+	}
+	var typeNode *ast.TypeNode
+	if node.DotDotDotToken != nil && node.Type == nil && node.Initializer == nil {
+		tx.reportInferenceFallback(node.AsNode())
+		typeNode = tx.factory.NewArrayTypeNode(tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword))
+	} else {
+		typeNode = tx.ensureType(node.AsNode(), node.Initializer)
+	}
+	return tx.factory.UpdateParameterDeclaration(node, nil /*modifiers*/, node.DotDotDotToken, tx.stripBindingInitializers(node.Name()), questionToken, typeNode, nil /*initializer*/)
+}
+
+// Removes the initializers of the elements of a binding pattern, which can't be part of a declaration.
+func (tx *DeclarationTransformer) stripBindingInitializers(name *ast.BindingName) *ast.BindingName {
+	if !ast.IsBindingPattern(name) {
+		return name
+	}
+	pattern := name.AsBindingPattern()
+	elements := make([]*ast.Node, len(pattern.Elements.Nodes))
+	for i, element := range pattern.Elements.Nodes {
+		if ast.IsBindingElement(element) {
+			bindingElement := element.AsBindingElement()
+			element = tx.factory.UpdateBindingElement(bindingElement, bindingElement.DotDotDotToken, bindingElement.PropertyName, tx.stripBindingInitializers(bindingElement.Name()), nil /*initializer*/)
+		}
+		elements[i] = element
+	}
+	elementList := tx.factory.NewNodeList(elements)
+	elementList.Loc = pattern.Elements.Loc
+	return tx.factory.UpdateBindingPattern(pattern, elementList)
+}
+
+// Gets the type of a variable, parameter or property from its type annotation, or else infers it from its
+// initializer. Reports an error and returns `any` when the type can't be inferred.
+func (tx *DeclarationTransformer) ensureType(node *ast.Node, initializer *ast.Expression) *ast.TypeNode {
+	if typeNode := node.Type(); typeNode != nil {
+		return typeNode
+	}
+	if initializer != nil {
+		savedCurrentDeclaration := tx.currentDeclaration
+		tx.currentDeclaration = node
+		typeNode := tx.typeFromExpression(initializer, false /*isConstContext*/)
+		tx.currentDeclaration = savedCurrentDeclaration
+		if typeNode != nil {
+			return typeNode
+		}
+	}
+	tx.reportInferenceFallback(node)
+	return tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword)
+}
+
+// Gets the return type annotation of a function-like declaration. Reports an error and returns `any` when there is
+// none, since return types are never inferred from function bodies.
+func (tx *DeclarationTransformer) ensureReturnType(node *ast.Node) *ast.TypeNode {
+	if typeNode := node.Type(); typeNode != nil {
+		return typeNode
+	}
+	tx.reportInferenceFallback(node)
+	return tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword)
+}
+
+// Infers the type of an expression from its syntax, as the type checker would for an initializer. Literals keep
+// their literal types in a const context (`as const`). Returns nil for expressions whose type can't be inferred.
+func (tx *DeclarationTransformer) typeFromExpression(node *ast.Expression, isConstContext bool) *ast.TypeNode {
+	switch node.Kind {
+	case ast.KindParenthesizedExpression:
+		return tx.typeFromExpression(node.Expression(), isConstContext)
+	case ast.KindNumericLiteral, ast.KindBigIntLiteral, ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral, ast.KindTrueKeyword, ast.KindFalseKeyword:
+		if isConstContext {
+			return tx.factory.NewLiteralTypeNode(node)
+		}
+		return tx.factory.NewKeywordTypeNode(getWidenedLiteralTypeKind(node))
+	case ast.KindPrefixUnaryExpression:
+		operand := node.AsPrefixUnaryExpression().Operand
+		switch node.AsPrefixUnaryExpression().Operator {
+		case ast.KindMinusToken:
+			if ast.IsNumericLiteral(operand) || ast.IsBigIntLiteral(operand) {
+				if isConstContext {
+					return tx.factory.NewLiteralTypeNode(node)
+				}
+				return tx.factory.NewKeywordTypeNode(getWidenedLiteralTypeKind(operand))
+			}
+		case ast.KindPlusToken:
+			if ast.IsNumericLiteral(operand) {
+				return tx.factory.NewKeywordTypeNode(ast.KindNumberKeyword)
+			}
+		}
+	case ast.KindTemplateExpression:
+		return tx.factory.NewKeywordTypeNode(ast.KindStringKeyword)
+	case ast.KindNullKeyword:
+		if tx.strictNullChecks() {
+			return tx.factory.NewLiteralTypeNode(tx.factory.NewToken(ast.KindNullKeyword))
+		}
+	case ast.KindIdentifier:
+		if node.Text() == "undefined" && tx.strictNullChecks() {
+			return tx.factory.NewKeywordTypeNode(ast.KindUndefinedKeyword)
+		}
+	case ast.KindAsExpression, ast.KindTypeAssertionExpression:
+		typeNode := node.Type()
+		if ast.IsConstTypeReference(typeNode) {
+			return tx.typeFromExpression(node.Expression(), true /*isConstContext*/)
+		}
+		return typeNode
+	case ast.KindObjectLiteralExpression:
+		return tx.typeFromObjectLiteral(node.AsObjectLiteralExpression(), isConstContext)
+	case ast.KindArrayLiteralExpression:
+		return tx.typeFromArrayLiteral(node.AsArrayLiteralExpression(), isConstContext)
+	case ast.KindFunctionExpression, ast.KindArrowFunction:
+		return tx.factory.NewFunctionTypeNode(node.TypeParameterList(), tx.transformParameters(node.FunctionLikeData().Parameters), tx.ensureReturnType(node))
+	case ast.KindClassExpression:
+		tx.reportExpressionError(node, diagnostics.Inference_from_class_expressions_is_not_supported_with_isolatedDeclarations)
+		return tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword)
+	}
+	return nil
+}
+
+func (tx *DeclarationTransformer) typeFromObjectLiteral(node *ast.ObjectLiteralExpression, isConstContext bool) *ast.TypeNode {
+	var members []*ast.Node
+	for _, property := range node.Properties.Nodes {
+		switch property.Kind {
+		case ast.KindShorthandPropertyAssignment:
+			tx.reportExpressionError(property, diagnostics.Objects_that_contain_shorthand_properties_can_t_be_inferred_with_isolatedDeclarations)
+			continue
+		case ast.KindSpreadAssignment:
+			tx.reportExpressionError(property, diagnostics.Objects_that_contain_spread_assignments_can_t_be_inferred_with_isolatedDeclarations)
+			continue
+		}
+		name := property.Name()
+		if ast.IsComputedPropertyName(name) && !ast.IsStringOrNumericLiteralLike(name.Expression()) && !ast.IsEntityNameExpression(name.Expression()) {
+			tx.reportExpressionError(name, diagnostics.Computed_properties_must_be_number_or_string_literals_variables_or_dotted_expressions_with_isolatedDeclarations)
+			continue
+		}
+		var modifiers *ast.ModifierList
+		if isConstContext {
+			modifiers = tx.newModifierList(ast.ModifierFlagsReadonly)
+		}
+		switch property.Kind {
+		case ast.KindPropertyAssignment:
+			initializer := property.Initializer()
+			typeNode := tx.typeFromExpression(initializer, isConstContext)
+			if typeNode == nil {
+				tx.reportExpressionError(initializer, diagnostics.Expression_type_can_t_be_inferred_with_isolatedDeclarations)
+				typeNode = tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword)
+			}
+			members = append(members, tx.factory.NewPropertySignatureDeclaration(modifiers, name, nil, typeNode, nil))
+		case ast.KindMethodDeclaration:
+			method := property.AsMethodDeclaration()
+			parameters := tx.transformParameters(method.Parameters)
+			returnType := tx.ensureReturnType(property)
+			if isConstContext {
+				functionType := tx.factory.NewFunctionTypeNode(method.TypeParameters, parameters, returnType)
+				members = append(members, tx.factory.NewPropertySignatureDeclaration(modifiers, name, method.PostfixToken, functionType, nil))
+			} else {
+				members = append(members, tx.factory.NewMethodSignatureDeclaration(nil, name, method.PostfixToken, method.TypeParameters, parameters, returnType))
+			}
+		case ast.KindGetAccessor, ast.KindSetAccessor:
+			if getFirstAccessor(property, node.Properties.Nodes) != property {
+				// An accessor pair declares a single property.
+				continue
+			}
+			typeNode := getAccessorType(property, node.Properties.Nodes)
+			if typeNode == nil {
+				tx.reportInferenceFallback(property)
+				typeNode = tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword)
+			}
+			if getOtherAccessor(property, node.Properties.Nodes, ast.KindSetAccessor) == nil && property.Kind == ast.KindGetAccessor {
+				modifiers = tx.newModifierList(ast.ModifierFlagsReadonly)
+			}
+			members = append(members, tx.factory.NewPropertySignatureDeclaration(modifiers, name, nil, typeNode, nil))
+		}
+	}
+	return tx.factory.NewTypeLiteralNode(tx.factory.NewNodeList(members))
+}
+
+func (tx *DeclarationTransformer) typeFromArrayLiteral(node *ast.ArrayLiteralExpression, isConstContext bool) *ast.TypeNode {
+	if !isConstContext {
+		tx.reportExpressionError(node.AsNode(), diagnostics.Only_const_arrays_can_be_inferred_with_isolatedDeclarations)
+		return tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword)
+	}
+	elements := make([]*ast.Node, len(node.Elements.Nodes))
+	for i, element := range node.Elements.Nodes {
+		if ast.IsSpreadElement(element) {
+			tx.reportExpressionError(element, diagnostics.Arrays_with_spread_elements_can_t_inferred_with_isolatedDeclarations)
+			return tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword)
+		}
+		elements[i] = tx.typeFromExpression(element, true /*isConstContext*/)
+		if elements[i] == nil {
+			tx.reportExpressionError(element, diagnostics.Expression_type_can_t_be_inferred_with_isolatedDeclarations)
+			elements[i] = tx.factory.NewKeywordTypeNode(ast.KindAnyKeyword)
+		}
+	}
+	//  readonly [1, 2]
+	tupleType := tx.factory.NewTupleTypeNode(tx.factory.NewNodeList(elements))
+	tx.emitContext.AddEmitFlags(tupleType, printer.EFSingleLine)
+	return tx.factory.NewTypeOperatorNode(ast.KindReadonlyKeyword, tupleType)
+}
+
+// Reports that the type of a declaration can't be inferred, with a suggestion to add a type annotation.
+func (tx *DeclarationTransformer) reportInferenceFallback(node *ast.Node) {
+	var diagnostic *ast.Diagnostic
+	switch node.Kind {
+	case ast.KindFunctionDeclaration:
+		diagnostic = tx.newDiagnosticForNode(node, diagnostics.Function_must_have_an_explicit_return_type_annotation_with_isolatedDeclarations)
+		diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node, diagnostics.Add_a_return_type_to_the_function_declaration))
+	case ast.KindFunctionExpression, ast.KindArrowFunction:
+		diagnostic = tx.newDiagnosticForNode(node, diagnostics.Function_must_have_an_explicit_return_type_annotation_with_isolatedDeclarations)
+		tx.addCurrentDeclarationRelatedInfo(diagnostic)
+		diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node, diagnostics.Add_a_return_type_to_the_function_expression))
+	case ast.KindMethodDeclaration:
+		diagnostic = tx.newDiagnosticForNode(node, diagnostics.Method_must_have_an_explicit_return_type_annotation_with_isolatedDeclarations)
+		tx.addCurrentDeclarationRelatedInfo(diagnostic)
+		diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node, diagnostics.Add_a_return_type_to_the_method))
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		errorNode := node
+		if node.Kind == ast.KindSetAccessor && len(node.Parameters()) != 0 {
+			errorNode = node.Parameters()[0]
+		}
+		diagnostic = tx.newDiagnosticForNode(errorNode, diagnostics.At_least_one_accessor_must_have_an_explicit_return_type_annotation_with_isolatedDeclarations)
+		if node.Kind == ast.KindSetAccessor {
+			diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node, diagnostics.Add_a_type_to_parameter_of_the_set_accessor_declaration))
+		} else {
+			diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node, diagnostics.Add_a_return_type_to_the_get_accessor_declaration))
+		}
+	case ast.KindVariableDeclaration:
+		diagnostic = tx.newDiagnosticForNode(node, diagnostics.Variable_must_have_an_explicit_type_annotation_with_isolatedDeclarations)
+		diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node, diagnostics.Add_a_type_annotation_to_the_variable_0, tx.getTextOfNode(node.Name())))
+	case ast.KindPropertyDeclaration:
+		diagnostic = tx.newDiagnosticForNode(node, diagnostics.Property_must_have_an_explicit_type_annotation_with_isolatedDeclarations)
+		diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node, diagnostics.Add_a_type_annotation_to_the_property_0, tx.getTextOfNode(node.Name())))
+	case ast.KindParameter:
+		diagnostic = tx.newDiagnosticForNode(node, diagnostics.Parameter_must_have_an_explicit_type_annotation_with_isolatedDeclarations)
+		diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node, diagnostics.Add_a_type_annotation_to_the_parameter_0, tx.getTextOfNode(node.Name())))
+	case ast.KindExportAssignment:
+		expression := node.Expression()
+		diagnostic = tx.newDiagnosticForNode(expression, diagnostics.Default_exports_can_t_be_inferred_with_isolatedDeclarations)
+		diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(expression, diagnostics.Move_the_expression_in_default_export_to_a_variable_and_add_a_type_annotation_to_it))
+	default:
+		diagnostic = tx.newDiagnosticForNode(node, diagnostics.Expression_type_can_t_be_inferred_with_isolatedDeclarations)
+		tx.addCurrentDeclarationRelatedInfo(diagnostic)
+	}
+	tx.addDiagnostic(diagnostic)
+}
+
+// Reports that the type of an expression in an initializer can't be inferred, with a suggestion to add a type
+// annotation to the declaration.
+func (tx *DeclarationTransformer) reportExpressionError(node *ast.Node, message *diagnostics.Message) {
+	diagnostic := tx.newDiagnosticForNode(node, message)
+	tx.addCurrentDeclarationRelatedInfo(diagnostic)
+	tx.addDiagnostic(diagnostic)
+}
+
+func (tx *DeclarationTransformer) addCurrentDeclarationRelatedInfo(diagnostic *ast.Diagnostic) {
+	node := tx.currentDeclaration
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case ast.KindVariableDeclaration:
+		diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node, diagnostics.Add_a_type_annotation_to_the_variable_0, tx.getTextOfNode(node.Name())))
+	case ast.KindPropertyDeclaration:
+		diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node, diagnostics.Add_a_type_annotation_to_the_property_0, tx.getTextOfNode(node.Name())))
+	case ast.KindParameter:
+		diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node, diagnostics.Add_a_type_annotation_to_the_parameter_0, tx.getTextOfNode(node.Name())))
+	case ast.KindExportAssignment:
+		diagnostic.AddRelatedInfo(tx.newDiagnosticForNode(node.Expression(), diagnostics.Move_the_expression_in_default_export_to_a_variable_and_add_a_type_annotation_to_it))
+	}
+}
+
+func (tx *DeclarationTransformer) newDiagnosticForNode(node *ast.Node, message *diagnostics.Message, args ...any) *ast.Diagnostic {
+	return ast.NewDiagnostic(tx.sourceFile, binder.GetErrorRangeForNode(tx.sourceFile, node), message, args...)
+}
+
+func (tx *DeclarationTransformer) addDiagnostic(diagnostic *ast.Diagnostic) {
+	tx.diagnostics = append(tx.diagnostics, diagnostic)
+}
+
+func (tx *DeclarationTransformer) getTextOfNode(node *ast.Node) string {
+	return scanner.GetSourceTextOfNodeFromSourceFile(tx.sourceFile, node, false /*includeTrivia*/)
+}
+
+func (tx *DeclarationTransformer) strictNullChecks() bool {
+	return tx.compilerOptions.StrictNullChecks.DefaultIfUnknown(tx.compilerOptions.Strict).IsTrue()
+}
+
+// Gets the modifiers of a declaration in a declaration file. Top-level declarations other than types are `declare`d,
+// and modifiers that have no meaning in declaration files are removed.
+func (tx *DeclarationTransformer) ensureModifiers(node *ast.Node, isTopLevel bool) *ast.ModifierList {
+	flags := node.ModifierFlags() &^ (ast.ModifierFlagsPublic | ast.ModifierFlagsAsync | ast.ModifierFlagsOverride | ast.ModifierFlagsDecorator)
+	if !isTopLevel {
+		flags &^= ast.ModifierFlagsAmbient
+	} else if !ast.IsInterfaceDeclaration(node) && !ast.IsTypeAliasDeclaration(node) {
+		flags |= ast.ModifierFlagsAmbient
+	}
+	if flags&ast.ModifierFlagsDefault != 0 {
+		// `declare` is never needed, nor allowed, alongside `default`.
+		flags &^= ast.ModifierFlagsAmbient
+	}
+	return tx.newModifierList(flags)
+}
+
+var modifierOrder = []struct {
+	flag ast.ModifierFlags
+	kind ast.Kind
+}{
+	{ast.ModifierFlagsExport, ast.KindExportKeyword},
+	{ast.ModifierFlagsAmbient, ast.KindDeclareKeyword},
+	{ast.ModifierFlagsDefault, ast.KindDefaultKeyword},
+	{ast.ModifierFlagsConst, ast.KindConstKeyword},
+	{ast.ModifierFlagsPublic, ast.KindPublicKeyword},
+	{ast.ModifierFlagsPrivate, ast.KindPrivateKeyword},
+	{ast.ModifierFlagsProtected, ast.KindProtectedKeyword},
+	{ast.ModifierFlagsAbstract, ast.KindAbstractKeyword},
+	{ast.ModifierFlagsStatic, ast.KindStaticKeyword},
+	{ast.ModifierFlagsOverride, ast.KindOverrideKeyword},
+	{ast.ModifierFlagsReadonly, ast.KindReadonlyKeyword},
+	{ast.ModifierFlagsAccessor, ast.KindAccessorKeyword},
+	{ast.ModifierFlagsAsync, ast.KindAsyncKeyword},
+	{ast.ModifierFlagsIn, ast.KindInKeyword},
+	{ast.ModifierFlagsOut, ast.KindOutKeyword},
+}
+
+func (tx *DeclarationTransformer) newModifierList(flags ast.ModifierFlags) *ast.ModifierList {
+	var modifiers []*ast.Node
+	for _, modifier := range modifierOrder {
+		if flags&modifier.flag != 0 {
+			modifiers = append(modifiers, tx.factory.NewModifier(modifier.kind))
+		}
+	}
+	if len(modifiers) == 0 {
+		return nil
+	}
+	return tx.factory.NewModifierList(modifiers)
+}
+
+// Gets the initializer of a `const` variable or `readonly` property with a literal value, which declares its literal type.
+func (tx *DeclarationTransformer) newLiteralInitializer(node *ast.Expression) *ast.Expression {
+	if node.Kind == ast.KindNoSubstitutionTemplateLiteral {
+		return tx.factory.NewStringLiteral(node.Text())
+	}
+	return node
+}
+
+// Gets whether node is marked `@internal` and is left out of declaration files under `stripInternal`.
+func (tx *DeclarationTransformer) shouldStripInternal(node *ast.Node) bool {
+	return tx.compilerOptions.StripInternal.IsTrue() && isInternalDeclaration(node, tx.sourceFile)
+}
+
+func (tx *DeclarationTransformer) newEmptyExports() *ast.Statement {
+	return tx.factory.NewExportDeclaration(nil /*modifiers*/, false /*isTypeOnly*/, tx.factory.NewNamedExports(tx.factory.NewNodeList([]*ast.Node{})), nil /*moduleSpecifier*/, nil /*attributes*/)
+}
+
+// Gets whether a leading comment of node has an `@internal` tag.
+func isInternalDeclaration(node *ast.Node, sourceFile *ast.SourceFile) bool {
+	text := sourceFile.Text
+	if ast.IsParameter(node) && node.Parent != nil {
+		// Parameters are usually on the same line as the preceding token, so their comments are trailing comments.
+		parameters := node.Parent.Parameters()
+		var comments []ast.CommentRange
+		if index := slices.Index(parameters, node); index > 0 {
+			pos := scanner.SkipTriviaEx(text, parameters[index-1].End()+1, &scanner.SkipTriviaOptions{StopAtComments: true})
+			comments = slices.Collect(scanner.GetTrailingCommentRanges(nil /*f*/, text, pos))
+			comments = slices.AppendSeq(comments, scanner.GetLeadingCommentRanges(nil /*f*/, text, node.Pos()))
+		} else {
+			pos := scanner.SkipTriviaEx(text, node.Pos(), &scanner.SkipTriviaOptions{StopAtComments: true})
+			comments = slices.Collect(scanner.GetTrailingCommentRanges(nil /*f*/, text, pos))
+		}
+		return len(comments) != 0 && hasInternalAnnotation(comments[len(comments)-1], text)
+	}
+	for comment := range scanner.GetLeadingCommentRanges(nil /*f*/, text, node.Pos()) {
+		if hasInternalAnnotation(comment, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasInternalAnnotation(comment ast.CommentRange, text string) bool {
+	return strings.Contains(text[comment.Pos():comment.End()], "@internal")
+}
+
+// Gets whether a statement of a module is visible outside of it, and so is part of the declaration file.
+func isVisibleStatement(node *ast.Statement) bool {
+	switch node.Kind {
+	case ast.KindExportDeclaration, ast.KindExportAssignment, ast.KindNamespaceExportDeclaration:
+		return true
+	case ast.KindModuleDeclaration:
+		// Module augmentations and global augmentations
+		if ast.IsAmbientModule(node) {
+			return true
+		}
+	}
+	return ast.HasSyntacticModifier(node, ast.ModifierFlagsExport)
+}
+
+// Export declarations and assignments prevent the other declarations of a declaration file from being exported.
+func isScopeMarker(node *ast.Statement) bool {
+	return ast.IsExportDeclaration(node) || ast.IsExportAssignment(node)
+}
+
+// Gets the names declared by a statement, which it is referenced by.
+func getDeclaredNames(node *ast.Statement) []string {
+	switch node.Kind {
+	case ast.KindVariableStatement:
+		var names []string
+		for _, declaration := range node.AsVariableStatement().DeclarationList.AsVariableDeclarationList().Declarations.Nodes {
+			names = appendBindingNames(names, declaration.Name())
+		}
+		return names
+	case ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindTypeAliasDeclaration,
+		ast.KindEnumDeclaration, ast.KindModuleDeclaration, ast.KindImportEqualsDeclaration:
+		if name := node.Name(); name != nil && ast.IsIdentifier(name) {
+			return []string{name.Text()}
+		}
+	}
+	return nil
+}
+
+func appendBindingNames(names []string, name *ast.BindingName) []string {
+	if name == nil {
+		return names
+	}
+	if ast.IsBindingPattern(name) {
+		for _, element := range name.AsBindingPattern().Elements.Nodes {
+			names = appendBindingNames(names, element.Name())
+		}
+		return names
+	}
+	return append(names, name.Text())
+}
+
+// Collects the names of the local declarations that a declaration refers to, in type references, type queries,
+// heritage clauses, computed property names and exports.
+func collectReferences(node *ast.Node, referenced *core.Set[string]) {
+	var visit ast.Visitor
+	visit = func(node *ast.Node) bool {
+		switch node.Kind {
+		case ast.KindTypeReference:
+			referenced.Add(ast.GetFirstIdentifier(node.AsTypeReferenceNode().TypeName).Text())
+		case ast.KindTypeQuery:
+			referenced.Add(ast.GetFirstIdentifier(node.AsTypeQueryNode().ExprName).Text())
+		case ast.KindExpressionWithTypeArguments, ast.KindComputedPropertyName:
+			if expression := node.Expression(); ast.IsEntityNameExpression(expression) {
+				referenced.Add(ast.GetFirstIdentifier(expression).Text())
+			}
+		case ast.KindExportAssignment:
+			if expression := node.Expression(); ast.IsIdentifier(expression) {
+				referenced.Add(expression.Text())
+			}
+		case ast.KindImportEqualsDeclaration:
+			if moduleReference := node.AsImportEqualsDeclaration().ModuleReference; ast.IsEntityName(moduleReference) {
+				referenced.Add(ast.GetFirstIdentifier(moduleReference).Text())
+			}
+		case ast.KindExportDeclaration:
+			exportDeclaration := node.AsExportDeclaration()
+			if exportDeclaration.ModuleSpecifier == nil && exportDeclaration.ExportClause != nil && ast.IsNamedExports(exportDeclaration.ExportClause) {
+				for _, specifier := range exportDeclaration.ExportClause.AsNamedExports().Elements.Nodes {
+					referenced.Add(specifier.PropertyNameOrName().Text())
+				}
+			}
+			return false
+		}
+		node.ForEachChild(visit)
+		return false
+	}
+	visit(node)
+}
+
+// Gets whether the implementation of a function, method or constructor has overloads, which declare it instead.
+func hasOverloads(node *ast.Node, siblings []*ast.Node) bool {
+	return core.Some(siblings, func(sibling *ast.Node) bool {
+		return sibling != node && sibling.Kind == node.Kind && sibling.Body() == nil && isSameMember(sibling, node)
+	})
+}
+
+func isSameMember(a *ast.Node, b *ast.Node) bool {
+	if ast.HasSyntacticModifier(a, ast.ModifierFlagsStatic) != ast.HasSyntacticModifier(b, ast.ModifierFlagsStatic) {
+		return false
+	}
+	if a.Kind == ast.KindConstructor {
+		return true
+	}
+	aName, aOk := ast.TryGetTextOfPropertyName(a.Name())
+	bName, bOk := ast.TryGetTextOfPropertyName(b.Name())
+	return aOk && bOk && aName == bName
+}
+
+func getOtherAccessor(node *ast.Node, members []*ast.Node, kind ast.Kind) *ast.Node {
+	if node.Kind == kind {
+		return node
+	}
+	return core.Find(members, func(member *ast.Node) bool {
+		return member.Kind == kind && isSameMember(member, node)
+	})
+}
+
+func getFirstAccessor(node *ast.Node, members []*ast.Node) *ast.Node {
+	return core.Find(members, func(member *ast.Node) bool {
+		return ast.IsAccessor(member) && isSameMember(member, node)
+	})
+}
+
+// Gets the type of the property declared by an accessor from the annotations of the get and set accessors.
+func getAccessorType(node *ast.Node, members []*ast.Node) *ast.TypeNode {
+	if getAccessor := getOtherAccessor(node, members, ast.KindGetAccessor); getAccessor != nil && getAccessor.Type() != nil {
+		return getAccessor.Type()
+	}
+	if setAccessor := getOtherAccessor(node, members, ast.KindSetAccessor); setAccessor != nil {
+		if parameters := setAccessor.Parameters(); len(parameters) != 0 {
+			return parameters[0].Type()
+		}
+	}
+	return nil
+}
+
+func isOptionalParameter(node *ast.Node) bool {
+	parameter := node.AsParameterDeclaration()
+	return parameter.QuestionToken != nil || parameter.Initializer != nil || parameter.DotDotDotToken != nil
+}
+
+// Gets whether an initializer is a literal whose type a `const` variable or `readonly` property keeps.
+func isPrimitiveLiteralValue(node *ast.Expression) bool {
+	if node == nil {
+		return false
+	}
+	switch node.Kind {
+	case ast.KindNumericLiteral, ast.KindBigIntLiteral, ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral,
+		ast.KindTrueKeyword, ast.KindFalseKeyword:
+		return true
+	case ast.KindPrefixUnaryExpression:
+		prefixUnary := node.AsPrefixUnaryExpression()
+		return prefixUnary.Operator == ast.KindMinusToken && (ast.IsNumericLiteral(prefixUnary.Operand) || ast.IsBigIntLiteral(prefixUnary.Operand))
+	}
+	return false
+}
+
+func getWidenedLiteralTypeKind(node *ast.Expression) ast.Kind {
+	switch node.Kind {
+	case ast.KindNumericLiteral:
+		return ast.KindNumberKeyword
+	case ast.KindBigIntLiteral:
+		return ast.KindBigIntKeyword
+	case ast.KindTrueKeyword, ast.KindFalseKeyword:
+		return ast.KindBooleanKeyword
+	default:
+		return ast.KindStringKeyword
+	}
+}
@@ -0,0 +1,108 @@
+package transformers
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/testutil/emittestutil"
+	"github.com/microsoft/typescript-go/internal/testutil/parsetestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestDeclarationTransformer(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title   string
+		input   string
+		output  string
+		errors  []int32
+		options *core.CompilerOptions
+	}{
+		{title: "FunctionDeclaration#1", input: "export function f(a: number, b?: string): void {}", output: "export declare function f(a: number, b?: string): void;"},
+		{title: "FunctionDeclaration#2", input: "export function f(a = 1, b = \"\", ...c: number[]): void {}", output: "export declare function f(a?: number, b?: string, ...c: number[]): void;"},
+		{title: "FunctionDeclaration#3", input: "export function f(a = 1, b: number): void {}", output: "export declare function f(a: number, b: number): void;"},
+		{title: "FunctionDeclaration#4", input: "export function f(x: number): number;\nexport function f(x: string): string;\nexport function f(x: any) { return x; }", output: "export declare function f(x: number): number;\nexport declare function f(x: string): string;"},
+		{title: "FunctionDeclaration#5", input: "export async function f(): Promise<void> {}", output: "export declare function f(): Promise<void>;"},
+		{title: "FunctionDeclaration#6", input: "export default function (): void {}", output: "export default function (): void;"},
+		{title: "FunctionDeclaration#7", input: "export function f() {}", output: "export declare function f(): any;", errors: []int32{9007}},
+		{title: "FunctionDeclaration#8", input: "export function f(a, ...b): void {}", output: "export declare function f(a: any, ...b: any[]): void;", errors: []int32{9011, 9011}},
+		{title: "FunctionDeclaration#9", input: "export function f({ a = 1 }: { a?: number }): void {}", output: "export declare function f({ a }: {\n    a?: number;\n}): void;"},
+		{title: "VariableStatement#1", input: "export const a = 1, b = \"b\", c = -1, d = true, e = `e`;", output: "export declare const a = 1, b = \"b\", c = -1, d = true, e = \"e\";"},
+		{title: "VariableStatement#2", input: "export let a = 1, b = \"b\", c = -1n, d = false, e = `${a}`;", output: "export declare let a: number, b: string, c: bigint, d: boolean, e: string;"},
+		{title: "VariableStatement#3", input: "export var a: number = f();", output: "export declare var a: number;"},
+		{title: "VariableStatement#4", input: "export const a = f();", output: "export declare const a: any;", errors: []int32{9010}},
+		{title: "VariableStatement#5", input: "export const a = <T>{} as T, b = 1 as number;", output: "export declare const a: T, b: number;"},
+		{title: "VariableStatement#6", input: "export const a = { b: 1, c: \"c\", d(): void {}, get e(): number { return 1; } };", output: "export declare const a: {\n    b: number;\n    c: string;\n    d(): void;\n    readonly e: number;\n};"},
+		{title: "VariableStatement#7", input: "export const a = { b: 1, c: [1, \"c\"] } as const;", output: "export declare const a: {\n    readonly b: 1;\n    readonly c: readonly [1, \"c\"];\n};"},
+		{title: "VariableStatement#8", input: "export const a = { b, ...c, d: f(), [e()]: 1 };", output: "export declare const a: {\n    d: any;\n};", errors: []int32{9016, 9015, 9013, 9014}},
+		{title: "VariableStatement#9", input: "export const a = [1];", output: "export declare const a: any;", errors: []int32{9017}},
+		{title: "VariableStatement#10", input: "export const a = (b: number): string => \"\", c = function (): void {};", output: "export declare const a: (b: number) => string, c: () => void;"},
+		{title: "VariableStatement#11", input: "export const a = () => {};", output: "export declare const a: () => any;", errors: []int32{9007}},
+		{title: "VariableStatement#12", input: "export const { a, b } = c;", output: "export declare const a: any, b: any;", errors: []int32{9019, 9019}},
+		{title: "VariableStatement#13", input: "export const a = class {};", output: "export declare const a: any;", errors: []int32{9022}},
+		{title: "ClassDeclaration#1", input: "export class C { a: number; b = 1; readonly c = 1; static d = \"\"; e?: string; f!: string; }", output: "export declare class C {\n    a: number;\n    b: number;\n    readonly c = 1;\n    static d: string;\n    e?: string;\n    f: string;\n}"},
+		{title: "ClassDeclaration#2", input: "export class C { private a = 1; #b = 1; private c(): void {} #d() {} }", output: "export declare class C {\n    #private;\n    private a;\n    private c;\n}"},
+		{title: "ClassDeclaration#3", input: "export class C { constructor(public a: number, private b: string, readonly c = 1) {} }", output: "export declare class C {\n    a: number;\n    private b;\n    readonly c: number;\n    constructor(a: number, b: string, c?: number);\n}"},
+		{title: "ClassDeclaration#4", input: "export class C { private constructor(a: number) {} }", output: "export declare class C {\n    private constructor();\n}"},
+		{title: "ClassDeclaration#5", input: "export class C { m(a: number): void; m(a: string): void; m(a: any) {} }", output: "export declare class C {\n    m(a: number): void;\n    m(a: string): void;\n}"},
+		{title: "ClassDeclaration#6", input: "export class C { get a(): number { return 1; } set a(v) {} get b() { return 1; } }", output: "export declare class C {\n    get a(): number;\n    set a(v: number);\n    get b(): any;\n}", errors: []int32{9009}},
+		{title: "ClassDeclaration#7", input: "export class C { m() {} a = f(); }", output: "export declare class C {\n    m(): any;\n    a: any;\n}", errors: []int32{9008, 9012}},
+		{title: "ClassDeclaration#8", input: "export abstract class C<T> extends B<T> implements I { abstract m(): void; static { } [key: string]: any; }", output: "export declare abstract class C<T> extends B<T> implements I {\n    abstract m(): void;\n    [key: string]: any;\n}"},
+		{title: "ClassDeclaration#9", input: "export class C extends f() {}", output: "export declare class C {\n}", errors: []int32{9021}},
+		{title: "ClassDeclaration#10", input: "export default class { override m(): void {} }", output: "export default class {\n    m(): void;\n}"},
+		{title: "EnumDeclaration#1", input: "export enum E { A, B = 5, C, D = \"d\", F = B | 1, G = E.A + 2 }", output: "export declare enum E {\n    A = 0,\n    B = 5,\n    C = 6,\n    D = \"d\",\n    F = 5,\n    G = 2\n}"},
+		{title: "EnumDeclaration#2", input: "export const enum E { A = -1, B }", output: "export declare const enum E {\n    A = -1,\n    B = 0\n}"},
+		{title: "EnumDeclaration#3", input: "export enum E { A = X, B }", output: "export declare enum E {\n    A,\n    B\n}", errors: []int32{9020}},
+		{title: "EnumDeclaration#4", input: "export enum E { A = \"a\", B = `b${1}`, C = A + 1, D = `${E.A}d${2 * 3}`, F = `f` }", output: "export declare enum E {\n    A = \"a\",\n    B = \"b1\",\n    C = \"a1\",\n    D = \"ad6\",\n    F = \"f\"\n}"},
+		{title: "EnumDeclaration#5", input: "export enum E { A = `a${X}` }", output: "export declare enum E {\n    A\n}", errors: []int32{9020}},
+		{title: "ModuleDeclaration#1", input: "export namespace N { export const a = 1; export function f(): void {} }", output: "export declare namespace N {\n    const a = 1;\n    function f(): void;\n}"},
+		{title: "ModuleDeclaration#2", input: "export namespace N { const a = 1; export type T = typeof a; }", output: "export declare namespace N {\n    const a = 1;\n    export type T = typeof a;\n    export {};\n}"},
+		{title: "ModuleDeclaration#3", input: "export namespace A.B { export let c: number; }", output: "export declare namespace A.B {\n    let c: number;\n}"},
+		{title: "AmbientDeclaration", input: "export declare function f(): void;\ndeclare global { interface Window {} }\ndeclare module \"m\" {}", output: "export declare function f(): void;\ndeclare global {\n    interface Window {\n    }\n}\ndeclare module \"m\" { }"},
+		{title: "LocalDeclaration#1", input: "interface I { a: number }\nclass C {}\nexport const a: I = null!;", output: "interface I {\n    a: number;\n}\nexport declare const a: I;\nexport {};"},
+		{title: "LocalDeclaration#2", input: "class C {}\nexport { C };", output: "declare class C {\n}\nexport { C };"},
+		{title: "LocalDeclaration#3", input: "const a = 1;\nexport default a;", output: "declare const a = 1;\nexport default a;"},
+		{title: "LocalDeclaration#4", input: "const a = 1;\nf(a);\nimport \"m\";", output: "import \"m\";"},
+		{title: "LocalDeclaration#5", input: "const a = 1;\nexport {};", output: "export {};"},
+		{title: "Script", input: "const a = 1;\nfunction f(): void {}\nf();", output: "declare const a = 1;\ndeclare function f(): void;"},
+		{title: "ImportDeclaration", input: "import { A, B } from \"a\";\nimport C, * as D from \"c\";\nimport type { E } from \"e\";\nexport let a: A | E;", output: "import { A } from \"a\";\nimport type { E } from \"e\";\nexport declare let a: A | E;"},
+		{title: "ImportEqualsDeclaration", input: "import a = require(\"a\");\nimport b = a.b;\nexport let c: b.C;", output: "import a = require(\"a\");\nimport b = a.b;\nexport declare let c: b.C;"},
+		{title: "ExportAssignment#1", input: "export default { a: 1 };", output: "declare const _default: {\n    a: number;\n};\nexport default _default;"},
+		{title: "ExportAssignment#2", input: "export default f();", output: "declare const _default: any;\nexport default _default;", errors: []int32{9037}},
+		{title: "ExportAssignment#3", input: "const a = 1;\nexport = a;", output: "declare const a = 1;\nexport = a;"},
+		{title: "StripInternal#1", input: "/** @internal */\nexport function f(): void {}\nexport const a = 1,\n    /* @internal */ b = 2;", output: "export declare const a = 1;", options: &core.CompilerOptions{StripInternal: core.TSTrue}},
+		{title: "StripInternal#2", input: "export class C {\n    constructor(public a: number, /** @internal */ public b: number) {}\n    /** @internal */ c: number;\n    d(): void {}\n}", output: "export declare class C {\n    a: number;\n    constructor(a: number, b: number);\n    d(): void;\n}", options: &core.CompilerOptions{StripInternal: core.TSTrue}},
+		{title: "StripInternal#3", input: "export interface I {\n    a: number;\n    /** @internal */ b: number;\n}\nexport enum E {\n    A,\n    /** @internal */ B,\n    C\n}", output: "export interface I {\n    a: number;\n}\nexport declare enum E {\n    A = 0,\n    C = 2\n}", options: &core.CompilerOptions{StripInternal: core.TSTrue}},
+		{title: "StripInternal#4", input: "/** @internal */\nexport const a = 1;", output: "export declare const a = 1;"},
+		{title: "TypeDeclarations", input: "export interface I { a: number }\nexport type T = I;", output: "export interface I {\n    a: number;\n}\nexport type T = I;"},
+	}
+
+	for _, rec := range data {
+		t.Run(rec.title, func(t *testing.T) {
+			t.Parallel()
+			file := parsetestutil.ParseTypeScript(rec.input, false /*jsx*/)
+			parsetestutil.CheckDiagnostics(t, file)
+			options := rec.options
+			if options == nil {
+				options = &core.CompilerOptions{}
+			}
+			emitContext := printer.NewEmitContext()
+			tx := NewDeclarationTransformer(emitContext, options)
+			emittestutil.CheckEmit(t, emitContext, tx.TransformSourceFile(file), rec.output)
+			assert.DeepEqual(t, rec.errors, core.Map(tx.Diagnostics(), (*ast.Diagnostic).Code))
+		})
+	}
+}
+
+func TestReportInferenceFallbackForOtherDeclarations(t *testing.T) {
+	t.Parallel()
+	file := parsetestutil.ParseTypeScript("export interface I { a; }", false /*jsx*/)
+	parsetestutil.CheckDiagnostics(t, file)
+	tx := NewDeclarationTransformer(printer.NewEmitContext(), &core.CompilerOptions{})
+	tx.sourceFile = file
+	property := file.Statements.Nodes[0].Members()[0]
+	tx.reportInferenceFallback(property)
+	assert.DeepEqual(t, []int32{9013}, core.Map(tx.Diagnostics(), (*ast.Diagnostic).Code))
+}
@@ -279,6 +279,11 @@ func (tx *RuntimeSyntaxTransformer) addVarForDeclaration(statements []*ast.State
 }
 
 func (tx *RuntimeSyntaxTransformer) visitEnumDeclaration(node *ast.EnumDeclaration) *ast.Node {
+	if !tx.shouldEmitEnumDeclaration(node) {
+		// !!! Use NotEmittedStatement to preserve comments
+		return nil
+	}
+
 	statements := []*ast.Statement{}
 
 	// If needed, we should emit a variable declaration for the enum:
@@ -331,6 +336,13 @@ func (tx *RuntimeSyntaxTransformer) visitEnumDeclaration(node *ast.EnumDeclarati
 	return tx.factory.NewSyntaxList(append(statements, enumStatement))
 }
 
+// Determines whether to emit an enum declaration. References to the members of a const enum are inlined, so the
+// declaration itself is only emitted when `preserveConstEnums` or `isolatedModules` is set.
+func (tx *RuntimeSyntaxTransformer) shouldEmitEnumDeclaration(node *ast.EnumDeclaration) bool {
+	// The type eraser removes the `const` modifier, so check the original declaration.
+	return !ast.IsEnumConst(tx.emitContext.MostOriginal(node.AsNode())) || tx.compilerOptions.ShouldPreserveConstEnums()
+}
+
 // Transforms the body of an enum declaration.
 func (tx *RuntimeSyntaxTransformer) transformEnumBody(node *ast.EnumDeclaration) *ast.BlockNode {
 	savedCurrentEnum := tx.currentEnum
@@ -13,9 +13,10 @@ import (
 func TestEnumTransformer(t *testing.T) {
 	t.Parallel()
 	data := []struct {
-		title  string
-		input  string
-		output string
+		title   string
+		input   string
+		output  string
+		options *core.CompilerOptions
 	}{
 		{title: "empty enum", input: "enum E {}", output: `var E;
 (function (E) {
@@ -187,11 +188,19 @@ var E;
     E[E["B"] = 1] = "B";
 })(E || (E = {}));`},
 
-		{title: "const enum", input: "const enum E {A, B}", output: `var E;
+		{title: "const enum", input: "const enum E {A, B}", output: ``},
+
+		{title: "const enum with preserveConstEnums", input: "const enum E {A, B}", output: `var E;
 (function (E) {
     E[E["A"] = 0] = "A";
     E[E["B"] = 1] = "B";
-})(E || (E = {}));`},
+})(E || (E = {}));`, options: &core.CompilerOptions{PreserveConstEnums: core.TSTrue}},
+
+		{title: "const enum with isolatedModules", input: "export const enum E {A}", output: `export { E };
+var E;
+(function (E) {
+    E[E["A"] = 0] = "A";
+})(E || (E = {}));`, options: &core.CompilerOptions{IsolatedModules: core.TSTrue}},
 
 		{title: "merged enum", input: "enum E {A} enum E {B=A}", output: `var E;
 (function (E) {
@@ -219,7 +228,10 @@ var E;
 	for _, rec := range data {
 		t.Run(rec.title, func(t *testing.T) {
 			t.Parallel()
-			options := &core.CompilerOptions{}
+			options := rec.options
+			if options == nil {
+				options = &core.CompilerOptions{}
+			}
 			file := parsetestutil.ParseTypeScript(rec.input, false /*jsx*/)
 			parsetestutil.CheckDiagnostics(t, file)
 			binder.BindSourceFile(file, options)
@@ -2,6 +2,7 @@ package transformers
 
 import (
 	"slices"
+	"strings"
 
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/core"
@@ -206,8 +207,17 @@ func isIdentifierReference(name *ast.IdentifierNode, parent *ast.Node) bool {
 }
 
 func constantValue(node *ast.Expression) any {
+	return evaluateConstantValue(node, nil)
+}
+
+// evaluateConstantValue is like constantValue, but also evaluates references (identifiers, property accesses and
+// element accesses) with evaluateEntity, when it is not nil.
+func evaluateConstantValue(node *ast.Expression, evaluateEntity func(node *ast.Expression) any) any {
 	node = ast.SkipOuterExpressions(node, ast.OEKAll)
 	switch {
+	case evaluateEntity != nil && (ast.IsIdentifier(node) || ast.IsPropertyAccessExpression(node) || ast.IsElementAccessExpression(node)):
+		return evaluateEntity(node)
+
 	case ast.IsStringLiteralLike(node):
 		return node.Text()
 
@@ -216,7 +226,7 @@ func constantValue(node *ast.Expression) any {
 
 	case ast.IsPrefixUnaryExpression(node):
 		prefixUnary := node.AsPrefixUnaryExpression()
-		if value, ok := constantValue(prefixUnary.Operand).(jsnum.Number); ok {
+		if value, ok := evaluateConstantValue(prefixUnary.Operand, evaluateEntity).(jsnum.Number); ok {
 			switch prefixUnary.Operator {
 			case ast.KindPlusToken:
 				return value
@@ -227,10 +237,26 @@ func constantValue(node *ast.Expression) any {
 			}
 		}
 
+	case ast.IsTemplateExpression(node):
+		template := node.AsTemplateExpression()
+		var sb strings.Builder
+		sb.WriteString(template.Head.Text())
+		for _, span := range template.TemplateSpans.Nodes {
+			text, ok := constantValueToString(evaluateConstantValue(span.Expression(), evaluateEntity))
+			if !ok {
+				return nil
+			}
+			sb.WriteString(text)
+			sb.WriteString(span.AsTemplateSpan().Literal.Text())
+		}
+		return sb.String()
+
 	case ast.IsBinaryExpression(node):
 		binary := node.AsBinaryExpression()
-		leftNum, leftIsNum := constantValue(binary.Left).(jsnum.Number)
-		rightNum, rightIsNum := constantValue(binary.Right).(jsnum.Number)
+		left := evaluateConstantValue(binary.Left, evaluateEntity)
+		right := evaluateConstantValue(binary.Right, evaluateEntity)
+		leftNum, leftIsNum := left.(jsnum.Number)
+		rightNum, rightIsNum := right.(jsnum.Number)
 		if leftIsNum && rightIsNum {
 			switch binary.OperatorToken.Kind {
 			case ast.KindBarToken:
@@ -258,11 +284,29 @@ func constantValue(node *ast.Expression) any {
 			case ast.KindAsteriskAsteriskToken:
 				return leftNum.Exponentiate(rightNum)
 			}
+		} else if binary.OperatorToken.Kind == ast.KindPlusToken {
+			// string concatenation, as in `"a" + 1`
+			leftText, leftOk := constantValueToString(left)
+			rightText, rightOk := constantValueToString(right)
+			if leftOk && rightOk {
+				return leftText + rightText
+			}
 		}
 	}
 	return nil
 }
 
+// constantValueToString converts a constant value to a string, as JavaScript does when it is concatenated to a string.
+func constantValueToString(value any) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case jsnum.Number:
+		return value.String(), true
+	}
+	return "", false
+}
+
 func constantExpression(value any, factory *ast.NodeFactory) *ast.Expression {
 	switch value := value.(type) {
 	case string:
@@ -65,6 +65,15 @@ type CommandLineOption struct {
 	ElementOptions map[string]*CommandLineOption
 }
 
+func (o *CommandLineOption) ShortName() string {
+	return o.shortName
+}
+
+// ShowInSimplifiedHelpView reports whether the option is listed by `tsc --help` without `--all`.
+func (o *CommandLineOption) ShowInSimplifiedHelpView() bool {
+	return o.showInSimplifiedHelpView
+}
+
 func (o *CommandLineOption) DeprecatedKeys() *core.Set[string] {
 	if o.Kind != CommandLineOptionTypeEnum {
 		return nil
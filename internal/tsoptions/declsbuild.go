@@ -6,9 +6,9 @@ import (
 	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
 )
 
-var BuildOpts = slices.Concat(commonOptionsWithBuild, optionsForBuild)
+var BuildOpts = slices.Concat(commonOptionsWithBuild, OptionsForBuild)
 
-var tscBuildOption = CommandLineOption{
+var TscBuildOption = CommandLineOption{
 	Name:                     "build",
 	Kind:                     "boolean",
 	shortName:                "b",
@@ -18,8 +18,8 @@ var tscBuildOption = CommandLineOption{
 	DefaultValueDescription:  false,
 }
 
-var optionsForBuild = []*CommandLineOption{
-	&tscBuildOption,
+var OptionsForBuild = []*CommandLineOption{
+	&TscBuildOption,
 	{
 		Name:                    "verbose",
 		shortName:               "v",
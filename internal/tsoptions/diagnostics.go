@@ -46,7 +46,7 @@ func getParseCommandLineWorkerDiagnostics(decls []*CommandLineOption) *ParseComm
 var watchOptionsDidYouMeanDiagnostics = &ParseCommandLineWorkerDiagnostics{
 	didYouMean: DidYouMeanOptionsDiagnostics{
 		// no alternateMode
-		OptionDeclarations:          optionsForWatch,
+		OptionDeclarations:          OptionsForWatch,
 		UnknownOptionDiagnostic:     diagnostics.Unknown_watch_option_0,
 		UnknownDidYouMeanDiagnostic: diagnostics.Unknown_watch_option_0_Did_you_mean_1,
 	},
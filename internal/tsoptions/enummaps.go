@@ -202,6 +202,17 @@ func GetDefaultLibFileName(options *core.CompilerOptions) string {
 	return name
 }
 
+// GetNameOfScriptTarget returns the name of target as it is written in the target option, such as "es6" for
+// ScriptTargetES2015.
+func GetNameOfScriptTarget(target core.ScriptTarget) string {
+	for name, value := range targetOptionMap.Entries() {
+		if value == target {
+			return name
+		}
+	}
+	return ""
+}
+
 var watchFileEnumMap = collections.NewOrderedMapFromList([]collections.MapEntry[string, any]{
 	{Key: "fixedpollinginterval", Value: core.WatchFileKindFixedPollingInterval},
 	{Key: "prioritypollinginterval", Value: core.WatchFileKindPriorityPollingInterval},
@@ -0,0 +1,227 @@
+package tsoptions
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// defaultInitCompilerOptions are the options enabled in the tsconfig.json written by `tsc --init`, unless
+// they are given on the command line.
+var defaultInitCompilerOptions = collections.NewOrderedMapFromList([]collections.MapEntry[string, any]{
+	{Key: "module", Value: core.ModuleKindCommonJS},
+	{Key: "target", Value: core.ScriptTargetES2016},
+	{Key: "strict", Value: true},
+	{Key: "esModuleInterop", Value: true},
+	{Key: "forceConsistentCasingInFileNames", Value: true},
+	{Key: "skipLibCheck", Value: true},
+})
+
+// initCategories are the categories of options written by `tsc --init`, in order. Options of other
+// categories are only written when they are given on the command line.
+var initCategories = []*diagnostics.Message{
+	diagnostics.Projects,
+	diagnostics.Language_and_Environment,
+	diagnostics.Modules,
+	diagnostics.JavaScript_Support,
+	diagnostics.Emit,
+	diagnostics.Interop_Constraints,
+	diagnostics.Type_Checking,
+	diagnostics.Completeness,
+}
+
+// GenerateTSConfig returns the text of the tsconfig.json written by `tsc --init`. Every compiler option
+// is listed with its description, grouped by category; the options of the command line and the
+// recommended defaults are enabled, and the others are commented out. Paths are written relative to
+// the current directory of comparePathsOptions.
+func GenerateTSConfig(commandLine *ParsedCommandLine, comparePathsOptions tspath.ComparePathsOptions, newLine string) string {
+	compilerOptionsMap := serializeInitCompilerOptions(commandLine, comparePathsOptions)
+
+	type entry struct {
+		value       string
+		description string
+	}
+
+	categorizedOptions := collections.NewOrderedMapWithSizeHint[*diagnostics.Message, []*CommandLineOption](len(initCategories))
+	for _, category := range initCategories {
+		categorizedOptions.Set(category, nil)
+	}
+	for _, option := range OptionsDeclarations {
+		if option.IsCommandLineOnly || option.Category == nil {
+			continue
+		}
+		if !slices.Contains(initCategories, option.Category) && !compilerOptionsMap.Has(option.Name) {
+			continue
+		}
+		categorizedOptions.Set(option.Category, append(categorizedOptions.GetOrZero(option.Category), option))
+	}
+
+	// Serialize the options and their descriptions, so that the descriptions can be aligned.
+	marginLength := 0
+	seenKnownKeys := 0
+	var entries []entry
+	for category, options := range categorizedOptions.Entries() {
+		if len(entries) != 0 {
+			entries = append(entries, entry{})
+		}
+		entries = append(entries, entry{value: "/* " + category.Message() + " */"})
+		for _, option := range options {
+			var optionName string
+			if value, ok := compilerOptionsMap.Get(option.Name); ok {
+				seenKnownKeys++
+				optionName = fmt.Sprintf("%q: %s", option.Name, core.Must(core.StringifyJson(value, "", "")))
+				if seenKnownKeys != compilerOptionsMap.Size() {
+					optionName += ","
+				}
+			} else {
+				optionName = fmt.Sprintf("// %q: %s,", option.Name, core.Must(core.StringifyJson(getDefaultValueForOption(option), "", "")))
+			}
+			description := option.Name
+			if option.Description != nil {
+				description = option.Description.Message()
+			}
+			entries = append(entries, entry{value: optionName, description: "/* " + description + " */"})
+			marginLength = max(marginLength, len(optionName))
+		}
+	}
+
+	tab := "  "
+	var result []string
+	result = append(result, "{")
+	result = append(result, tab+`"compilerOptions": {`)
+	result = append(result, tab+tab+"/* "+diagnostics.Visit_https_Colon_Slash_Slashaka_ms_Slashtsconfig_to_read_more_about_this_file.Message()+" */")
+	result = append(result, "")
+	for _, entry := range entries {
+		if entry.value == "" {
+			result = append(result, "")
+			continue
+		}
+		line := tab + tab + entry.value
+		if entry.description != "" {
+			line += strings.Repeat(" ", marginLength-len(entry.value)+2) + entry.description
+		}
+		result = append(result, line)
+	}
+	if fileNames := commandLine.FileNames(); len(fileNames) != 0 {
+		result = append(result, tab+"},")
+		result = append(result, tab+`"files": [`)
+		for i, fileName := range fileNames {
+			line := tab + tab + core.Must(core.StringifyJson(fileName, "", ""))
+			if i != len(fileNames)-1 {
+				line += ","
+			}
+			result = append(result, line)
+		}
+		result = append(result, tab+"]")
+	} else {
+		result = append(result, tab+"}")
+	}
+	result = append(result, "}")
+	return strings.Join(result, newLine) + newLine
+}
+
+// GetCompilerOptionsDiffValue lists the options of the tsconfig.json written by `tsc --init` that differ
+// from the defaults of the compiler, or that are enabled by `tsc --init` itself.
+func GetCompilerOptionsDiffValue(commandLine *ParsedCommandLine, comparePathsOptions tspath.ComparePathsOptions, newLine string) string {
+	compilerOptionsMap := serializeInitCompilerOptions(commandLine, comparePathsOptions)
+	var result []string
+	for _, option := range OptionsDeclarations {
+		value, ok := compilerOptionsMap.Get(option.Name)
+		if !ok {
+			continue
+		}
+		if core.Must(core.StringifyJson(value, "", "")) != core.Must(core.StringifyJson(getDefaultValueForOption(option), "", "")) || defaultInitCompilerOptions.Has(option.Name) {
+			if values, ok := value.([]string); ok {
+				value = strings.Join(values, ",")
+			}
+			result = append(result, fmt.Sprintf("  %s: %v", option.Name, value))
+		}
+	}
+	return strings.Join(result, newLine) + newLine
+}
+
+// serializeInitCompilerOptions returns the values of the compiler options given on the command line, as
+// they are written in tsconfig.json, merged with the defaults of `tsc --init`.
+func serializeInitCompilerOptions(commandLine *ParsedCommandLine, comparePathsOptions tspath.ComparePathsOptions) *collections.OrderedMap[string, any] {
+	result := &collections.OrderedMap[string, any]{}
+	add := func(name string, value any) {
+		option := commandLineCompilerOptionsMap[name]
+		if option == nil || value == nil || result.Has(name) {
+			return
+		}
+		if option.Category == diagnostics.Command_line_Options || option.Category == diagnostics.Output_Formatting {
+			return
+		}
+		// The command line parser made paths absolute, so they are made relative again.
+		if option.isFilePath {
+			switch v := value.(type) {
+			case string:
+				value = tspath.ConvertToRelativePath(v, comparePathsOptions)
+			case []string:
+				value = core.Map(v, func(path string) string { return tspath.ConvertToRelativePath(path, comparePathsOptions) })
+			}
+		}
+		result.Set(name, getNameOfCompilerOptionValue(option, value))
+	}
+	if options, ok := commandLine.Raw.(*collections.OrderedMap[string, any]); ok {
+		for name, value := range options.Entries() {
+			add(name, value)
+		}
+	}
+	for name, value := range defaultInitCompilerOptions.Entries() {
+		add(name, value)
+	}
+	return result
+}
+
+// getNameOfCompilerOptionValue maps the parsed values of enum options back to their names in tsconfig.json.
+func getNameOfCompilerOptionValue(option *CommandLineOption, value any) any {
+	switch option.Kind {
+	case CommandLineOptionTypeEnum:
+		for key, enumValue := range option.EnumMap().Entries() {
+			if enumValue == value {
+				return key
+			}
+		}
+	case CommandLineOptionTypeList, CommandLineOptionTypeListOrElement:
+		if values, ok := value.([]string); ok && option.Elements() != nil && option.Elements().Kind == CommandLineOptionTypeEnum {
+			return core.Map(values, func(value string) string {
+				return getNameOfCompilerOptionValue(option.Elements(), value).(string)
+			})
+		}
+	}
+	return value
+}
+
+func getDefaultValueForOption(option *CommandLineOption) any {
+	switch option.Kind {
+	case CommandLineOptionTypeNumber:
+		return 1
+	case CommandLineOptionTypeBoolean:
+		return true
+	case CommandLineOptionTypeString:
+		if !option.isFilePath {
+			return ""
+		}
+		if defaultValue, ok := option.DefaultValueDescription.(string); ok {
+			return "./" + defaultValue
+		}
+		return "./"
+	case CommandLineOptionTypeList:
+		return []string{}
+	case CommandLineOptionTypeListOrElement:
+		return getDefaultValueForOption(option.Elements())
+	case CommandLineOptionTypeObject:
+		return map[string]any{}
+	default:
+		for key := range option.EnumMap().Keys() {
+			return key
+		}
+		panic("Expected the enum map of option " + option.Name + " to have entries.")
+	}
+}
@@ -0,0 +1,55 @@
+package tsoptions
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs"
+)
+
+var localeRegexp = regexp.MustCompile(`^([a-z]+)(?:[_-]([a-z]+))?$`)
+
+// LoadLocale loads the translations of diagnostic messages for locale, which has the form <language> or
+// <language>-<territory>. The translations are read from the diagnosticMessages.generated.json file of the
+// subdirectory of dir named after the locale, or else after its language. It returns a nil locale, so that
+// messages are written in English, when neither file can be used.
+func LoadLocale(locale string, fs vfs.FS, dir string) (*diagnostics.Locale, []*ast.Diagnostic) {
+	// Roughly corresponds to 'validateLocaleAndSetLanguage' from utilities.ts
+	matches := localeRegexp.FindStringSubmatch(strings.ToLower(locale))
+	if matches == nil {
+		return nil, []*ast.Diagnostic{ast.NewCompilerDiagnostic(diagnostics.Locale_must_be_of_the_form_language_or_language_territory_For_example_0_or_1, "en", "ja-jp")}
+	}
+	language, territory := matches[1], matches[2]
+
+	var errors []*ast.Diagnostic
+	tryLoad := func(name string) *diagnostics.Locale {
+		fileName := tspath.CombinePaths(dir, name, "diagnosticMessages.generated.json")
+		if !fs.FileExists(fileName) {
+			return nil
+		}
+		text, ok := fs.ReadFile(fileName)
+		if !ok {
+			errors = append(errors, ast.NewCompilerDiagnostic(diagnostics.Unable_to_open_file_0, fileName))
+			return nil
+		}
+		result, err := diagnostics.ParseLocale(text)
+		if err != nil {
+			errors = append(errors, ast.NewCompilerDiagnostic(diagnostics.Corrupted_locale_file_0, fileName))
+			return nil
+		}
+		return result
+	}
+
+	// First try the entire locale, then fall back to just the language.
+	var result *diagnostics.Locale
+	if territory != "" {
+		result = tryLoad(language + "-" + territory)
+	}
+	if result == nil {
+		result = tryLoad(language)
+	}
+	return result, errors
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
 )
 
 type ParsedCommandLine struct {
@@ -13,8 +14,10 @@ type ParsedCommandLine struct {
 	ConfigFile *TsConfigSourceFile `json:"configFile"` // TsConfigSourceFile, used in Program and ExecuteCommandLine
 	Errors     []*ast.Diagnostic   `json:"errors"`
 	Raw        any                 `json:"raw"`
-	// WildcardDirectories map[string]watchDirectoryFlags
-	CompileOnSave *bool `json:"compileOnSave"`
+	// WildcardDirectories maps the directories to watch for files added to or removed from the project
+	// to whether they must be watched recursively.
+	WildcardDirectories map[string]bool `json:"wildcardDirectories,omitempty"`
+	CompileOnSave       *bool           `json:"compileOnSave"`
 	// TypeAquisition *core.TypeAcquisition
 }
 
@@ -45,3 +48,42 @@ func (p *ParsedCommandLine) GetConfigFileParsingDiagnostics() []*ast.Diagnostic
 	}
 	return p.Errors
 }
+
+// GetMatchedFileSpec returns the entry of the "files" list of the config file that names fileName, as it is
+// written in the config file, or "" if there is none.
+func (p *ParsedCommandLine) GetMatchedFileSpec(fileName string, comparePathsOptions tspath.ComparePathsOptions) string {
+	if p.ConfigFile == nil || p.ConfigFile.configFileSpecs == nil {
+		return ""
+	}
+	basePath := tspath.GetDirectoryPath(tspath.GetNormalizedAbsolutePath(p.ConfigFile.SourceFile.FileName(), comparePathsOptions.CurrentDirectory))
+	for i, fileSpec := range p.ConfigFile.configFileSpecs.validatedFilesSpec {
+		if tspath.ComparePaths(tspath.GetNormalizedAbsolutePath(fileSpec, basePath), fileName, comparePathsOptions) == 0 {
+			return p.ConfigFile.configFileSpecs.validatedFilesSpecBeforeSubstitution[i]
+		}
+	}
+	return ""
+}
+
+// GetMatchedIncludeSpec returns the "include" spec of the config file that matches fileName, as it is written
+// in the config file, or "" if there is none. isDefault reports that the config file has no "include" list, so that the default spec "**/*"
+// matches every file.
+func (p *ParsedCommandLine) GetMatchedIncludeSpec(fileName string, comparePathsOptions tspath.ComparePathsOptions) (spec string, isDefault bool) {
+	if p.ConfigFile == nil || p.ConfigFile.configFileSpecs == nil || len(p.ConfigFile.configFileSpecs.validatedIncludeSpecs) == 0 {
+		return "", false
+	}
+	if p.ConfigFile.configFileSpecs.isDefaultIncludeSpec {
+		return "", true
+	}
+	isJsonFile := tspath.FileExtensionIs(fileName, tspath.ExtensionJson)
+	basePath := tspath.GetDirectoryPath(tspath.GetNormalizedAbsolutePath(p.ConfigFile.SourceFile.FileName(), comparePathsOptions.CurrentDirectory))
+	for i, includeSpec := range p.ConfigFile.configFileSpecs.validatedIncludeSpecs {
+		if isJsonFile && !tspath.FileExtensionIs(includeSpec, tspath.ExtensionJson) {
+			continue
+		}
+		pattern := getSubPatternFromSpec(includeSpec, basePath, usageFiles, wildcardMatchers[usageFiles])
+		if pattern != "" && core.Must(getRegexFromPattern("("+pattern+")$", comparePathsOptions.UseCaseSensitiveFileNames).MatchString(fileName)) {
+			return p.ConfigFile.configFileSpecs.validatedIncludeSpecsBeforeSubstitution[i], false
+		}
+	}
+	return "", false
+}
@@ -379,6 +379,10 @@ func ParseCompilerOptions(key string, value any, allOptions *core.CompilerOption
 		allOptions.NoEmit = parseTristate(value)
 	case "showConfig":
 		allOptions.ShowConfig = parseTristate(value)
+	case "help":
+		allOptions.Help = parseTristate(value)
+	case "all":
+		allOptions.All = parseTristate(value)
 	case "configFilePath":
 		allOptions.ConfigFilePath = parseString(value)
 	case "noDtsResolution":
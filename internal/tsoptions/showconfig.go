@@ -0,0 +1,150 @@
+package tsoptions
+
+import (
+	"reflect"
+
+	"github.com/dlclark/regexp2"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/compiler/diagnostics"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// optionsHiddenFromShowConfig are the options that describe the invocation of tsc rather than the project,
+// so `--showConfig` leaves them out.
+var optionsHiddenFromShowConfig = []string{"showConfig", "configFile", "configFilePath", "help", "init", "listFiles", "listEmittedFiles", "project", "build", "version"}
+
+// ConvertToTSConfig returns the config that `tsc --showConfig` prints for a parsed command line: the
+// compiler options that are set, as they are written in tsconfig.json with paths relative to
+// configFileName, along with the references, files and include/exclude specs of the project.
+func ConvertToTSConfig(configParseResult *ParsedCommandLine, configFileName string, comparePathsOptions tspath.ComparePathsOptions) *collections.OrderedMap[string, any] {
+	configFilePath := tspath.GetNormalizedAbsolutePath(configFileName, comparePathsOptions.CurrentDirectory)
+	var specs *configFileSpecs
+	if configParseResult.ConfigFile != nil {
+		specs = configParseResult.ConfigFile.configFileSpecs
+	}
+
+	// Files matched by the include specs are covered by them, so only the others are listed.
+	matchesIncludeSpecs := func(fileName string) bool { return false }
+	if specs != nil && len(specs.validatedIncludeSpecs) != 0 {
+		patterns := getFileMatcherPatterns(tspath.GetDirectoryPath(configFilePath), specs.validatedExcludeSpecs, specs.validatedIncludeSpecs, comparePathsOptions.UseCaseSensitiveFileNames, comparePathsOptions.CurrentDirectory)
+		if patterns.includeFilePattern != "" {
+			includeRegex := getRegexFromPattern(patterns.includeFilePattern, comparePathsOptions.UseCaseSensitiveFileNames)
+			var excludeRegex *regexp2.Regexp
+			if patterns.excludePattern != "" {
+				excludeRegex = getRegexFromPattern(patterns.excludePattern, comparePathsOptions.UseCaseSensitiveFileNames)
+			}
+			matchesIncludeSpecs = func(fileName string) bool {
+				return core.Must(includeRegex.MatchString(fileName)) && (excludeRegex == nil || !core.Must(excludeRegex.MatchString(fileName)))
+			}
+		}
+	}
+	var files []string
+	for _, fileName := range configParseResult.FileNames() {
+		fileName = tspath.GetNormalizedAbsolutePath(fileName, comparePathsOptions.CurrentDirectory)
+		if !matchesIncludeSpecs(fileName) {
+			files = append(files, getRelativePathFromFile(configFilePath, fileName, comparePathsOptions))
+		}
+	}
+
+	compilerOptions := serializeOptions(configParseResult.CompilerOptions(), commandLineCompilerOptionsMap, configFilePath, comparePathsOptions)
+	for _, name := range optionsHiddenFromShowConfig {
+		compilerOptions.Delete(name)
+	}
+	// !!! implied options of computed options, such as `useDefineForClassFields` for `target`
+
+	config := &collections.OrderedMap[string, any]{}
+	config.Set("compilerOptions", compilerOptions)
+	if watchOptions := configParseResult.ParsedConfig.WatchOptions; watchOptions != nil {
+		watchOptionsMap := make(map[string]*CommandLineOption, len(OptionsForWatch))
+		for _, option := range OptionsForWatch {
+			watchOptionsMap[option.Name] = option
+		}
+		if serialized := serializeOptions(watchOptions, watchOptionsMap, configFilePath, comparePathsOptions); serialized.Size() != 0 {
+			config.Set("watchOptions", serialized)
+		}
+	}
+	if references := configParseResult.ProjectReferences(); len(references) != 0 {
+		config.Set("references", core.Map(references, func(reference core.ProjectReference) *collections.OrderedMap[string, any] {
+			result := &collections.OrderedMap[string, any]{}
+			result.Set("path", reference.OriginalPath)
+			if reference.Circular {
+				result.Set("circular", true)
+			}
+			return result
+		}))
+	}
+	if len(files) != 0 {
+		config.Set("files", files)
+	}
+	if specs != nil {
+		if include := specs.validatedIncludeSpecs; len(include) != 0 && !(len(include) == 1 && include[0] == defaultIncludeSpec) {
+			config.Set("include", include)
+		}
+		if len(specs.validatedExcludeSpecs) != 0 {
+			config.Set("exclude", specs.validatedExcludeSpecs)
+		}
+	}
+	if configParseResult.CompileOnSave != nil && *configParseResult.CompileOnSave {
+		config.Set("compileOnSave", true)
+	}
+	return config
+}
+
+// serializeOptions returns the options of a core.CompilerOptions or core.WatchOptions that are set, keyed
+// by the names of their declarations in optionsMap, as they are written in tsconfig.json.
+func serializeOptions(options any, optionsMap map[string]*CommandLineOption, configFilePath string, comparePathsOptions tspath.ComparePathsOptions) *collections.OrderedMap[string, any] {
+	result := &collections.OrderedMap[string, any]{}
+	optionsValue := reflect.ValueOf(options).Elem()
+	optionsType := optionsValue.Type()
+	for i := range optionsType.NumField() {
+		fieldValue := optionsValue.Field(i)
+		if fieldValue.IsZero() {
+			continue
+		}
+		name := optionsType.Field(i).Tag.Get("json")
+		if name == "moduleDetectionKind" {
+			name = "moduleDetection"
+		}
+		option := optionsMap[name]
+		if option == nil || option.Category == diagnostics.Command_line_Options || option.Category == diagnostics.Output_Formatting {
+			continue
+		}
+
+		var value any
+		switch v := fieldValue.Interface().(type) {
+		case core.Tristate:
+			value = v.IsTrue()
+		case *int:
+			value = *v
+		case string:
+			if option.isFilePath {
+				value = getRelativePathFromFile(configFilePath, tspath.GetNormalizedAbsolutePath(v, tspath.GetDirectoryPath(configFilePath)), comparePathsOptions)
+			} else {
+				value = v
+			}
+		case []string:
+			if option.Elements() != nil && option.Elements().isFilePath {
+				value = core.Map(v, func(path string) string {
+					return getRelativePathFromFile(configFilePath, tspath.GetNormalizedAbsolutePath(path, tspath.GetDirectoryPath(configFilePath)), comparePathsOptions)
+				})
+			} else {
+				value = getNameOfCompilerOptionValue(option, v)
+			}
+		default:
+			value = getNameOfCompilerOptionValue(option, v)
+		}
+		result.Set(name, value)
+	}
+	return result
+}
+
+// getRelativePathFromFile returns the path of to relative to the directory of from, as it is written in
+// a config file.
+func getRelativePathFromFile(from string, to string, comparePathsOptions tspath.ComparePathsOptions) string {
+	relativePath := tspath.GetRelativePathFromDirectory(tspath.GetDirectoryPath(from), to, comparePathsOptions)
+	if tspath.PathIsAbsolute(relativePath) || tspath.PathIsRelative(relativePath) {
+		return relativePath
+	}
+	return "./" + relativePath
+}
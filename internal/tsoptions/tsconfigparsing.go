@@ -94,6 +94,9 @@ type configFileSpecs struct {
 	validatedIncludeSpecs []string
 	validatedExcludeSpecs []string
 	isDefaultIncludeSpec  bool
+	// The validated "files" and "include" specs as written, before "${configDir}" is substituted
+	validatedFilesSpecBeforeSubstitution    []string
+	validatedIncludeSpecsBeforeSubstitution []string
 }
 type fileExtensionInfo struct {
 	extension      string
@@ -198,6 +201,11 @@ type TsConfigSourceFile struct {
 	SourceFile          *ast.SourceFile
 }
 
+// ExtendedSourceFiles returns the file names of the configs extended by the config, directly or indirectly.
+func (t *TsConfigSourceFile) ExtendedSourceFiles() []string {
+	return t.extendedSourceFiles
+}
+
 func tsconfigToSourceFile(tsconfigSourceFile *TsConfigSourceFile) *ast.SourceFile {
 	if tsconfigSourceFile == nil {
 		return nil
@@ -1096,6 +1104,8 @@ func parseJsonConfigFileContentWorker(
 	var validatedIncludeSpecs []string
 	var validatedExcludeSpecs []string
 	var validatedFilesSpec []string
+	var validatedIncludeSpecsBeforeSubstitution []string
+	var validatedFilesSpecBeforeSubstitution []string
 	// The exclude spec list is converted into a regular expression, which allows us to quickly
 	// test whether a file or directory should be excluded before recursively traversing the
 	// file system.
@@ -1103,6 +1113,7 @@ func parseJsonConfigFileContentWorker(
 		var err []*ast.Diagnostic
 		validatedIncludeSpecs, err = validateSpecs(includeSpecs.sliceValue, true /*disallowTrailingRecursion*/, tsconfigToSourceFile(sourceFile), "include")
 		errors = append(errors, err...)
+		validatedIncludeSpecsBeforeSubstitution = slices.Clone(validatedIncludeSpecs)
 		substituteStringArrayWithConfigDirTemplate(validatedIncludeSpecs, basePathForFileNames)
 	}
 	if excludeSpecs.sliceValue != nil {
@@ -1118,6 +1129,7 @@ func parseJsonConfigFileContentWorker(
 				validatedFilesSpec = append(validatedFilesSpec, spec)
 			}
 		}
+		validatedFilesSpecBeforeSubstitution = slices.Clone(validatedFilesSpec)
 		substituteStringArrayWithConfigDirTemplate(validatedFilesSpec, basePathForFileNames)
 	}
 	configFileSpecs := configFileSpecs{
@@ -1128,6 +1140,8 @@ func parseJsonConfigFileContentWorker(
 		validatedIncludeSpecs,
 		validatedExcludeSpecs,
 		isDefaultIncludeSpec,
+		validatedFilesSpecBeforeSubstitution,
+		validatedIncludeSpecsBeforeSubstitution,
 	}
 
 	if sourceFile != nil {
@@ -1180,9 +1194,10 @@ func parseJsonConfigFileContentWorker(
 			FileNames:         getFileNames(basePathForFileNames),
 			ProjectReferences: getProjectReferences(basePathForFileNames),
 		},
-		ConfigFile: sourceFile,
-		Raw:        parsedConfig.raw,
-		Errors:     errors,
+		ConfigFile:          sourceFile,
+		Raw:                 parsedConfig.raw,
+		Errors:              errors,
+		WildcardDirectories: getWildcardDirectories(configFileSpecs.validatedIncludeSpecs, configFileSpecs.validatedExcludeSpecs, basePathForFileNames, host.FS().UseCaseSensitiveFileNames()),
 	}
 }
 
@@ -1457,7 +1472,7 @@ func getFileNamesFromConfigSpecs(
 	// remove a literal file.
 	for _, fileName := range validatedFilesSpec {
 		file := tspath.GetNormalizedAbsolutePath(fileName, basePath)
-		literalFileMap.Set(keyMappper(fileName), file)
+		literalFileMap.Set(keyMappper(file), file)
 	}
 
 	var jsonOnlyIncludeRegexes []*regexp2.Regexp
@@ -455,3 +455,106 @@ func matchFiles(path string, extensions []string, excludes []string, includes []
 func readDirectory(host vfs.FS, currentDir string, path string, extensions []string, excludes []string, includes []string, depth *int) []string {
 	return matchFiles(path, extensions, excludes, includes, host.UseCaseSensitiveFileNames(), currentDir, depth, host)
 }
+
+// MatchesExclude reports whether pathToCheck is matched by one of the exclude specs, such as the
+// excludeDirectories and excludeFiles watch options.
+func MatchesExclude(pathToCheck string, excludeSpecs []string, useCaseSensitiveFileNames bool, currentDirectory string) bool {
+	excludeSpecs = core.Filter(excludeSpecs, func(spec string) bool { return !invalidDotDotAfterRecursiveWildcard(spec) })
+	excludePattern := getRegularExpressionForWildcard(excludeSpecs, tspath.NormalizePath(currentDirectory), usageExclude)
+	if excludePattern == "" {
+		return false
+	}
+	excludeRegex := getRegexFromPattern(excludePattern, useCaseSensitiveFileNames)
+	if core.Must(excludeRegex.MatchString(pathToCheck)) {
+		return true
+	}
+	return !tspath.HasExtension(pathToCheck) && core.Must(excludeRegex.MatchString(tspath.EnsureTrailingDirectorySeparator(pathToCheck)))
+}
+
+// getWildcardDirectories gets the directories that must be watched to find files added to or
+// removed from a project by its "include" specs, mapped to whether they must be watched recursively.
+func getWildcardDirectories(includeSpecs []string, excludeSpecs []string, basePath string, useCaseSensitiveFileNames bool) map[string]bool {
+	// We watch a directory recursively if it contains a wildcard anywhere in a directory segment
+	// of the pattern:
+	//
+	//  /a/b/**/d   - Watch /a/b recursively to catch changes to any d in any subfolder recursively
+	//  /a/b/*/d    - Watch /a/b recursively to catch any d in any immediate subfolder, even if a new subfolder is added
+	//  /a/b        - Watch /a/b recursively to catch changes to anything in any recursive subfoler
+	//
+	// We watch a directory without recursion if it contains a wildcard in the file segment of
+	// the pattern:
+	//
+	//  /a/b/*      - Watch /a/b directly to catch any new file
+	//  /a/b/a?z    - Watch /a/b directly to catch any new file matching a?z
+	var excludeRegex *regexp2.Regexp
+	if excludePattern := getRegularExpressionForWildcard(excludeSpecs, basePath, usageExclude); excludePattern != "" {
+		excludeRegex = getRegexFromPattern(excludePattern, useCaseSensitiveFileNames)
+	}
+	wildcardDirectories := make(map[string]bool)
+	if len(includeSpecs) == 0 {
+		return wildcardDirectories
+	}
+	wildcardKeyToPath := make(map[string]string)
+	var recursiveKeys []string
+	for _, file := range includeSpecs {
+		spec := tspath.NormalizePath(tspath.CombinePaths(basePath, file))
+		if excludeRegex != nil && core.Must(excludeRegex.MatchString(spec)) {
+			continue
+		}
+		key, path, recursive, ok := getWildcardDirectoryFromSpec(spec, useCaseSensitiveFileNames)
+		if !ok {
+			continue
+		}
+		existingPath, hasExisting := wildcardKeyToPath[key]
+		if !hasExisting {
+			wildcardDirectories[path] = recursive
+			wildcardKeyToPath[key] = path
+		} else if recursive && !wildcardDirectories[existingPath] {
+			wildcardDirectories[existingPath] = recursive
+		} else {
+			continue
+		}
+		if recursive {
+			recursiveKeys = append(recursiveKeys, key)
+		}
+	}
+	// Remove any subpaths under an existing recursively watched directory.
+	comparePathsOptions := tspath.ComparePathsOptions{UseCaseSensitiveFileNames: useCaseSensitiveFileNames, CurrentDirectory: basePath}
+	for path := range wildcardDirectories {
+		key := toCanonicalKey(path, useCaseSensitiveFileNames)
+		for _, recursiveKey := range recursiveKeys {
+			if key != recursiveKey && tspath.ContainsPath(recursiveKey, key, comparePathsOptions) {
+				delete(wildcardDirectories, path)
+				break
+			}
+		}
+	}
+	return wildcardDirectories
+}
+
+func getWildcardDirectoryFromSpec(spec string, useCaseSensitiveFileNames bool) (key string, path string, recursive bool, ok bool) {
+	// The directory to watch is everything before the last directory separator preceding the first wildcard.
+	if wildcardIndex := strings.IndexAny(spec, "*?"); wildcardIndex != -1 {
+		if separatorIndex := strings.LastIndexByte(spec[:wildcardIndex], '/'); separatorIndex != -1 {
+			path = spec[:separatorIndex]
+			lastSeparatorIndex := strings.LastIndexByte(spec, '/')
+			questionWildcardIndex := strings.IndexByte(spec, '?')
+			starWildcardIndex := strings.IndexByte(spec, '*')
+			recursive = questionWildcardIndex != -1 && questionWildcardIndex < lastSeparatorIndex ||
+				starWildcardIndex != -1 && starWildcardIndex < lastSeparatorIndex
+			return toCanonicalKey(path, useCaseSensitiveFileNames), path, recursive, true
+		}
+	}
+	if isImplicitGlob(spec[strings.LastIndexByte(spec, '/')+1:]) {
+		path = tspath.RemoveTrailingDirectorySeparator(spec)
+		return toCanonicalKey(path, useCaseSensitiveFileNames), path, true, true
+	}
+	return "", "", false, false
+}
+
+func toCanonicalKey(path string, useCaseSensitiveFileNames bool) string {
+	if useCaseSensitiveFileNames {
+		return path
+	}
+	return strings.ToLower(path)
+}
@@ -0,0 +1,189 @@
+//go:build linux
+
+package vfswatch
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"golang.org/x/sys/unix"
+)
+
+const inotifyMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MODIFY | unix.IN_ATTRIB |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_DELETE_SELF | unix.IN_MOVE_SELF | unix.IN_ONLYDIR
+
+var errInotifyClosed = errors.New("vfswatch: inotify instance is closed")
+
+// inotify is an eventSource backed by a single inotify instance. Each watched directory has one
+// inotify watch, shared by all of its subscriptions.
+type inotify struct {
+	fd   int
+	file *os.File
+
+	mu          sync.Mutex
+	closed      bool
+	directories map[int32]*inotifyDirectory
+	byPath      map[string]*inotifyDirectory
+}
+
+type inotifyDirectory struct {
+	wd            int32
+	path          string
+	subscriptions []*inotifySubscription
+}
+
+type inotifySubscription struct {
+	callback eventCallback
+	onLost   func()
+}
+
+func newEventSource() eventSource {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil
+	}
+	w := &inotify{
+		fd: fd,
+		// As the descriptor is non-blocking, reads go through the runtime poller, and closing the
+		// file interrupts a pending read.
+		file:        os.NewFile(uintptr(fd), "inotify"),
+		directories: make(map[int32]*inotifyDirectory),
+		byPath:      make(map[string]*inotifyDirectory),
+	}
+	go w.run()
+	return w
+}
+
+func (w *inotify) watchDirectory(path string, callback eventCallback, onLost func()) (func(), error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil, errInotifyClosed
+	}
+	directory := w.byPath[path]
+	if directory == nil {
+		wd, err := unix.InotifyAddWatch(w.fd, path, inotifyMask)
+		if err != nil {
+			// Most likely ENOSPC, when the limit on the number of watches is reached.
+			return nil, err
+		}
+		if existing := w.directories[int32(wd)]; existing != nil {
+			// The same directory is already watched through another path, such as a symlink.
+			return nil, &os.PathError{Op: "watch", Path: path, Err: unix.EEXIST}
+		}
+		directory = &inotifyDirectory{wd: int32(wd), path: path}
+		w.directories[directory.wd] = directory
+		w.byPath[path] = directory
+	}
+	subscription := &inotifySubscription{callback: callback, onLost: onLost}
+	directory.subscriptions = append(directory.subscriptions, subscription)
+	return func() { w.unsubscribe(directory, subscription) }, nil
+}
+
+func (w *inotify) unsubscribe(directory *inotifyDirectory, subscription *inotifySubscription) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	directory.subscriptions = slices.DeleteFunc(directory.subscriptions, func(s *inotifySubscription) bool { return s == subscription })
+	if len(directory.subscriptions) == 0 && w.directories[directory.wd] == directory {
+		w.removeDirectory(directory)
+		if !w.closed {
+			_, _ = unix.InotifyRmWatch(w.fd, uint32(directory.wd))
+		}
+	}
+}
+
+func (w *inotify) removeDirectory(directory *inotifyDirectory) {
+	delete(w.directories, directory.wd)
+	delete(w.byPath, directory.path)
+}
+
+func (w *inotify) run() {
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			return
+		}
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			// struct inotify_event { int wd; uint32_t mask; uint32_t cookie; uint32_t len; char name[]; }
+			wd := int32(binary.NativeEndian.Uint32(buf[offset:]))
+			mask := binary.NativeEndian.Uint32(buf[offset+4:])
+			nameLength := int(binary.NativeEndian.Uint32(buf[offset+12:]))
+			nameStart := offset + unix.SizeofInotifyEvent
+			name := strings.TrimRight(string(buf[nameStart:nameStart+nameLength]), "\x00")
+			offset = nameStart + nameLength
+			w.dispatch(wd, mask, name)
+		}
+	}
+}
+
+func (w *inotify) dispatch(wd int32, mask uint32, name string) {
+	w.mu.Lock()
+	if mask&unix.IN_Q_OVERFLOW != 0 {
+		// Events were dropped, so no watch can be trusted anymore.
+		var lost []*inotifySubscription
+		for _, directory := range w.directories {
+			lost = append(lost, directory.subscriptions...)
+			_, _ = unix.InotifyRmWatch(w.fd, uint32(directory.wd))
+		}
+		clear(w.directories)
+		clear(w.byPath)
+		w.mu.Unlock()
+		for _, subscription := range lost {
+			subscription.onLost()
+		}
+		return
+	}
+	directory := w.directories[wd]
+	if directory == nil {
+		w.mu.Unlock()
+		return
+	}
+	subscriptions := slices.Clone(directory.subscriptions)
+	if mask&(unix.IN_IGNORED|unix.IN_DELETE_SELF|unix.IN_MOVE_SELF) != 0 {
+		// The directory was removed or moved away; its path no longer refers to what is watched.
+		w.removeDirectory(directory)
+		if mask&unix.IN_IGNORED == 0 {
+			_, _ = unix.InotifyRmWatch(w.fd, uint32(wd))
+		}
+		w.mu.Unlock()
+		for _, subscription := range subscriptions {
+			subscription.onLost()
+		}
+		return
+	}
+	w.mu.Unlock()
+
+	if name == "" {
+		return
+	}
+	kind := EventKindChanged
+	switch {
+	case mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0:
+		kind = EventKindCreated
+	case mask&(unix.IN_DELETE|unix.IN_MOVED_FROM) != 0:
+		kind = EventKindDeleted
+	}
+	entryName := tspath.CombinePaths(directory.path, name)
+	isDirectory := mask&unix.IN_ISDIR != 0
+	for _, subscription := range subscriptions {
+		subscription.callback(entryName, kind, isDirectory)
+	}
+}
+
+func (w *inotify) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	w.closed = true
+	clear(w.directories)
+	clear(w.byPath)
+	_ = w.file.Close()
+}
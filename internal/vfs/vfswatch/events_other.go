@@ -0,0 +1,9 @@
+//go:build !linux
+
+package vfswatch
+
+// newEventSource returns nil, as file system events are not implemented on this platform; all
+// watches poll.
+func newEventSource() eventSource {
+	return nil
+}
@@ -0,0 +1,264 @@
+package vfswatch
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+const (
+	pollingIntervalLow    = 250 * time.Millisecond
+	pollingIntervalMedium = 500 * time.Millisecond
+	pollingIntervalHigh   = 2000 * time.Millisecond
+)
+
+// pollWatch is a watch that is checked for changes on an interval.
+type pollWatch struct {
+	// check reports whether the watched entry changed since it was last checked.
+	check func() bool
+
+	interval    time.Duration
+	maxInterval time.Duration
+	current     time.Duration
+	nextPoll    time.Time
+	closed      bool
+}
+
+// schedule sets when w is next checked. Watches with a maxInterval back off while their entry does
+// not change, and are checked at their interval again as soon as it does.
+func (w *pollWatch) schedule(now time.Time, changed bool) {
+	if changed || w.current == 0 {
+		w.current = w.interval
+	} else if w.maxInterval > w.current {
+		w.current = min(w.current*2, w.maxInterval)
+	}
+	w.nextPoll = now.Add(w.current)
+}
+
+type poller struct {
+	mu      sync.Mutex
+	watches []*pollWatch
+
+	// pollMu serializes polls, so that the checks and callbacks of a watch never overlap.
+	pollMu sync.Mutex
+
+	background bool
+	ticker     *time.Ticker
+	tick       time.Duration
+	done       chan struct{}
+	closed     bool
+}
+
+func newPoller(background bool) *poller {
+	return &poller{background: background}
+}
+
+func (p *poller) add(w *pollWatch) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	w.schedule(time.Now(), false /*changed*/)
+	p.watches = append(p.watches, w)
+	if !p.background {
+		return
+	}
+	// Tick often enough for the watch with the shortest interval.
+	if p.ticker == nil {
+		p.tick = w.interval
+		p.ticker = time.NewTicker(p.tick)
+		p.done = make(chan struct{})
+		go p.run(p.ticker, p.done)
+	} else if w.interval < p.tick {
+		p.tick = w.interval
+		p.ticker.Reset(p.tick)
+	}
+}
+
+func (p *poller) remove(w *pollWatch) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w.closed = true
+	p.watches = slices.DeleteFunc(p.watches, func(watch *pollWatch) bool { return watch == w })
+}
+
+func (p *poller) run(ticker *time.Ticker, done chan struct{}) {
+	for {
+		select {
+		case now := <-ticker.C:
+			p.poll(now, false /*force*/)
+		case <-done:
+			return
+		}
+	}
+}
+
+// poll checks the watches that are due, or all of them if force is set.
+func (p *poller) poll(now time.Time, force bool) {
+	p.pollMu.Lock()
+	defer p.pollMu.Unlock()
+	p.mu.Lock()
+	watches := slices.Clone(p.watches)
+	p.mu.Unlock()
+	for _, w := range watches {
+		p.mu.Lock()
+		due := !w.closed && (force || !now.Before(w.nextPoll))
+		p.mu.Unlock()
+		if due {
+			w.schedule(now, w.check())
+		}
+	}
+}
+
+func (p *poller) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	if p.ticker != nil {
+		p.ticker.Stop()
+		close(p.done)
+	}
+	p.watches = nil
+}
+
+type pollWatcher struct {
+	poller *poller
+	watch  *pollWatch
+}
+
+func (w *pollWatcher) Close() {
+	w.poller.remove(w.watch)
+}
+
+// pollingIntervals gets the interval at which a file or directory is polled, and the interval it
+// may back off to while it is unchanged. The watchInterval option overrides the interval.
+//
+//   - fixedInterval polls every entry every 250ms.
+//   - priorityInterval polls files in node_modules every 2s, other declaration files every 500ms
+//     and all other entries every 250ms.
+//   - dynamicPriority starts at 250ms, and backs off to 2s while the entry is unchanged.
+//   - fixedChunkSize polls every entry every 2s.
+func pollingIntervals(kind core.PollingKind, path string, options *core.WatchOptions) (interval time.Duration, maxInterval time.Duration) {
+	switch kind {
+	case core.PollingKindPriorityInterval:
+		switch {
+		case strings.Contains(path, "/node_modules/"):
+			interval = pollingIntervalHigh
+		case tspath.IsDeclarationFileName(path):
+			interval = pollingIntervalMedium
+		default:
+			interval = pollingIntervalLow
+		}
+	case core.PollingKindDynamicPriority:
+		interval, maxInterval = pollingIntervalLow, pollingIntervalHigh
+	case core.PollingKindFixedChunkSize:
+		interval = pollingIntervalHigh
+	default:
+		interval = pollingIntervalLow
+	}
+	if options.Interval != nil && *options.Interval > 0 {
+		interval = time.Duration(*options.Interval) * time.Millisecond
+		maxInterval = max(maxInterval, interval)
+	}
+	return interval, maxInterval
+}
+
+type fileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+func (h *Host) statFile(fileName string) fileState {
+	if info := h.fs.Stat(fileName); info != nil && !info.IsDir() {
+		return fileState{exists: true, modTime: info.ModTime(), size: info.Size()}
+	}
+	return fileState{}
+}
+
+func (h *Host) pollFile(fileName string, callback FileWatcherCallback, kind core.PollingKind, options *core.WatchOptions) Watcher {
+	state := h.statFile(fileName)
+	interval, maxInterval := pollingIntervals(kind, fileName, options)
+	w := &pollWatch{
+		interval:    interval,
+		maxInterval: maxInterval,
+		check: func() bool {
+			oldState := state
+			state = h.statFile(fileName)
+			switch {
+			case !oldState.exists && state.exists:
+				callback(fileName, EventKindCreated)
+			case oldState.exists && !state.exists:
+				callback(fileName, EventKindDeleted)
+			case state.exists && (state.modTime != oldState.modTime || state.size != oldState.size):
+				callback(fileName, EventKindChanged)
+			default:
+				return false
+			}
+			return true
+		},
+	}
+	h.poller.add(w)
+	return &pollWatcher{poller: h.poller, watch: w}
+}
+
+// pollDirectory polls the entries of a directory. Only added and removed entries are reported, as
+// changes to files are seen by the watches of the files themselves.
+func (h *Host) pollDirectory(path string, callback DirectoryWatcherCallback, recursive bool, kind core.PollingKind, options *core.WatchOptions) Watcher {
+	entries := h.readEntries(path, recursive, options)
+	interval, maxInterval := pollingIntervals(kind, path, options)
+	w := &pollWatch{
+		interval:    interval,
+		maxInterval: maxInterval,
+		check: func() bool {
+			oldEntries := entries
+			entries = h.readEntries(path, recursive, options)
+			var changed []string
+			for entry := range entries.Keys() {
+				if !oldEntries.Has(entry) {
+					changed = append(changed, entry)
+				}
+			}
+			for entry := range oldEntries.Keys() {
+				if !entries.Has(entry) {
+					changed = append(changed, entry)
+				}
+			}
+			slices.Sort(changed)
+			for _, entry := range changed {
+				callback(entry)
+			}
+			return len(changed) > 0
+		},
+	}
+	h.poller.add(w)
+	return &pollWatcher{poller: h.poller, watch: w}
+}
+
+func (h *Host) readEntries(path string, recursive bool, options *core.WatchOptions) *core.Set[string] {
+	var result core.Set[string]
+	var visit func(directory string)
+	visit = func(directory string) {
+		entries := h.fs.GetAccessibleEntries(directory)
+		for _, file := range entries.Files {
+			result.Add(tspath.CombinePaths(directory, file))
+		}
+		for _, subdirectory := range entries.Directories {
+			subdirectoryPath := tspath.CombinePaths(directory, subdirectory)
+			result.Add(subdirectoryPath)
+			if recursive && !h.isExcluded(subdirectoryPath, options.ExcludeDir) {
+				visit(subdirectoryPath)
+			}
+		}
+	}
+	visit(path)
+	return &result
+}
@@ -0,0 +1,328 @@
+// Package vfswatch watches files and directories of a [vfs.FS] for changes.
+//
+// Watches use the file system events of the platform when the watch options ask for them and they
+// are available, and fall back to polling the [vfs.FS] otherwise.
+package vfswatch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs"
+)
+
+type EventKind int32
+
+const (
+	EventKindCreated EventKind = iota
+	EventKindChanged
+	EventKindDeleted
+)
+
+// FileWatcherCallback is called when a watched file is created, changed or deleted.
+type FileWatcherCallback func(fileName string, kind EventKind)
+
+// DirectoryWatcherCallback is called with the path of an entry added to, removed from or changed
+// within a watched directory.
+type DirectoryWatcherCallback func(fileName string)
+
+// Watcher is an active watch.
+type Watcher interface {
+	Close()
+}
+
+// Host creates watches. Callbacks may be called on any goroutine, but never concurrently for the
+// same watch.
+type Host struct {
+	fs               vfs.FS
+	currentDirectory string
+	// background is set when watches are kept up to date on background goroutines, rather than
+	// only when Poll is called.
+	background bool
+
+	poller *poller
+
+	eventsOnce sync.Once
+	events     eventSource
+}
+
+// NewHost returns a Host that watches fs using file system events where possible and polls on
+// background goroutines otherwise. fs must be backed by the file system of the OS.
+func NewHost(fs vfs.FS, currentDirectory string) *Host {
+	return &Host{
+		fs:               fs,
+		currentDirectory: currentDirectory,
+		background:       true,
+		poller:           newPoller(true /*background*/),
+	}
+}
+
+// NewPollingHost returns a Host that only detects changes when Poll is called. It is meant for
+// virtual file systems.
+func NewPollingHost(fs vfs.FS, currentDirectory string) *Host {
+	return &Host{
+		fs:               fs,
+		currentDirectory: currentDirectory,
+		poller:           newPoller(false /*background*/),
+	}
+}
+
+// Poll checks every polled watch for changes, regardless of its polling interval.
+func (h *Host) Poll() {
+	h.poller.poll(time.Now(), true /*force*/)
+}
+
+// Close stops all background work of the host. Watches of a closed host no longer report changes.
+func (h *Host) Close() {
+	h.poller.close()
+	h.eventsOnce.Do(func() {})
+	if h.events != nil {
+		h.events.close()
+	}
+}
+
+// WatchFile watches fileName with the strategy selected by the watchFile and fallbackPolling
+// options. Files matched by excludeFiles are not watched.
+func (h *Host) WatchFile(fileName string, callback FileWatcherCallback, options *core.WatchOptions) Watcher {
+	if options == nil {
+		options = &core.WatchOptions{}
+	}
+	if h.isExcluded(fileName, options.ExcludeFiles) {
+		return noopWatcher{}
+	}
+	switch options.FileKind {
+	case core.WatchFileKindFixedPollingInterval:
+		return h.pollFile(fileName, callback, core.PollingKindFixedInterval, options)
+	case core.WatchFileKindPriorityPollingInterval:
+		return h.pollFile(fileName, callback, core.PollingKindPriorityInterval, options)
+	case core.WatchFileKindDynamicPriorityPolling:
+		return h.pollFile(fileName, callback, core.PollingKindDynamicPriority, options)
+	case core.WatchFileKindFixedChunkSizePolling:
+		return h.pollFile(fileName, callback, core.PollingKindFixedChunkSize, options)
+	}
+	w := &switchingWatcher{}
+	// Watching the parent directory, rather than the file itself, also sees files replaced by a rename
+	// and files that do not exist yet.
+	closeEvents, ok := h.watchDirectoryEvents(tspath.GetDirectoryPath(fileName), func(entryName string, kind EventKind, isDirectory bool) {
+		if entryName == fileName && !isDirectory {
+			w.call(func() { callback(fileName, kind) })
+		}
+	}, func() {
+		w.call(func() { callback(fileName, EventKindDeleted) })
+		w.replace(h.pollFile(fileName, callback, fallbackPollingKind(options), options).Close)
+	})
+	if !ok {
+		return h.pollFile(fileName, callback, fallbackPollingKind(options), options)
+	}
+	w.replace(closeEvents)
+	return w
+}
+
+// WatchDirectory watches the entries of path, and of all its subdirectories when recursive is set,
+// with the strategy selected by the watchDirectory and fallbackPolling options. Directories matched
+// by excludeDirectories are not watched.
+func (h *Host) WatchDirectory(path string, callback DirectoryWatcherCallback, recursive bool, options *core.WatchOptions) Watcher {
+	if options == nil {
+		options = &core.WatchOptions{}
+	}
+	if h.isExcluded(path, options.ExcludeDir) {
+		return noopWatcher{}
+	}
+	switch options.DirectoryKind {
+	case core.WatchDirectoryKindFixedPollingInterval:
+		return h.pollDirectory(path, callback, recursive, core.PollingKindFixedInterval, options)
+	case core.WatchDirectoryKindDynamicPriorityPolling:
+		return h.pollDirectory(path, callback, recursive, core.PollingKindDynamicPriority, options)
+	case core.WatchDirectoryKindFixedChunkSizePolling:
+		return h.pollDirectory(path, callback, recursive, core.PollingKindFixedChunkSize, options)
+	}
+	w := &switchingWatcher{}
+	onLost := func() {
+		w.call(func() { callback(path) })
+		w.replace(h.pollDirectory(path, callback, recursive, fallbackPollingKind(options), options).Close)
+	}
+	var closeEvents func()
+	var ok bool
+	if recursive {
+		closeEvents, ok = h.watchDirectoryEventsRecursive(path, func(entryName string) { w.call(func() { callback(entryName) }) }, onLost, options)
+	} else {
+		closeEvents, ok = h.watchDirectoryEvents(path, func(entryName string, kind EventKind, isDirectory bool) {
+			w.call(func() { callback(entryName) })
+		}, onLost)
+	}
+	if !ok {
+		return h.pollDirectory(path, callback, recursive, fallbackPollingKind(options), options)
+	}
+	w.replace(closeEvents)
+	return w
+}
+
+// watchDirectoryEvents subscribes to the file system events of the entries of path. onLost is
+// called when the directory can no longer be watched, for example because it was deleted.
+func (h *Host) watchDirectoryEvents(path string, callback eventCallback, onLost func()) (func(), bool) {
+	if !h.background {
+		return nil, false
+	}
+	h.eventsOnce.Do(func() {
+		h.events = newEventSource()
+	})
+	if h.events == nil || !h.fs.DirectoryExists(path) {
+		return nil, false
+	}
+	closeEvents, err := h.events.watchDirectory(path, callback, onLost)
+	if err != nil {
+		return nil, false
+	}
+	return closeEvents, true
+}
+
+// watchDirectoryEventsRecursive subscribes to the file system events of path and of each of its
+// subdirectories, following subdirectories as they are added and removed.
+func (h *Host) watchDirectoryEventsRecursive(path string, callback DirectoryWatcherCallback, onLost func(), options *core.WatchOptions) (func(), bool) {
+	var mu sync.Mutex
+	closed := false
+	subdirectories := make(map[string]func())
+
+	var addSubdirectory func(directory string, reportEntries bool)
+	var onEvent eventCallback = func(entryName string, kind EventKind, isDirectory bool) {
+		if isDirectory {
+			switch kind {
+			case EventKindCreated:
+				// Entries created before the new directory is watched would otherwise be missed.
+				addSubdirectory(entryName, true /*reportEntries*/)
+			case EventKindDeleted:
+				mu.Lock()
+				for subdirectory, closeSubdirectory := range subdirectories {
+					if tspath.ContainsPath(entryName, subdirectory, tspath.ComparePathsOptions{UseCaseSensitiveFileNames: h.fs.UseCaseSensitiveFileNames()}) {
+						closeSubdirectory()
+						delete(subdirectories, subdirectory)
+					}
+				}
+				mu.Unlock()
+			}
+		}
+		callback(entryName)
+	}
+	addSubdirectory = func(directory string, reportEntries bool) {
+		if h.isExcluded(directory, options.ExcludeDir) {
+			return
+		}
+		closeSubdirectory, ok := h.watchDirectoryEvents(directory, onEvent, func() {})
+		if !ok {
+			return
+		}
+		mu.Lock()
+		if _, exists := subdirectories[directory]; exists || closed {
+			mu.Unlock()
+			closeSubdirectory()
+			return
+		}
+		subdirectories[directory] = closeSubdirectory
+		mu.Unlock()
+		entries := h.fs.GetAccessibleEntries(directory)
+		if reportEntries {
+			for _, file := range entries.Files {
+				callback(tspath.CombinePaths(directory, file))
+			}
+		}
+		for _, subdirectory := range entries.Directories {
+			subdirectoryPath := tspath.CombinePaths(directory, subdirectory)
+			if reportEntries {
+				callback(subdirectoryPath)
+			}
+			addSubdirectory(subdirectoryPath, reportEntries)
+		}
+	}
+
+	closeRoot, ok := h.watchDirectoryEvents(path, onEvent, onLost)
+	if !ok {
+		return nil, false
+	}
+	for _, subdirectory := range h.fs.GetAccessibleEntries(path).Directories {
+		addSubdirectory(tspath.CombinePaths(path, subdirectory), false /*reportEntries*/)
+	}
+	return func() {
+		closeRoot()
+		mu.Lock()
+		defer mu.Unlock()
+		closed = true
+		for _, closeSubdirectory := range subdirectories {
+			closeSubdirectory()
+		}
+		clear(subdirectories)
+	}, true
+}
+
+func (h *Host) isExcluded(path string, excludeSpecs []string) bool {
+	return len(excludeSpecs) > 0 && tsoptions.MatchesExclude(path, excludeSpecs, h.fs.UseCaseSensitiveFileNames(), h.currentDirectory)
+}
+
+func fallbackPollingKind(options *core.WatchOptions) core.PollingKind {
+	if options.FallbackPolling != core.PollingKindNone {
+		return options.FallbackPolling
+	}
+	return core.PollingKindPriorityInterval
+}
+
+type noopWatcher struct{}
+
+func (noopWatcher) Close() {}
+
+// switchingWatcher is a watch whose implementation can be replaced, as when a watch that uses file
+// system events falls back to polling.
+type switchingWatcher struct {
+	mu       sync.Mutex
+	closed   bool
+	closeFn  func()
+	callback sync.Mutex
+}
+
+func (w *switchingWatcher) replace(closeFn func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		closeFn()
+		return
+	}
+	if w.closeFn != nil {
+		w.closeFn()
+	}
+	w.closeFn = closeFn
+}
+
+// call runs a callback of the watch unless it is closed, without overlapping other callbacks.
+func (w *switchingWatcher) call(fn func()) {
+	w.callback.Lock()
+	defer w.callback.Unlock()
+	w.mu.Lock()
+	closed := w.closed
+	w.mu.Unlock()
+	if !closed {
+		fn()
+	}
+}
+
+func (w *switchingWatcher) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.closed {
+		w.closed = true
+		if w.closeFn != nil {
+			w.closeFn()
+		}
+	}
+}
+
+// eventCallback is called with the path of an entry of a directory watched with file system events.
+type eventCallback func(entryName string, kind EventKind, isDirectory bool)
+
+// eventSource provides the file system events of the platform.
+type eventSource interface {
+	// watchDirectory subscribes to the events of the entries of path. onLost is called, at most once,
+	// when the directory can no longer be watched.
+	watchDirectory(path string, callback eventCallback, onLost func()) (func(), error)
+	close()
+}
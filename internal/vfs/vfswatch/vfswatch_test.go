@@ -0,0 +1,149 @@
+package vfswatch_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs/osvfs"
+	"github.com/microsoft/typescript-go/internal/vfs/vfstest"
+	"github.com/microsoft/typescript-go/internal/vfs/vfswatch"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) file(fileName string, kind vfswatch.EventKind) {
+	r.add([]string{"created", "changed", "deleted"}[kind] + " " + fileName)
+}
+
+func (r *recorder) directory(fileName string) {
+	r.add(fileName)
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := r.events
+	r.events = nil
+	slices.Sort(events)
+	return events
+}
+
+func TestPollingHost(t *testing.T) {
+	t.Parallel()
+
+	fs := vfstest.FromMap(map[string]string{
+		"/src/a.ts":              "a",
+		"/src/lib/b.ts":          "b",
+		"/src/node_modules/c.ts": "c",
+	}, true /*useCaseSensitiveFileNames*/)
+	host := vfswatch.NewPollingHost(fs, "/src")
+	defer host.Close()
+
+	var r recorder
+	options := &core.WatchOptions{ExcludeDir: []string{"node_modules"}, ExcludeFiles: []string{"lib/*.ts"}}
+	host.WatchFile("/src/a.ts", r.file, options)
+	host.WatchFile("/src/missing.ts", r.file, options)
+	host.WatchFile("/src/lib/b.ts", r.file, options)
+	host.WatchDirectory("/src", r.directory, true /*recursive*/, options)
+	host.Poll()
+	assert.Assert(t, r.take() == nil)
+
+	assert.NilError(t, fs.WriteFile("/src/a.ts", "a2", false))
+	assert.NilError(t, fs.WriteFile("/src/missing.ts", "", false))
+	assert.NilError(t, fs.WriteFile("/src/lib/b.ts", "b2", false))
+	assert.NilError(t, fs.WriteFile("/src/lib/d.ts", "d", false))
+	assert.NilError(t, fs.WriteFile("/src/node_modules/e.ts", "e", false))
+	host.Poll()
+	assert.DeepEqual(t, r.take(), []string{
+		"/src/lib/d.ts",
+		"/src/missing.ts",
+		"changed /src/a.ts",
+		"created /src/missing.ts",
+	})
+
+	host.Poll()
+	assert.Assert(t, r.take() == nil)
+}
+
+func TestPollingHostClose(t *testing.T) {
+	t.Parallel()
+
+	fs := vfstest.FromMap(map[string]string{"/src/a.ts": "a"}, true /*useCaseSensitiveFileNames*/)
+	host := vfswatch.NewPollingHost(fs, "/src")
+	defer host.Close()
+
+	var r recorder
+	watcher := host.WatchFile("/src/a.ts", r.file, nil)
+	watcher.Close()
+	assert.NilError(t, fs.WriteFile("/src/a.ts", "a2", false))
+	host.Poll()
+	assert.Assert(t, r.take() == nil)
+}
+
+func TestHost(t *testing.T) {
+	t.Parallel()
+
+	dir := tspath.NormalizePath(t.TempDir())
+	write := func(fileName string, text string) {
+		t.Helper()
+		assert.NilError(t, os.WriteFile(filepath.FromSlash(fileName), []byte(text), 0o666))
+	}
+	write(dir+"/a.ts", "a")
+
+	interval := 10
+	kinds := []struct {
+		name    string
+		options *core.WatchOptions
+	}{
+		{"fs events", &core.WatchOptions{}},
+		{"polling", &core.WatchOptions{FileKind: core.WatchFileKindFixedPollingInterval, DirectoryKind: core.WatchDirectoryKindFixedPollingInterval, Interval: &interval}},
+	}
+	for i, kind := range kinds {
+		if runtime.GOOS != "linux" && kind.options.FileKind == core.WatchFileKindNone {
+			// Without file system events, the watches poll at the default intervals.
+			continue
+		}
+		host := vfswatch.NewHost(osvfs.FS(), dir)
+		defer host.Close()
+
+		var files, directories recorder
+		host.WatchFile(dir+"/a.ts", files.file, kind.options)
+		host.WatchDirectory(dir, directories.directory, true /*recursive*/, kind.options)
+
+		subdirectory := dir + "/" + []string{"sub", "other"}[i]
+		assert.NilError(t, os.Mkdir(filepath.FromSlash(subdirectory), 0o777))
+		write(dir+"/a.ts", kind.name)
+		poll.WaitOn(t, func(poll.LogT) poll.Result {
+			if slices.Contains(files.take(), "changed "+dir+"/a.ts") {
+				return poll.Success()
+			}
+			return poll.Continue("waiting for %s change", kind.name)
+		}, poll.WithTimeout(10*time.Second))
+
+		write(subdirectory+"/b.ts", "b")
+		poll.WaitOn(t, func(poll.LogT) poll.Result {
+			if slices.Contains(directories.take(), subdirectory+"/b.ts") {
+				return poll.Success()
+			}
+			return poll.Continue("waiting for %s addition", kind.name)
+		}, poll.WithTimeout(10*time.Second))
+	}
+}
@@ -130,6 +130,8 @@ ParsedCommandLine::{
             "noEmitForJsFiles": null,
             "preserveWatchOutput": null,
             "pretty": null,
+            "help": null,
+            "all": null,
             "version": null,
             "watch": null,
             "showConfig": null,
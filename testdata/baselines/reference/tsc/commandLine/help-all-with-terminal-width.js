@@ -0,0 +1,679 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--help --all
+
+ExitStatus:: 0
+
+ParsedCommandLine::{
+    "parsedConfig": {
+        "compilerOptions": {
+            "allowJs": null,
+            "allowArbitraryExtensions": null,
+            "allowSyntheticDefaultImports": null,
+            "allowImportingTsExtensions": null,
+            "allowNonTsExtensions": null,
+            "allowUmdGlobalAccess": null,
+            "allowUnreachableCode": null,
+            "allowUnusedLabels": null,
+            "assumeChangesOnlyAffectDirectDependencies": null,
+            "alwaysStrict": null,
+            "baseUrl": "",
+            "build": null,
+            "checkJs": null,
+            "customConditions": null,
+            "composite": null,
+            "emitDeclarationOnly": null,
+            "emitBOM": null,
+            "emitDecoratorMetadata": null,
+            "downlevelIteration": null,
+            "declaration": null,
+            "declarationDir": "",
+            "declarationMap": null,
+            "disableSizeLimit": null,
+            "disableSourceOfProjectReferenceRedirect": null,
+            "disableSolutionSearching": null,
+            "disableReferencedProjectLoad": null,
+            "esModuleInterop": null,
+            "exactOptionalPropertyTypes": null,
+            "experimentalDecorators": null,
+            "forceConsistentCasingInFileNames": null,
+            "isolatedModules": null,
+            "isolatedDeclarations": null,
+            "ignoreDeprecations": "",
+            "importHelpers": null,
+            "inlineSourceMap": null,
+            "inlineSources": null,
+            "init": null,
+            "incremental": null,
+            "jsx": 0,
+            "jsxFactory": "",
+            "jsxFragmentFactory": "",
+            "jsxImportSource": "",
+            "keyofStringsOnly": null,
+            "lib": null,
+            "locale": "",
+            "mapRoot": "",
+            "module": 0,
+            "moduleResolution": 0,
+            "moduleSuffixes": null,
+            "moduleDetectionKind": 0,
+            "newLine": 0,
+            "noEmit": null,
+            "noCheck": null,
+            "noErrorTruncation": null,
+            "noFallthroughCasesInSwitch": null,
+            "noImplicitAny": null,
+            "noImplicitThis": null,
+            "noImplicitReturns": null,
+            "noEmitHelpers": null,
+            "noLib": null,
+            "noPropertyAccessFromIndexSignature": null,
+            "noUncheckedIndexedAccess": null,
+            "noEmitOnError": null,
+            "noUnusedLocals": null,
+            "noUnusedParameters": null,
+            "noResolve": null,
+            "noImplicitOverride": null,
+            "noUncheckedSideEffectImports": null,
+            "out": "",
+            "outDir": "",
+            "outFile": "",
+            "paths": null,
+            "preserveConstEnums": null,
+            "preserveSymlinks": null,
+            "project": "",
+            "resolveJsonModule": null,
+            "resolvePackageJsonExports": null,
+            "resolvePackageJsonImports": null,
+            "removeComments": null,
+            "rewriteRelativeImportExtensions": null,
+            "reactNamespace": "",
+            "rootDir": "",
+            "rootDirs": null,
+            "skipLibCheck": null,
+            "strict": null,
+            "strictBindCallApply": null,
+            "strictBuiltinIteratorReturn": null,
+            "strictFunctionTypes": null,
+            "strictNullChecks": null,
+            "strictPropertyInitialization": null,
+            "stripInternal": null,
+            "skipDefaultLibCheck": null,
+            "sourceMap": null,
+            "sourceRoot": "",
+            "suppressOutputPathCheck": null,
+            "target": 0,
+            "traceResolution": null,
+            "tsBuildInfoFile": "",
+            "typeRoots": null,
+            "types": null,
+            "useDefineForClassFields": null,
+            "useUnknownInCatchVariables": null,
+            "verbatimModuleSyntax": null,
+            "maxNodeModuleJsDepth": null,
+            "configFilePath": "",
+            "noDtsResolution": null,
+            "pathsBasePath": "",
+            "diagnostics": null,
+            "extendedDiagnostics": null,
+            "generateCpuProfile": "",
+            "generateTrace": "",
+            "listEmittedFiles": null,
+            "listFiles": null,
+            "explainFiles": null,
+            "listFilesOnly": null,
+            "noEmitForJsFiles": null,
+            "preserveWatchOutput": null,
+            "pretty": null,
+            "help": true,
+            "all": true,
+            "version": null,
+            "watch": null,
+            "showConfig": null,
+            "tscBuild": null
+        },
+        "watchOptions": {
+            "watchInterval": null,
+            "watchFile": 0,
+            "watchDirectory": 0,
+            "fallbackPolling": 0,
+            "synchronousWatchDirectory": null,
+            "excludeDirectories": null,
+            "excludeFiles": null
+        },
+        "fileNames": [],
+        "projectReferences": null
+    },
+    "configFile": null,
+    "errors": [],
+    "raw": {
+        "help": true,
+        "all": true
+    },
+    "compileOnSave": null
+}
+Output::
+tsc: The TypeScript Compiler - Version 7.0.0-dev                                                                   [44m     [39;49m
+                                                                                                                   [44m[97m  TS [39m[39;49m
+[1mALL COMPILER OPTIONS[22m
+
+### Command-line Options
+
+[94m            --all  [39mShow all compiler options.
+
+[94m       --help, -h  [39mPrint this message.
+
+
+
+[94m           --init  [39mInitializes a TypeScript project and creates a tsconfig.json file.
+
+[94m  --listFilesOnly  [39mPrint names of files that are part of the compilation and then stop processing.
+
+[94m         --locale  [39mSet the language of the messaging from TypeScript. This does not affect emit.
+
+[94m    --project, -p  [39mCompile the project given the path to its configuration file, or to a folder with a 'tsconfig.json'.
+
+[94m     --showConfig  [39mPrint the final configuration instead of building.
+
+[94m    --version, -v  [39mPrint the compiler's version.
+
+[94m      --watch, -w  [39mWatch input files.
+
+### Modules
+
+[94m         --allowArbitraryExtensions  [39mEnable importing files with any extension, provided a declaration file is present.
+                              type:  boolean
+                           default:  false
+
+[94m       --allowImportingTsExtensions  [39mAllow imports to include TypeScript file extensions. Requires '--moduleResolution b                                     undler' and either '--noEmit' or '--emitDeclarationOnly' to be set.
+                              type:  boolean
+                           default:  false
+
+[94m             --allowUmdGlobalAccess  [39mAllow accessing UMD globals from modules.
+                              type:  boolean
+                           default:  false
+
+[94m                          --baseUrl  [39mSpecify the base directory to resolve non-relative module names.
+
+[94m                 --customConditions  [39mConditions to set in addition to the resolver-specific defaults when resolving impo                                     rts.
+
+[94m                       --module, -m  [39mSpecify what module code is generated.
+                            one of:  none, commonjs, amd, umd, system, es6/es2015, es2020, es2022, esnext, node16, noden                                     ext, preserve
+                           default:  undefined
+
+[94m                 --moduleResolution  [39mSpecify how TypeScript looks up a file from a given module specifier.
+                            one of:  node16, nodenext, bundler
+                           default:  module === `AMD` or `UMD` or `System` or `ES6`, then `Classic`, Otherwise `Node`
+
+[94m                   --moduleSuffixes  [39mList of file name suffixes to search when resolving a module.
+
+[94m                        --noResolve  [39mDisallow 'import's, 'require's or '<reference>'s from expanding the number of files                                      TypeScript should add to a project.
+                              type:  boolean
+                           default:  false
+
+[94m     --noUncheckedSideEffectImports  [39mCheck side effect imports.
+                              type:  boolean
+                           default:  false
+
+[94m                            --paths  [39mSpecify a set of entries that re-map imports to additional lookup locations.
+                           default:  undefined
+
+[94m                --resolveJsonModule  [39mEnable importing .json files.
+                              type:  boolean
+                           default:  false
+
+[94m        --resolvePackageJsonExports  [39mUse the package.json 'exports' field when resolving package imports.
+                              type:  boolean
+                           default:  `true` when 'moduleResolution' is 'node16', 'nodenext', or 'bundler'; otherwise `fa                                     lse`.
+
+[94m        --resolvePackageJsonImports  [39mUse the package.json 'imports' field when resolving imports.
+                              type:  boolean
+                           default:  `true` when 'moduleResolution' is 'node16', 'nodenext', or 'bundler'; otherwise `fa                                     lse`.
+
+
+                              type:  boolean
+                           default:  false
+
+[94m                          --rootDir  [39mSpecify the root folder within your source files.
+                              type:  string
+                           default:  Computed from the list of input files
+
+[94m                         --rootDirs  [39mAllow multiple folders to be treated as one when resolving modules.
+                       one or more:  string
+                           default:  Computed from the list of input files
+
+[94m                        --typeRoots  [39mSpecify multiple folders that act like './node_modules/@types'.
+
+[94m                            --types  [39mSpecify type package names to be included without being referenced in a source file                                     .
+
+### JavaScript Support
+
+[94m               --allowJs  [39mAllow JavaScript files to be a part of your program. Use the 'checkJS' option to get errors fr                          om these files.
+                   type:  boolean
+                default:  false
+
+[94m               --checkJs  [39mEnable error reporting in type-checked JavaScript files.
+                   type:  boolean
+                default:  false
+
+[94m  --maxNodeModuleJsDepth  [39mSpecify the maximum folder depth used for checking JavaScript files from 'node_modules'. Only                           applicable with 'allowJs'.
+                   type:  number
+                default:  0
+
+### Interop Constraints
+
+[94m      --allowSyntheticDefaultImports  [39mAllow 'import x from y' when a module doesn't have a default export.
+                               type:  boolean
+                            default:  module === "system" or esModuleInterop
+
+[94m                   --esModuleInterop  [39mEmit additional JavaScript to ease support for importing CommonJS modules. This en                                      ables 'allowSyntheticDefaultImports' for type compatibility.
+                               type:  boolean
+                            default:  false
+
+[94m  --forceConsistentCasingInFileNames  [39mEnsure that casing is correct in imports.
+                               type:  boolean
+                            default:  true
+
+[94m              --isolatedDeclarations  [39mRequire sufficient annotation on exports so other tools can trivially generate dec                                      laration files.
+                               type:  boolean
+                            default:  false
+
+[94m                   --isolatedModules  [39mEnsure that each file can be safely transpiled without relying on other imports.
+                               type:  boolean
+                            default:  false
+
+[94m                  --preserveSymlinks  [39mDisable resolving symlinks to their realpath. This correlates to the same flag in                                       node.
+                               type:  boolean
+                            default:  false
+
+[94m              --verbatimModuleSyntax  [39mDo not transform or elide any imports or exports not marked as type-only, ensuring                                       they are written in the output file's format based on the 'module' setting.
+                               type:  boolean
+                            default:  false
+
+### Type Checking
+
+[94m                --allowUnreachableCode  [39mDisable error reporting for unreachable code.
+                                 type:  boolean
+                              default:  undefined
+
+[94m                   --allowUnusedLabels  [39mDisable error reporting for unused labels.
+                                 type:  boolean
+                              default:  undefined
+
+[94m                        --alwaysStrict  [39mEnsure 'use strict' is always emitted.
+                                 type:  boolean
+                              default:  `false`, unless `strict` is set
+
+[94m          --exactOptionalPropertyTypes  [39mInterpret optional property types as written, rather than adding 'undefined'.
+                                 type:  boolean
+                              default:  false
+
+[94m          --noFallthroughCasesInSwitch  [39mEnable error reporting for fallthrough cases in switch statements.
+                                 type:  boolean
+                              default:  false
+
+[94m                       --noImplicitAny  [39mEnable error reporting for expressions and declarations with an implied 'any' ty                                        pe.
+                                 type:  boolean
+                              default:  `false`, unless `strict` is set
+
+[94m                  --noImplicitOverride  [39mEnsure overriding members in derived classes are marked with an override modifie                                        r.
+                                 type:  boolean
+                              default:  false
+
+[94m                   --noImplicitReturns  [39mEnable error reporting for codepaths that do not explicitly return in a function                                        .
+                                 type:  boolean
+                              default:  false
+
+[94m                      --noImplicitThis  [39mEnable error reporting when 'this' is given the type 'any'.
+                                 type:  boolean
+                              default:  `false`, unless `strict` is set
+
+[94m  --noPropertyAccessFromIndexSignature  [39mEnforces using indexed accessors for keys declared using an indexed type.
+                                 type:  boolean
+                              default:  false
+
+[94m            --noUncheckedIndexedAccess  [39mAdd 'undefined' to a type when accessed using an index.
+                                 type:  boolean
+                              default:  false
+
+[94m                      --noUnusedLocals  [39mEnable error reporting when local variables aren't read.
+                                 type:  boolean
+                              default:  false
+
+[94m                  --noUnusedParameters  [39mRaise an error when a function parameter isn't read.
+                                 type:  boolean
+                              default:  false
+
+[94m                              --strict  [39mEnable all strict type-checking options.
+                                 type:  boolean
+                              default:  false
+
+[94m                 --strictBindCallApply  [39mCheck that the arguments for 'bind', 'call', and 'apply' methods match the origi                                        nal function.
+                                 type:  boolean
+                              default:  `false`, unless `strict` is set
+
+[94m         --strictBuiltinIteratorReturn  [39mBuilt-in iterators are instantiated with a 'TReturn' type of 'undefined' instead                                         of 'any'.
+                                 type:  boolean
+                              default:  `false`, unless `strict` is set
+
+[94m                 --strictFunctionTypes  [39mWhen assigning functions, check to ensure parameters and the return values are s                                        ubtype-compatible.
+                                 type:  boolean
+                              default:  `false`, unless `strict` is set
+
+[94m                    --strictNullChecks  [39mWhen type checking, take into account 'null' and 'undefined'.
+                                 type:  boolean
+                              default:  `false`, unless `strict` is set
+
+[94m        --strictPropertyInitialization  [39mCheck for class properties that are declared but not set in the constructor.
+                                 type:  boolean
+                              default:  `false`, unless `strict` is set
+
+[94m          --useUnknownInCatchVariables  [39mDefault catch clause variables as 'unknown' instead of 'any'.
+                                 type:  boolean
+                              default:  `false`, unless `strict` is set
+
+### Watch and Build Modes
+
+[94m  --assumeChangesOnlyAffectDirectDependencies  [39mHave recompiles in projects that use 'incremental' and 'watch' mode assum                                               e that changes within a file will only affect files directly depending on                                                it.
+                                        type:  boolean
+                                     default:  false
+
+### Backwards Compatibility
+
+[94m                         --charset  [39mNo longer supported. In early versions, manually set the text encoding for reading f                                    iles.
+                             type:  string
+                          default:  utf8
+
+[94m                --keyofStringsOnly  [39mMake keyof only return strings instead of string, numbers or symbols. Legacy option.
+                             type:  boolean
+                          default:  false
+
+[94m             --noImplicitUseStrict  [39mDisable adding 'use strict' directives in emitted JavaScript files.
+                             type:  boolean
+                          default:  false
+
+[94m           --noStrictGenericChecks  [39mDisable strict checking of generic signatures in function types.
+                             type:  boolean
+                          default:  false
+
+[94m                             --out  [39mDeprecated setting. Use 'outFile' instead.
+
+[94m            --preserveValueImports  [39mPreserve unused imported values in the JavaScript output that would otherwise be rem                                    oved.
+                             type:  boolean
+                          default:  false
+
+[94m    --suppressExcessPropertyErrors  [39mDisable reporting of excess property errors during the creation of object literals.
+                             type:  boolean
+                          default:  false
+
+[94m  --suppressImplicitAnyIndexErrors  [39mSuppress 'noImplicitAny' errors when indexing objects that lack index signatures.
+                             type:  boolean
+                          default:  false
+
+### Projects
+
+[94m                                --composite  [39mEnable constraints that allow a TypeScript project to be used with project                                              references.
+                                      type:  boolean
+                                   default:  false
+
+[94m             --disableReferencedProjectLoad  [39mReduce the number of projects loaded automatically by TypeScript.
+                                      type:  boolean
+                                   default:  false
+
+[94m                 --disableSolutionSearching  [39mOpt a project out of multi-project reference checking when editing.
+                                      type:  boolean
+                                   default:  false
+
+[94m  --disableSourceOfProjectReferenceRedirect  [39mDisable preferring source files instead of declaration files when referenci                                             ng composite projects.
+                                      type:  boolean
+                                   default:  false
+
+[94m                          --incremental, -i  [39mSave .tsbuildinfo files to allow for incremental compilation of projects.
+                                      type:  boolean
+                                   default:  `false`, unless `composite` is set
+
+[94m                          --tsBuildInfoFile  [39mSpecify the path to .tsbuildinfo incremental compilation file.
+                                      type:  string
+                                   default:  .tsbuildinfo
+
+### Emit
+
+[94m      --declaration, -d  [39mGenerate .d.ts files from TypeScript and JavaScript files in your project.
+                  type:  boolean
+               default:  `false`, unless `composite` is set
+
+[94m       --declarationDir  [39mSpecify the output directory for generated declaration files.
+
+[94m       --declarationMap  [39mCreate sourcemaps for d.ts files.
+                  type:  boolean
+               default:  false
+
+[94m   --downlevelIteration  [39mEmit more compliant, but verbose and less performant JavaScript for iteration.
+                  type:  boolean
+               default:  false
+
+[94m              --emitBOM  [39mEmit a UTF-8 Byte Order Mark (BOM) in the beginning of output files.
+                  type:  boolean
+               default:  false
+
+[94m  --emitDeclarationOnly  [39mOnly output d.ts files and not JavaScript files.
+                  type:  boolean
+               default:  false
+
+[94m        --importHelpers  [39mAllow importing helper functions from tslib once per project, instead of including them per-fil                         e.
+                  type:  boolean
+               default:  false
+
+[94m      --inlineSourceMap  [39mInclude sourcemap files inside the emitted JavaScript.
+                  type:  boolean
+               default:  false
+
+[94m        --inlineSources  [39mInclude source code in the sourcemaps inside the emitted JavaScript.
+                  type:  boolean
+               default:  false
+
+[94m              --mapRoot  [39mSpecify the location where debugger should locate map files instead of generated locations.
+
+[94m              --newLine  [39mSet the newline character for emitting files.
+                one of:  crlf, lf
+
+[94m               --noEmit  [39mDisable emitting files from a compilation.
+                  type:  boolean
+               default:  false
+
+[94m        --noEmitHelpers  [39mDisable generating custom helper functions like '__extends' in compiled output.
+                  type:  boolean
+               default:  false
+
+[94m        --noEmitOnError  [39mDisable emitting files if any type checking errors are reported.
+                  type:  boolean
+               default:  false
+
+[94m               --outDir  [39mSpecify an output folder for all emitted files.
+
+[94m              --outFile  [39mSpecify a file that bundles all outputs into one JavaScript file. If 'declaration' is true, als                         o designates a file that bundles all .d.ts output.
+
+[94m   --preserveConstEnums  [39mDisable erasing 'const enum' declarations in generated code.
+                  type:  boolean
+               default:  false
+
+[94m       --removeComments  [39mDisable emitting comments.
+                  type:  boolean
+               default:  false
+
+[94m            --sourceMap  [39mCreate source map files for emitted JavaScript files.
+                  type:  boolean
+               default:  false
+
+[94m           --sourceRoot  [39mSpecify the root path for debuggers to find the reference source code.
+
+[94m        --stripInternal  [39mDisable emitting declarations that have '@internal' in their JSDoc comments.
+                  type:  boolean
+               default:  false
+
+### Compiler Diagnostics
+
+[94m          --diagnostics  [39mOutput compiler performance information after building.
+                  type:  boolean
+               default:  false
+
+[94m         --explainFiles  [39mPrint files read during the compilation including why it was included.
+                  type:  boolean
+               default:  false
+
+[94m  --extendedDiagnostics  [39mOutput more detailed compiler performance information after building.
+                  type:  boolean
+               default:  false
+
+[94m   --generateCpuProfile  [39mEmit a v8 CPU profile of the compiler run for debugging.
+                  type:  string
+               default:  profile.cpuprofile
+
+[94m        --generateTrace  [39mGenerates an event trace and a list of types.
+
+[94m     --listEmittedFiles  [39mPrint the names of emitted files after a compilation.
+                  type:  boolean
+               default:  false
+
+[94m            --listFiles  [39mPrint all of the files read during the compilation.
+                  type:  boolean
+               default:  false
+
+[94m              --noCheck  [39mDisable full type checking (only critical parse and emit errors will be reported).
+                  type:  boolean
+               default:  false
+
+[94m      --traceResolution  [39mLog paths used during the 'moduleResolution' process.
+                  type:  boolean
+               default:  false
+
+### Editor Support
+
+[94m  --disableSizeLimit  [39mRemove the 20mb cap on total source code size for JavaScript files in the TypeScript language serv                      er.
+               type:  boolean
+            default:  false
+
+[94m           --plugins  [39mSpecify a list of language service plugins to include.
+
+            default:  undefined
+
+### Language and Environment
+
+[94m    --emitDecoratorMetadata  [39mEmit design-type metadata for decorated declarations in source files.
+                      type:  boolean
+                   default:  false
+
+[94m   --experimentalDecorators  [39mEnable experimental support for legacy experimental decorators.
+                      type:  boolean
+                   default:  false
+
+[94m                      --jsx  [39mSpecify what JSX code is generated.
+                    one of:  preserve, react-native, react, react-jsx, react-jsxdev
+                   default:  undefined
+
+[94m               --jsxFactory  [39mSpecify the JSX factory function used when targeting React JSX emit, e.g. 'React.createElem                             ent' or 'h'.
+                      type:  string
+                   default:  `React.createElement`
+
+[94m       --jsxFragmentFactory  [39mSpecify the JSX Fragment reference used for fragments when targeting React JSX emit e.g. 'R                             eact.Fragment' or 'Fragment'.
+                      type:  string
+                   default:  React.Fragment
+
+[94m          --jsxImportSource  [39mSpecify module specifier used to import the JSX factory functions when using 'jsx: react-js                             x*'.
+                      type:  string
+                   default:  react
+
+[94m                      --lib  [39mSpecify a set of bundled library declaration files that describe the target runtime environ                             ment.
+               one or more:  es5, es6/es2015, es7/es2016, es2017, es2018, es2019, es2020, es2021, es2022, es2023, esnext                             , dom, dom.iterable, dom.asynciterable, webworker, webworker.importscripts, webworker.itera                             ble, webworker.asynciterable, scripthost, es2015.core, es2015.collection, es2015.generator,                              es2015.iterable, es2015.promise, es2015.proxy, es2015.reflect, es2015.symbol, es2015.symbo                             l.wellknown, es2016.array.include, es2016.intl, es2017.date, es2017.object, es2017.sharedme                             mory, es2017.string, es2017.intl, es2017.typedarrays, es2018.asyncgenerator, es2018.asyncit                             erable/esnext.asynciterable, es2018.intl, es2018.promise, es2018.regexp, es2019.array, es20                             19.object, es2019.string, es2019.symbol/esnext.symbol, es2019.intl, es2020.bigint/esnext.bi                             gint, es2020.date, es2020.promise, es2020.sharedmemory, es2020.string, es2020.symbol.wellkn                             own, es2020.intl, es2020.number, es2021.promise, es2021.string, es2021.weakref/esnext.weakr                             ef, es2021.intl, es2022.array, es2022.error, es2022.intl, es2022.object, es2022.sharedmemor                             y, es2022.string, es2022.regexp, es2023.array, es2023.collection, es2023.intl, esnext.array                             , esnext.collection, esnext.intl, esnext.disposable, esnext.string, esnext.promise, esnext.                             decorators, esnext.object, esnext.regexp, esnext.iterator, decorators, decorators.legacy
+                   default:  undefined
+
+[94m          --moduleDetection  [39mControl what method is used to detect module-format JS files.
+                    one of:  auto, legacy, force
+                   default:  "auto": Treat files with imports, exports, import.meta, jsx (with jsx: react-jsx), or esm f                             ormat (with module: node16+) as modules.
+
+[94m                    --noLib  [39mDisable including any library files, including the default lib.d.ts.
+                      type:  boolean
+                   default:  false
+
+[94m           --reactNamespace  [39mSpecify the object invoked for 'createElement'. This only applies when targeting 'react' JS                             X emit.
+                      type:  string
+                   default:  `React`
+
+[94m               --target, -t  [39mSet the JavaScript language version for emitted JavaScript and include compatible library d                             eclarations.
+                    one of:  es5, es6/es2015, es2016, es2017, es2018, es2019, es2020, es2021, es2022, es2023, esnext
+                   default:  es5
+
+[94m  --useDefineForClassFields  [39mEmit ECMAScript-standard-compliant class fields.
+                      type:  boolean
+                   default:  `true` for ES2022 and above, including ESNext.
+
+### Output Formatting
+
+[94m    --noErrorTruncation  [39mDisable truncating types in error messages.
+                  type:  boolean
+               default:  false
+
+[94m  --preserveWatchOutput  [39mDisable wiping the console in watch mode.
+                  type:  boolean
+               default:  false
+
+[94m               --pretty  [39mEnable color and formatting in TypeScript's output to make compiler errors easier to read.
+                  type:  boolean
+               default:  true
+
+### Completeness
+
+[94m  --skipDefaultLibCheck  [39mSkip type checking .d.ts files that are included with TypeScript.
+                  type:  boolean
+               default:  false
+
+[94m         --skipLibCheck  [39mSkip type checking all .d.ts files.
+                  type:  boolean
+               default:  false
+
+You can learn about all of the compiler options at https://aka.ms/tsc
+
+[1mWATCH OPTIONS[22m
+
+Including --watch, -w will start watching the current project for the file changes. Once set, you can config watch mode with:
+
+
+                        type:  number
+                     default:  undefined
+
+[94m                  --watchFile  [39mSpecify how the TypeScript watch mode works.
+                      one of:  fixedpollinginterval, prioritypollinginterval, dynamicprioritypolling, fixedchunksizepoll                               ing, usefsevents, usefseventsonparentdirectory
+                     default:  usefsevents
+
+[94m             --watchDirectory  [39mSpecify how directories are watched on systems that lack recursive file-watching function                               ality.
+                      one of:  usefsevents, fixedpollinginterval, dynamicprioritypolling, fixedchunksizepolling
+                     default:  usefsevents
+
+[94m            --fallbackPolling  [39mSpecify what approach the watcher should use if the system runs out of native file watche                               rs.
+                      one of:  fixedinterval, priorityinterval, dynamicpriority, fixedchunksize
+                     default:  priorityinterval
+
+[94m  --synchronousWatchDirectory  [39mSynchronously call callbacks and update the state of directory watchers on platforms that                                don`t support recursive watching natively.
+                        type:  boolean
+                     default:  false
+
+[94m         --excludeDirectories  [39mRemove a list of directories from the watch process.
+
+[94m               --excludeFiles  [39mRemove a list of files from the watch mode's processing.
+
+[1mBUILD OPTIONS[22m
+
+Using --build, -b will make tsc behave more like a build orchestrator than a compiler. This is used to trigger building composite projects which you can learn more about at https://aka.ms/tsc-composite-builds
+
+[94m        --verbose, -v  [39mEnable verbose logging.
+
+[94m            --dry, -d  [39mShow what would be built (or deleted, if specified with '--clean')
+
+[94m          --force, -f  [39mBuild all projects, including those that appear to be up to date.
+
+[94m              --clean  [39mDelete the outputs of all projects.
+
+[94m  --stopBuildOnErrors  [39mSkip building downstream projects on error in upstream project.
+
+
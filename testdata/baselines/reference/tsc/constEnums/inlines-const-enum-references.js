@@ -0,0 +1,177 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/enums.ts] new file
+export const enum Direction {
+	Up = 1,
+	Down,
+	/** @internal */
+	Sideways,
+}
+//// [/home/src/workspaces/project/main.ts] new file
+import { Direction } from "./enums";
+export const up: number = Direction.Up;
+export const down: number = Direction["Down"];
+console.log(Direction.Up.toString());
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{
+	"compilerOptions": { "declaration": true, "isolatedDeclarations": true, "outDir": "dist" }
+}
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "allowJs": null,
+    "allowArbitraryExtensions": null,
+    "allowSyntheticDefaultImports": null,
+    "allowImportingTsExtensions": null,
+    "allowNonTsExtensions": null,
+    "allowUmdGlobalAccess": null,
+    "allowUnreachableCode": null,
+    "allowUnusedLabels": null,
+    "assumeChangesOnlyAffectDirectDependencies": null,
+    "alwaysStrict": null,
+    "baseUrl": "",
+    "build": null,
+    "checkJs": null,
+    "customConditions": null,
+    "composite": null,
+    "emitDeclarationOnly": null,
+    "emitBOM": null,
+    "emitDecoratorMetadata": null,
+    "downlevelIteration": null,
+    "declaration": null,
+    "declarationDir": "",
+    "declarationMap": null,
+    "disableSizeLimit": null,
+    "disableSourceOfProjectReferenceRedirect": null,
+    "disableSolutionSearching": null,
+    "disableReferencedProjectLoad": null,
+    "esModuleInterop": null,
+    "exactOptionalPropertyTypes": null,
+    "experimentalDecorators": null,
+    "forceConsistentCasingInFileNames": null,
+    "isolatedModules": null,
+    "isolatedDeclarations": null,
+    "ignoreDeprecations": "",
+    "importHelpers": null,
+    "inlineSourceMap": null,
+    "inlineSources": null,
+    "init": null,
+    "incremental": null,
+    "jsx": 0,
+    "jsxFactory": "",
+    "jsxFragmentFactory": "",
+    "jsxImportSource": "",
+    "keyofStringsOnly": null,
+    "lib": null,
+    "locale": "",
+    "mapRoot": "",
+    "module": 0,
+    "moduleResolution": 0,
+    "moduleSuffixes": null,
+    "moduleDetectionKind": 0,
+    "newLine": 0,
+    "noEmit": null,
+    "noCheck": null,
+    "noErrorTruncation": null,
+    "noFallthroughCasesInSwitch": null,
+    "noImplicitAny": null,
+    "noImplicitThis": null,
+    "noImplicitReturns": null,
+    "noEmitHelpers": null,
+    "noLib": null,
+    "noPropertyAccessFromIndexSignature": null,
+    "noUncheckedIndexedAccess": null,
+    "noEmitOnError": null,
+    "noUnusedLocals": null,
+    "noUnusedParameters": null,
+    "noResolve": null,
+    "noImplicitOverride": null,
+    "noUncheckedSideEffectImports": null,
+    "out": "",
+    "outDir": "",
+    "outFile": "",
+    "paths": null,
+    "preserveConstEnums": null,
+    "preserveSymlinks": null,
+    "project": "",
+    "resolveJsonModule": null,
+    "resolvePackageJsonExports": null,
+    "resolvePackageJsonImports": null,
+    "removeComments": null,
+    "rewriteRelativeImportExtensions": null,
+    "reactNamespace": "",
+    "rootDir": "",
+    "rootDirs": null,
+    "skipLibCheck": null,
+    "strict": null,
+    "strictBindCallApply": null,
+    "strictBuiltinIteratorReturn": null,
+    "strictFunctionTypes": null,
+    "strictNullChecks": null,
+    "strictPropertyInitialization": null,
+    "stripInternal": null,
+    "skipDefaultLibCheck": null,
+    "sourceMap": null,
+    "sourceRoot": "",
+    "suppressOutputPathCheck": null,
+    "target": 0,
+    "traceResolution": null,
+    "tsBuildInfoFile": "",
+    "typeRoots": null,
+    "types": null,
+    "useDefineForClassFields": null,
+    "useUnknownInCatchVariables": null,
+    "verbatimModuleSyntax": null,
+    "maxNodeModuleJsDepth": null,
+    "configFilePath": "",
+    "noDtsResolution": null,
+    "pathsBasePath": "",
+    "diagnostics": null,
+    "extendedDiagnostics": null,
+    "generateCpuProfile": "",
+    "generateTrace": "",
+    "listEmittedFiles": null,
+    "listFiles": null,
+    "explainFiles": null,
+    "listFilesOnly": null,
+    "noEmitForJsFiles": null,
+    "preserveWatchOutput": null,
+    "pretty": null,
+    "help": null,
+    "all": null,
+    "version": null,
+    "watch": null,
+    "showConfig": null,
+    "tscBuild": null
+}
+Output::
+//// [/home/src/workspaces/project/dist/enums.d.ts] new file
+export declare const enum Direction {
+    Up = 1,
+    Down = 2,
+    Sideways = 3
+}
+
+//// [/home/src/workspaces/project/dist/enums.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+
+//// [/home/src/workspaces/project/dist/main.d.ts] new file
+export declare const up: number;
+export declare const down: number;
+
+//// [/home/src/workspaces/project/dist/main.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.down = exports.up = void 0;
+exports.up = 1 /* Direction.Up */;
+exports.down = 2 /* Direction["Down"] */;
+console.log(1 /* Direction.Up */.toString());
+
+//// [/home/src/workspaces/project/enums.ts] no change
+//// [/home/src/workspaces/project/main.ts] no change
+//// [/home/src/workspaces/project/tsconfig.json] no change
+
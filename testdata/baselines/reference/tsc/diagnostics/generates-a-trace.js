@@ -0,0 +1,279 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--generateTrace trace
+//// [/home/src/workspaces/project/src/globals.d.ts] new file
+interface Array<T> { length: number; }
+interface Boolean {}
+interface Function {}
+interface CallableFunction {}
+interface NewableFunction {}
+interface IArguments {}
+interface Number {}
+interface Object {}
+interface RegExp {}
+interface String {}
+//// [/home/src/workspaces/project/src/main.ts] new file
+export type Pair<T> = [T, T]; export const pair: Pair<string | number> = ["a", 1];
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{
+	"compilerOptions": { "noLib": true, "types": [], "outDir": "dist" },
+	"files": ["src/globals.d.ts", "src/main.ts"]
+}
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "allowJs": null,
+    "allowArbitraryExtensions": null,
+    "allowSyntheticDefaultImports": null,
+    "allowImportingTsExtensions": null,
+    "allowNonTsExtensions": null,
+    "allowUmdGlobalAccess": null,
+    "allowUnreachableCode": null,
+    "allowUnusedLabels": null,
+    "assumeChangesOnlyAffectDirectDependencies": null,
+    "alwaysStrict": null,
+    "baseUrl": "",
+    "build": null,
+    "checkJs": null,
+    "customConditions": null,
+    "composite": null,
+    "emitDeclarationOnly": null,
+    "emitBOM": null,
+    "emitDecoratorMetadata": null,
+    "downlevelIteration": null,
+    "declaration": null,
+    "declarationDir": "",
+    "declarationMap": null,
+    "disableSizeLimit": null,
+    "disableSourceOfProjectReferenceRedirect": null,
+    "disableSolutionSearching": null,
+    "disableReferencedProjectLoad": null,
+    "esModuleInterop": null,
+    "exactOptionalPropertyTypes": null,
+    "experimentalDecorators": null,
+    "forceConsistentCasingInFileNames": null,
+    "isolatedModules": null,
+    "isolatedDeclarations": null,
+    "ignoreDeprecations": "",
+    "importHelpers": null,
+    "inlineSourceMap": null,
+    "inlineSources": null,
+    "init": null,
+    "incremental": null,
+    "jsx": 0,
+    "jsxFactory": "",
+    "jsxFragmentFactory": "",
+    "jsxImportSource": "",
+    "keyofStringsOnly": null,
+    "lib": null,
+    "locale": "",
+    "mapRoot": "",
+    "module": 0,
+    "moduleResolution": 0,
+    "moduleSuffixes": null,
+    "moduleDetectionKind": 0,
+    "newLine": 0,
+    "noEmit": null,
+    "noCheck": null,
+    "noErrorTruncation": null,
+    "noFallthroughCasesInSwitch": null,
+    "noImplicitAny": null,
+    "noImplicitThis": null,
+    "noImplicitReturns": null,
+    "noEmitHelpers": null,
+    "noLib": null,
+    "noPropertyAccessFromIndexSignature": null,
+    "noUncheckedIndexedAccess": null,
+    "noEmitOnError": null,
+    "noUnusedLocals": null,
+    "noUnusedParameters": null,
+    "noResolve": null,
+    "noImplicitOverride": null,
+    "noUncheckedSideEffectImports": null,
+    "out": "",
+    "outDir": "",
+    "outFile": "",
+    "paths": null,
+    "preserveConstEnums": null,
+    "preserveSymlinks": null,
+    "project": "",
+    "resolveJsonModule": null,
+    "resolvePackageJsonExports": null,
+    "resolvePackageJsonImports": null,
+    "removeComments": null,
+    "rewriteRelativeImportExtensions": null,
+    "reactNamespace": "",
+    "rootDir": "",
+    "rootDirs": null,
+    "skipLibCheck": null,
+    "strict": null,
+    "strictBindCallApply": null,
+    "strictBuiltinIteratorReturn": null,
+    "strictFunctionTypes": null,
+    "strictNullChecks": null,
+    "strictPropertyInitialization": null,
+    "stripInternal": null,
+    "skipDefaultLibCheck": null,
+    "sourceMap": null,
+    "sourceRoot": "",
+    "suppressOutputPathCheck": null,
+    "target": 0,
+    "traceResolution": null,
+    "tsBuildInfoFile": "",
+    "typeRoots": null,
+    "types": null,
+    "useDefineForClassFields": null,
+    "useUnknownInCatchVariables": null,
+    "verbatimModuleSyntax": null,
+    "maxNodeModuleJsDepth": null,
+    "configFilePath": "",
+    "noDtsResolution": null,
+    "pathsBasePath": "",
+    "diagnostics": null,
+    "extendedDiagnostics": null,
+    "generateCpuProfile": "",
+    "generateTrace": "/home/src/workspaces/project/trace",
+    "listEmittedFiles": null,
+    "listFiles": null,
+    "explainFiles": null,
+    "listFilesOnly": null,
+    "noEmitForJsFiles": null,
+    "preserveWatchOutput": null,
+    "pretty": null,
+    "help": null,
+    "all": null,
+    "version": null,
+    "watch": null,
+    "showConfig": null,
+    "tscBuild": null
+}
+Output::
+//// [/home/src/workspaces/project/dist/main.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.pair = void 0;
+exports.pair = ["a", 1];
+
+//// [/home/src/workspaces/project/src/globals.d.ts] no change
+//// [/home/src/workspaces/project/src/main.ts] no change
+//// [/home/src/workspaces/project/trace/trace.json] new file
+[
+{"pid":1,"tid":1,"ph":"M","cat":"__metadata","ts":0,"name":"process_name","args":{"name":"tsc"}},
+{"pid":1,"tid":1,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Main"}},
+{"pid":1,"tid":1,"ph":"M","cat":"disabled-by-default-devtools.timeline","ts":0,"name":"TracingStartedInBrowser"},
+{"pid":1,"tid":1,"ph":"X","cat":"parse","ts":0,"name":"createSourceFile","args":{"path":"/home/src/workspaces/project/src/globals.d.ts"}},
+{"pid":1,"tid":1,"ph":"X","cat":"parse","ts":0,"name":"createSourceFile","args":{"path":"/home/src/workspaces/project/src/main.ts"}},
+{"pid":1,"tid":1,"ph":"X","cat":"program","ts":0,"name":"createProgram","args":{"configFilePath":"/home/src/workspaces/project/tsconfig.json","rootDir":""}},
+{"pid":1,"tid":1,"ph":"X","cat":"bind","ts":0,"name":"bindSourceFile","args":{"path":"/home/src/workspaces/project/src/globals.d.ts"}},
+{"pid":1,"tid":1,"ph":"X","cat":"bind","ts":0,"name":"bindSourceFile","args":{"path":"/home/src/workspaces/project/src/main.ts"}},
+{"pid":1,"tid":1,"ph":"X","cat":"bind","ts":0,"name":"bindSourceFiles"},
+{"pid":1,"tid":1,"ph":"X","cat":"check","ts":0,"name":"checkSourceFile","args":{"path":"/home/src/workspaces/project/src/globals.d.ts"}},
+{"pid":1,"tid":1,"ph":"X","cat":"check","ts":0,"name":"checkSourceFile","args":{"path":"/home/src/workspaces/project/src/main.ts"}},
+{"pid":1,"tid":1,"ph":"X","cat":"check","ts":0,"name":"checkSourceFiles"},
+{"pid":1,"tid":1,"ph":"X","cat":"emit","ts":0,"name":"emitJsFileOrBundle","args":{"jsFilePath":"/home/src/workspaces/project/dist/main.js"}},
+{"pid":1,"tid":1,"ph":"X","cat":"emit","ts":0,"name":"emit"}]
+
+//// [/home/src/workspaces/project/trace/types.json] new file
+[
+{"id":1,"intrinsicName":"any","flags":["Any"]},
+{"id":2,"intrinsicName":"any","flags":["Any"]},
+{"id":3,"intrinsicName":"any","flags":["Any"]},
+{"id":4,"intrinsicName":"any","flags":["Any"]},
+{"id":5,"intrinsicName":"error","flags":["Any"]},
+{"id":6,"intrinsicName":"unresolved","flags":["Any"]},
+{"id":7,"intrinsicName":"any","flags":["Any"]},
+{"id":8,"intrinsicName":"intrinsic","flags":["Any"]},
+{"id":9,"intrinsicName":"unknown","flags":["Unknown"]},
+{"id":10,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":11,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":12,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":13,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":14,"intrinsicName":"null","flags":["Null"]},
+{"id":15,"intrinsicName":"null","flags":["Null"]},
+{"id":16,"intrinsicName":"string","flags":["String"]},
+{"id":17,"intrinsicName":"number","flags":["Number"]},
+{"id":18,"intrinsicName":"bigint","flags":["BigInt"]},
+{"id":19,"flags":["BooleanLiteral"]},
+{"id":20,"flags":["BooleanLiteral"]},
+{"id":21,"flags":["BooleanLiteral"]},
+{"id":22,"flags":["BooleanLiteral"]},
+{"id":23,"unionTypes":[19,21],"flags":["Boolean","Union"]},
+{"id":24,"intrinsicName":"symbol","flags":["ESSymbol"]},
+{"id":25,"intrinsicName":"void","flags":["Void"]},
+{"id":26,"intrinsicName":"never","flags":["Never"]},
+{"id":27,"intrinsicName":"never","flags":["Never"]},
+{"id":28,"intrinsicName":"never","flags":["Never"]},
+{"id":29,"intrinsicName":"never","flags":["Never"]},
+{"id":30,"intrinsicName":"object","flags":["NonPrimitive"]},
+{"id":31,"unionTypes":[16,17],"flags":["Union"]},
+{"id":32,"unionTypes":[16,17,24],"flags":["Union"]},
+{"id":33,"unionTypes":[17,18],"flags":["Union"]},
+{"id":34,"flags":["TemplateLiteral"]},
+{"id":35,"unionTypes":[16,17,18,19,21],"flags":["Union"]},
+{"id":36,"intrinsicName":"never","flags":["Never"]},
+{"id":37,"flags":["Object"]},
+{"id":38,"symbolName":"__type","flags":["Object"]},
+{"id":39,"flags":["Object"]},
+{"id":40,"flags":["Object"]},
+{"id":41,"flags":["Object"]},
+{"id":42,"flags":["Object"]},
+{"id":43,"flags":["Object"]},
+{"id":44,"flags":["Object"]},
+{"id":45,"flags":["TypeParameter"]},
+{"id":46,"flags":["TypeParameter"]},
+{"id":47,"flags":["TypeParameter"]},
+{"id":48,"flags":["TypeParameter"]},
+{"id":49,"flags":["TypeParameter"]},
+{"id":50,"flags":["StringLiteral"]},
+{"id":51,"flags":["NumberLiteral"]},
+{"id":52,"flags":["BigIntLiteral"]},
+{"id":53,"flags":["StringLiteral"]},
+{"id":54,"flags":["StringLiteral"]},
+{"id":55,"flags":["StringLiteral"]},
+{"id":56,"flags":["StringLiteral"]},
+{"id":57,"flags":["StringLiteral"]},
+{"id":58,"flags":["StringLiteral"]},
+{"id":59,"flags":["StringLiteral"]},
+{"id":60,"flags":["StringLiteral"]},
+{"id":61,"unionTypes":[53,54,55,56,57,58,59,60],"flags":["Union"]},
+{"id":62,"symbolName":"IArguments","firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":5,"character":0},"end":{"line":5,"character":23}},"flags":["Object"]},
+{"id":63,"symbolName":"globalThis","flags":["Object"]},
+{"id":64,"symbolName":"Array","instantiatedType":64,"typeArguments":[65],"firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":0,"character":0},"end":{"line":0,"character":38}},"flags":["Object"]},
+{"id":65,"symbolName":"T","firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":0,"character":16},"end":{"line":0,"character":17}},"flags":["TypeParameter"]},
+{"id":66,"symbolName":"Array","firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":0,"character":0},"end":{"line":0,"character":38}},"flags":["TypeParameter"]},
+{"id":67,"symbolName":"Object","firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":7,"character":0},"end":{"line":7,"character":19}},"flags":["Object"]},
+{"id":68,"symbolName":"Function","firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":2,"character":0},"end":{"line":2,"character":21}},"flags":["Object"]},
+{"id":69,"symbolName":"String","firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":9,"character":0},"end":{"line":9,"character":19}},"flags":["Object"]},
+{"id":70,"symbolName":"Number","firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":6,"character":0},"end":{"line":6,"character":19}},"flags":["Object"]},
+{"id":71,"symbolName":"Boolean","firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":1,"character":0},"end":{"line":1,"character":20}},"flags":["Object"]},
+{"id":72,"symbolName":"RegExp","firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":8,"character":0},"end":{"line":8,"character":19}},"flags":["Object"]},
+{"id":73,"symbolName":"Array","instantiatedType":64,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":0,"character":0},"end":{"line":0,"character":38}},"flags":["Object"]},
+{"id":74,"symbolName":"Array","instantiatedType":64,"typeArguments":[2],"firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":0,"character":0},"end":{"line":0,"character":38}},"flags":["Object"]},
+{"id":75,"symbolName":"Array","instantiatedType":64,"typeArguments":[65,66],"firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":0,"character":0},"end":{"line":0,"character":38}},"flags":["Object"]},
+{"id":76,"symbolName":"CallableFunction","firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":3,"character":0},"end":{"line":3,"character":29}},"flags":["Object"]},
+{"id":77,"symbolName":"NewableFunction","firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":4,"character":0},"end":{"line":4,"character":28}},"flags":["Object"]},
+{"id":78,"symbolName":"T","firstDeclaration":{"path":"/home/src/workspaces/project/src/main.ts","start":{"line":0,"character":17},"end":{"line":0,"character":18}},"flags":["TypeParameter"]},
+{"id":79,"flags":["TypeParameter"]},
+{"id":80,"flags":["TypeParameter"]},
+{"id":81,"flags":["NumberLiteral"]},
+{"id":82,"isTuple":true,"instantiatedType":82,"typeArguments":[79,80],"flags":["Object"]},
+{"id":83,"flags":["TypeParameter"]},
+{"id":84,"symbolName":"Pair","isTuple":true,"aliasTypeArguments":[78],"instantiatedType":82,"flags":["Object"]},
+{"id":85,"symbolName":"Pair","isTuple":true,"aliasTypeArguments":[31],"instantiatedType":82,"typeArguments":[31,31],"flags":["Object"]},
+{"id":86,"flags":["StringLiteral"]},
+{"id":87,"flags":["StringLiteral"]},
+{"id":88,"flags":["NumberLiteral"]},
+{"id":89,"flags":["NumberLiteral"]},
+{"id":90,"isTuple":true,"instantiatedType":82,"typeArguments":[16,17],"flags":["Object"]},
+{"id":91,"isTuple":true,"instantiatedType":82,"typeArguments":[16,17],"flags":["Object"]},
+{"id":92,"isTuple":true,"instantiatedType":82,"typeArguments":[31,31],"flags":["Object"]},
+{"id":93,"unionTypes":[79,80],"flags":["Union"]},
+{"id":94,"symbolName":"Array","instantiatedType":64,"typeArguments":[93],"firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":0,"character":0},"end":{"line":0,"character":38}},"flags":["Object"]},
+{"id":95,"symbolName":"Array","instantiatedType":64,"typeArguments":[31],"firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":0,"character":0},"end":{"line":0,"character":38}},"flags":["Object"]},
+{"id":96,"symbolName":"Array","instantiatedType":64,"typeArguments":[31,92],"firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":0,"character":0},"end":{"line":0,"character":38}},"flags":["Object"]},
+{"id":97,"symbolName":"Array","instantiatedType":64,"typeArguments":[31,91],"firstDeclaration":{"path":"/home/src/workspaces/project/src/globals.d.ts","start":{"line":0,"character":0},"end":{"line":0,"character":38}},"flags":["Object"]}]
+
+//// [/home/src/workspaces/project/tsconfig.json] no change
+
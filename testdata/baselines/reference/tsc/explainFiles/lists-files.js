@@ -0,0 +1,229 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--listFiles
+//// [/home/src/workspaces/project/node_modules/@types/helpers/index.d.ts] new file
+declare function help(): void;
+//// [/home/src/workspaces/project/node_modules/pad/index.d.ts] new file
+export declare const pad: number;
+//// [/home/src/workspaces/project/node_modules/pad/package.json] new file
+{ "name": "pad", "version": "1.0.0", "types": "index.d.ts" }
+//// [/home/src/workspaces/project/src/globals.d.ts] new file
+declare const version: string;
+//// [/home/src/workspaces/project/src/main.ts] new file
+/// <reference path="./globals.d.ts" />
+/// <reference types="helpers" />
+/// <reference lib="dom" />
+import { util } from "./util";
+import { pad } from "pad";
+export const x = util + pad;
+//// [/home/src/workspaces/project/src/util.ts] new file
+export const util = 1;
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{
+	"compilerOptions": { "lib": ["es2020"], "outDir": "dist" },
+	"files": ["src/main.ts"],
+	"include": ["src/**/*.ts"]
+}
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "allowJs": null,
+    "allowArbitraryExtensions": null,
+    "allowSyntheticDefaultImports": null,
+    "allowImportingTsExtensions": null,
+    "allowNonTsExtensions": null,
+    "allowUmdGlobalAccess": null,
+    "allowUnreachableCode": null,
+    "allowUnusedLabels": null,
+    "assumeChangesOnlyAffectDirectDependencies": null,
+    "alwaysStrict": null,
+    "baseUrl": "",
+    "build": null,
+    "checkJs": null,
+    "customConditions": null,
+    "composite": null,
+    "emitDeclarationOnly": null,
+    "emitBOM": null,
+    "emitDecoratorMetadata": null,
+    "downlevelIteration": null,
+    "declaration": null,
+    "declarationDir": "",
+    "declarationMap": null,
+    "disableSizeLimit": null,
+    "disableSourceOfProjectReferenceRedirect": null,
+    "disableSolutionSearching": null,
+    "disableReferencedProjectLoad": null,
+    "esModuleInterop": null,
+    "exactOptionalPropertyTypes": null,
+    "experimentalDecorators": null,
+    "forceConsistentCasingInFileNames": null,
+    "isolatedModules": null,
+    "isolatedDeclarations": null,
+    "ignoreDeprecations": "",
+    "importHelpers": null,
+    "inlineSourceMap": null,
+    "inlineSources": null,
+    "init": null,
+    "incremental": null,
+    "jsx": 0,
+    "jsxFactory": "",
+    "jsxFragmentFactory": "",
+    "jsxImportSource": "",
+    "keyofStringsOnly": null,
+    "lib": null,
+    "locale": "",
+    "mapRoot": "",
+    "module": 0,
+    "moduleResolution": 0,
+    "moduleSuffixes": null,
+    "moduleDetectionKind": 0,
+    "newLine": 0,
+    "noEmit": null,
+    "noCheck": null,
+    "noErrorTruncation": null,
+    "noFallthroughCasesInSwitch": null,
+    "noImplicitAny": null,
+    "noImplicitThis": null,
+    "noImplicitReturns": null,
+    "noEmitHelpers": null,
+    "noLib": null,
+    "noPropertyAccessFromIndexSignature": null,
+    "noUncheckedIndexedAccess": null,
+    "noEmitOnError": null,
+    "noUnusedLocals": null,
+    "noUnusedParameters": null,
+    "noResolve": null,
+    "noImplicitOverride": null,
+    "noUncheckedSideEffectImports": null,
+    "out": "",
+    "outDir": "",
+    "outFile": "",
+    "paths": null,
+    "preserveConstEnums": null,
+    "preserveSymlinks": null,
+    "project": "",
+    "resolveJsonModule": null,
+    "resolvePackageJsonExports": null,
+    "resolvePackageJsonImports": null,
+    "removeComments": null,
+    "rewriteRelativeImportExtensions": null,
+    "reactNamespace": "",
+    "rootDir": "",
+    "rootDirs": null,
+    "skipLibCheck": null,
+    "strict": null,
+    "strictBindCallApply": null,
+    "strictBuiltinIteratorReturn": null,
+    "strictFunctionTypes": null,
+    "strictNullChecks": null,
+    "strictPropertyInitialization": null,
+    "stripInternal": null,
+    "skipDefaultLibCheck": null,
+    "sourceMap": null,
+    "sourceRoot": "",
+    "suppressOutputPathCheck": null,
+    "target": 0,
+    "traceResolution": null,
+    "tsBuildInfoFile": "",
+    "typeRoots": null,
+    "types": null,
+    "useDefineForClassFields": null,
+    "useUnknownInCatchVariables": null,
+    "verbatimModuleSyntax": null,
+    "maxNodeModuleJsDepth": null,
+    "configFilePath": "",
+    "noDtsResolution": null,
+    "pathsBasePath": "",
+    "diagnostics": null,
+    "extendedDiagnostics": null,
+    "generateCpuProfile": "",
+    "generateTrace": "",
+    "listEmittedFiles": null,
+    "listFiles": true,
+    "explainFiles": null,
+    "listFilesOnly": null,
+    "noEmitForJsFiles": null,
+    "preserveWatchOutput": null,
+    "pretty": null,
+    "help": null,
+    "all": null,
+    "version": null,
+    "watch": null,
+    "showConfig": null,
+    "tscBuild": null
+}
+Output::
+bundled:///libs/lib.es5.d.ts
+bundled:///libs/lib.es2015.d.ts
+bundled:///libs/lib.es2016.d.ts
+bundled:///libs/lib.es2017.d.ts
+bundled:///libs/lib.es2018.d.ts
+bundled:///libs/lib.es2019.d.ts
+bundled:///libs/lib.es2020.d.ts
+bundled:///libs/lib.dom.d.ts
+bundled:///libs/lib.es2015.core.d.ts
+bundled:///libs/lib.es2015.collection.d.ts
+bundled:///libs/lib.es2015.generator.d.ts
+bundled:///libs/lib.es2015.iterable.d.ts
+bundled:///libs/lib.es2015.promise.d.ts
+bundled:///libs/lib.es2015.proxy.d.ts
+bundled:///libs/lib.es2015.reflect.d.ts
+bundled:///libs/lib.es2015.symbol.d.ts
+bundled:///libs/lib.es2015.symbol.wellknown.d.ts
+bundled:///libs/lib.es2016.array.include.d.ts
+bundled:///libs/lib.es2016.intl.d.ts
+bundled:///libs/lib.es2017.date.d.ts
+bundled:///libs/lib.es2017.object.d.ts
+bundled:///libs/lib.es2017.sharedmemory.d.ts
+bundled:///libs/lib.es2017.string.d.ts
+bundled:///libs/lib.es2017.intl.d.ts
+bundled:///libs/lib.es2017.typedarrays.d.ts
+bundled:///libs/lib.es2018.asyncgenerator.d.ts
+bundled:///libs/lib.es2018.asynciterable.d.ts
+bundled:///libs/lib.es2018.intl.d.ts
+bundled:///libs/lib.es2018.promise.d.ts
+bundled:///libs/lib.es2018.regexp.d.ts
+bundled:///libs/lib.es2019.array.d.ts
+bundled:///libs/lib.es2019.object.d.ts
+bundled:///libs/lib.es2019.string.d.ts
+bundled:///libs/lib.es2019.symbol.d.ts
+bundled:///libs/lib.es2019.intl.d.ts
+bundled:///libs/lib.es2020.bigint.d.ts
+bundled:///libs/lib.es2020.date.d.ts
+bundled:///libs/lib.es2020.promise.d.ts
+bundled:///libs/lib.es2020.sharedmemory.d.ts
+bundled:///libs/lib.es2020.string.d.ts
+bundled:///libs/lib.es2020.symbol.wellknown.d.ts
+bundled:///libs/lib.es2020.intl.d.ts
+bundled:///libs/lib.es2020.number.d.ts
+bundled:///libs/lib.decorators.d.ts
+bundled:///libs/lib.decorators.legacy.d.ts
+/home/src/workspaces/project/src/globals.d.ts
+/home/src/workspaces/project/node_modules/@types/helpers/index.d.ts
+/home/src/workspaces/project/src/util.ts
+/home/src/workspaces/project/node_modules/pad/index.d.ts
+/home/src/workspaces/project/src/main.ts
+//// [/home/src/workspaces/project/dist/main.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.x = void 0;
+const util_1 = require("./util");
+const pad_1 = require("pad");
+exports.x = util_1.util + pad_1.pad;
+
+//// [/home/src/workspaces/project/dist/util.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.util = void 0;
+exports.util = 1;
+
+//// [/home/src/workspaces/project/node_modules/@types/helpers/index.d.ts] no change
+//// [/home/src/workspaces/project/node_modules/pad/index.d.ts] no change
+//// [/home/src/workspaces/project/node_modules/pad/package.json] no change
+//// [/home/src/workspaces/project/src/globals.d.ts] no change
+//// [/home/src/workspaces/project/src/main.ts] no change
+//// [/home/src/workspaces/project/src/util.ts] no change
+//// [/home/src/workspaces/project/tsconfig.json] no change
+
@@ -56,7 +56,7 @@ Input::--showConfig
 	export const x = 10;
 
 
-ExitStatus:: 5
+ExitStatus:: 0
 
 CompilerOptions::{
     "allowJs": null,
@@ -177,13 +177,46 @@ CompilerOptions::{
     "noEmitForJsFiles": null,
     "preserveWatchOutput": null,
     "pretty": null,
+    "help": null,
+    "all": null,
     "version": null,
     "watch": null,
     "showConfig": true,
     "tscBuild": null
 }
 Output::
-No output
+{
+    "compilerOptions": {
+        "baseUrl": "./",
+        "declaration": true,
+        "declarationDir": "./decls",
+        "outDir": "./outDir",
+        "paths": {
+            "@myscope/*": [
+                "/home/src/projects/myproject/types/*"
+            ],
+            "other/*": [
+                "other/*"
+            ]
+        },
+        "traceResolution": true,
+        "typeRoots": [
+            "../configs/first/root1",
+            "./root2",
+            "../configs/first/root3"
+        ]
+    },
+    "files": [
+        "./main.ts"
+    ],
+    "include": [
+        "/home/src/projects/myproject/src"
+    ],
+    "exclude": [
+        "/home/src/projects/myproject/outDir",
+        "/home/src/projects/myproject/decls"
+    ]
+}
 //// [/home/src/projects/configs/first/tsconfig.json] no change
 //// [/home/src/projects/configs/second/tsconfig.json] no change
 //// [/home/src/projects/myproject/main.ts] no change
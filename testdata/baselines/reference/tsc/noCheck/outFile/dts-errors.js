@@ -134,6 +134,8 @@ CompilerOptions::{
     "noEmitForJsFiles": null,
     "preserveWatchOutput": null,
     "pretty": null,
+    "help": null,
+    "all": null,
     "version": null,
     "watch": null,
     "showConfig": null,
@@ -0,0 +1,190 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--traceResolution
+//// [/home/src/workspaces/project/node_modules/pad/index.d.ts] new file
+export declare const pad: number;
+//// [/home/src/workspaces/project/node_modules/pad/package.json] new file
+{ "name": "pad", "version": "1.0.0", "exports": { ".": { "import": "./index.mjs", "types": "./index.d.ts" } } }
+//// [/home/src/workspaces/project/src/main.ts] new file
+import { util } from "./util.js"; import { pad } from "pad"; export const x = util + pad;
+//// [/home/src/workspaces/project/src/other.ts] new file
+import { util } from "./util.js"; export const y = util;
+//// [/home/src/workspaces/project/src/util.ts] new file
+export const util = 1;
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{
+	"compilerOptions": { "module": "nodenext", "noEmit": true, "types": [] },
+	"files": ["src/main.ts", "src/other.ts"]
+}
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "allowJs": null,
+    "allowArbitraryExtensions": null,
+    "allowSyntheticDefaultImports": null,
+    "allowImportingTsExtensions": null,
+    "allowNonTsExtensions": null,
+    "allowUmdGlobalAccess": null,
+    "allowUnreachableCode": null,
+    "allowUnusedLabels": null,
+    "assumeChangesOnlyAffectDirectDependencies": null,
+    "alwaysStrict": null,
+    "baseUrl": "",
+    "build": null,
+    "checkJs": null,
+    "customConditions": null,
+    "composite": null,
+    "emitDeclarationOnly": null,
+    "emitBOM": null,
+    "emitDecoratorMetadata": null,
+    "downlevelIteration": null,
+    "declaration": null,
+    "declarationDir": "",
+    "declarationMap": null,
+    "disableSizeLimit": null,
+    "disableSourceOfProjectReferenceRedirect": null,
+    "disableSolutionSearching": null,
+    "disableReferencedProjectLoad": null,
+    "esModuleInterop": null,
+    "exactOptionalPropertyTypes": null,
+    "experimentalDecorators": null,
+    "forceConsistentCasingInFileNames": null,
+    "isolatedModules": null,
+    "isolatedDeclarations": null,
+    "ignoreDeprecations": "",
+    "importHelpers": null,
+    "inlineSourceMap": null,
+    "inlineSources": null,
+    "init": null,
+    "incremental": null,
+    "jsx": 0,
+    "jsxFactory": "",
+    "jsxFragmentFactory": "",
+    "jsxImportSource": "",
+    "keyofStringsOnly": null,
+    "lib": null,
+    "locale": "",
+    "mapRoot": "",
+    "module": 0,
+    "moduleResolution": 0,
+    "moduleSuffixes": null,
+    "moduleDetectionKind": 0,
+    "newLine": 0,
+    "noEmit": null,
+    "noCheck": null,
+    "noErrorTruncation": null,
+    "noFallthroughCasesInSwitch": null,
+    "noImplicitAny": null,
+    "noImplicitThis": null,
+    "noImplicitReturns": null,
+    "noEmitHelpers": null,
+    "noLib": null,
+    "noPropertyAccessFromIndexSignature": null,
+    "noUncheckedIndexedAccess": null,
+    "noEmitOnError": null,
+    "noUnusedLocals": null,
+    "noUnusedParameters": null,
+    "noResolve": null,
+    "noImplicitOverride": null,
+    "noUncheckedSideEffectImports": null,
+    "out": "",
+    "outDir": "",
+    "outFile": "",
+    "paths": null,
+    "preserveConstEnums": null,
+    "preserveSymlinks": null,
+    "project": "",
+    "resolveJsonModule": null,
+    "resolvePackageJsonExports": null,
+    "resolvePackageJsonImports": null,
+    "removeComments": null,
+    "rewriteRelativeImportExtensions": null,
+    "reactNamespace": "",
+    "rootDir": "",
+    "rootDirs": null,
+    "skipLibCheck": null,
+    "strict": null,
+    "strictBindCallApply": null,
+    "strictBuiltinIteratorReturn": null,
+    "strictFunctionTypes": null,
+    "strictNullChecks": null,
+    "strictPropertyInitialization": null,
+    "stripInternal": null,
+    "skipDefaultLibCheck": null,
+    "sourceMap": null,
+    "sourceRoot": "",
+    "suppressOutputPathCheck": null,
+    "target": 0,
+    "traceResolution": true,
+    "tsBuildInfoFile": "",
+    "typeRoots": null,
+    "types": null,
+    "useDefineForClassFields": null,
+    "useUnknownInCatchVariables": null,
+    "verbatimModuleSyntax": null,
+    "maxNodeModuleJsDepth": null,
+    "configFilePath": "",
+    "noDtsResolution": null,
+    "pathsBasePath": "",
+    "diagnostics": null,
+    "extendedDiagnostics": null,
+    "generateCpuProfile": "",
+    "generateTrace": "",
+    "listEmittedFiles": null,
+    "listFiles": null,
+    "explainFiles": null,
+    "listFilesOnly": null,
+    "noEmitForJsFiles": null,
+    "preserveWatchOutput": null,
+    "pretty": null,
+    "help": null,
+    "all": null,
+    "version": null,
+    "watch": null,
+    "showConfig": null,
+    "tscBuild": null
+}
+Output::
+======== Resolving module './util.js' from '/home/src/workspaces/project/src/main.ts'. ========
+Module resolution kind is not specified, using 'NodeNext'.
+Resolving in CJS mode with conditions 'require', 'types', 'node'.
+Loading module as file / folder, candidate module location '/home/src/workspaces/project/src/util.js', target file types: TypeScript, JavaScript, Declaration.
+File name '/home/src/workspaces/project/src/util.js' has a '.js' extension - stripping it.
+File '/home/src/workspaces/project/src/util.ts' exists - use it as a name resolution result.
+======== Module name './util.js' was successfully resolved to '/home/src/workspaces/project/src/util.ts'. ========
+======== Resolving module 'pad' from '/home/src/workspaces/project/src/main.ts'. ========
+Module resolution kind is not specified, using 'NodeNext'.
+Resolving in CJS mode with conditions 'require', 'types', 'node'.
+File '/home/src/workspaces/project/src/package.json' does not exist.
+File '/home/src/workspaces/project/package.json' does not exist.
+File '/home/src/workspaces/package.json' does not exist.
+File '/home/src/package.json' does not exist.
+File '/home/package.json' does not exist.
+File '/package.json' does not exist.
+Loading module 'pad' from 'node_modules' folder, target file types: TypeScript, JavaScript, Declaration.
+Searching all ancestor node_modules directories for preferred extensions: TypeScript, Declaration.
+Directory '/home/src/workspaces/project/src/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/workspaces/project/src/node_modules/@types' does not exist, skipping all lookups in it.
+Found 'package.json' at '/home/src/workspaces/project/node_modules/pad/package.json'.
+Entering conditional exports.
+Saw non-matching condition 'import'.
+Matched 'exports' condition 'types'.
+Using 'exports' subpath '.' with target './index.d.ts'.
+File '/home/src/workspaces/project/node_modules/pad/index.d.ts' exists - use it as a name resolution result.
+'package.json' does not have a 'peerDependencies' field.
+Resolved under condition 'types'.
+Exiting conditional exports.
+Resolving real path for '/home/src/workspaces/project/node_modules/pad/index.d.ts', result '/home/src/workspaces/project/node_modules/pad/index.d.ts'.
+======== Module name 'pad' was successfully resolved to '/home/src/workspaces/project/node_modules/pad/index.d.ts' with Package ID 'pad@1.0.0'. ========
+======== Resolving module './util.js' from '/home/src/workspaces/project/src/other.ts'. ========
+Resolution for module './util.js' was found in cache from location '/home/src/workspaces/project/src'.
+======== Module name './util.js' was successfully resolved to '/home/src/workspaces/project/src/util.ts'. ========
+//// [/home/src/workspaces/project/node_modules/pad/index.d.ts] no change
+//// [/home/src/workspaces/project/node_modules/pad/package.json] no change
+//// [/home/src/workspaces/project/src/main.ts] no change
+//// [/home/src/workspaces/project/src/other.ts] no change
+//// [/home/src/workspaces/project/src/util.ts] no change
+//// [/home/src/workspaces/project/tsconfig.json] no change
+
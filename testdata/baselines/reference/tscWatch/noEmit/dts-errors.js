@@ -134,6 +134,8 @@ CompilerOptions::{
     "noEmitForJsFiles": null,
     "preserveWatchOutput": null,
     "pretty": null,
+    "help": null,
+    "all": null,
     "version": null,
     "watch": true,
     "showConfig": null,
@@ -142,6 +144,11 @@ CompilerOptions::{
 
 
 Output::
+[[90m12:00:00 AM[0m] Starting compilation in watch mode...
+
+
+[[90m12:00:00 AM[0m] Found 0 errors. Watching for file changes.
+
 //// [/home/src/workspaces/project/a.ts] no change
 //// [/home/src/workspaces/project/tsconfig.json] no change
 
@@ -150,6 +157,11 @@ Output::
 Edit:: fix syntax error
 
 Output::
+[[90m12:00:00 AM[0m] File change detected. Starting incremental compilation...
+
+
+[[90m12:00:00 AM[0m] Found 0 errors. Watching for file changes.
+
 //// [/home/src/workspaces/project/a.ts] modified. new content:
 const a = "hello";
 //// [/home/src/workspaces/project/tsconfig.json] no change
@@ -159,6 +171,11 @@ const a = "hello";
 Edit:: emit after fixing error
 
 Output::
+[[90m12:00:00 AM[0m] File change detected. Starting incremental compilation...
+
+
+[[90m12:00:00 AM[0m] Found 0 errors. Watching for file changes.
+
 //// [/home/src/workspaces/project/a.js] new file
 const a = "hello";
 
@@ -176,6 +193,11 @@ const a = "hello";
 Edit:: no emit run after fixing error
 
 Output::
+[[90m12:00:00 AM[0m] File change detected. Starting incremental compilation...
+
+
+[[90m12:00:00 AM[0m] Found 0 errors. Watching for file changes.
+
 //// [/home/src/workspaces/project/a.js] no change
 //// [/home/src/workspaces/project/a.ts] no change
 //// [/home/src/workspaces/project/tsconfig.json] modified. new content:
@@ -192,6 +214,11 @@ Output::
 Edit:: introduce error
 
 Output::
+[[90m12:00:01 AM[0m] File change detected. Starting incremental compilation...
+
+
+[[90m12:00:01 AM[0m] Found 0 errors. Watching for file changes.
+
 //// [/home/src/workspaces/project/a.js] no change
 //// [/home/src/workspaces/project/a.ts] modified. new content:
 const a = class { private p = 10; };
@@ -202,6 +229,11 @@ const a = class { private p = 10; };
 Edit:: emit when error
 
 Output::
+[[90m12:00:01 AM[0m] File change detected. Starting incremental compilation...
+
+
+[[90m12:00:01 AM[0m] Found 0 errors. Watching for file changes.
+
 //// [/home/src/workspaces/project/a.js] modified. new content:
 const a = class {
     p = 10;
@@ -221,6 +253,11 @@ const a = class {
 Edit:: no emit run when error
 
 Output::
+[[90m12:00:01 AM[0m] File change detected. Starting incremental compilation...
+
+
+[[90m12:00:01 AM[0m] Found 0 errors. Watching for file changes.
+
 //// [/home/src/workspaces/project/a.js] no change
 //// [/home/src/workspaces/project/a.ts] no change
 //// [/home/src/workspaces/project/tsconfig.json] modified. new content:
@@ -133,6 +133,8 @@ CompilerOptions::{
     "noEmitForJsFiles": null,
     "preserveWatchOutput": null,
     "pretty": null,
+    "help": null,
+    "all": null,
     "version": null,
     "watch": true,
     "showConfig": null,
@@ -141,10 +143,12 @@ CompilerOptions::{
 
 
 Output::
-a.ts(1,17): error TS1002: Unterminated string literal.
+[[90m12:00:00 AM[0m] Starting compilation in watch mode...
+
 
+a.ts(1,17): error TS1002: Unterminated string literal.
 
-Found 1 error in a.ts[90m:1[0m
+[[90m12:00:00 AM[0m] Found 1 error. Watching for file changes.
 
 //// [/home/src/workspaces/project/a.ts] no change
 //// [/home/src/workspaces/project/tsconfig.json] no change
@@ -154,6 +158,11 @@ Found 1 error in a.ts[90m:1[0m
 Edit:: fix syntax error
 
 Output::
+[[90m12:00:00 AM[0m] File change detected. Starting incremental compilation...
+
+
+[[90m12:00:00 AM[0m] Found 0 errors. Watching for file changes.
+
 //// [/home/src/workspaces/project/a.ts] modified. new content:
 const a = "hello";
 //// [/home/src/workspaces/project/tsconfig.json] no change
@@ -163,6 +172,11 @@ const a = "hello";
 Edit:: emit after fixing error
 
 Output::
+[[90m12:00:00 AM[0m] File change detected. Starting incremental compilation...
+
+
+[[90m12:00:00 AM[0m] Found 0 errors. Watching for file changes.
+
 //// [/home/src/workspaces/project/a.js] new file
 const a = "hello";
 
@@ -179,6 +193,11 @@ const a = "hello";
 Edit:: no emit run after fixing error
 
 Output::
+[[90m12:00:00 AM[0m] File change detected. Starting incremental compilation...
+
+
+[[90m12:00:00 AM[0m] Found 0 errors. Watching for file changes.
+
 //// [/home/src/workspaces/project/a.js] no change
 //// [/home/src/workspaces/project/a.ts] no change
 //// [/home/src/workspaces/project/tsconfig.json] modified. new content:
@@ -194,10 +213,12 @@ Output::
 Edit:: introduce error
 
 Output::
-a.ts(1,17): error TS1002: Unterminated string literal.
+[[90m12:00:01 AM[0m] File change detected. Starting incremental compilation...
 
 
-Found 1 error in a.ts[90m:1[0m
+a.ts(1,17): error TS1002: Unterminated string literal.
+
+[[90m12:00:01 AM[0m] Found 1 error. Watching for file changes.
 
 //// [/home/src/workspaces/project/a.js] no change
 //// [/home/src/workspaces/project/a.ts] modified. new content:
@@ -209,10 +230,12 @@ const a = "hello
 Edit:: emit when error
 
 Output::
-a.ts(1,17): error TS1002: Unterminated string literal.
+[[90m12:00:01 AM[0m] File change detected. Starting incremental compilation...
 
 
-Found 1 error in a.ts[90m:1[0m
+a.ts(1,17): error TS1002: Unterminated string literal.
+
+[[90m12:00:01 AM[0m] Found 1 error. Watching for file changes.
 
 //// [/home/src/workspaces/project/a.js] modified. new content:
 const a = "hello;
@@ -230,10 +253,12 @@ const a = "hello;
 Edit:: no emit run when error
 
 Output::
-a.ts(1,17): error TS1002: Unterminated string literal.
+[[90m12:00:01 AM[0m] File change detected. Starting incremental compilation...
 
 
-Found 1 error in a.ts[90m:1[0m
+a.ts(1,17): error TS1002: Unterminated string literal.
+
+[[90m12:00:01 AM[0m] Found 1 error. Watching for file changes.
 
 //// [/home/src/workspaces/project/a.js] no change
 //// [/home/src/workspaces/project/a.ts] no change